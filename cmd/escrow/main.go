@@ -0,0 +1,138 @@
+// Автономная утилита оператора депонирования ключей организации.
+// Закрытый ключ депонирования хранится только у оператора и не передаётся на сервер.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/escrow"
+	"go-pass-keeper/pkg/crypto"
+	"os"
+
+	"github.com/spf13/pflag"
+)
+
+// Команды утилиты
+const (
+	keygenCommand  = "keygen"
+	recoverCommand = "recover"
+)
+
+// функция main вызывается автоматически при запуске утилиты
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case keygenCommand:
+		err = runKeygen(os.Args[2:])
+	case recoverCommand:
+		err = runRecover(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// usage - вывод списка команд
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s %s|%s [flags]\n", os.Args[0], keygenCommand, recoverCommand)
+}
+
+// runKeygen - команда создания пары ключей депонирования: открытый ключ задаётся серверу
+// (--escrow_public_key), закрытый хранится у оператора
+func runKeygen(arguments []string) error {
+	var private, public string
+	flags := pflag.NewFlagSet(keygenCommand, pflag.ContinueOnError)
+	flags.StringVar(&private, "private", "escrow.key", "Path to write base64 escrow private key")
+	flags.StringVar(&public, "public", "escrow.pub", "Path to write base64 escrow public key")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	pub, priv, err := crypto.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(private, crypto.EncodeBoxKey(priv)+"\n", 0600); err != nil {
+		return err
+	}
+	if err := writeFile(public, crypto.EncodeBoxKey(pub)+"\n", 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Escrow key pair created, public key fingerprint %s\n", crypto.KeyFingerprint(pub))
+	return nil
+}
+
+// runRecover - команда расшифровки депонированного ключа из пакета сервера и выдачи
+// нового ключа восстановления (ответ применяется на сервере командой escrow-apply)
+func runRecover(arguments []string) error {
+	var private, input, output string
+	flags := pflag.NewFlagSet(recoverCommand, pflag.ContinueOnError)
+	flags.StringVar(&private, "key", "escrow.key", "Path to base64 escrow private key")
+	flags.StringVarP(&input, "package", "p", "", "Path to escrow package exported by the server")
+	flags.StringVarP(&output, "out", "o", "", "Path to write escrow response")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if input == "" || output == "" {
+		return errors.New("package and response files are required")
+	}
+
+	data, err := os.ReadFile(private)
+	if err != nil {
+		return fmt.Errorf("failed to read escrow private key: %w", err)
+	}
+	key, err := crypto.DecodePrivateKey(string(data))
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("failed to open escrow package: %w", err)
+	}
+	defer in.Close()
+	p, err := escrow.ReadPackage(in)
+	if err != nil {
+		return err
+	}
+	resp, code, err := escrow.Recover(key, p)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(output, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create escrow response: %w", err)
+	}
+	if err := escrow.Write(out, resp); err != nil {
+		out.Close()
+		os.Remove(output)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to close escrow response: %w", err)
+	}
+	fmt.Printf("Escrow package of user %s (exported by %s) is opened.\n", p.Login, p.Operator)
+	fmt.Printf("Apply %s on the server and pass the recovery code to the user:\n\n  %s\n", output, code)
+	return nil
+}
+
+// writeFile - метод записывает новый файл (существующий файл не перезаписывается)
+func writeFile(path string, content string, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -1,13 +1,26 @@
 package main
 
 import (
+	"fmt"
 	"go-pass-keeper/internal/app"
 	"go-pass-keeper/internal/grpcserver/config"
 	"go-pass-keeper/pkg/logger"
+	"os"
 )
 
 // функция main вызывается автоматически при запуске приложения
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case config.ExportUserCommand, config.ImportUserCommand:
+			os.Exit(runBackup(os.Args[1], os.Args[2:]))
+		case config.MigrateCommand:
+			os.Exit(runMigrate(os.Args[2:]))
+		case config.EscrowExportCommand, config.EscrowApplyCommand:
+			os.Exit(runEscrow(os.Args[1], os.Args[2:]))
+		}
+	}
+
 	config := config.NewConfig()
 	defer logger.Sync()
 
@@ -15,3 +28,55 @@ func main() {
 
 	a.Run()
 }
+
+// runBackup - запуск команды выгрузки или восстановления учётной записи
+func runBackup(command string, args []string) int {
+	cfg, err := config.NewBackupConfig(command, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if command == config.ExportUserCommand {
+		err = app.ExportUser(cfg)
+	} else {
+		err = app.ImportUser(cfg)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// runMigrate - запуск команды управления миграциями
+func runMigrate(args []string) int {
+	cfg, err := config.NewMigrateConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err := app.Migrate(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// runEscrow - запуск команды оператора депонирования ключей
+func runEscrow(command string, args []string) int {
+	cfg, err := config.NewEscrowConfig(command, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if command == config.EscrowExportCommand {
+		err = app.EscrowExport(cfg)
+	} else {
+		err = app.EscrowApply(cfg)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -3,13 +3,17 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"go-pass-keeper/internal/grpcserver"
 	"go-pass-keeper/internal/grpcserver/config"
 	interceptors "go-pass-keeper/internal/grpcserver/interceptors"
+	"go-pass-keeper/internal/kms"
 	"go-pass-keeper/internal/services"
 	"go-pass-keeper/internal/storage"
 	"go-pass-keeper/internal/token"
+	"go-pass-keeper/internal/workers"
+	"go-pass-keeper/pkg/crypto"
 	"go-pass-keeper/pkg/logger"
 	"os"
 	"os/signal"
@@ -36,44 +40,99 @@ func (a *App) Run() {
 	}
 
 	logger.Info(
-		"Starting server config:", a.config,
+		"Starting server config:", a.config.Redacted(),
 	)
 
-	th, err := token.NewJWT(a.config.JWTSecret)
+	if err := a.config.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid config: %s ", err.Error()))
+	}
+
+	th, err := newTokenHandler(a.config)
 	if err != nil {
-		logger.Error("Error token handler", err.Error())
+		panic(fmt.Sprintf("can't initialize token handler: %s ", err.Error()))
 	}
 	db, err := storage.NewDatabase(a.config.DatabaseDSN)
 	if err != nil {
 		logger.Error("Error create database", err.Error())
 	}
-	err = db.Initialize()
-	if err != nil {
+	if a.config.NoMigrate {
+		logger.Info("Database migrations are skipped")
+	} else if err := db.Initialize(); err != nil {
 		logger.Error("Error initialize database", err.Error())
 	}
 	// хранилище пользователей
 	users := storage.NewUserStorage(db)
+	// открытый ключ депонирования организации
+	escrowKey, err := newEscrowKey(a.config)
+	if err != nil {
+		panic(fmt.Sprintf("can't load escrow public key: %s ", err.Error()))
+	}
+	// менеджер мастер-ключей шифрования на сервере
+	keys, err := newKeyManager(a.config)
+	if err != nil {
+		panic(fmt.Sprintf("can't initialize key manager: %s ", err.Error()))
+	}
 	// хранилище секретов
-	secrets := storage.NewSecretStorage(db)
+	secrets := storage.NewSecretStorage(db, keys)
+	// хранилище вложений секретов
+	attachments := storage.NewAttachmentStorage(db, secrets)
+	// хранилище переданных секретов
+	shares := storage.NewShareStorage(db, secrets)
+	// хранилище организаций
+	orgs := storage.NewOrganizationStorage(db)
+	// секрет для ответов на вход по SRP с неизвестным логином
+	seed, err := th.Secret("srp-fake-verifier")
+	if err != nil {
+		panic(fmt.Sprintf("can't derive login secret: %s ", err.Error()))
+	}
 	// сервис пользователей
-	us := services.NewUser(users, th)
+	us := services.NewUser(users, th, seed)
 	// сервис секретов
-	ks := services.NewKeeper(secrets)
+	ks := services.NewKeeper(secrets, orgs)
+	// сервис вложений секретов
+	ats := services.NewAttachment(secrets, orgs, attachments)
+	// сервис передачи секретов
+	ss := services.NewShare(users, secrets, shares, orgs)
+	// сервис ключей хранилища
+	kys := services.NewKeys(users)
+	// сервис организаций
+	osvc := services.NewOrganization(users, orgs)
+	// сервис администрирования
+	admins, err := services.ResolveAdmins(context.Background(), users, a.config.AdminLogins)
+	if err != nil {
+		panic(fmt.Sprintf("can't resolve admins: %s ", err.Error()))
+	}
+	as := services.NewAdmin(users, admins)
+	// сервис депонирования ключей
+	es := services.NewEscrow(users, escrowKey)
 	a.server = grpcserver.NewServer(
 		// адрес
 		grpcserver.UseListenAddr(a.config.ListenAddr),
+		// адрес REST/JSON шлюза
+		grpcserver.UseGatewayAddr(a.config.GatewayAddr),
 		// перехватчики обычные запросов
-		grpcserver.UseUnaryInterceptors(interceptors.CreateUnaryInterceptors(th)...),
+		grpcserver.UseUnaryInterceptors(interceptors.CreateUnaryInterceptors(th, users)...),
 		// перехватчики потоковых запросов
-		grpcserver.UseStreamInterceptors(interceptors.CreateStreamInterceptors(th)...),
+		grpcserver.UseStreamInterceptors(interceptors.CreateStreamInterceptors(th, users)...),
+		// открытые ключи проверки токенов
+		grpcserver.UseHandler("/.well-known/jwks.json", th.JWKS()),
 		// используемые сервисы
-		grpcserver.UseServices(us, ks),
+		grpcserver.UseServices(us, ks, ats, ss, kys, osvc, as, es),
 	)
 
 	if err := a.server.Start(); err != nil {
 		logger.Error("Error start server", err.Error())
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// перешифрование ключей данных после ротации мастер-ключа
+	if keys != nil {
+		go workers.Rewrap(ctx, secrets, a.config.Rewrap)
+	}
+	// удаление и архивирование секретов с истёкшим сроком действия
+	go workers.Expire(ctx, secrets, a.config.Expire)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
 
@@ -82,6 +141,48 @@ func (a *App) Run() {
 	logger.Info("Shutdown signal received")
 
 	close(stop)
+	cancel()
 	a.server.Stop()
 	logger.Info("Shutdown completed")
 }
+
+// newTokenHandler - метод создания обработчика токенов: асимметричная подпись,
+// если задан ключ подписи, иначе общий секрет HMAC
+func newTokenHandler(cfg *config.Config) (*token.JWT, error) {
+	if cfg.JWTKey == "" {
+		return token.NewJWT(cfg.JWTSecret)
+	}
+	signKey, err := token.LoadKey(cfg.JWTKey)
+	if err != nil {
+		return nil, err
+	}
+	verifyKeys := make([]*token.Key, 0, len(cfg.JWTVerify))
+	for _, path := range cfg.JWTVerify {
+		key, err := token.LoadKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verifyKeys = append(verifyKeys, key)
+	}
+	return token.NewKeyJWT(signKey, verifyKeys...)
+}
+
+// newKeyManager - метод создания менеджера мастер-ключей (nil - шифрование на сервере выключено)
+func newKeyManager(cfg *config.Config) (kms.KeyManager, error) {
+	if cfg.MasterKey == "" {
+		return nil, nil
+	}
+	return kms.LoadLocalKeyManager(cfg.MasterKey, cfg.MasterOld...)
+}
+
+// newEscrowKey - метод загрузки открытого ключа депонирования (nil - депонирование выключено)
+func newEscrowKey(cfg *config.Config) ([]byte, error) {
+	if cfg.EscrowKey == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(cfg.EscrowKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read escrow public key: %w", err)
+	}
+	return crypto.DecodePublicKey(string(data))
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-pass-keeper/internal/archive"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/kms"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/token"
+	"go-pass-keeper/pkg/crypto"
+	"os"
+	"time"
+)
+
+// ExportUser - команда выгрузки учётной записи и секретов личного хранилища в архив
+func ExportUser(cfg *config.BackupConfig) error {
+	var signKey *token.Key
+	if cfg.Key != "" {
+		key, err := token.LoadKey(cfg.Key)
+		if err != nil {
+			return err
+		}
+		signKey = key
+	}
+	backups, err := newBackupStorage(cfg)
+	if err != nil {
+		return err
+	}
+
+	a := &archive.Archive{Version: archive.Version, Exported: time.Now().UTC(), Secrets: []archive.Secret{}}
+	attachments := 0
+	user, err := backups.Export(context.Background(), cfg.Login, func(b *models.SecretBackup) error {
+		m := b.Secret
+		s := archive.Secret{
+			ID:           m.ID,
+			Type:         m.Type,
+			Name:         m.Name,
+			Content:      m.Content,
+			Created:      m.Created,
+			Updated:      m.Updated,
+			ExpirePolicy: m.ExpirePolicy,
+			Archived:     m.Archived,
+			Meta:         m.Meta,
+			WrappedKey:   m.WrappedKey,
+			Revision:     m.Revision,
+			SearchIndex:  m.SearchIndex,
+		}
+		if m.Expires.Valid {
+			s.Expires = &m.Expires.Time
+		}
+		for _, at := range b.Attachments {
+			s.Attachments = append(s.Attachments, archive.Attachment{
+				ID:      at.ID,
+				Name:    at.Name,
+				Size:    at.Size,
+				Content: at.Content,
+				Meta:    at.Meta,
+				Created: at.Created,
+			})
+		}
+		attachments += len(b.Attachments)
+		a.Secrets = append(a.Secrets, s)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export user %s: %w", cfg.Login, err)
+	}
+	a.Account = archive.Account{
+		ID:           user.ID,
+		Login:        user.Login,
+		PasswordHash: user.Password,
+		Salt:         user.Salt,
+		PublicKey:    user.PublicKey,
+		PrivateKey:   user.PrivateKey,
+		Created:      user.Created,
+		KDF:          &user.KDF,
+		WrappedKey:   user.WrappedKey,
+		SRPSalt:      user.SRPSalt,
+		Verifier:     user.Verifier,
+		KeyCheck:     user.KeyCheck,
+		RecoveryKey:  user.RecoveryKey,
+		EscrowKey:    user.EscrowKey,
+		VaultRoot:    user.VaultRoot,
+		VaultSeq:     user.VaultSeq,
+	}
+
+	f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	if err := archive.Write(f, a, cfg.Passphrase, signKey); err != nil {
+		f.Close()
+		os.Remove(cfg.File)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if signKey == nil {
+		fmt.Println("Warning: archive is not signed, its authenticity can not be verified on import")
+	}
+	fmt.Printf("Exported user %s with %d secrets and %d attachments to %s\n", user.Login, len(a.Secrets), attachments, cfg.File)
+	return nil
+}
+
+// ImportUser - команда восстановления учётной записи из архива (при необходимости под новым логином,
+// если исходной учётной записи нет на сервере)
+func ImportUser(cfg *config.BackupConfig) error {
+	var verifyKey *token.Key
+	if cfg.Key != "" {
+		key, err := token.LoadKey(cfg.Key)
+		if err != nil {
+			return err
+		}
+		verifyKey = key
+	}
+	f, err := os.Open(cfg.File)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+	var a *archive.Archive
+	if verifyKey == nil && cfg.InsecureUnsigned {
+		fmt.Println("Warning: archive signature is not verified")
+		a, err = archive.ReadUnsigned(f, cfg.Passphrase)
+	} else {
+		a, err = archive.Read(f, cfg.Passphrase, verifyKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	backups, err := newBackupStorage(cfg)
+	if err != nil {
+		return err
+	}
+	user := &models.UserData{
+		ID:          a.Account.ID,
+		Login:       a.Account.Login,
+		Password:    a.Account.PasswordHash,
+		Salt:        a.Account.Salt,
+		PublicKey:   a.Account.PublicKey,
+		PrivateKey:  a.Account.PrivateKey,
+		Created:     a.Account.Created,
+		KDF:         crypto.LegacyKDFParams,
+		WrappedKey:  a.Account.WrappedKey,
+		SRPSalt:     a.Account.SRPSalt,
+		Verifier:    a.Account.Verifier,
+		KeyCheck:    a.Account.KeyCheck,
+		RecoveryKey: a.Account.RecoveryKey,
+		EscrowKey:   a.Account.EscrowKey,
+		VaultRoot:   a.Account.VaultRoot,
+		VaultSeq:    a.Account.VaultSeq,
+	}
+	if a.Account.KDF != nil {
+		user.KDF = *a.Account.KDF
+	}
+	if cfg.Login != "" {
+		user.Login = cfg.Login
+	}
+	attachments := 0
+	secrets := make([]*models.SecretBackup, 0, len(a.Secrets))
+	for _, s := range a.Secrets {
+		m := &models.SecretData{
+			ID:           s.ID,
+			Type:         s.Type,
+			Name:         s.Name,
+			Content:      s.Content,
+			Created:      s.Created,
+			Updated:      s.Updated,
+			ExpirePolicy: s.ExpirePolicy,
+			Archived:     s.Archived,
+			Meta:         s.Meta,
+			WrappedKey:   s.WrappedKey,
+			Revision:     s.Revision,
+			SearchIndex:  s.SearchIndex,
+		}
+		if s.Expires != nil {
+			m.Expires = sql.NullTime{Time: *s.Expires, Valid: true}
+		}
+		b := &models.SecretBackup{Secret: m}
+		for _, at := range s.Attachments {
+			b.Attachments = append(b.Attachments, &models.AttachmentData{
+				ID:      at.ID,
+				Name:    at.Name,
+				Size:    at.Size,
+				Content: at.Content,
+				Meta:    at.Meta,
+				Created: at.Created,
+			})
+		}
+		attachments += len(b.Attachments)
+		secrets = append(secrets, b)
+	}
+	if _, err := backups.Import(context.Background(), user, secrets); err != nil {
+		return fmt.Errorf("failed to import user %s: %w", user.Login, err)
+	}
+	fmt.Printf("Imported user %s with %d secrets and %d attachments from %s\n", user.Login, len(secrets), attachments, cfg.File)
+	return nil
+}
+
+// newBackupStorage - метод подключения к базе данных для выгрузки и восстановления учётных записей
+func newBackupStorage(cfg *config.BackupConfig) (*storage.BackupStorage, error) {
+	var keys kms.KeyManager
+	if cfg.MasterKey != "" {
+		m, err := kms.LoadLocalKeyManager(cfg.MasterKey, cfg.MasterOld...)
+		if err != nil {
+			return nil, err
+		}
+		keys = m
+	}
+	db, err := storage.NewDatabase(cfg.DatabaseDSN)
+	if err != nil {
+		return nil, err
+	}
+	// служебная команда не выполняет миграции: схема должна соответствовать версии программы
+	if err := db.CheckSchema(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return storage.NewBackupStorage(db, storage.NewSecretStorage(db, keys)), nil
+}
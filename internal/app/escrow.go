@@ -0,0 +1,121 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/escrow"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/storage"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// EscrowExport - команда выгрузки депонированного ключа пользователя в пакет для оператора
+// (выгрузка записывается в журнал депонирования от имени роли подключения к базе данных)
+func EscrowExport(cfg *config.EscrowConfig) error {
+	escrows, err := newEscrowStorage(cfg)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create escrow package: %w", err)
+	}
+	user, operator, err := escrows.Export(context.Background(), cfg.Login)
+	if err != nil {
+		f.Close()
+		os.Remove(cfg.File)
+		return fmt.Errorf("failed to export escrowed key of user %s: %w", cfg.Login, err)
+	}
+	p := &escrow.Package{
+		Version:   escrow.Version,
+		UserID:    user.ID,
+		Login:     user.Login,
+		EscrowKey: user.EscrowKey,
+		KeyCheck:  user.KeyCheck,
+		Operator:  operator,
+		Exported:  time.Now().UTC(),
+	}
+	if err := escrow.Write(f, p); err != nil {
+		f.Close()
+		os.Remove(cfg.File)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close escrow package: %w", err)
+	}
+	fmt.Printf("Exported escrowed key of user %s to %s\n", user.Login, cfg.File)
+	return nil
+}
+
+// EscrowApply - команда сохранения ключа восстановления из ответа оператора. Замена ключа
+// восстановления подтверждается логином пользователя, применение записывается в журнал депонирования.
+func EscrowApply(cfg *config.EscrowConfig) error {
+	f, err := os.Open(cfg.File)
+	if err != nil {
+		return fmt.Errorf("failed to open escrow response: %w", err)
+	}
+	defer f.Close()
+	resp, err := escrow.ReadResponse(f)
+	if err != nil {
+		return err
+	}
+	escrows, err := newEscrowStorage(cfg)
+	if err != nil {
+		return err
+	}
+	replace, err := escrows.HasRecoveryKey(context.Background(), resp.UserID, resp.Login)
+	if err != nil {
+		return fmt.Errorf("failed to check recovery key of user %s: %w", resp.Login, err)
+	}
+	if err := confirmApply(cfg, resp, replace, os.Stdin); err != nil {
+		return err
+	}
+	operator, err := escrows.Apply(context.Background(), resp.UserID, resp.Login, resp.RecoveryKey)
+	if err != nil {
+		return fmt.Errorf("failed to apply escrow response for user %s: %w", resp.Login, err)
+	}
+	fmt.Printf("Recovery key of user %s is replaced by operator %s (recorded in the escrow audit log), pass the recovery code to the user\n",
+		resp.Login, operator)
+	return nil
+}
+
+// confirmApply - метод запрашивает подтверждение замены ключа восстановления: оператор вводит
+// логин пользователя из ответа (или передаёт его флагом --confirm)
+func confirmApply(cfg *config.EscrowConfig, resp *escrow.Response, replace bool, in io.Reader) error {
+	fmt.Printf("Escrow response for user %s (%s) created at %s\n", resp.Login, resp.UserID, resp.Created.Format(time.RFC3339))
+	if replace {
+		fmt.Println("The user already has a recovery key: it will be replaced and the previous recovery code will stop working")
+	}
+	confirm := cfg.Confirm
+	if confirm == "" {
+		fmt.Print("Type the login of the user to confirm: ")
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		confirm = strings.TrimSpace(line)
+	}
+	if confirm != resp.Login {
+		return errors.New("escrow response is not applied: confirmation does not match the user login")
+	}
+	return nil
+}
+
+// newEscrowStorage - метод подключения к базе данных для команд оператора депонирования
+func newEscrowStorage(cfg *config.EscrowConfig) (*storage.EscrowStorage, error) {
+	db, err := storage.NewDatabase(cfg.DatabaseDSN)
+	if err != nil {
+		return nil, err
+	}
+	// служебная команда не выполняет миграции: схема должна соответствовать версии программы
+	if err := db.CheckSchema(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return storage.NewEscrowStorage(db), nil
+}
@@ -0,0 +1,17 @@
+package app
+
+import (
+	"context"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/storage"
+)
+
+// Migrate - команда управления миграциями базы данных (up, down, status, version)
+func Migrate(cfg *config.MigrateConfig) error {
+	db, err := storage.NewDatabase(cfg.DatabaseDSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Migrate(context.Background(), cfg.Action)
+}
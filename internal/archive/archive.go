@@ -0,0 +1,210 @@
+// Package archive предоставляет формат архива учётной записи пользователя
+// для переноса между серверами и восстановления из резервной копии.
+// Содержимое архива сжимается и шифруется ключом, полученным из пароля архива,
+// а сам архив подписывается ключом сервера (Ed25519 или ECDSA P-256). При чтении подпись
+// обязательна, неподписанный архив читается только явным вызовом ReadUnsigned.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/token"
+	"go-pass-keeper/pkg/crypto"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version - текущая версия формата архива (2 - архив содержит вложения и слепой индекс секретов)
+const Version = 2
+
+// minVersion - минимальная читаемая версия формата архива
+const minVersion = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrSignature          = errors.New("invalid archive signature")
+)
+
+// Account - учётная запись пользователя в архиве
+type Account struct {
+	// идентификатор сохраняется, так как содержимое секретов привязано к нему при шифровании
+	ID           uuid.UUID `json:"id"`
+	Login        string    `json:"login"`
+	PasswordHash string    `json:"password_hash"` // хеш пароля (bcrypt) переносится без изменений (пусто после перехода на SRP)
+	Salt         string    `json:"salt"`
+	PublicKey    []byte    `json:"public_key,omitempty"`
+	PrivateKey   []byte    `json:"private_key,omitempty"` // закрытый ключ, зашифрованный на клиенте
+	Created      time.Time `json:"created"`
+	// параметры получения ключа из пароля (в архивах без них - crypto.LegacyKDFParams)
+	// и ключ шифрования, зашифрованный ключом из пароля (необязательный)
+	KDF        *crypto.KDFParams `json:"kdf,omitempty"`
+	WrappedKey []byte            `json:"wrapped_key,omitempty"`
+	// соль и проверочное значение пароля SRP (в архивах без них - вход по хешу пароля)
+	SRPSalt  []byte `json:"srp_salt,omitempty"`
+	Verifier []byte `json:"srp_verifier,omitempty"`
+	// контрольное значение ключа шифрования (необязательное)
+	KeyCheck []byte `json:"key_check,omitempty"`
+	// ключ шифрования, зашифрованный ключом восстановления (необязательный)
+	RecoveryKey []byte `json:"recovery_key,omitempty"`
+	// ключ шифрования, депонированный организации (необязательный)
+	EscrowKey []byte `json:"escrow_key,omitempty"`
+	// состояние личного хранилища, подписанное на клиенте, и его номер (необязательные)
+	VaultRoot []byte `json:"vault_root,omitempty"`
+	VaultSeq  int64  `json:"vault_seq,omitempty"`
+}
+
+// Secret - секрет личного хранилища в архиве (содержимое зашифровано на клиенте)
+type Secret struct {
+	ID      uuid.UUID `json:"id"`
+	Type    string    `json:"type"`
+	Name    string    `json:"name"`
+	Content []byte    `json:"content"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+	// срок действия и действие по его истечении (необязательные)
+	Expires      *time.Time `json:"expires,omitempty"`
+	ExpirePolicy string     `json:"expire_policy,omitempty"`
+	Archived     bool       `json:"archived,omitempty"`
+	// метаданные, зашифрованные на клиенте (необязательные)
+	Meta []byte `json:"meta,omitempty"`
+	// ключ данных, зашифрованный на клиенте ключом хранилища (необязательный)
+	WrappedKey []byte `json:"wrapped_key,omitempty"`
+	// ревизия секрета (в архивах без неё - 1)
+	Revision int64 `json:"revision,omitempty"`
+	// слепой индекс: ключевые хеши слов названия и тегов (необязательный)
+	SearchIndex [][]byte `json:"search_index,omitempty"`
+	// вложения секрета (в архивах версии 1 не выгружались)
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment - вложение секрета в архиве (содержимое и название зашифрованы на клиенте)
+type Attachment struct {
+	ID      uuid.UUID `json:"id"`
+	Name    string    `json:"name,omitempty"` // название вложений, добавленных до шифрования названия на клиенте
+	Size    int64     `json:"size"`
+	Content []byte    `json:"content"`
+	Meta    []byte    `json:"meta,omitempty"` // название, зашифрованное на клиенте
+	Created time.Time `json:"created"`
+}
+
+// Archive - содержимое архива
+type Archive struct {
+	Version  int       `json:"version"`
+	Exported time.Time `json:"exported"`
+	Account  Account   `json:"account"`
+	Secrets  []Secret  `json:"secrets"`
+}
+
+// file - представление архива в файле
+type file struct {
+	Version    int    `json:"version"`
+	Ciphertext []byte `json:"ciphertext"`          // сжатое и зашифрованное содержимое (соль и параметры kdf в заголовке)
+	KeyID      string `json:"key_id,omitempty"`    // идентификатор ключа подписи
+	Signature  string `json:"signature,omitempty"` // подпись версии, соли и шифротекста
+}
+
+// Write - метод записывает архив, зашифрованный паролем passphrase.
+// Если задан ключ signKey, архив подписывается.
+func Write(w io.Writer, a *Archive, passphrase string, signKey *token.Key) error {
+	if passphrase == "" {
+		return errors.New("empty archive passphrase")
+	}
+	var payload bytes.Buffer
+	zw := gzip.NewWriter(&payload)
+	if err := json.NewEncoder(zw).Encode(a); err != nil {
+		return fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+
+	ciphertext, err := crypto.EncryptWithPassword(passphrase, payload.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt archive: %w", err)
+	}
+
+	f := file{Version: a.Version, Ciphertext: ciphertext}
+	if signKey != nil {
+		if signKey.Private == nil {
+			return errors.New("signing key has no private part")
+		}
+		f.KeyID = signKey.ID
+		f.Signature, err = signKey.Method.Sign(f.signingString(), signKey.Private)
+		if err != nil {
+			return fmt.Errorf("failed to sign archive: %w", err)
+		}
+	}
+	if err := json.NewEncoder(w).Encode(f); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// Read - метод читает архив, зашифрованный паролем passphrase. Архив обязан быть подписан
+// ключом verifyKey: без ключа проверки чтение отклоняется.
+func Read(r io.Reader, passphrase string, verifyKey *token.Key) (*Archive, error) {
+	if verifyKey == nil {
+		return nil, fmt.Errorf("%w: verification key is required", ErrSignature)
+	}
+	return read(r, passphrase, verifyKey)
+}
+
+// ReadUnsigned - метод читает архив без проверки подписи. Подлинность такого архива
+// не подтверждена, метод используется только по явному указанию оператора.
+func ReadUnsigned(r io.Reader, passphrase string) (*Archive, error) {
+	return read(r, passphrase, nil)
+}
+
+// read - метод читает архив и проверяет подпись ключом verifyKey (nil - без проверки)
+func read(r io.Reader, passphrase string, verifyKey *token.Key) (*Archive, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if f.Version < minVersion || f.Version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, f.Version)
+	}
+	if verifyKey != nil {
+		if f.Signature == "" || f.KeyID != verifyKey.ID {
+			return nil, ErrSignature
+		}
+		if err := verifyKey.Method.Verify(f.signingString(), f.Signature, verifyKey.Public); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrSignature, err.Error())
+		}
+	}
+
+	payload, err := crypto.DecryptWithPassword(passphrase, f.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt archive (wrong passphrase?): %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+	defer zr.Close()
+
+	a := &Archive{}
+	if err := json.NewDecoder(zr).Decode(a); err != nil {
+		return nil, fmt.Errorf("failed to decode archive: %w", err)
+	}
+	if a.Version != f.Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, a.Version)
+	}
+	return a, nil
+}
+
+// signingString - метод формирует подписываемую строку архива
+func (f file) signingString() string {
+	var b bytes.Buffer
+	b.WriteString(strconv.Itoa(f.Version))
+	// пустое поле на месте соли архивов первого формата сохраняет подписываемую строку прежней
+	b.WriteString("..")
+	b.Write(f.Ciphertext)
+	return b.String()
+}
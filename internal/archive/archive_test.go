@@ -0,0 +1,169 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"go-pass-keeper/internal/token"
+	"go-pass-keeper/pkg/crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testArchive() *Archive {
+	created := time.Date(2025, time.October, 9, 10, 0, 0, 0, time.UTC)
+	return &Archive{
+		Version:  Version,
+		Exported: created,
+		Account: Account{
+			Login:        "alice",
+			PasswordHash: "$2a$06$hash",
+			Salt:         "c2FsdA==",
+			PublicKey:    []byte("public"),
+			PrivateKey:   []byte("private"),
+			Created:      created,
+		},
+		Secrets: []Secret{
+			{Type: "password", Name: "mail", Content: []byte("ciphertext"), Created: created, Updated: created,
+				SearchIndex: [][]byte{[]byte("token")},
+				Attachments: []Attachment{{Size: 4, Content: []byte("file"), Meta: []byte("name"), Created: created}}},
+		},
+	}
+}
+
+func testKey(t *testing.T) *token.Key {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := token.NewKey(priv)
+	require.NoError(t, err)
+	return key
+}
+
+func TestWriteRead(t *testing.T) {
+	signKey := testKey(t)
+	verifyKey, err := token.NewKey(signKey.Public)
+	require.NoError(t, err)
+	otherKey := testKey(t)
+
+	testCases := []struct {
+		name          string
+		signKey       *token.Key
+		verifyKey     *token.Key
+		passphrase    string
+		expectedError string
+	}{
+		{
+			name:          "Unsigned archive without verification key #1",
+			passphrase:    "archive-passphrase",
+			expectedError: "verification key is required",
+		},
+		{
+			name:       "Signed archive #2",
+			signKey:    signKey,
+			verifyKey:  verifyKey,
+			passphrase: "archive-passphrase",
+		},
+		{
+			name:          "Unsigned archive with required signature #3",
+			verifyKey:     verifyKey,
+			passphrase:    "archive-passphrase",
+			expectedError: "invalid archive signature",
+		},
+		{
+			name:          "Signed by another key #4",
+			signKey:       otherKey,
+			verifyKey:     verifyKey,
+			passphrase:    "archive-passphrase",
+			expectedError: "invalid archive signature",
+		},
+		{
+			name:          "Wrong passphrase #5",
+			signKey:       signKey,
+			verifyKey:     verifyKey,
+			passphrase:    "wrong-passphrase",
+			expectedError: "failed to decrypt archive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, testArchive(), "archive-passphrase", tc.signKey))
+
+			a, err := Read(&buf, tc.passphrase, tc.verifyKey)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testArchive(), a)
+		})
+	}
+}
+
+func TestReadTampered(t *testing.T) {
+	signKey := testKey(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testArchive(), "archive-passphrase", signKey))
+
+	var f file
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &f))
+	f.Ciphertext[len(f.Ciphertext)-1] ^= 0xff
+	data, err := json.Marshal(f)
+	require.NoError(t, err)
+
+	_, err = Read(bytes.NewReader(data), "archive-passphrase", signKey)
+	assert.True(t, errors.Is(err, ErrSignature))
+
+	f.Version = Version + 1
+	data, err = json.Marshal(f)
+	require.NoError(t, err)
+	_, err = ReadUnsigned(bytes.NewReader(data), "archive-passphrase")
+	assert.True(t, errors.Is(err, ErrUnsupportedVersion))
+
+	assert.Error(t, Write(&buf, testArchive(), "", nil))
+}
+
+func TestReadUnsigned(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testArchive(), "archive-passphrase", nil))
+	a, err := ReadUnsigned(&buf, "archive-passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, testArchive(), a)
+}
+
+func TestReadVersion1(t *testing.T) {
+	// архив первой версии (без вложений и индекса) читается
+	v1 := testArchive()
+	v1.Version = 1
+	v1.Secrets[0].SearchIndex, v1.Secrets[0].Attachments = nil, nil
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, v1, "archive-passphrase", nil))
+	a, err := ReadUnsigned(&buf, "archive-passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, v1, a)
+
+	// архив с солью отдельно от шифротекста (ключ без заголовка) больше не поддерживается
+	var payload bytes.Buffer
+	zw := gzip.NewWriter(&payload)
+	require.NoError(t, json.NewEncoder(zw).Encode(v1))
+	require.NoError(t, zw.Close())
+	salt, err := crypto.GenerateSalt()
+	require.NoError(t, err)
+	key, err := crypto.MakeCryptoKey("archive-passphrase", salt)
+	require.NoError(t, err)
+	ciphertext, err := crypto.Encrypt(key, payload.Bytes())
+	require.NoError(t, err)
+	data, err := json.Marshal(map[string]any{"version": 1, "salt": salt, "ciphertext": ciphertext})
+	require.NoError(t, err)
+	_, err = ReadUnsigned(bytes.NewReader(data), "archive-passphrase")
+	require.ErrorIs(t, err, crypto.ErrUnsupportedEnvelope)
+}
@@ -0,0 +1,110 @@
+// Package escrow предоставляет формат файлов депонирования ключей для учётных записей,
+// управляемых организацией. Сервер выгружает пакет с ключом хранилища, зашифрованным
+// открытым ключом депонирования, оператор расшифровывает его автономной утилитой
+// с закрытым ключом и формирует ответ - ключ хранилища, зашифрованный новым ключом
+// восстановления, который сервер сохраняет вместо прежнего.
+package escrow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version - текущая версия формата файлов депонирования
+const Version = 1
+
+// ErrUnsupportedVersion - неподдерживаемая версия файла депонирования
+var ErrUnsupportedVersion = errors.New("unsupported escrow file version")
+
+// Package - пакет с депонированным ключом хранилища пользователя
+type Package struct {
+	Version   int       `json:"version"`
+	UserID    uuid.UUID `json:"user_id"`
+	Login     string    `json:"login"`
+	EscrowKey []byte    `json:"escrow_key"`          // ключ хранилища, зашифрованный открытым ключом депонирования
+	KeyCheck  []byte    `json:"key_check,omitempty"` // контрольное значение ключа хранилища (необязательное)
+	Operator  string    `json:"operator"`            // идентификатор оператора, выгрузившего пакет
+	Exported  time.Time `json:"exported"`
+}
+
+// Response - ответ оператора с ключом хранилища, зашифрованным новым ключом восстановления
+type Response struct {
+	Version     int       `json:"version"`
+	UserID      uuid.UUID `json:"user_id"`
+	Login       string    `json:"login"`
+	RecoveryKey []byte    `json:"recovery_key"`
+	Created     time.Time `json:"created"`
+}
+
+// Recover - метод расшифровывает депонированный ключ хранилища закрытым ключом депонирования
+// и шифрует его новым ключом восстановления (возвращает ответ и код восстановления для пользователя)
+func Recover(privateKey []byte, p *Package) (*Response, string, error) {
+	if p.Version != Version {
+		return nil, "", fmt.Errorf("%w: %d", ErrUnsupportedVersion, p.Version)
+	}
+	info := &models.AuthInfo{UserID: p.UserID.String(), KeyCheck: p.KeyCheck}
+	key, err := info.OpenEscrowKey(privateKey, p.EscrowKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open escrowed key: %w", err)
+	}
+	recoveryKey, err := crypto.GenerateRecoveryKey()
+	if err != nil {
+		return nil, "", err
+	}
+	wrapped, err := info.WrapRecoveryKey(recoveryKey, key)
+	if err != nil {
+		return nil, "", err
+	}
+	r := &Response{
+		Version:     Version,
+		UserID:      p.UserID,
+		Login:       p.Login,
+		RecoveryKey: wrapped,
+		Created:     time.Now().UTC(),
+	}
+	return r, crypto.FormatRecoveryCode(recoveryKey), nil
+}
+
+// Write - метод записывает пакет или ответ в формате JSON
+func Write(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write escrow file: %w", err)
+	}
+	return nil
+}
+
+// ReadPackage - метод читает пакет с депонированным ключом
+func ReadPackage(r io.Reader) (*Package, error) {
+	var p Package
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to read escrow package: %w", err)
+	}
+	if p.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, p.Version)
+	}
+	return &p, nil
+}
+
+// ReadResponse - метод читает ответ оператора
+func ReadResponse(r io.Reader) (*Response, error) {
+	var resp Response
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read escrow response: %w", err)
+	}
+	if resp.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, resp.Version)
+	}
+	if len(resp.RecoveryKey) == 0 {
+		return nil, errors.New("empty recovery key in escrow response")
+	}
+	return &resp, nil
+}
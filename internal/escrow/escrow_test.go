@@ -0,0 +1,91 @@
+package escrow
+
+import (
+	"bytes"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPackage(t *testing.T, escrowPublic []byte, key []byte) *Package {
+	uid := uuid.New()
+	info := &models.AuthInfo{UserID: uid.String()}
+	check, err := info.NewKeyCheck(key)
+	require.NoError(t, err)
+	sealed, err := info.EscrowKey(escrowPublic, key)
+	require.NoError(t, err)
+	return &Package{
+		Version:   Version,
+		UserID:    uid,
+		Login:     "alice",
+		EscrowKey: sealed,
+		KeyCheck:  check,
+		Operator:  "security-officer",
+		Exported:  time.Date(2025, time.October, 19, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRecover(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	escrowPublic, escrowPrivate, err := crypto.GenerateKeyPair()
+	require.NoError(t, err)
+	_, otherPrivate, err := crypto.GenerateKeyPair()
+	require.NoError(t, err)
+	p := testPackage(t, escrowPublic, key)
+
+	resp, code, err := Recover(escrowPrivate, p)
+	require.NoError(t, err)
+	assert.Equal(t, p.UserID, resp.UserID)
+	assert.Equal(t, p.Login, resp.Login)
+
+	// пользователь восстанавливает ключ хранилища по выданному коду
+	recoveryKey, err := crypto.ParseRecoveryCode(code)
+	require.NoError(t, err)
+	info := &models.AuthInfo{UserID: p.UserID.String(), KeyCheck: p.KeyCheck}
+	recovered, err := info.RecoverKey(recoveryKey, resp.RecoveryKey)
+	require.NoError(t, err)
+	assert.Equal(t, key, recovered)
+
+	_, _, err = Recover(otherPrivate, p)
+	assert.ErrorIs(t, err, models.ErrWrongKey, "Wrong escrow private key")
+
+	// пакет, выгруженный для другого пользователя, не расшифровывается
+	other := *p
+	other.UserID = uuid.New()
+	_, _, err = Recover(escrowPrivate, &other)
+	assert.ErrorIs(t, err, models.ErrWrongKey, "Escrowed key is bound to the user")
+
+	old := *p
+	old.Version = 0
+	_, _, err = Recover(escrowPrivate, &old)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestWriteRead(t *testing.T) {
+	escrowPublic, _, err := crypto.GenerateKeyPair()
+	require.NoError(t, err)
+	p := testPackage(t, escrowPublic, []byte("0123456789abcdef0123456789abcdef"))
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, p))
+	read, err := ReadPackage(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, p, read)
+
+	resp := &Response{Version: Version, UserID: p.UserID, Login: p.Login, RecoveryKey: []byte("wrapped"), Created: p.Exported}
+	buf.Reset()
+	require.NoError(t, Write(&buf, resp))
+	readResp, err := ReadResponse(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, resp, readResp)
+
+	_, err = ReadResponse(bytes.NewBufferString(`{"version":1,"login":"alice"}`))
+	assert.Error(t, err, "Empty recovery key")
+	_, err = ReadPackage(bytes.NewBufferString(`{"version":2}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
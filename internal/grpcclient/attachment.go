@@ -0,0 +1,169 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient/interceptors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// AttachmentClient модель клиента для работы с вложениями секретов
+type AttachmentClient struct {
+	serverAddr string
+	conn       *grpc.ClientConn
+	client     pb.AttachmentClient
+	opts       []grpc.DialOption
+	ctx        context.Context
+}
+
+// AttachmentClientOption определяет тип для опций
+type AttachmentClientOption func(*AttachmentClient)
+
+// NewAttachmentClient - метод создает новый экземпляр AttachmentClient
+func NewAttachmentClient(serverAddr string, token string, opts ...AttachmentClientOption) *AttachmentClient {
+	client := &AttachmentClient{
+		serverAddr: serverAddr,
+		opts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(interceptors.AuthInterceptor(token)),
+			grpc.WithStreamInterceptor(interceptors.AuthStreamInterceptor(token)),
+		},
+	}
+	// Применяем переданные опции
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// UseAttachmentOptions - метод добавляет дополнительные grpc опции
+func UseAttachmentOptions(opts ...grpc.DialOption) AttachmentClientOption {
+	return func(uc *AttachmentClient) {
+		uc.opts = append(uc.opts, opts...)
+	}
+}
+
+// Connect - метод устанавливает соединение с сервером
+func (uc *AttachmentClient) Connect(ctx context.Context) error {
+	_, err := url.ParseRequestURI(uc.serverAddr)
+	if err != nil {
+		return fmt.Errorf("invalid server address: %w", err)
+	}
+	conn, err := grpc.NewClient(uc.serverAddr, uc.opts...)
+	if err != nil {
+		logger.Error("Failed to connect to server", err.Error())
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	uc.conn = conn
+	uc.client = pb.NewAttachmentClient(conn)
+	uc.ctx = ctx
+	return nil
+}
+
+// Close - метод закрывает соединение
+func (uc *AttachmentClient) Close() error {
+	if uc.conn != nil {
+		return uc.conn.Close()
+	}
+	return nil
+}
+
+// AddAttachment - метод добавляет к секрету вложение (название и содержимое должны быть
+// зашифрованы, см. AttachmentInfo.Seal)
+func (uc *AttachmentClient) AddAttachment(info *models.AttachmentInfo, content []byte) (*models.AttachmentInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.AddAttachment(uc.ctx, &pb.AddAttachmentRequest{
+		Meta:    info.ToProtoMetadata(),
+		Content: content,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.AttachmentInfoFromProto(resp.GetMeta()), nil
+	case codes.PermissionDenied, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists:
+		logger.Warn("Add attachment rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Add attachment error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// GetAttachments - метод получает список вложений секрета
+func (uc *AttachmentClient) GetAttachments(sid string) ([]*models.AttachmentInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetAttachments(uc.ctx, &pb.GetAttachmentsRequest{SecretId: sid})
+	switch status.Code(err) {
+	case codes.OK:
+		res := make([]*models.AttachmentInfo, 0, len(resp.GetAttachments()))
+		for _, meta := range resp.GetAttachments() {
+			res = append(res, models.AttachmentInfoFromProto(meta))
+		}
+		return res, nil
+	case codes.PermissionDenied, codes.NotFound:
+		logger.Warn("Get attachments rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get attachments error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// GetAttachment - метод получает вложение с зашифрованным содержимым
+func (uc *AttachmentClient) GetAttachment(aid string) (*models.AttachmentInfo, []byte, error) {
+	if uc.client == nil {
+		return nil, nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetAttachment(uc.ctx, &pb.GetAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: aid}})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.AttachmentInfoFromProto(resp.GetMeta()), resp.GetContent(), nil
+	case codes.PermissionDenied, codes.NotFound:
+		logger.Warn("Get attachment rejected", err.Error())
+		return nil, nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get attachment error", err.Error())
+		return nil, nil, fmt.Errorf("internal error")
+	}
+}
+
+// DeleteAttachment - метод удаляет вложение
+func (uc *AttachmentClient) DeleteAttachment(aid string) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.DeleteAttachment(uc.ctx, &pb.DeleteAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: aid}})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.PermissionDenied, codes.NotFound:
+		logger.Warn("Delete attachment rejected", err.Error())
+		return fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Delete attachment error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
@@ -0,0 +1,285 @@
+package grpcclient
+
+import (
+	"context"
+	"go-pass-keeper/internal/models"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/proto/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAttachmentClient_AddAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockAttachmentClient(ctrl)
+	created := time.Date(2025, 10, 11, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.AttachmentClient
+		ExpectedResult *models.AttachmentInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Add attachment",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddAttachment(gomock.Any(), gomock.Any()).Return(
+					&pb.AddAttachmentResponse{Meta: &pb.AttachmentMetadata{
+						Id: "a1", SecretId: "s1", Size: 4, Created: timestamppb.New(created), EncryptedMeta: []byte("name"),
+					}}, nil,
+				)
+			},
+			Client:         mockClient,
+			ExpectedResult: &models.AttachmentInfo{ID: "a1", SecretID: "s1", Size: 4, Created: created, Meta: []byte("name")},
+		},
+		{
+			TestName: "Error. Attachment is too large",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddAttachment(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.InvalidArgument, "attachment is too large"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "attachment is too large",
+		},
+		{
+			TestName: "Error. Attachment already exists",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddAttachment(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.AlreadyExists, "already exists"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "already exists",
+		},
+		{
+			TestName: "Error. Unauthenticated",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddAttachment(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "unauthenticated"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user unauthenticated",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &AttachmentClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			res, err := uc.AddAttachment(&models.AttachmentInfo{ID: "a1", SecretID: "s1", Size: 4, Meta: []byte("name")}, []byte("data"))
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, res)
+			}
+		})
+	}
+}
+
+func TestAttachmentClient_GetAttachments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockAttachmentClient(ctrl)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.AttachmentClient
+		ExpectedResult []*models.AttachmentInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Get attachments",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetAttachments(gomock.Any(), gomock.Any()).Return(
+					&pb.GetAttachmentsResponse{Attachments: []*pb.AttachmentMetadata{
+						{Id: "a1", SecretId: "s1", Name: "scan.pdf", Size: 4},
+					}}, nil,
+				)
+			},
+			Client: mockClient,
+			ExpectedResult: []*models.AttachmentInfo{
+				{ID: "a1", SecretID: "s1", Name: "scan.pdf", Size: 4, Created: time.Unix(0, 0).UTC()},
+			},
+		},
+		{
+			TestName: "Error. Permission denied",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetAttachments(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.PermissionDenied, "access denied"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "access denied",
+		},
+		{
+			TestName: "Error. Internal",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetAttachments(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Internal, "db down"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "internal error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &AttachmentClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			res, err := uc.GetAttachments("s1")
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, res)
+			}
+		})
+	}
+}
+
+func TestAttachmentClient_GetAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockAttachmentClient(ctrl)
+
+	testCases := []struct {
+		TestName        string
+		SetupMocks      func()
+		Client          pb.AttachmentClient
+		ExpectedResult  *models.AttachmentInfo
+		ExpectedContent []byte
+		ExpectedError   string
+	}{
+		{
+			TestName: "Success. Get attachment",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetAttachment(gomock.Any(), gomock.Any()).Return(
+					&pb.GetAttachmentResponse{
+						Meta:    &pb.AttachmentMetadata{Id: "a1", SecretId: "s1", Name: "scan.pdf", Size: 4},
+						Content: []byte("data"),
+					}, nil,
+				)
+			},
+			Client:          mockClient,
+			ExpectedResult:  &models.AttachmentInfo{ID: "a1", SecretID: "s1", Name: "scan.pdf", Size: 4, Created: time.Unix(0, 0).UTC()},
+			ExpectedContent: []byte("data"),
+		},
+		{
+			TestName: "Error. Not found",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetAttachment(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.NotFound, "attachment not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "attachment not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &AttachmentClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			res, content, err := uc.GetAttachment("a1")
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, res)
+				assert.Equal(t, tc.ExpectedContent, content)
+			}
+		})
+	}
+}
+
+func TestAttachmentClient_DeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockAttachmentClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.AttachmentClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Delete attachment",
+			SetupMocks: func() {
+				mockClient.EXPECT().DeleteAttachment(gomock.Any(), gomock.Any()).Return(&pb.DeleteAttachmentResponse{}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Permission denied",
+			SetupMocks: func() {
+				mockClient.EXPECT().DeleteAttachment(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.PermissionDenied, "access denied"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "access denied",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &AttachmentClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.DeleteAttachment("a1")
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
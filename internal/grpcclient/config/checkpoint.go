@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// checkpointsFile - файл последних проверенных состояний личных хранилищ (рядом с файлом настроек)
+const checkpointsFile = "vaults.json"
+
+// VaultCheckpoint - последнее подписанное состояние личного хранилища, проверенное на этом клиенте.
+// Сохраняется между сеансами, чтобы удаление или откат состояния на сервере не принимались
+// за первое использование.
+type VaultCheckpoint struct {
+	Sequence int64  `json:"sequence"`
+	Root     []byte `json:"root"`
+}
+
+// LoadCheckpoint - загрузка последнего проверенного состояния хранилища пользователя userID
+// на сервере server (nil - состояние на этом клиенте ещё не проверялось)
+func (cm *Config) LoadCheckpoint(server string, userID string) (*VaultCheckpoint, error) {
+	checkpoints, err := cm.loadCheckpoints()
+	if err != nil {
+		return nil, err
+	}
+	cp, ok := checkpoints[checkpointKey(server, userID)]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+// SaveCheckpoint - сохранение последнего проверенного состояния хранилища пользователя userID
+// на сервере server (состояние с меньшим номером не заменяет сохранённое)
+func (cm *Config) SaveCheckpoint(server string, userID string, cp VaultCheckpoint) error {
+	checkpoints, err := cm.loadCheckpoints()
+	if err != nil {
+		return err
+	}
+	key := checkpointKey(server, userID)
+	if prev, ok := checkpoints[key]; ok && prev.Sequence > cp.Sequence {
+		return nil
+	}
+	checkpoints[key] = cp
+	data, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	os.MkdirAll(filepath.Dir(cm.configPath), 0700)
+	return os.WriteFile(cm.checkpointsPath(), data, 0600)
+}
+
+// loadCheckpoints - метод читает файл проверенных состояний (отсутствующий файл - пустой список).
+// Повреждённый файл не заменяется, чтобы сохранённые состояния не были потеряны незаметно.
+func (cm *Config) loadCheckpoints() (map[string]VaultCheckpoint, error) {
+	checkpoints := make(map[string]VaultCheckpoint)
+	data, err := os.ReadFile(cm.checkpointsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// checkpointsPath - путь к файлу проверенных состояний
+func (cm *Config) checkpointsPath() string {
+	return filepath.Join(filepath.Dir(cm.configPath), checkpointsFile)
+}
+
+// checkpointKey - ключ состояния хранилища пользователя на сервере
+func checkpointKey(server string, userID string) string {
+	return server + "/" + userID
+}
@@ -41,8 +41,8 @@ func (cm *Config) Save(config *settings.Settings) error {
 		return err
 	}
 
-	os.MkdirAll(filepath.Dir(cm.configPath), 0755)
-	return os.WriteFile(cm.configPath, data, 0644)
+	os.MkdirAll(filepath.Dir(cm.configPath), 0700)
+	return os.WriteFile(cm.configPath, data, 0600)
 }
 
 // DefaultConfig - дефолтный конфиг
@@ -51,6 +51,5 @@ func (cm *Config) DefaultConfig() *settings.Settings {
 		ServerURL:  "localhost",
 		ServerPort: "8080",
 		Timeout:    30,
-		Secret:     "secret",
 	}
 }
@@ -67,3 +67,46 @@ func TestInvalidJSON(t *testing.T) {
 		t.Errorf("Should return default config for invalid JSON")
 	}
 }
+
+func TestCheckpoint(t *testing.T) {
+	manager := &Config{configPath: filepath.Join(t.TempDir(), "config.json")}
+
+	cp, err := manager.LoadCheckpoint("localhost:8080", "user-1")
+	if err != nil || cp != nil {
+		t.Fatalf("Expected no checkpoint, got %v, %v", cp, err)
+	}
+
+	if err := manager.SaveCheckpoint("localhost:8080", "user-1", VaultCheckpoint{Sequence: 5, Root: []byte("root-5")}); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	// состояние с меньшим номером не заменяет сохранённое
+	if err := manager.SaveCheckpoint("localhost:8080", "user-1", VaultCheckpoint{Sequence: 3, Root: []byte("root-3")}); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	cp, err = manager.LoadCheckpoint("localhost:8080", "user-1")
+	if err != nil || cp == nil || cp.Sequence != 5 || string(cp.Root) != "root-5" {
+		t.Errorf("Expected checkpoint 5, got %v, %v", cp, err)
+	}
+
+	// состояния разных пользователей хранятся отдельно
+	cp, err = manager.LoadCheckpoint("localhost:8080", "user-2")
+	if err != nil || cp != nil {
+		t.Errorf("Expected no checkpoint for other user, got %v, %v", cp, err)
+	}
+
+	info, err := os.Stat(manager.checkpointsPath())
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	// повреждённый файл не считается отсутствием проверенного состояния
+	if err := os.WriteFile(manager.checkpointsPath(), []byte("{invalid"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := manager.LoadCheckpoint("localhost:8080", "user-1"); err == nil {
+		t.Error("Expected error for corrupted checkpoints file")
+	}
+}
@@ -0,0 +1,134 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient/interceptors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// ErrEscrowNotConfigured - ошибка: депонирование ключей на сервере не настроено
+var ErrEscrowNotConfigured = errors.New("escrow is not configured")
+
+// EscrowClient модель клиента депонирования ключей организации
+type EscrowClient struct {
+	serverAddr string
+	conn       *grpc.ClientConn
+	client     pb.EscrowClient
+	opts       []grpc.DialOption
+	ctx        context.Context
+}
+
+// EscrowClientOption определяет тип для опций
+type EscrowClientOption func(*EscrowClient)
+
+// NewEscrowClient - метод создает новый экземпляр EscrowClient
+func NewEscrowClient(serverAddr string, token string, opts ...EscrowClientOption) *EscrowClient {
+	client := &EscrowClient{
+		serverAddr: serverAddr,
+		opts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(interceptors.AuthInterceptor(token)),
+			grpc.WithStreamInterceptor(interceptors.AuthStreamInterceptor(token)),
+		},
+	}
+	// Применяем переданные опции
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// UseEscrowOptions - метод добавляет дополнительные grpc опции
+func UseEscrowOptions(opts ...grpc.DialOption) EscrowClientOption {
+	return func(uc *EscrowClient) {
+		uc.opts = append(uc.opts, opts...)
+	}
+}
+
+// Connect - метод устанавливает соединение с сервером
+func (uc *EscrowClient) Connect(ctx context.Context) error {
+	_, err := url.ParseRequestURI(uc.serverAddr)
+	if err != nil {
+		return fmt.Errorf("invalid server address: %w", err)
+	}
+	conn, err := grpc.NewClient(uc.serverAddr, uc.opts...)
+	if err != nil {
+		logger.Error("Failed to connect to server", err.Error())
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	uc.conn = conn
+	uc.client = pb.NewEscrowClient(conn)
+	uc.ctx = ctx
+	return nil
+}
+
+// Close - метод закрывает соединение
+func (uc *EscrowClient) Close() error {
+	if uc.conn != nil {
+		return uc.conn.Close()
+	}
+	return nil
+}
+
+// GetEscrowKey - метод получает открытый ключ депонирования организации
+func (uc *EscrowClient) GetEscrowKey() ([]byte, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetEscrowKey(uc.ctx, &pb.GetEscrowKeyRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.GetPublicKey(), nil
+	case codes.FailedPrecondition:
+		return nil, ErrEscrowNotConfigured
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get escrow key error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// SetEscrowKey - метод сохраняет ключ шифрования, зашифрованный открытым ключом депонирования
+func (uc *EscrowClient) SetEscrowKey(sealed []byte) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.SetEscrowKey(uc.ctx, &pb.SetEscrowKeyRequest{EscrowKey: sealed})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.FailedPrecondition:
+		return ErrEscrowNotConfigured
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	case codes.InvalidArgument:
+		logger.Warn("Invalid escrow key", err.Error())
+		return fmt.Errorf("invalid escrow key")
+	default:
+		logger.Warn("Set escrow key error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// Enroll - метод депонирует ключ хранилища key: шифрует его открытым ключом депонирования
+// publicKey (подтверждённым пользователем по отпечатку) и сохраняет на сервере
+func (uc *EscrowClient) Enroll(auth *models.AuthInfo, publicKey []byte, key []byte) error {
+	sealed, err := auth.EscrowKey(publicKey, key)
+	if err != nil {
+		return err
+	}
+	return uc.SetEscrowKey(sealed)
+}
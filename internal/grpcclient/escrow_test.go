@@ -0,0 +1,154 @@
+package grpcclient
+
+import (
+	"context"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/proto/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEscrowClient_GetEscrowKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockEscrowClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.EscrowClient
+		ExpectedKey   []byte
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Get escrow key",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetEscrowKey(gomock.Any(), gomock.Any()).Return(
+					&pb.GetEscrowKeyResponse{PublicKey: []byte("public")}, nil,
+				)
+			},
+			Client:      mockClient,
+			ExpectedKey: []byte("public"),
+		},
+		{
+			TestName: "Error. Escrow is not configured",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetEscrowKey(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.FailedPrecondition, "escrow is not configured"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: ErrEscrowNotConfigured.Error(),
+		},
+		{
+			TestName: "Error. Unauthenticated",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetEscrowKey(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "unknown user"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user unauthenticated",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &EscrowClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			key, err := uc.GetEscrowKey()
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedKey, key)
+			}
+		})
+	}
+}
+
+func TestEscrowClient_Enroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockEscrowClient(ctrl)
+
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+	escrowPublic, escrowPrivate, err := crypto.GenerateKeyPair()
+	require.NoError(t, err, "GenerateKeyPair failed")
+	auth := &models.AuthInfo{UserID: "user-1"}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		PublicKey     []byte
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Enroll vault key",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetEscrowKey(gomock.Any(), gomock.Cond(func(r *pb.SetEscrowKeyRequest) bool {
+					// на сервер передаётся только ключ, зашифрованный открытым ключом депонирования
+					key, err := auth.OpenEscrowKey(escrowPrivate, r.GetEscrowKey())
+					return err == nil && string(key) == string(vaultKey)
+				})).Return(&pb.SetEscrowKeyResponse{}, nil)
+			},
+			PublicKey: escrowPublic,
+		},
+		{
+			TestName: "Error. Escrow is not configured",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetEscrowKey(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.FailedPrecondition, "escrow is not configured"),
+				)
+			},
+			PublicKey:     escrowPublic,
+			ExpectedError: ErrEscrowNotConfigured.Error(),
+		},
+		{
+			TestName:      "Error. Invalid escrow public key",
+			SetupMocks:    func() {},
+			PublicKey:     []byte("invalid"),
+			ExpectedError: "invalid public key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &EscrowClient{
+				client: mockClient,
+				ctx:    context.Background(),
+			}
+
+			err := uc.Enroll(auth, tc.PublicKey, vaultKey)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
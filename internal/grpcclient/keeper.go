@@ -2,6 +2,7 @@ package grpcclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-pass-keeper/internal/grpcclient/interceptors"
 	"go-pass-keeper/internal/models"
@@ -15,6 +16,9 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ErrBatchRolledBack - ошибка пакета операций, изменения которого отменены
+var ErrBatchRolledBack = errors.New("batch rolled back")
+
 // KeeperClient модель клиента для работы с секретами
 type KeeperClient struct {
 	serverAddr string
@@ -97,6 +101,9 @@ func (uc *KeeperClient) AddSecret(info *models.SecretInfo, content []byte) (*mod
 	switch status.Code(err) {
 	case codes.OK:
 		return models.SecretInfoFromProtoMetadata(resp.GetMeta()), nil
+	case codes.PermissionDenied:
+		logger.Warn("Access denied", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
 	case codes.Unauthenticated:
 		logger.Warn("User unauthenticated", err.Error())
 		return nil, fmt.Errorf("user unauthenticated")
@@ -115,6 +122,9 @@ func (uc *KeeperClient) GetSecret(sid string) (*models.SecretInfo, []byte, error
 	switch status.Code(err) {
 	case codes.OK:
 		return models.SecretInfoFromProtoMetadata(resp.GetMeta()), resp.GetContent(), nil
+	case codes.PermissionDenied:
+		logger.Warn("Access denied", err.Error())
+		return nil, nil, fmt.Errorf("%s", status.Convert(err).Message())
 	case codes.Unauthenticated:
 		logger.Warn("User unauthenticated", err.Error())
 		return nil, nil, fmt.Errorf("user unauthenticated")
@@ -124,15 +134,34 @@ func (uc *KeeperClient) GetSecret(sid string) (*models.SecretInfo, []byte, error
 	}
 }
 
-// GetSecrets - метод получает список секретов пользователя
+// GetSecrets - метод получает список секретов личного хранилища пользователя
 func (uc *KeeperClient) GetSecrets() ([]*models.SecretInfo, error) {
+	return uc.GetVaultSecrets("")
+}
+
+// GetVaultSecrets - метод получает список секретов хранилища организации (пустой oid - личное хранилище)
+func (uc *KeeperClient) GetVaultSecrets(oid string) ([]*models.SecretInfo, error) {
+	return uc.listSecrets(oid, false)
+}
+
+// GetArchivedSecrets - метод получает список архивных (с истёкшим сроком) секретов хранилища
+// организации (пустой oid - личное хранилище)
+func (uc *KeeperClient) GetArchivedSecrets(oid string) ([]*models.SecretInfo, error) {
+	return uc.listSecrets(oid, true)
+}
+
+// listSecrets - метод запрашивает список действующих или архивных секретов хранилища
+func (uc *KeeperClient) listSecrets(oid string, archived bool) ([]*models.SecretInfo, error) {
 	if uc.client == nil {
 		return nil, fmt.Errorf("client not connected")
 	}
-	resp, err := uc.client.GetSecrets(uc.ctx, &pb.GetSecretsRequest{})
+	resp, err := uc.client.GetSecrets(uc.ctx, &pb.GetSecretsRequest{OrgId: oid, Archived: archived})
 	switch status.Code(err) {
 	case codes.OK:
 		return models.SecretsResponseToSecretInfo(resp), nil
+	case codes.PermissionDenied:
+		logger.Warn("Access denied", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
 	case codes.Unauthenticated:
 		logger.Warn("User unauthenticated", err.Error())
 		return nil, fmt.Errorf("user unauthenticated")
@@ -142,6 +171,32 @@ func (uc *KeeperClient) GetSecrets() ([]*models.SecretInfo, error) {
 	}
 }
 
+// SearchSecrets - метод ищет действующие секреты хранилища (oid пустой для личного)
+// по значениям слепого индекса слов запроса
+func (uc *KeeperClient) SearchSecrets(oid string, tokens [][]byte) ([]*models.SecretInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.SearchSecrets(uc.ctx, &pb.SearchSecretsRequest{OrgId: oid, Tokens: tokens})
+	switch status.Code(err) {
+	case codes.OK:
+		res := make([]*models.SecretInfo, 0, len(resp.GetSecrets()))
+		for _, meta := range resp.GetSecrets() {
+			res = append(res, models.SecretInfoFromProtoMetadata(meta))
+		}
+		return res, nil
+	case codes.PermissionDenied, codes.InvalidArgument:
+		logger.Warn("Search rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Search secrets error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
 // DeleteSecret - метод удаляет секрет
 func (uc *KeeperClient) DeleteSecret(sid string) (string, error) {
 	if uc.client == nil {
@@ -151,6 +206,9 @@ func (uc *KeeperClient) DeleteSecret(sid string) (string, error) {
 	switch status.Code(err) {
 	case codes.OK:
 		return resp.GetMeta().GetId(), nil
+	case codes.PermissionDenied:
+		logger.Warn("Access denied", err.Error())
+		return "", fmt.Errorf("%s", status.Convert(err).Message())
 	case codes.Unauthenticated:
 		logger.Warn("User unauthenticated", err.Error())
 		return "", fmt.Errorf("user unauthenticated")
@@ -176,6 +234,9 @@ func (uc *KeeperClient) EditSecret(info *models.SecretInfo, content []byte) (*mo
 	switch status.Code(err) {
 	case codes.OK:
 		return models.SecretInfoFromProtoMetadata(resp.GetMeta()), nil
+	case codes.PermissionDenied:
+		logger.Warn("Access denied", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
 	case codes.Unauthenticated:
 		logger.Warn("User unauthenticated", err.Error())
 		return nil, fmt.Errorf("user unauthenticated")
@@ -184,3 +245,68 @@ func (uc *KeeperClient) EditSecret(info *models.SecretInfo, content []byte) (*mo
 		return nil, fmt.Errorf("internal error")
 	}
 }
+
+// SetExpiration - метод изменяет срок действия секрета (нулевое значение - бессрочно)
+// и действие по его истечении
+func (uc *KeeperClient) SetExpiration(info *models.SecretInfo) (*models.SecretInfo, error) {
+	if info == nil {
+		return nil, fmt.Errorf("invalid info")
+	}
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.SetExpiration(uc.ctx, &pb.SetExpirationRequest{Meta: info.ToProtoMetadata()})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.SecretInfoFromProtoMetadata(resp.GetMeta()), nil
+	case codes.PermissionDenied, codes.InvalidArgument:
+		logger.Warn("Set expiration rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Set expiration error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// BatchSecrets - метод выполняет пакет операций над секретами за один запрос в одной транзакции.
+// Если пакет отменён, возвращаются результаты операций и ошибка ErrBatchRolledBack
+// с описанием операции, вызвавшей отказ.
+func (uc *KeeperClient) BatchSecrets(ops []*models.SecretOperation) ([]*models.SecretOperationResult, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	request := &pb.BatchSecretsRequest{Operations: make([]*pb.SecretOperation, 0, len(ops))}
+	for _, op := range ops {
+		request.Operations = append(request.Operations, op.ToProto())
+	}
+	resp, err := uc.client.BatchSecrets(uc.ctx, request)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		logger.Warn("Batch rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Batch secrets error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+
+	results := make([]*models.SecretOperationResult, 0, len(resp.GetResults()))
+	for _, result := range resp.GetResults() {
+		results = append(results, models.SecretOperationResultFromProto(result))
+	}
+	if resp.GetCommitted() {
+		return results, nil
+	}
+	for i, result := range resp.GetResults() {
+		if codes.Code(result.GetCode()) != codes.Aborted {
+			return results, fmt.Errorf("%w: operation %d: %s", ErrBatchRolledBack, i+1, result.GetError())
+		}
+	}
+	return results, ErrBatchRolledBack
+}
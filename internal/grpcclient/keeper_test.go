@@ -597,3 +597,289 @@ func TestKeeperClient_EditSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestKeeperClient_GetArchivedSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeeperClient(ctrl)
+
+	pbCreatedTime := timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC))
+	mdCreatedTime := time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)
+
+	mockClient.EXPECT().GetSecrets(gomock.Any(), &pb.GetSecretsRequest{Archived: true}).Return(
+		&pb.GetSecretsResponse{
+			Secrets: []*pb.SecretMetadata{
+				{Id: "1", Name: "secret1", Type: "password", Created: pbCreatedTime, Updated: pbCreatedTime,
+					Expires: pbCreatedTime, ExpirePolicy: "archive", Archived: true},
+			},
+		}, nil,
+	)
+	uc := &KeeperClient{client: mockClient, ctx: context.Background()}
+
+	result, err := uc.GetArchivedSecrets("")
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.SecretInfo{
+		{ID: "1", Name: "secret1", Type: "password", Created: mdCreatedTime, Updated: mdCreatedTime,
+			Expires: mdCreatedTime, ExpirePolicy: "archive", Archived: true},
+	}, result)
+}
+
+func TestKeeperClient_SetExpiration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeeperClient(ctrl)
+
+	expires := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.KeeperClient
+		Info           *models.SecretInfo
+		ExpectedResult *models.SecretInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Set expiration",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetExpiration(gomock.Any(), &pb.SetExpirationRequest{
+					Meta: &pb.SecretMetadata{Id: "1", ExpirePolicy: "delete", Expires: timestamppb.New(expires)},
+				}).Return(&pb.SetExpirationResponse{
+					Meta: &pb.SecretMetadata{Id: "1", ExpirePolicy: "delete", Expires: timestamppb.New(expires)},
+				}, nil)
+			},
+			Client:         mockClient,
+			Info:           &models.SecretInfo{ID: "1", ExpirePolicy: "delete", Expires: expires},
+			ExpectedResult: &models.SecretInfo{ID: "1", ExpirePolicy: "delete", Expires: expires, Created: time.Unix(0, 0).UTC(), Updated: time.Unix(0, 0).UTC()},
+		},
+		{
+			TestName:      "Error. Invalid info",
+			SetupMocks:    func() {},
+			Client:        mockClient,
+			Info:          nil,
+			ExpectedError: "invalid info",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			Info:          &models.SecretInfo{ID: "1"},
+			ExpectedError: "client not connected",
+		},
+		{
+			TestName: "Error. Unknown policy",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetExpiration(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.InvalidArgument, "unknown expire policy"),
+				)
+			},
+			Client:        mockClient,
+			Info:          &models.SecretInfo{ID: "1", ExpirePolicy: "burn"},
+			ExpectedError: "unknown expire policy",
+		},
+		{
+			TestName: "Error. User unauthenticated",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetExpiration(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "unauthenticated"),
+				)
+			},
+			Client:        mockClient,
+			Info:          &models.SecretInfo{ID: "1"},
+			ExpectedError: "user unauthenticated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeeperClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			result, err := uc.SetExpiration(tc.Info)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, result)
+			}
+		})
+	}
+}
+
+func TestKeeperClient_BatchSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeeperClient(ctrl)
+
+	ops := []*models.SecretOperation{
+		{Kind: models.OperationAdd, Info: &models.SecretInfo{Name: "secret1", Type: "password"}, Content: []byte("content")},
+		{Kind: models.OperationDelete, Info: &models.SecretInfo{ID: "2"}},
+	}
+	request := &pb.BatchSecretsRequest{Operations: []*pb.SecretOperation{
+		{Op: &pb.SecretOperation_Add{Add: &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Name: "secret1", Type: "password"}, Content: []byte("content")}}},
+		{Op: &pb.SecretOperation_Delete{Delete: &pb.DeleteSecretRequest{Meta: &pb.SecretMetadata{Id: "2"}}}},
+	}}
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.KeeperClient
+		ExpectedResult []*models.SecretOperationResult
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Batch committed",
+			SetupMocks: func() {
+				mockClient.EXPECT().BatchSecrets(gomock.Any(), request).Return(&pb.BatchSecretsResponse{
+					Committed: true,
+					Results: []*pb.SecretOperationResult{
+						{Meta: &pb.SecretMetadata{Id: "1", Name: "secret1", Type: "password"}},
+						{Meta: &pb.SecretMetadata{Id: "2"}},
+					},
+				}, nil)
+			},
+			Client: mockClient,
+			ExpectedResult: []*models.SecretOperationResult{
+				{Info: &models.SecretInfo{ID: "1", Name: "secret1", Type: "password", Created: time.Unix(0, 0).UTC(), Updated: time.Unix(0, 0).UTC()}},
+				{Info: &models.SecretInfo{ID: "2", Created: time.Unix(0, 0).UTC(), Updated: time.Unix(0, 0).UTC()}},
+			},
+		},
+		{
+			TestName: "Error. Batch rolled back",
+			SetupMocks: func() {
+				mockClient.EXPECT().BatchSecrets(gomock.Any(), gomock.Any()).Return(&pb.BatchSecretsResponse{
+					Results: []*pb.SecretOperationResult{
+						{Code: int32(codes.Aborted), Error: "rolled back"},
+						{Code: int32(codes.NotFound), Error: "not found"},
+					},
+				}, nil)
+			},
+			Client:        mockClient,
+			ExpectedError: "batch rolled back: operation 2: not found",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+		{
+			TestName: "Error. User unauthenticated",
+			SetupMocks: func() {
+				mockClient.EXPECT().BatchSecrets(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "unauthenticated"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user unauthenticated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeeperClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			result, err := uc.BatchSecrets(ops)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, result)
+			}
+		})
+	}
+}
+
+func TestKeeperClient_SearchSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeeperClient(ctrl)
+
+	pbCreatedTime := timestamppb.New(time.Date(2025, time.October, 12, 9, 0, 0, 0, time.UTC))
+	mdCreatedTime := time.Date(2025, time.October, 12, 9, 0, 0, 0, time.UTC)
+	tokens := [][]byte{[]byte("token")}
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.KeeperClient
+		ExpectedResult []*models.SecretInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Search secrets",
+			SetupMocks: func() {
+				mockClient.EXPECT().SearchSecrets(gomock.Any(), &pb.SearchSecretsRequest{OrgId: "org", Tokens: tokens}).Return(
+					&pb.SearchSecretsResponse{Secrets: []*pb.SecretMetadata{
+						{Id: "1", OrgId: "org", Name: "GitHub", Type: "password", Created: pbCreatedTime, Updated: pbCreatedTime},
+					}}, nil,
+				)
+			},
+			Client: mockClient,
+			ExpectedResult: []*models.SecretInfo{
+				{ID: "1", OrgID: "org", Name: "GitHub", Type: "password", Created: mdCreatedTime, Updated: mdCreatedTime},
+			},
+		},
+		{
+			TestName: "Error. Empty search query",
+			SetupMocks: func() {
+				mockClient.EXPECT().SearchSecrets(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.InvalidArgument, "empty search query"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "empty search query",
+		},
+		{
+			TestName: "Error. Internal",
+			SetupMocks: func() {
+				mockClient.EXPECT().SearchSecrets(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Internal, "db down"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "internal error",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeeperClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			result, err := uc.SearchSecrets("org", tokens)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient/interceptors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// ErrRecoveryKeyNotFound - ошибка отсутствия ключа восстановления на сервере
+var ErrRecoveryKeyNotFound = errors.New("recovery key not found")
+
+var (
+	// ErrVaultRootNotFound - ошибка отсутствия подписанного состояния хранилища на сервере
+	ErrVaultRootNotFound = errors.New("vault root not found")
+	// ErrVaultRootConflict - ошибка замены состояния хранилища, изменённого другим клиентом
+	ErrVaultRootConflict = errors.New("vault root changed by another client")
+)
+
+// KeysClient модель клиента сервиса ключей личного хранилища
+type KeysClient struct {
+	serverAddr string
+	conn       *grpc.ClientConn
+	client     pb.KeysClient
+	opts       []grpc.DialOption
+	ctx        context.Context
+}
+
+// KeysClientOption определяет тип для опций
+type KeysClientOption func(*KeysClient)
+
+// NewKeysClient - метод создает новый экземпляр KeysClient
+func NewKeysClient(serverAddr string, token string, opts ...KeysClientOption) *KeysClient {
+	client := &KeysClient{
+		serverAddr: serverAddr,
+		opts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(interceptors.AuthInterceptor(token)),
+			grpc.WithStreamInterceptor(interceptors.AuthStreamInterceptor(token)),
+		},
+	}
+	// Применяем переданные опции
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// UseKeysOptions - метод добавляет дополнительные grpc опции
+func UseKeysOptions(opts ...grpc.DialOption) KeysClientOption {
+	return func(uc *KeysClient) {
+		uc.opts = append(uc.opts, opts...)
+	}
+}
+
+// Connect - метод устанавливает соединение с сервером
+func (uc *KeysClient) Connect(ctx context.Context) error {
+	_, err := url.ParseRequestURI(uc.serverAddr)
+	if err != nil {
+		return fmt.Errorf("invalid server address: %w", err)
+	}
+	conn, err := grpc.NewClient(uc.serverAddr, uc.opts...)
+	if err != nil {
+		logger.Error("Failed to connect to server", err.Error())
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	uc.conn = conn
+	uc.client = pb.NewKeysClient(conn)
+	uc.ctx = ctx
+	return nil
+}
+
+// Close - метод закрывает соединение
+func (uc *KeysClient) Close() error {
+	if uc.conn != nil {
+		return uc.conn.Close()
+	}
+	return nil
+}
+
+// SetKdf - метод сохраняет новые параметры получения ключа из пароля и ключ шифрования,
+// зашифрованный ключом, полученным с этими параметрами
+func (uc *KeysClient) SetKdf(kdf crypto.KDFParams, wrapped []byte) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.SetKdf(uc.ctx, &pb.SetKdfRequest{Kdf: models.KDFToProto(kdf), WrappedKey: wrapped})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	case codes.InvalidArgument:
+		logger.Warn("Invalid kdf parameters", err.Error())
+		return fmt.Errorf("invalid kdf parameters")
+	default:
+		logger.Warn("Set kdf error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// SetKeyCheck - метод сохраняет контрольное значение ключа шифрования
+func (uc *KeysClient) SetKeyCheck(check []byte) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.SetKeyCheck(uc.ctx, &pb.SetKeyCheckRequest{KeyCheck: check})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	case codes.InvalidArgument:
+		logger.Warn("Invalid key check", err.Error())
+		return fmt.Errorf("invalid key check")
+	default:
+		logger.Warn("Set key check error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// SetRecoveryKey - метод сохраняет ключ шифрования, зашифрованный ключом восстановления
+func (uc *KeysClient) SetRecoveryKey(wrapped []byte) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.SetRecoveryKey(uc.ctx, &pb.SetRecoveryKeyRequest{RecoveryKey: wrapped})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	case codes.InvalidArgument:
+		logger.Warn("Invalid recovery key", err.Error())
+		return fmt.Errorf("invalid recovery key")
+	default:
+		logger.Warn("Set recovery key error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// SetVaultRoot - метод сохраняет подписанное состояние личного хранилища с номером seq
+// (prev - номер состояния, на основе которого оно получено)
+func (uc *KeysClient) SetVaultRoot(root []byte, seq int64, prev int64) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.SetVaultRoot(uc.ctx, &pb.SetVaultRootRequest{VaultRoot: root, Sequence: seq, PreviousSequence: prev})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.Aborted:
+		return ErrVaultRootConflict
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	case codes.InvalidArgument:
+		logger.Warn("Invalid vault root", err.Error())
+		return fmt.Errorf("invalid vault root")
+	default:
+		logger.Warn("Set vault root error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// GetVaultRoot - метод получает подписанное состояние личного хранилища и его номер
+func (uc *KeysClient) GetVaultRoot() ([]byte, int64, error) {
+	if uc.client == nil {
+		return nil, 0, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetVaultRoot(uc.ctx, &pb.GetVaultRootRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.GetVaultRoot(), resp.GetSequence(), nil
+	case codes.NotFound:
+		return nil, 0, ErrVaultRootNotFound
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, 0, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get vault root error", err.Error())
+		return nil, 0, fmt.Errorf("internal error")
+	}
+}
+
+// GetRecoveryKey - метод получает ключ шифрования, зашифрованный ключом восстановления
+func (uc *KeysClient) GetRecoveryKey() ([]byte, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetRecoveryKey(uc.ctx, &pb.GetRecoveryKeyRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.GetRecoveryKey(), nil
+	case codes.NotFound:
+		return nil, ErrRecoveryKeyNotFound
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get recovery key error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// Recover - метод восстанавливает доступ к хранилищу ключом восстановления recoveryKey:
+// расшифровывает ключ хранилища и сохраняет его на сервере зашифрованным ключом из нового секрета.
+// Возвращает ключ хранилища, новые параметры и зашифрованный ключ записываются в auth.
+func (uc *KeysClient) Recover(auth *models.AuthInfo, recoveryKey []byte, secret string) ([]byte, error) {
+	wrapped, err := uc.GetRecoveryKey()
+	if err != nil {
+		return nil, err
+	}
+	key, err := auth.RecoverKey(recoveryKey, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	kdf := auth.KDF
+	if kdf.Weaker(crypto.DefaultKDFParams) {
+		kdf = crypto.DefaultKDFParams
+	}
+	wrappedKey, err := auth.WrapKey([]byte(secret), kdf, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.SetKdf(kdf, wrappedKey); err != nil {
+		return nil, err
+	}
+	auth.KDF = kdf
+	auth.WrappedKey = wrappedKey
+	return key, nil
+}
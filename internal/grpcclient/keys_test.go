@@ -0,0 +1,393 @@
+package grpcclient
+
+import (
+	"context"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/proto/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKeysClient_SetKdf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeysClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.KeysClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Set kdf",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetKdf(gomock.Any(), &pb.SetKdfRequest{
+					Kdf:        &pb.KdfParams{Algorithm: "argon2id", Memory: 65536, Iterations: 3, Parallelism: 4},
+					WrappedKey: []byte("wrapped"),
+				}).Return(&pb.SetKdfResponse{}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Weaker kdf",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetKdf(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.InvalidArgument, "kdf parameters are weaker than required"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "invalid kdf parameters",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeysClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.SetKdf(crypto.DefaultKDFParams, []byte("wrapped"))
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKeysClient_SetKeyCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeysClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.KeysClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Set key check",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetKeyCheck(gomock.Any(), &pb.SetKeyCheckRequest{KeyCheck: []byte("check")}).
+					Return(&pb.SetKeyCheckResponse{}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Invalid key check",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetKeyCheck(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.InvalidArgument, "invalid key check"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "invalid key check",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeysClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.SetKeyCheck([]byte("check"))
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKeysClient_SetRecoveryKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeysClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.KeysClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Set recovery key",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetRecoveryKey(gomock.Any(), &pb.SetRecoveryKeyRequest{RecoveryKey: []byte("wrapped")}).
+					Return(&pb.SetRecoveryKeyResponse{}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Invalid recovery key",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetRecoveryKey(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.InvalidArgument, "invalid recovery key"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "invalid recovery key",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeysClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.SetRecoveryKey([]byte("wrapped"))
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKeysClient_SetVaultRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeysClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.KeysClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Set vault root",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetVaultRoot(gomock.Any(), &pb.SetVaultRootRequest{VaultRoot: []byte("root"), Sequence: 2, PreviousSequence: 1}).
+					Return(&pb.SetVaultRootResponse{}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Vault root changed by another client",
+			SetupMocks: func() {
+				mockClient.EXPECT().SetVaultRoot(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Aborted, "conflict"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: ErrVaultRootConflict.Error(),
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeysClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.SetVaultRoot([]byte("root"), 2, 1)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKeysClient_GetVaultRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeysClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.KeysClient
+		ExpectedRoot  []byte
+		ExpectedSeq   int64
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Get vault root",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetVaultRoot(gomock.Any(), gomock.Any()).
+					Return(&pb.GetVaultRootResponse{VaultRoot: []byte("root"), Sequence: 3}, nil)
+			},
+			Client:       mockClient,
+			ExpectedRoot: []byte("root"),
+			ExpectedSeq:  3,
+		},
+		{
+			TestName: "Error. Vault root not set",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetVaultRoot(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.NotFound, "not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: ErrVaultRootNotFound.Error(),
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeysClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			root, seq, err := uc.GetVaultRoot()
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedRoot, root)
+				assert.Equal(t, tc.ExpectedSeq, seq)
+			}
+		})
+	}
+}
+
+func TestKeysClient_Recover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockKeysClient(ctrl)
+
+	const salt = "MDEyMzQ1Njc4OTAxMjM0NQ=="
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+	recoveryKey, err := crypto.GenerateRecoveryKey()
+	require.NoError(t, err, "GenerateRecoveryKey failed")
+	otherKey, err := crypto.GenerateRecoveryKey()
+	require.NoError(t, err, "GenerateRecoveryKey failed")
+	auth := models.AuthInfo{Salt: salt, UserID: "user-1", KDF: crypto.LegacyKDFParams}
+	wrapped, err := auth.WrapRecoveryKey(recoveryKey, vaultKey)
+	require.NoError(t, err, "WrapRecoveryKey failed")
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		RecoveryKey   []byte
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Recover vault key with new secret",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetRecoveryKey(gomock.Any(), gomock.Any()).
+					Return(&pb.GetRecoveryKeyResponse{RecoveryKey: wrapped}, nil)
+				mockClient.EXPECT().SetKdf(gomock.Any(), gomock.Cond(func(r *pb.SetKdfRequest) bool {
+					return r.GetKdf().GetAlgorithm() == crypto.KDFAlgorithmArgon2id && len(r.GetWrappedKey()) > 0
+				})).Return(&pb.SetKdfResponse{}, nil)
+			},
+			RecoveryKey: recoveryKey,
+		},
+		{
+			TestName: "Error. Recovery key not set",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetRecoveryKey(gomock.Any(), gomock.Any()).
+					Return(nil, status.Error(codes.NotFound, "not found"))
+			},
+			RecoveryKey:   recoveryKey,
+			ExpectedError: ErrRecoveryKeyNotFound.Error(),
+		},
+		{
+			TestName: "Error. Wrong recovery key",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetRecoveryKey(gomock.Any(), gomock.Any()).
+					Return(&pb.GetRecoveryKeyResponse{RecoveryKey: wrapped}, nil)
+			},
+			RecoveryKey:   otherKey,
+			ExpectedError: models.ErrWrongKey.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &KeysClient{
+				client: mockClient,
+				ctx:    context.Background(),
+			}
+
+			info := auth
+			key, err := uc.Recover(&info, tc.RecoveryKey, "new secret")
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, vaultKey, key)
+			// ключ хранилища открывается новым секретом
+			unlocked, err := info.UnlockKey("new secret")
+			require.NoError(t, err, "UnlockKey failed")
+			assert.Equal(t, vaultKey, unlocked)
+		})
+	}
+}
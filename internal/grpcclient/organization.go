@@ -0,0 +1,259 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient/interceptors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"net/url"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// OrganizationClient модель клиента для работы с организациями (командными хранилищами)
+type OrganizationClient struct {
+	serverAddr string
+	conn       *grpc.ClientConn
+	client     pb.OrganizationClient
+	opts       []grpc.DialOption
+	ctx        context.Context
+}
+
+// OrganizationClientOption определяет тип для опций
+type OrganizationClientOption func(*OrganizationClient)
+
+// NewOrganizationClient - метод создает новый экземпляр OrganizationClient
+func NewOrganizationClient(serverAddr string, token string, opts ...OrganizationClientOption) *OrganizationClient {
+	client := &OrganizationClient{
+		serverAddr: serverAddr,
+		opts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(interceptors.AuthInterceptor(token)),
+			grpc.WithStreamInterceptor(interceptors.AuthStreamInterceptor(token)),
+		},
+	}
+	// Применяем переданные опции
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// UseOrganizationOptions - метод добавляет дополнительные grpc опции
+func UseOrganizationOptions(opts ...grpc.DialOption) OrganizationClientOption {
+	return func(uc *OrganizationClient) {
+		uc.opts = append(uc.opts, opts...)
+	}
+}
+
+// Connect - метод устанавливает соединение с сервером
+func (uc *OrganizationClient) Connect(ctx context.Context) error {
+	_, err := url.ParseRequestURI(uc.serverAddr)
+	if err != nil {
+		return fmt.Errorf("invalid server address: %w", err)
+	}
+	conn, err := grpc.NewClient(uc.serverAddr, uc.opts...)
+	if err != nil {
+		logger.Error("Failed to connect to server", err.Error())
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	uc.conn = conn
+	uc.client = pb.NewOrganizationClient(conn)
+	uc.ctx = ctx
+	return nil
+}
+
+// Close - метод закрывает соединение
+func (uc *OrganizationClient) Close() error {
+	if uc.conn != nil {
+		return uc.conn.Close()
+	}
+	return nil
+}
+
+// CreateOrganization - метод создаёт организацию (ключ хранилища зашифрован открытым ключом создателя)
+func (uc *OrganizationClient) CreateOrganization(name string, vaultKey []byte) (*models.OrganizationInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.CreateOrganization(uc.ctx, &pb.CreateOrganizationRequest{Name: name, VaultKey: vaultKey})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.OrganizationInfoFromProto(resp.GetOrganization()), nil
+	case codes.AlreadyExists:
+		logger.Warn("Organization already exists", err.Error())
+		return nil, fmt.Errorf("organization %s already exists", name)
+	case codes.InvalidArgument:
+		logger.Warn("Create organization rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Create organization error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// GetOrganizations - метод получает список организаций пользователя
+func (uc *OrganizationClient) GetOrganizations() ([]*models.OrganizationInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetOrganizations(uc.ctx, &pb.GetOrganizationsRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		res := make([]*models.OrganizationInfo, 0, len(resp.GetOrganizations()))
+		for _, org := range resp.GetOrganizations() {
+			res = append(res, models.OrganizationInfoFromProto(org))
+		}
+		return res, nil
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get organizations error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// GetMembers - метод получает список участников организации
+func (uc *OrganizationClient) GetMembers(oid string) ([]*models.MemberInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetMembers(uc.ctx, &pb.GetMembersRequest{OrgId: oid})
+	switch status.Code(err) {
+	case codes.OK:
+		res := make([]*models.MemberInfo, 0, len(resp.GetMembers()))
+		for _, member := range resp.GetMembers() {
+			res = append(res, models.MemberInfoFromProto(member))
+		}
+		return res, nil
+	case codes.PermissionDenied, codes.InvalidArgument:
+		logger.Warn("Get members rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get members error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// AddMember - метод добавляет участника в организацию (ключ хранилища зашифрован открытым ключом участника)
+func (uc *OrganizationClient) AddMember(oid string, login string, role string, vaultKey []byte) (*models.MemberInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.AddMember(uc.ctx, &pb.AddMemberRequest{OrgId: oid, Login: login, Role: role, VaultKey: vaultKey})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.MemberInfoFromProto(resp.GetMember()), nil
+	case codes.NotFound:
+		logger.Warn("User not found", err.Error())
+		return nil, fmt.Errorf("user %s not found", login)
+	case codes.AlreadyExists:
+		logger.Warn("Member already exists", err.Error())
+		return nil, fmt.Errorf("user %s already in organization", login)
+	case codes.PermissionDenied, codes.InvalidArgument:
+		logger.Warn("Add member rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Add member error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// UpdateMember - метод изменяет роль участника организации
+func (uc *OrganizationClient) UpdateMember(oid string, login string, role string) (*models.MemberInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.UpdateMember(uc.ctx, &pb.UpdateMemberRequest{OrgId: oid, Login: login, Role: role})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.MemberInfoFromProto(resp.GetMember()), nil
+	case codes.NotFound:
+		logger.Warn("Member not found", err.Error())
+		return nil, fmt.Errorf("member %s not found", login)
+	case codes.PermissionDenied, codes.InvalidArgument:
+		logger.Warn("Update member rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Update member error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// RemoveMember - метод удаляет участника из организации
+func (uc *OrganizationClient) RemoveMember(oid string, login string) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.RemoveMember(uc.ctx, &pb.RemoveMemberRequest{OrgId: oid, Login: login})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound:
+		logger.Warn("Member not found", err.Error())
+		return fmt.Errorf("member %s not found", login)
+	case codes.PermissionDenied, codes.InvalidArgument:
+		logger.Warn("Remove member rejected", err.Error())
+		return fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Remove member error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// RotateVaultKey - метод заменяет ключ хранилища организации: members - новый ключ,
+// зашифрованный открытым ключом каждого участника (по логину), secrets - все секреты
+// организации с ключом данных, зашифрованным новым ключом, и слепым индексом на новом ключе
+func (uc *OrganizationClient) RotateVaultKey(oid string, members map[string][]byte, secrets []*models.SecretInfo) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	request := &pb.RotateVaultKeyRequest{OrgId: oid}
+	logins := make([]string, 0, len(members))
+	for login := range members {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	for _, login := range logins {
+		request.Members = append(request.Members, &pb.MemberVaultKey{Login: login, VaultKey: members[login]})
+	}
+	for _, secret := range secrets {
+		request.Secrets = append(request.Secrets, &pb.SecretDataKey{Id: secret.ID, WrappedKey: secret.DataKey, SearchIndex: secret.SearchIndex})
+	}
+	_, err := uc.client.RotateVaultKey(uc.ctx, request)
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.PermissionDenied, codes.InvalidArgument, codes.NotFound, codes.Aborted:
+		logger.Warn("Rotate vault key rejected", err.Error())
+		return fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Rotate vault key error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
@@ -0,0 +1,230 @@
+package grpcclient
+
+import (
+	"context"
+	"go-pass-keeper/internal/models"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/proto/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestOrganizationClient_GetOrganizations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockOrganizationClient(ctrl)
+
+	created := time.Date(2025, time.October, 3, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.OrganizationClient
+		ExpectedResult []*models.OrganizationInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Get organizations",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetOrganizations(gomock.Any(), &pb.GetOrganizationsRequest{}).Return(
+					&pb.GetOrganizationsResponse{Organizations: []*pb.OrganizationInfo{
+						{Id: "org-1", Name: "team", Role: "admin", VaultKey: []byte("key"), Created: timestamppb.New(created)},
+					}}, nil,
+				)
+			},
+			Client: mockClient,
+			ExpectedResult: []*models.OrganizationInfo{
+				{ID: "org-1", Name: "team", Role: "admin", VaultKey: []byte("key"), Created: created},
+			},
+		},
+		{
+			TestName: "Error. User unauthenticated",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetOrganizations(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "unauthenticated"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user unauthenticated",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &OrganizationClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			result, err := uc.GetOrganizations()
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, result)
+			}
+		})
+	}
+}
+
+func TestOrganizationClient_AddMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockOrganizationClient(ctrl)
+
+	created := time.Date(2025, time.October, 3, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.OrganizationClient
+		ExpectedResult *models.MemberInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Add member",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddMember(gomock.Any(), &pb.AddMemberRequest{
+					OrgId: "org-1", Login: "bob", Role: "member", VaultKey: []byte("key"),
+				}).Return(&pb.AddMemberResponse{Member: &pb.Member{Login: "bob", Role: "member", Created: timestamppb.New(created)}}, nil)
+			},
+			Client:         mockClient,
+			ExpectedResult: &models.MemberInfo{Login: "bob", Role: "member", Created: created},
+		},
+		{
+			TestName: "Error. User not found",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddMember(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.NotFound, "not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user bob not found",
+		},
+		{
+			TestName: "Error. Insufficient role",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddMember(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.PermissionDenied, "insufficient role"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "insufficient role",
+		},
+		{
+			TestName: "Error. Already a member",
+			SetupMocks: func() {
+				mockClient.EXPECT().AddMember(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.AlreadyExists, "already exists"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "already in organization",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &OrganizationClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			result, err := uc.AddMember("org-1", "bob", "member", []byte("key"))
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, result)
+			}
+		})
+	}
+}
+
+func TestOrganizationClient_RotateVaultKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockOrganizationClient(ctrl)
+
+	members := map[string][]byte{"bob": []byte("key-bob"), "alice": []byte("key-alice")}
+	secrets := []*models.SecretInfo{{ID: "s1", DataKey: []byte("data-key"), SearchIndex: [][]byte{[]byte("token")}}}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.OrganizationClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Rotate vault key",
+			SetupMocks: func() {
+				mockClient.EXPECT().RotateVaultKey(gomock.Any(), &pb.RotateVaultKeyRequest{
+					OrgId: "org-1",
+					Members: []*pb.MemberVaultKey{
+						{Login: "alice", VaultKey: []byte("key-alice")},
+						{Login: "bob", VaultKey: []byte("key-bob")},
+					},
+					Secrets: []*pb.SecretDataKey{{Id: "s1", WrappedKey: []byte("data-key"), SearchIndex: [][]byte{[]byte("token")}}},
+				}).Return(&pb.RotateVaultKeyResponse{}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Members changed",
+			SetupMocks: func() {
+				mockClient.EXPECT().RotateVaultKey(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Aborted, "organization members or secrets changed"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "organization members or secrets changed",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &OrganizationClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.RotateVaultKey("org-1", members, secrets)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ type Settings struct {
 	ServerURL  string `json:"server_url"`
 	ServerPort string `json:"server_port"`
 	Timeout    int    `json:"timeout"`
-	Secret     string // не сохраняем для секурности
+	Secret     string `json:"-"` // секрет хранилища вводится в окне настроек и в файл не сохраняется
 }
 
 // ServerAddress - формирование строки адреса сервера
@@ -0,0 +1,218 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient/interceptors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// ErrKeyPairNotFound - ошибка отсутствия пары ключей на сервере
+var ErrKeyPairNotFound = errors.New("key pair not found")
+
+// ShareClient модель клиента для передачи секретов между пользователями
+type ShareClient struct {
+	serverAddr string
+	conn       *grpc.ClientConn
+	client     pb.ShareClient
+	opts       []grpc.DialOption
+	ctx        context.Context
+}
+
+// ShareClientOption определяет тип для опций
+type ShareClientOption func(*ShareClient)
+
+// NewShareClient - метод создает новый экземпляр ShareClient
+func NewShareClient(serverAddr string, token string, opts ...ShareClientOption) *ShareClient {
+	client := &ShareClient{
+		serverAddr: serverAddr,
+		opts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(interceptors.AuthInterceptor(token)),
+			grpc.WithStreamInterceptor(interceptors.AuthStreamInterceptor(token)),
+		},
+	}
+	// Применяем переданные опции
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// UseShareOptions - метод добавляет дополнительные grpc опции
+func UseShareOptions(opts ...grpc.DialOption) ShareClientOption {
+	return func(uc *ShareClient) {
+		uc.opts = append(uc.opts, opts...)
+	}
+}
+
+// Connect - метод устанавливает соединение с сервером
+func (uc *ShareClient) Connect(ctx context.Context) error {
+	_, err := url.ParseRequestURI(uc.serverAddr)
+	if err != nil {
+		return fmt.Errorf("invalid server address: %w", err)
+	}
+	conn, err := grpc.NewClient(uc.serverAddr, uc.opts...)
+	if err != nil {
+		logger.Error("Failed to connect to server", err.Error())
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	uc.conn = conn
+	uc.client = pb.NewShareClient(conn)
+	uc.ctx = ctx
+	return nil
+}
+
+// Close - метод закрывает соединение
+func (uc *ShareClient) Close() error {
+	if uc.conn != nil {
+		return uc.conn.Close()
+	}
+	return nil
+}
+
+// SetKeyPair - метод сохраняет пару ключей пользователя (закрытый ключ должен быть зашифрован)
+func (uc *ShareClient) SetKeyPair(public []byte, private []byte) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.SetKeyPair(uc.ctx, &pb.SetKeyPairRequest{PublicKey: public, PrivateKey: private})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Set key pair error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
+
+// GetKeyPair - метод получает пару ключей пользователя (открытый, зашифрованный закрытый)
+func (uc *ShareClient) GetKeyPair() ([]byte, []byte, error) {
+	if uc.client == nil {
+		return nil, nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetKeyPair(uc.ctx, &pb.GetKeyPairRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.GetPublicKey(), resp.GetPrivateKey(), nil
+	case codes.NotFound:
+		return nil, nil, ErrKeyPairNotFound
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get key pair error", err.Error())
+		return nil, nil, fmt.Errorf("internal error")
+	}
+}
+
+// GetPublicKey - метод получает открытый ключ пользователя по логину
+func (uc *ShareClient) GetPublicKey(login string) ([]byte, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.GetPublicKey(uc.ctx, &pb.GetPublicKeyRequest{Login: login})
+	switch status.Code(err) {
+	case codes.OK:
+		return resp.GetPublicKey(), nil
+	case codes.NotFound:
+		logger.Warn("Recipient not found", err.Error())
+		return nil, fmt.Errorf("user %s not found", login)
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Get public key error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// ShareSecret - метод передает секрет пользователю по ссылке (ключ данных секрета зашифрован
+// открытым ключом получателя, получатель видит текущее содержимое секрета)
+func (uc *ShareClient) ShareSecret(sid string, recipient string, wrappedKey []byte) (*models.SharedSecretInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.ShareSecret(uc.ctx, &pb.ShareSecretRequest{
+		Meta:       &pb.SecretMetadata{Id: sid},
+		Recipient:  recipient,
+		WrappedKey: wrappedKey,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return models.SharedSecretInfoFromProto(resp.GetShare()), nil
+	case codes.NotFound:
+		logger.Warn("Share secret not found", err.Error())
+		return nil, fmt.Errorf("secret or user not found")
+	case codes.PermissionDenied, codes.InvalidArgument, codes.FailedPrecondition:
+		logger.Warn("Share secret rejected", err.Error())
+		return nil, fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Share secret error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// ListSharedWithMe - метод получает список секретов, переданных пользователю
+func (uc *ShareClient) ListSharedWithMe() ([]*models.SharedSecretInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	resp, err := uc.client.ListSharedWithMe(uc.ctx, &pb.ListSharedWithMeRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		res := make([]*models.SharedSecretInfo, 0, len(resp.GetShares()))
+		for _, share := range resp.GetShares() {
+			res = append(res, models.SharedSecretInfoFromProto(share))
+		}
+		return res, nil
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return nil, fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("List shared secrets error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+}
+
+// RevokeShare - метод отзывает доступ пользователя к секрету
+func (uc *ShareClient) RevokeShare(sid string, recipient string) error {
+	if uc.client == nil {
+		return fmt.Errorf("client not connected")
+	}
+	_, err := uc.client.RevokeShare(uc.ctx, &pb.RevokeShareRequest{
+		Meta:      &pb.SecretMetadata{Id: sid},
+		Recipient: recipient,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound:
+		logger.Warn("Share not found", err.Error())
+		return fmt.Errorf("share not found")
+	case codes.PermissionDenied:
+		logger.Warn("Revoke share rejected", err.Error())
+		return fmt.Errorf("%s", status.Convert(err).Message())
+	case codes.Unauthenticated:
+		logger.Warn("User unauthenticated", err.Error())
+		return fmt.Errorf("user unauthenticated")
+	default:
+		logger.Warn("Revoke share error", err.Error())
+		return fmt.Errorf("internal error")
+	}
+}
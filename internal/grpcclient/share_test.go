@@ -0,0 +1,233 @@
+package grpcclient
+
+import (
+	"context"
+	"go-pass-keeper/internal/models"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/proto/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestShareClient_GetKeyPair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockShareClient(ctrl)
+
+	testCases := []struct {
+		TestName        string
+		SetupMocks      func()
+		Client          pb.ShareClient
+		ExpectedPublic  []byte
+		ExpectedPrivate []byte
+		ExpectedError   string
+	}{
+		{
+			TestName: "Success. Get key pair",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetKeyPair(gomock.Any(), gomock.Any()).Return(
+					&pb.GetKeyPairResponse{PublicKey: []byte("public"), PrivateKey: []byte("private")}, nil,
+				)
+			},
+			Client:          mockClient,
+			ExpectedPublic:  []byte("public"),
+			ExpectedPrivate: []byte("private"),
+		},
+		{
+			TestName: "Error. Key pair not found",
+			SetupMocks: func() {
+				mockClient.EXPECT().GetKeyPair(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.NotFound, "not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: ErrKeyPairNotFound.Error(),
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &ShareClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			public, private, err := uc.GetKeyPair()
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedPublic, public)
+				assert.Equal(t, tc.ExpectedPrivate, private)
+			}
+		})
+	}
+}
+
+func TestShareClient_ShareSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockShareClient(ctrl)
+
+	pbCreatedTime := timestamppb.New(time.Date(2025, time.October, 1, 10, 30, 0, 0, time.UTC))
+	mdCreatedTime := time.Date(2025, time.October, 1, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.ShareClient
+		ExpectedResult *models.SharedSecretInfo
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Share secret",
+			SetupMocks: func() {
+				mockClient.EXPECT().ShareSecret(gomock.Any(), &pb.ShareSecretRequest{
+					Meta:       &pb.SecretMetadata{Id: "secret-123"},
+					Recipient:  "bob",
+					WrappedKey: []byte("key"),
+				}).Return(&pb.ShareSecretResponse{Share: &pb.SharedSecret{
+					Id:        "share-1",
+					Meta:      &pb.SecretMetadata{Id: "secret-123", Name: "test-secret", Type: "password"},
+					Recipient: "bob",
+					Created:   pbCreatedTime,
+				}}, nil)
+			},
+			Client: mockClient,
+			ExpectedResult: &models.SharedSecretInfo{
+				ID:        "share-1",
+				Secret:    &models.SecretInfo{ID: "secret-123", Name: "test-secret", Type: "password", Created: time.Unix(0, 0).UTC(), Updated: time.Unix(0, 0).UTC()},
+				Recipient: "bob",
+				Created:   mdCreatedTime,
+			},
+		},
+		{
+			TestName: "Error. Recipient not found",
+			SetupMocks: func() {
+				mockClient.EXPECT().ShareSecret(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.NotFound, "not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "secret or user not found",
+		},
+		{
+			TestName: "Error. Permission denied",
+			SetupMocks: func() {
+				mockClient.EXPECT().ShareSecret(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.PermissionDenied, "secret belongs to another user"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "secret belongs to another user",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &ShareClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			result, err := uc.ShareSecret("secret-123", "bob", []byte("key"))
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedResult, result)
+			}
+		})
+	}
+}
+
+func TestShareClient_RevokeShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockShareClient(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Client        pb.ShareClient
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Revoke share",
+			SetupMocks: func() {
+				mockClient.EXPECT().RevokeShare(gomock.Any(), &pb.RevokeShareRequest{
+					Meta:      &pb.SecretMetadata{Id: "secret-123"},
+					Recipient: "bob",
+				}).Return(&pb.RevokeShareResponse{Meta: &pb.SecretMetadata{Id: "secret-123"}}, nil)
+			},
+			Client: mockClient,
+		},
+		{
+			TestName: "Error. Share not found",
+			SetupMocks: func() {
+				mockClient.EXPECT().RevokeShare(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.NotFound, "not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "share not found",
+		},
+		{
+			TestName: "Error. User unauthenticated",
+			SetupMocks: func() {
+				mockClient.EXPECT().RevokeShare(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "unauthenticated"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user unauthenticated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &ShareClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			err := uc.RevokeShare("secret-123", "bob")
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package grpcclient
 import (
 	"context"
 	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
 	"go-pass-keeper/pkg/logger"
 	pb "go-pass-keeper/pkg/proto"
 	"net/url"
@@ -73,50 +75,106 @@ func (uc *UserClient) Close() error {
 	return nil
 }
 
-// Register - метод регистрирует нового пользователя
-func (uc *UserClient) Register(login string, password string) (string, string, error) {
+// Register - метод регистрирует нового пользователя (серверу передаётся только проверочное значение пароля SRP)
+func (uc *UserClient) Register(login string, password string) (*models.AuthInfo, error) {
 	if uc.client == nil {
-		return "", "", fmt.Errorf("client not connected")
+		return nil, fmt.Errorf("client not connected")
+	}
+	salt, verifier, err := crypto.SRPVerifier(password)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := uc.client.Register(uc.ctx, &pb.RegisterRequest{
-		Login:    login,
-		Password: password,
+		Login:       login,
+		SrpSalt:     salt,
+		SrpVerifier: verifier,
 	})
 
 	switch status.Code(err) {
 	case codes.OK:
 		logger.Info("User registered", login)
-		return resp.GetToken(), resp.GetSalt(), nil
+		return &models.AuthInfo{Token: resp.GetToken(), Salt: resp.GetSalt(), UserID: resp.GetUserId(), KDF: models.KDFFromProto(resp.GetKdf())}, nil
 	case codes.InvalidArgument:
 		logger.Warn("invalid user", err.Error())
-		return "", "", fmt.Errorf("invalid user")
+		return nil, fmt.Errorf("invalid user")
 	default:
 		logger.Warn("User register error", err.Error())
-		return "", "", fmt.Errorf("internal error")
+		return nil, fmt.Errorf("internal error")
 	}
 }
 
-// Login - метод авторизует пользователя
-func (uc *UserClient) Login(login, password string) (string, string, error) {
+// Login - метод авторизует пользователя по SRP: пароль серверу не передаётся, а сервер подтверждает,
+// что знает проверочное значение пароля. Учётные записи, созданные до появления SRP, так не входят,
+// пока пользователь не выполнит перенос (см. MigrateLogin): пароль никогда не передаётся автоматически.
+func (uc *UserClient) Login(login, password string) (*models.AuthInfo, error) {
 	if uc.client == nil {
-		return "", "", fmt.Errorf("client not connected")
+		return nil, fmt.Errorf("client not connected")
+	}
+	srp, err := crypto.NewSRPClient(password)
+	if err != nil {
+		return nil, err
 	}
 
+	start, err := uc.client.LoginStart(uc.ctx, &pb.LoginStartRequest{Login: login, Public: srp.Public()})
+	if err != nil {
+		logger.Warn("User login error", err.Error())
+		return nil, fmt.Errorf("internal error")
+	}
+	proof, err := srp.Proof(start.GetSrpSalt(), start.GetPublic())
+	if err != nil {
+		logger.Warn("User login error", err.Error())
+		return nil, fmt.Errorf("invalid server response")
+	}
+
+	resp, err := uc.client.LoginFinish(uc.ctx, &pb.LoginFinishRequest{SessionId: start.GetSessionId(), Proof: proof})
+	if err == nil {
+		if err := srp.VerifyServer(resp.GetServerProof()); err != nil {
+			logger.Warn("Server authentication failed", err.Error())
+			return nil, fmt.Errorf("server authentication failed")
+		}
+	}
+	return loginResult(login, resp, err)
+}
+
+// MigrateLogin - метод однократного переноса учётной записи, созданной до появления SRP: пароль
+// передаётся серверу вместе с проверочным значением, которое сервер сохраняет вместо хеша пароля.
+// Вызывается только по явному подтверждению пользователя; после переноса вход выполняется по SRP.
+func (uc *UserClient) MigrateLogin(login, password string) (*models.AuthInfo, error) {
+	if uc.client == nil {
+		return nil, fmt.Errorf("client not connected")
+	}
+	salt, verifier, err := crypto.SRPVerifier(password)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := uc.client.Login(uc.ctx, &pb.LoginRequest{
-		Login:    login,
-		Password: password,
+		Login:       login,
+		Password:    password,
+		SrpSalt:     salt,
+		SrpVerifier: verifier,
 	})
+	return loginResult(login, resp, err)
+}
 
+// loginResult - метод формирует результат авторизации по ответу сервера
+func loginResult(login string, resp *pb.LoginResponse, err error) (*models.AuthInfo, error) {
 	switch status.Code(err) {
 	case codes.OK:
 		logger.Info("User is authorized", login)
-		return resp.GetToken(), resp.GetSalt(), nil
+		return &models.AuthInfo{
+			Token:      resp.GetToken(),
+			Salt:       resp.GetSalt(),
+			UserID:     resp.GetUserId(),
+			KDF:        models.KDFFromProto(resp.GetKdf()),
+			WrappedKey: resp.GetWrappedKey(),
+			KeyCheck:   resp.GetKeyCheck(),
+		}, nil
 	case codes.Unauthenticated:
 		logger.Warn("User unauthenticated", err.Error())
-		return "", "", fmt.Errorf("user unauthenticated")
+		return nil, fmt.Errorf("user unauthenticated")
 	default:
 		logger.Warn("User login error", err.Error())
-		return "", "", fmt.Errorf("internal error")
+		return nil, fmt.Errorf("internal error")
 	}
 }
@@ -2,6 +2,7 @@ package grpcclient
 
 import (
 	"context"
+	"go-pass-keeper/pkg/crypto"
 	pb "go-pass-keeper/pkg/proto"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -67,32 +69,35 @@ func TestUserClient_Register(t *testing.T) {
 	mockClient := mocks.NewMockUserClient(ctrl)
 
 	testCases := []struct {
-		TestName      string
-		SetupMocks    func()
-		Client        pb.UserClient
-		Login         string
-		Password      string
-		ExpectedToken string
-		ExpectedSalt  string
-		ExpectedError string
+		TestName       string
+		SetupMocks     func()
+		Client         pb.UserClient
+		Login          string
+		Password       string
+		ExpectedToken  string
+		ExpectedSalt   string
+		ExpectedUserID string
+		ExpectedError  string
 	}{
 		{
 			TestName: "Success. Register user",
 			SetupMocks: func() {
-				mockClient.EXPECT().Register(gomock.Any(), &pb.RegisterRequest{
-					Login:    "testuser",
-					Password: "testpass",
-				}).Return(&pb.RegisterResponse{
-					Token: "jwt-token",
-					Salt:  "salt-value",
+				// пароль серверу не передаётся, только проверочное значение SRP
+				mockClient.EXPECT().Register(gomock.Any(), gomock.Cond(func(r *pb.RegisterRequest) bool {
+					return r.GetLogin() == "testuser" && len(r.GetSrpSalt()) != 0 && len(r.GetSrpVerifier()) != 0
+				})).Return(&pb.RegisterResponse{
+					Token:  "jwt-token",
+					Salt:   "salt-value",
+					UserId: "user-id",
 				}, nil)
 			},
-			Client:        mockClient,
-			Login:         "testuser",
-			Password:      "testpass",
-			ExpectedToken: "jwt-token",
-			ExpectedSalt:  "salt-value",
-			ExpectedError: "",
+			Client:         mockClient,
+			Login:          "testuser",
+			Password:       "testpass",
+			ExpectedToken:  "jwt-token",
+			ExpectedSalt:   "salt-value",
+			ExpectedUserID: "user-id",
+			ExpectedError:  "",
 		},
 		{
 			TestName:      "Error. Client not connected",
@@ -143,15 +148,16 @@ func TestUserClient_Register(t *testing.T) {
 				ctx:    context.Background(),
 			}
 
-			token, salt, err := uc.Register(tc.Login, tc.Password)
+			info, err := uc.Register(tc.Login, tc.Password)
 
 			if tc.ExpectedError != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tc.ExpectedError)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, tc.ExpectedToken, token)
-				assert.Equal(t, tc.ExpectedSalt, salt)
+				assert.Equal(t, tc.ExpectedToken, info.Token)
+				assert.Equal(t, tc.ExpectedSalt, info.Salt)
+				assert.Equal(t, tc.ExpectedUserID, info.UserID)
 			}
 		})
 	}
@@ -162,33 +168,66 @@ func TestUserClient_Login(t *testing.T) {
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockUserClient(ctrl)
 
+	srpSalt, verifier, err := crypto.SRPVerifier("testpass")
+	require.NoError(t, err, "SRPVerifier failed")
+	// srpServer - имитация сервера SRP с проверочным значением пароля testpass
+	srpServer := func(response *pb.LoginResponse) {
+		var server *crypto.SRPServer
+		mockClient.EXPECT().LoginStart(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, r *pb.LoginStartRequest, _ ...grpc.CallOption) (*pb.LoginStartResponse, error) {
+				var err error
+				server, err = crypto.NewSRPServer(verifier, r.GetPublic())
+				if err != nil {
+					return nil, status.Error(codes.InvalidArgument, err.Error())
+				}
+				return &pb.LoginStartResponse{SessionId: "session", SrpSalt: srpSalt, Public: server.Public()}, nil
+			})
+		mockClient.EXPECT().LoginFinish(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, r *pb.LoginFinishRequest, _ ...grpc.CallOption) (*pb.LoginResponse, error) {
+				proof, err := server.Verify(r.GetProof())
+				if err != nil {
+					return nil, status.Error(codes.Unauthenticated, err.Error())
+				}
+				if response.ServerProof == nil {
+					response.ServerProof = proof
+				}
+				return response, nil
+			})
+	}
+
 	testCases := []struct {
-		TestName      string
-		SetupMocks    func()
-		Client        pb.UserClient
-		Login         string
-		Password      string
-		ExpectedToken string
-		ExpectedSalt  string
-		ExpectedError string
+		TestName       string
+		SetupMocks     func()
+		Client         pb.UserClient
+		Login          string
+		Password       string
+		ExpectedToken  string
+		ExpectedSalt   string
+		ExpectedUserID string
+		ExpectedKDF    crypto.KDFParams
+		ExpectedKey    []byte
+		ExpectedError  string
 	}{
 		{
-			TestName: "Success. Login user",
+			TestName: "Success. Login user by SRP",
 			SetupMocks: func() {
-				mockClient.EXPECT().Login(gomock.Any(), &pb.LoginRequest{
-					Login:    "testuser",
-					Password: "testpass",
-				}).Return(&pb.LoginResponse{
-					Token: "jwt-token",
-					Salt:  "salt-value",
-				}, nil)
+				srpServer(&pb.LoginResponse{
+					Token:      "jwt-token",
+					Salt:       "salt-value",
+					UserId:     "user-id",
+					Kdf:        &pb.KdfParams{Algorithm: "argon2id", Memory: 65536, Iterations: 3, Parallelism: 4},
+					WrappedKey: []byte("wrapped"),
+				})
 			},
-			Client:        mockClient,
-			Login:         "testuser",
-			Password:      "testpass",
-			ExpectedToken: "jwt-token",
-			ExpectedSalt:  "salt-value",
-			ExpectedError: "",
+			Client:         mockClient,
+			Login:          "testuser",
+			Password:       "testpass",
+			ExpectedToken:  "jwt-token",
+			ExpectedSalt:   "salt-value",
+			ExpectedUserID: "user-id",
+			ExpectedKDF:    crypto.DefaultKDFParams,
+			ExpectedKey:    []byte("wrapped"),
+			ExpectedError:  "",
 		},
 		{
 			TestName:      "Error. Client not connected",
@@ -201,23 +240,42 @@ func TestUserClient_Login(t *testing.T) {
 			ExpectedError: "client not connected",
 		},
 		{
-			TestName: "Error. Unauthenticated",
+			TestName: "Error. Wrong password",
 			SetupMocks: func() {
-				mockClient.EXPECT().Login(gomock.Any(), gomock.Any()).Return(
-					nil, status.Error(codes.Unauthenticated, "invalid credentials"),
+				srpServer(&pb.LoginResponse{Token: "jwt-token"})
+			},
+			Client:        mockClient,
+			Login:         "testuser",
+			Password:      "wrong",
+			ExpectedError: "user unauthenticated",
+		},
+		{
+			TestName: "Error. Server does not know verifier",
+			SetupMocks: func() {
+				srpServer(&pb.LoginResponse{Token: "jwt-token", ServerProof: []byte("forged")})
+			},
+			Client:        mockClient,
+			Login:         "testuser",
+			Password:      "testpass",
+			ExpectedError: "server authentication failed",
+		},
+		{
+			TestName: "Error. Password is not sent without migration",
+			SetupMocks: func() {
+				// сервер прежней версии сообщает об отсутствии проверочного значения, пароль не передаётся
+				mockClient.EXPECT().LoginStart(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.FailedPrecondition, "password verifier is not set"),
 				)
 			},
 			Client:        mockClient,
 			Login:         "testuser",
 			Password:      "testpass",
-			ExpectedToken: "",
-			ExpectedSalt:  "",
-			ExpectedError: "user unauthenticated",
+			ExpectedError: "internal error",
 		},
 		{
 			TestName: "Error. Internal error",
 			SetupMocks: func() {
-				mockClient.EXPECT().Login(gomock.Any(), gomock.Any()).Return(
+				mockClient.EXPECT().LoginStart(gomock.Any(), gomock.Any()).Return(
 					nil, status.Error(codes.Internal, "internal error"),
 				)
 			},
@@ -239,15 +297,83 @@ func TestUserClient_Login(t *testing.T) {
 				ctx:    context.Background(),
 			}
 
-			token, salt, err := uc.Login(tc.Login, tc.Password)
+			info, err := uc.Login(tc.Login, tc.Password)
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedToken, info.Token)
+				assert.Equal(t, tc.ExpectedSalt, info.Salt)
+				assert.Equal(t, tc.ExpectedUserID, info.UserID)
+				assert.Equal(t, tc.ExpectedKDF, info.KDF)
+				assert.Equal(t, tc.ExpectedKey, info.WrappedKey)
+			}
+		})
+	}
+}
+
+func TestUserClient_MigrateLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockUserClient(ctrl)
+
+	testCases := []struct {
+		TestName       string
+		SetupMocks     func()
+		Client         pb.UserClient
+		ExpectedToken  string
+		ExpectedUserID string
+		ExpectedError  string
+	}{
+		{
+			TestName: "Success. Migrate to SRP",
+			SetupMocks: func() {
+				mockClient.EXPECT().Login(gomock.Any(), gomock.Cond(func(r *pb.LoginRequest) bool {
+					return r.GetLogin() == "testuser" && r.GetPassword() == "testpass" && len(r.GetSrpSalt()) != 0 && len(r.GetSrpVerifier()) != 0
+				})).Return(&pb.LoginResponse{Token: "jwt-token", UserId: "user-id"}, nil)
+			},
+			Client:         mockClient,
+			ExpectedToken:  "jwt-token",
+			ExpectedUserID: "user-id",
+		},
+		{
+			TestName: "Error. Already migrated or wrong password",
+			SetupMocks: func() {
+				mockClient.EXPECT().Login(gomock.Any(), gomock.Any()).Return(
+					nil, status.Error(codes.Unauthenticated, "not found"),
+				)
+			},
+			Client:        mockClient,
+			ExpectedError: "user unauthenticated",
+		},
+		{
+			TestName:      "Error. Client not connected",
+			SetupMocks:    func() {},
+			Client:        nil,
+			ExpectedError: "client not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			uc := &UserClient{
+				client: tc.Client,
+				ctx:    context.Background(),
+			}
+
+			info, err := uc.MigrateLogin("testuser", "testpass")
 
 			if tc.ExpectedError != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tc.ExpectedError)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, tc.ExpectedToken, token)
-				assert.Equal(t, tc.ExpectedSalt, salt)
+				assert.Equal(t, tc.ExpectedToken, info.Token)
+				assert.Equal(t, tc.ExpectedUserID, info.UserID)
 			}
 		})
 	}
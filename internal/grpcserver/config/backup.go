@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/spf13/pflag"
+)
+
+// Команды выгрузки и восстановления учётной записи
+const (
+	ExportUserCommand = "export-user"
+	ImportUserCommand = "import-user"
+)
+
+// BackupConfig модель настроек команд выгрузки и восстановления учётной записи
+type BackupConfig struct {
+	DatabaseDSN string   `env:"DATABASE_URI" envDefault:""`
+	MasterKey   string   `env:"MASTER_KEY" envDefault:""`
+	MasterOld   []string `env:"MASTER_KEYS_OLD" envSeparator:","`
+	Passphrase  string   `env:"ARCHIVE_PASSPHRASE" envDefault:""`
+	Login       string   // логин выгружаемого пользователя (при восстановлении - новый логин)
+	File        string   // путь к файлу архива
+	Key         string   // путь к ключу подписи (выгрузка) или проверки подписи (восстановление)
+	// выгрузка без подписи и восстановление без проверки подписи (только по явному указанию оператора)
+	InsecureUnsigned bool
+}
+
+// NewBackupConfig - создание конфигурации команды выгрузки или восстановления учётной записи
+func NewBackupConfig(command string, arguments []string) (*BackupConfig, error) {
+	var args BackupConfig
+	if err := env.Parse(&args); err != nil {
+		return nil, fmt.Errorf("failed to parse enviroment var: %w", err)
+	}
+
+	flags := pflag.NewFlagSet(command, pflag.ContinueOnError)
+	flags.StringVarP(&args.DatabaseDSN, "dsn", "d", args.DatabaseDSN, "Database DSN")
+	flags.StringVar(&args.MasterKey, "master_key", args.MasterKey, "Path to base64 master key for server-side encryption of secrets")
+	flags.StringSliceVar(&args.MasterOld, "master_keys_old", args.MasterOld, "Comma-separated paths to previous master keys")
+	flags.StringVarP(&args.File, "file", "f", "", "Path to archive file")
+	flags.StringVarP(&args.Passphrase, "passphrase", "p", args.Passphrase, "Archive passphrase (better set ARCHIVE_PASSPHRASE)")
+	flags.BoolVar(&args.InsecureUnsigned, "insecure_unsigned", false, "Allow unsigned archive (authenticity is not verified)")
+	switch command {
+	case ExportUserCommand:
+		flags.StringVar(&args.Login, "login", "", "Login of the user to export")
+		flags.StringVar(&args.Key, "sign_key", "", "Path to PEM private key (Ed25519 or ECDSA P-256) to sign archive")
+	case ImportUserCommand:
+		flags.StringVar(&args.Login, "login", "", "New login of the restored user (default - login from archive); the archived account must not exist on this server")
+		flags.StringVar(&args.Key, "verify_key", "", "Path to PEM key to verify archive signature")
+	default:
+		return nil, fmt.Errorf("unknown command %s", command)
+	}
+	if err := flags.Parse(arguments); err != nil {
+		return nil, err
+	}
+
+	if args.File == "" {
+		return nil, errors.New("archive file is required")
+	}
+	if args.Passphrase == "" {
+		return nil, errors.New("archive passphrase is required")
+	}
+	if command == ExportUserCommand && args.Login == "" {
+		return nil, errors.New("login is required")
+	}
+	if args.Key == "" && !args.InsecureUnsigned {
+		switch command {
+		case ExportUserCommand:
+			return nil, errors.New("sign key is required (use --insecure_unsigned to export unsigned archive)")
+		default:
+			return nil, errors.New("verify key is required (use --insecure_unsigned to import unsigned archive)")
+		}
+	}
+	return &args, nil
+}
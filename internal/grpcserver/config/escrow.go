@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/spf13/pflag"
+)
+
+// Команды оператора депонирования ключей
+const (
+	EscrowExportCommand = "escrow-export"
+	EscrowApplyCommand  = "escrow-apply"
+)
+
+// EscrowConfig модель настроек команд оператора депонирования ключей.
+// Оператор определяется по роли подключения к базе данных, а не по значению из настроек.
+type EscrowConfig struct {
+	DatabaseDSN string `env:"DATABASE_URI" envDefault:""`
+	Login       string // логин пользователя, чей депонированный ключ выгружается
+	File        string // путь к файлу пакета (выгрузка) или ответа оператора (применение)
+	Confirm     string // логин пользователя, подтверждающий замену ключа восстановления (пусто - запросить)
+}
+
+// NewEscrowConfig - создание конфигурации команды оператора депонирования ключей
+func NewEscrowConfig(command string, arguments []string) (*EscrowConfig, error) {
+	var args EscrowConfig
+	if err := env.Parse(&args); err != nil {
+		return nil, fmt.Errorf("failed to parse enviroment var: %w", err)
+	}
+
+	flags := pflag.NewFlagSet(command, pflag.ContinueOnError)
+	flags.StringVarP(&args.DatabaseDSN, "dsn", "d", args.DatabaseDSN, "Database DSN")
+	switch command {
+	case EscrowExportCommand:
+		flags.StringVar(&args.Login, "login", "", "Login of the user whose escrowed key is exported")
+		flags.StringVarP(&args.File, "file", "f", "", "Path to escrow package file")
+	case EscrowApplyCommand:
+		flags.StringVarP(&args.File, "file", "f", "", "Path to escrow response file")
+		flags.StringVar(&args.Confirm, "confirm", "", "Login of the user to confirm replacing the recovery key (default - ask)")
+	default:
+		return nil, fmt.Errorf("unknown command %s", command)
+	}
+	if err := flags.Parse(arguments); err != nil {
+		return nil, err
+	}
+
+	if args.File == "" {
+		return nil, errors.New("escrow file is required")
+	}
+	if command == EscrowExportCommand && args.Login == "" {
+		return nil, errors.New("login is required")
+	}
+	return &args, nil
+}
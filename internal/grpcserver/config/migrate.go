@@ -0,0 +1,48 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/caarlos0/env"
+	"github.com/spf13/pflag"
+)
+
+// MigrateCommand - команда управления миграциями базы данных
+const MigrateCommand = "migrate"
+
+// MigrateConfig модель настроек команды управления миграциями
+type MigrateConfig struct {
+	DatabaseDSN string `env:"DATABASE_URI" envDefault:""`
+	Action      string // действие: up, down, status или version
+}
+
+// NewMigrateConfig - создание конфигурации команды управления миграциями
+func NewMigrateConfig(arguments []string) (*MigrateConfig, error) {
+	var args MigrateConfig
+	if err := env.Parse(&args); err != nil {
+		return nil, fmt.Errorf("failed to parse enviroment var: %w", err)
+	}
+
+	flags := pflag.NewFlagSet(MigrateCommand, pflag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s up|down|status|version [flags]\n", MigrateCommand)
+		flags.PrintDefaults()
+	}
+	flags.StringVarP(&args.DatabaseDSN, "dsn", "d", args.DatabaseDSN, "Database DSN")
+	if err := flags.Parse(arguments); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return nil, errors.New("migrate action is required")
+	}
+	args.Action = flags.Arg(0)
+	switch args.Action {
+	case "up", "down", "status", "version":
+	default:
+		return nil, fmt.Errorf("unknown migrate action %q", args.Action)
+	}
+	return &args, nil
+}
@@ -1,18 +1,36 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"time"
 
 	"github.com/caarlos0/env"
 	"github.com/spf13/pflag"
 )
 
+// DefaultJWTSecret - секрет JWT по умолчанию, допустимый только в режиме разработки
+const DefaultJWTSecret = "secret"
+
 // Config модель настроек сервера
 type Config struct {
-	ListenAddr  string `env:"RUN_ADDRESS" envDefault:"localhost:8080"`
-	LogLevel    string `env:"LOG_LEVEL" envDefault:"info"`
-	DatabaseDSN string `env:"DATABASE_URI" envDefault:""`
-	JWTSecret   string `env:"JWT_SECRET" envDefault:"secret"`
+	ListenAddr  string        `env:"RUN_ADDRESS" envDefault:"localhost:8080"`
+	GatewayAddr string        `env:"GATEWAY_ADDRESS" envDefault:""`
+	LogLevel    string        `env:"LOG_LEVEL" envDefault:"info"`
+	DatabaseDSN string        `env:"DATABASE_URI" envDefault:""`
+	NoMigrate   bool          `env:"NO_MIGRATE" envDefault:"false"`
+	JWTSecret   string        `env:"JWT_SECRET" envDefault:"secret"`
+	JWTKey      string        `env:"JWT_KEY" envDefault:""`
+	JWTVerify   []string      `env:"JWT_VERIFY_KEYS" envSeparator:","`
+	DevMode     bool          `env:"DEV_MODE" envDefault:"false"`
+	MasterKey   string        `env:"MASTER_KEY" envDefault:""`
+	MasterOld   []string      `env:"MASTER_KEYS_OLD" envSeparator:","`
+	Rewrap      time.Duration `env:"REWRAP_INTERVAL" envDefault:"1h"`
+	Expire      time.Duration `env:"EXPIRE_INTERVAL" envDefault:"1m"`
+	AdminLogins []string      `env:"ADMIN_LOGINS" envSeparator:","`
+	EscrowKey   string        `env:"ESCROW_PUBLIC_KEY" envDefault:""`
 }
 
 // NewConfig - создание новой конфигурации
@@ -24,25 +42,120 @@ func NewConfig() *Config {
 	}
 
 	var (
-		server   = pflag.StringP("server", "a", args.ListenAddr, "Server listen address in a form host:port.")
-		logLevel = pflag.StringP("log_level", "l", args.LogLevel, "Log level.")
-		DSN      = pflag.StringP("dsn", "d", args.DatabaseDSN, "Database DSN")
-		secret   = pflag.StringP("secret", "s", args.JWTSecret, "Secret to JWT")
+		server    = pflag.StringP("server", "a", args.ListenAddr, "Server listen address in a form host:port.")
+		gateway   = pflag.StringP("gateway", "g", args.GatewayAddr, "REST/JSON gateway listen address in a form host:port (empty - disabled).")
+		logLevel  = pflag.StringP("log_level", "l", args.LogLevel, "Log level.")
+		DSN       = pflag.StringP("dsn", "d", args.DatabaseDSN, "Database DSN")
+		noMigrate = pflag.Bool("no-migrate", args.NoMigrate, "Don't run database migrations at startup (use 'migrate up')")
+		secret    = pflag.StringP("secret", "s", args.JWTSecret, "Secret to JWT")
+		jwtKey    = pflag.String("jwt_key", args.JWTKey, "Path to PEM private key (Ed25519 or ECDSA P-256) to sign JWT (requires gateway to publish JWKS)")
+		verify    = pflag.StringSlice("jwt_verify_keys", args.JWTVerify, "Comma-separated paths to PEM keys that are still accepted for JWT verification")
+		devMode   = pflag.Bool("dev", args.DevMode, "Development mode (allows default JWT secret)")
+		master    = pflag.String("master_key", args.MasterKey, "Path to base64 master key for server-side encryption of secrets")
+		oldKeys   = pflag.StringSlice("master_keys_old", args.MasterOld, "Comma-separated paths to previous master keys (for rotation)")
+		rewrap    = pflag.Duration("rewrap_interval", args.Rewrap, "Interval of re-wrapping data keys with the current master key")
+		expire    = pflag.Duration("expire_interval", args.Expire, "Interval of deleting or archiving expired secrets")
+		admins    = pflag.StringSlice("admins", args.AdminLogins, "Comma-separated logins of registered server administrators (resolved at startup)")
+		escrow    = pflag.String("escrow_public_key", args.EscrowKey, "Path to base64 X25519 public key of the organization key escrow (empty - disabled)")
 	)
 	pflag.Parse()
 
 	return &Config{
 		ListenAddr:  *server,
+		GatewayAddr: *gateway,
 		LogLevel:    *logLevel,
 		DatabaseDSN: *DSN,
+		NoMigrate:   *noMigrate,
 		JWTSecret:   *secret,
+		JWTKey:      *jwtKey,
+		JWTVerify:   *verify,
+		DevMode:     *devMode,
+		MasterKey:   *master,
+		MasterOld:   *oldKeys,
+		Rewrap:      *rewrap,
+		Expire:      *expire,
+		AdminLogins: *admins,
+		EscrowKey:   *escrow,
+	}
+}
+
+// Validate - метод проверки настроек перед запуском сервера
+func (c *Config) Validate() error {
+	if c.JWTKey == "" && len(c.JWTVerify) > 0 {
+		return errors.New("JWT verification keys require a signing key")
+	}
+	// открытые ключи проверки токенов публикуются только через REST/JSON шлюз
+	if c.JWTKey != "" && c.GatewayAddr == "" {
+		return errors.New("JWT signing key requires a gateway address to publish JWKS")
+	}
+	if c.JWTKey == "" && c.JWTSecret == DefaultJWTSecret && !c.DevMode {
+		return errors.New("default JWT secret is allowed only in development mode")
+	}
+	if c.MasterKey == "" && len(c.MasterOld) > 0 {
+		return errors.New("previous master keys require a current master key")
+	}
+	return nil
+}
+
+// redacted - значение, которым в журнале заменяются секреты и пути к ключам
+const redacted = "[REDACTED]"
+
+// dsnPassword - пароль в строке подключения к базе в формате "ключ=значение"
+var dsnPassword = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Redacted - метод возвращает копию настроек для записи в журнал: секрет JWT, пароль базы данных
+// и пути к файлам ключей заменены (по ним можно найти ключи на сервере)
+func (c *Config) Redacted() Config {
+	r := *c
+	if r.JWTSecret != "" {
+		r.JWTSecret = redacted
 	}
+	r.DatabaseDSN = redactDSN(r.DatabaseDSN)
+	r.JWTKey = redactPath(r.JWTKey)
+	r.MasterKey = redactPath(r.MasterKey)
+	r.EscrowKey = redactPath(r.EscrowKey)
+	r.JWTVerify = redactPaths(r.JWTVerify)
+	r.MasterOld = redactPaths(r.MasterOld)
+	return r
 }
+
+// redactPath - метод заменяет непустой путь к ключу
+func redactPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactPaths - метод заменяет пути к ключам (количество сохраняется)
+func redactPaths(paths []string) []string {
+	if len(paths) == 0 {
+		return paths
+	}
+	r := make([]string, len(paths))
+	for i, path := range paths {
+		r[i] = redactPath(path)
+	}
+	return r
+}
+
+// redactDSN - метод заменяет пароль в строке подключения к базе (URL или "ключ=значение")
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
+
+// DefaultConfig - конфигурация по умолчанию (режим разработки)
 func DefaultConfig() *Config {
 	return &Config{
 		ListenAddr:  "localhost:8080",
 		LogLevel:    "info",
 		DatabaseDSN: "",
-		JWTSecret:   "secret",
+		JWTSecret:   DefaultJWTSecret,
+		DevMode:     true,
+		Rewrap:      time.Hour,
+		Expire:      time.Minute,
 	}
 }
@@ -2,7 +2,11 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/token"
 	"go-pass-keeper/pkg/logger"
 	"go-pass-keeper/pkg/usercontext"
 
@@ -19,28 +23,50 @@ import (
 
 // tokenHandler интефрейс для работы с токеном
 type tokenHandler interface {
-	// DecodeUserId - извлечение ID пользователя из токена
-	DecodeUserId(token string) (string, error)
+	// ParseJWT - разбор токена с проверкой подписи
+	ParseJWT(token string) (*token.JWTClaims, error)
 }
 
-// MakeAuthFunc - метод создания функции авторизации для перехватчика
-func MakeAuthFunc(handler tokenHandler) auth.AuthFunc {
+// accountChecker интерфейс для проверки состояния учётной записи
+type accountChecker interface {
+	// GetStatus - получение состояния учётной записи (блокировка, принудительный выход)
+	GetStatus(ctx context.Context, uid uuid.UUID) (*models.UserData, error)
+}
+
+// MakeAuthFunc - метод создания функции авторизации для перехватчика.
+// Токены заблокированных пользователей и выпущенные до принудительного выхода отклоняются.
+func MakeAuthFunc(handler tokenHandler, accounts accountChecker) auth.AuthFunc {
 	return func(ctx context.Context) (context.Context, error) {
 		jwt, err := auth.AuthFromMD(ctx, "bearer")
 		if err != nil {
 			return nil, err
 		}
 
-		uid, err := handler.DecodeUserId(jwt)
+		claims, err := handler.ParseJWT(jwt)
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
 		}
 
-		u, err := uuid.Parse(uid)
+		u, err := uuid.Parse(claims.Id)
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
 		}
 
+		account, err := accounts.GetStatus(ctx, u)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, status.Error(codes.Unauthenticated, "unknown user")
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if account.Disabled {
+			return nil, status.Error(codes.PermissionDenied, "account disabled")
+		}
+		// токены прежних поколений отозваны принудительным выходом (в том числе выпущенные в ту же секунду)
+		if claims.Generation != account.Generation {
+			return nil, status.Error(codes.Unauthenticated, "auth token revoked")
+		}
+
 		// создаем контекст, и добавляем в него ID пользователя (чтобы отвязать обработчик от парсинга cookie)
 		ctx = usercontext.SetUserId(ctx, u)
 		return ctx, nil
@@ -86,18 +112,18 @@ func InterceptorLogger(l *zap.Logger) logging.Logger {
 }
 
 // CreateUnaryInterceptors - метод для создания перехватчиков обычных запросов
-func CreateUnaryInterceptors(handler tokenHandler) []grpc.UnaryServerInterceptor {
+func CreateUnaryInterceptors(handler tokenHandler, accounts accountChecker) []grpc.UnaryServerInterceptor {
 	return []grpc.UnaryServerInterceptor{
 
 		logging.UnaryServerInterceptor(InterceptorLogger(logger.Get().Desugar())),
 		recovery.UnaryServerInterceptor(),
-		auth.UnaryServerInterceptor(MakeAuthFunc(handler)),
+		auth.UnaryServerInterceptor(MakeAuthFunc(handler, accounts)),
 	}
 }
 
 // CreateStreamInterceptors - метод для создания перехватчиков потоковых запросов
-func CreateStreamInterceptors(handler tokenHandler) []grpc.StreamServerInterceptor {
+func CreateStreamInterceptors(handler tokenHandler, accounts accountChecker) []grpc.StreamServerInterceptor {
 	return []grpc.StreamServerInterceptor{
-		auth.StreamServerInterceptor(MakeAuthFunc(handler)),
+		auth.StreamServerInterceptor(MakeAuthFunc(handler, accounts)),
 	}
 }
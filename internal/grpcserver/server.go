@@ -1,11 +1,16 @@
 package grpcserver
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go-pass-keeper/pkg/logger"
 	"net"
+	"net/http"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Service - интерфейс сервиса
@@ -14,10 +19,19 @@ type Service interface {
 	RegisterService(grpc.ServiceRegistrar)
 }
 
+// Gateway - интерфейс сервиса, доступного через REST/JSON шлюз
+type Gateway interface {
+	// RegisterGateway - регистрация обработчиков шлюза, проксирующих запросы на endpoint
+	RegisterGateway(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
+}
+
 // Server - структура сервера с использованием GRPC
 type Server struct {
 	listenAddr         string                         // адрес
+	gatewayAddr        string                         // адрес REST/JSON шлюза (пустой - шлюз выключен)
 	server             *grpc.Server                   // указатель на сервер
+	gateway            *http.Server                   // указатель на сервер шлюза
+	handlers           map[string]http.Handler        // дополнительные HTTP обработчики шлюза (GET)
 	services           []Service                      // сервисы
 	unaryInterceptors  []grpc.UnaryServerInterceptor  // перехватчики простых запросов
 	streamInterceptors []grpc.StreamServerInterceptor // перехватчики потоковых запросов
@@ -33,6 +47,23 @@ func UseListenAddr(a string) Params {
 	}
 }
 
+// UseGatewayAddr - метод устанавливает адрес REST/JSON шлюза
+func UseGatewayAddr(a string) Params {
+	return func(server *Server) {
+		server.gatewayAddr = a
+	}
+}
+
+// UseHandler - метод добавляет HTTP обработчик GET запросов, публикуемый через шлюз
+func UseHandler(pattern string, h http.Handler) Params {
+	return func(server *Server) {
+		if server.handlers == nil {
+			server.handlers = make(map[string]http.Handler)
+		}
+		server.handlers[pattern] = h
+	}
+}
+
 // UseServices - метод устанавливает используемые сервисы
 func UseServices(in ...Service) Params {
 	return func(server *Server) {
@@ -94,10 +125,59 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	if s.gatewayAddr != "" {
+		if err := s.startGateway(); err != nil {
+			s.server.Stop()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startGateway - метод запуска REST/JSON шлюза.
+// Шлюз проксирует запросы на GRPC сервер, поэтому к ним применяются те же перехватчики,
+// а заголовок Authorization передаётся как метаданные запроса.
+func (s *Server) startGateway() error {
+	lis, err := net.Listen("tcp", s.gatewayAddr)
+	if err != nil {
+		return fmt.Errorf("error listen gateway tcp: %w", err)
+	}
+	mux := runtime.NewServeMux()
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	for _, service := range s.services {
+		if gw, ok := service.(Gateway); ok {
+			if err := gw.RegisterGateway(context.Background(), mux, s.listenAddr, opts); err != nil {
+				lis.Close()
+				return fmt.Errorf("error register gateway: %w", err)
+			}
+		}
+	}
+	for pattern, h := range s.handlers {
+		if err := mux.HandlePath(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			h.ServeHTTP(w, r)
+		}); err != nil {
+			lis.Close()
+			return fmt.Errorf("error register handler %s: %w", pattern, err)
+		}
+	}
+	s.gateway = &http.Server{Handler: mux}
+	go func() {
+		logger.Info("Starting gateway on", s.gatewayAddr)
+		if err := s.gateway.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Gateway error", err.Error())
+		}
+	}()
+
 	return nil
 }
 
 // Stop - метод остановки сервера
 func (s *Server) Stop() {
+	if s.gateway != nil {
+		if err := s.gateway.Shutdown(context.Background()); err != nil {
+			logger.Error("Gateway shutdown error", err.Error())
+		}
+	}
 	s.server.GracefulStop()
 }
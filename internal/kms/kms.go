@@ -0,0 +1,23 @@
+// Package kms предоставляет управление мастер-ключами для шифрования данных на сервере.
+// Данные шифруются ключами данных, которые хранятся рядом с записями в зашифрованном
+// мастер-ключом виде (envelope encryption).
+package kms
+
+import (
+	"context"
+	"errors"
+)
+
+// KeyManager - интерфейс менеджера мастер-ключей (локальный файл, внешний KMS)
+type KeyManager interface {
+	// KeyID - идентификатор текущего мастер-ключа
+	KeyID() string
+	// Wrap - шифрование ключа данных текущим мастер-ключом (возвращает идентификатор мастер-ключа)
+	Wrap(ctx context.Context, dataKey []byte) ([]byte, string, error)
+	// Unwrap - расшифровка ключа данных мастер-ключом с указанным идентификатором
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+var (
+	ErrUnknownKey = errors.New("unknown master key")
+)
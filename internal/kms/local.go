@@ -0,0 +1,104 @@
+package kms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"go-pass-keeper/pkg/crypto"
+	"os"
+	"strings"
+)
+
+// masterKeyLen - длина мастер-ключа (AES-256)
+const masterKeyLen = 32
+
+// LocalKeyManager - менеджер мастер-ключей, хранящихся в локальных файлах.
+// Заменяет внешний KMS: первый ключ используется для шифрования,
+// остальные - только для расшифровки на время ротации.
+type LocalKeyManager struct {
+	current string            // идентификатор текущего мастер-ключа
+	keys    map[string][]byte // мастер-ключи по идентификатору
+}
+
+// NewLocalKeyManager - метод создания менеджера по текущему и предыдущим мастер-ключам
+func NewLocalKeyManager(current []byte, previous ...[]byte) (*LocalKeyManager, error) {
+	if len(current) != masterKeyLen {
+		return nil, fmt.Errorf("invalid master key length %d", len(current))
+	}
+	m := &LocalKeyManager{
+		current: keyID(current),
+		keys:    map[string][]byte{keyID(current): current},
+	}
+	for _, key := range previous {
+		if len(key) != masterKeyLen {
+			return nil, fmt.Errorf("invalid master key length %d", len(key))
+		}
+		m.keys[keyID(key)] = key
+	}
+	return m, nil
+}
+
+// LoadLocalKeyManager - метод загрузки мастер-ключей из файлов (ключ в base64)
+func LoadLocalKeyManager(current string, previous ...string) (*LocalKeyManager, error) {
+	key, err := LoadMasterKey(current)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([][]byte, 0, len(previous))
+	for _, path := range previous {
+		k, err := LoadMasterKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return NewLocalKeyManager(key, keys...)
+}
+
+// LoadMasterKey - метод чтения мастер-ключа из файла (ключ в base64)
+func LoadMasterKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode master key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// KeyID - метод возвращает идентификатор текущего мастер-ключа
+func (m *LocalKeyManager) KeyID() string {
+	return m.current
+}
+
+// Wrap - метод шифрует ключ данных текущим мастер-ключом
+func (m *LocalKeyManager) Wrap(ctx context.Context, dataKey []byte) ([]byte, string, error) {
+	wrapped, err := crypto.Encrypt(m.keys[m.current], dataKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return wrapped, m.current, nil
+}
+
+// Unwrap - метод расшифровывает ключ данных мастер-ключом с указанным идентификатором
+func (m *LocalKeyManager) Unwrap(ctx context.Context, id string, wrapped []byte) ([]byte, error) {
+	key, ok := m.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	dataKey, err := crypto.Decrypt(key, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// keyID - метод вычисляет идентификатор мастер-ключа (начало хеша SHA-256)
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,79 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	key := make([]byte, masterKeyLen)
+	for i := range key {
+		key[i] = b
+	}
+	return key
+}
+
+func TestLocalKeyManager(t *testing.T) {
+	ctx := context.Background()
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	oldManager, err := NewLocalKeyManager(testKey(1))
+	require.NoError(t, err)
+	wrapped, oldID, err := oldManager.Wrap(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, oldManager.KeyID(), oldID)
+
+	// после ротации ключ данных расшифровывается старым ключом и шифруется новым
+	rotated, err := NewLocalKeyManager(testKey(2), testKey(1))
+	require.NoError(t, err)
+	assert.NotEqual(t, oldID, rotated.KeyID())
+
+	unwrapped, err := rotated.Unwrap(ctx, oldID, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	rewrapped, newID, err := rotated.Wrap(ctx, unwrapped)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.KeyID(), newID)
+
+	// без старого ключа расшифровка невозможна
+	withoutOld, err := NewLocalKeyManager(testKey(2))
+	require.NoError(t, err)
+	_, err = withoutOld.Unwrap(ctx, oldID, wrapped)
+	assert.True(t, errors.Is(err, ErrUnknownKey))
+
+	unwrapped, err = withoutOld.Unwrap(ctx, newID, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = NewLocalKeyManager([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestLoadLocalKeyManager(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current.key")
+	previous := filepath.Join(dir, "previous.key")
+	bad := filepath.Join(dir, "bad.key")
+	require.NoError(t, os.WriteFile(current, []byte(base64.StdEncoding.EncodeToString(testKey(3))+"\n"), 0600))
+	require.NoError(t, os.WriteFile(previous, []byte(base64.StdEncoding.EncodeToString(testKey(4))), 0600))
+	require.NoError(t, os.WriteFile(bad, []byte("not base64!"), 0600))
+
+	m, err := LoadLocalKeyManager(current, previous)
+	require.NoError(t, err)
+	assert.Equal(t, keyID(testKey(3)), m.KeyID())
+	assert.Len(t, m.keys, 2)
+
+	_, err = LoadLocalKeyManager(bad)
+	assert.Error(t, err)
+
+	_, err = LoadLocalKeyManager(filepath.Join(dir, "missing.key"))
+	assert.Error(t, err)
+}
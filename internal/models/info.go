@@ -1,36 +1,223 @@
 package models
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go-pass-keeper/pkg/crypto"
 	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/securemem"
 	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // SecretInfo - модель информации о секрете
 type SecretInfo struct {
 	ID      string
+	OrgID   string
 	Name    string
 	Type    string
 	Created time.Time
 	Updated time.Time
+	// срок действия (нулевое значение - бессрочно) и действие по его истечении
+	Expires      time.Time
+	ExpirePolicy string
+	Archived     bool
+	// слепой индекс для поиска на сервере (ключевые хеши слов названия и тегов)
+	SearchIndex [][]byte
+	// заметки и теги хранятся вместе с названием в метаданных, зашифрованных на клиенте
+	Notes         string
+	Tags          []string
+	EncryptedMeta []byte
+	// ключ данных секрета, зашифрованный ключом хранилища (пусто - данные зашифрованы ключом хранилища)
+	DataKey []byte
+	// ревизия и хеш содержимого, зашифрованного на клиенте (по ним проверяется целостность хранилища)
+	Revision    int64
+	ContentHash []byte
 }
 
 // ToProtoMetadata - метод конвертирует информацию в метаданные
 func (i *SecretInfo) ToProtoMetadata() *pb.SecretMetadata {
-	return &pb.SecretMetadata{
-		Id:   i.ID,
-		Name: i.Name,
-		Type: i.Type,
+	meta := &pb.SecretMetadata{
+		Id:            i.ID,
+		Type:          i.Type,
+		OrgId:         i.OrgID,
+		ExpirePolicy:  i.ExpirePolicy,
+		SearchIndex:   i.SearchIndex,
+		EncryptedMeta: i.EncryptedMeta,
+		WrappedKey:    i.DataKey,
+	}
+	// при наличии зашифрованных метаданных название в открытом виде не передаётся
+	if len(i.EncryptedMeta) == 0 {
+		meta.Name = i.Name
+	}
+	if !i.Expires.IsZero() {
+		meta.Expires = timestamppb.New(i.Expires)
+	}
+	return meta
+}
+
+// SealMeta - метод шифрует название, заметки и теги секрета ключом key
+// с привязкой к владельцу хранилища owner, идентификатору и типу секрета
+func (i *SecretInfo) SealMeta(key []byte, owner string) error {
+	meta := &SecretMeta{Name: i.Name, Notes: i.Notes, Tags: i.Tags}
+	data, err := meta.Encrypt(key, MetaAD(owner, i.ID, i.Type))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt metadata: %w", err)
+	}
+	i.EncryptedMeta = data
+	return nil
+}
+
+// OpenMeta - метод расшифровывает метаданные секрета хранилища owner ключом key и заполняет название,
+// заметки и теги (секрет без зашифрованных метаданных остаётся без изменений)
+func (i *SecretInfo) OpenMeta(key []byte, owner string) error {
+	if len(i.EncryptedMeta) == 0 {
+		return nil
+	}
+	meta := &SecretMeta{}
+	if err := meta.Decrypt(key, i.EncryptedMeta, MetaAD(owner, i.ID, i.Type)); err != nil {
+		return fmt.Errorf("failed to decrypt metadata: %w", err)
 	}
+	i.Name, i.Notes, i.Tags = meta.Name, meta.Notes, meta.Tags
+	return nil
+}
+
+// MoveNotes - метод переносит заметки из содержимого секрета, сохранённого до появления метаданных,
+// в метаданные (заметки из метаданных не заменяются) и сообщает, были ли заметки в содержимом
+func (i *SecretInfo) MoveNotes(extra *SecretExtra) bool {
+	if extra.LegacyNotes == "" {
+		return false
+	}
+	if i.Notes == "" {
+		i.Notes = extra.LegacyNotes
+	}
+	extra.LegacyNotes = ""
+	return true
+}
+
+// UpgradeContent - метод расшифровывает содержимое секрета хранилища owner ключом oldKey и шифрует его
+// в текущем формате ключом newKey; заметки из содержимого переносятся в метаданные (см. MoveNotes)
+func (i *SecretInfo) UpgradeContent(oldKey []byte, newKey []byte, owner string, content []byte) ([]byte, error) {
+	secret, extra, err := NewSecretPayload(i.Type)
+	if err != nil {
+		return nil, err
+	}
+	ad := SecretAD(owner, i.ID, i.Type)
+	if err := secret.Decrypt(oldKey, content, ad); err != nil {
+		return nil, fmt.Errorf("failed to decrypt content: %w", err)
+	}
+	defer secret.Wipe()
+	i.MoveNotes(extra)
+	return secret.Encrypt(newKey, ad)
+}
+
+// NewDataKey - метод создаёт случайный ключ данных секрета, шифрует его ключом хранилища vaultKey
+// с привязкой к владельцу owner и идентификатору секрета и возвращает ключ в открытом виде
+func (i *SecretInfo) NewDataKey(vaultKey []byte, owner string) ([]byte, error) {
+	key, err := crypto.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := crypto.EncryptWithAD(vaultKey, key, DataKeyAD(owner, i.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	i.DataKey = wrapped
+	return key, nil
+}
+
+// OpenDataKey - метод расшифровывает ключ данных секрета ключом хранилища vaultKey
+// (секрет без собственного ключа зашифрован ключом хранилища, он и возвращается)
+func (i *SecretInfo) OpenDataKey(vaultKey []byte, owner string) ([]byte, error) {
+	if len(i.DataKey) == 0 {
+		return vaultKey, nil
+	}
+	key, err := crypto.DecryptWithAD(vaultKey, i.DataKey, DataKeyAD(owner, i.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return key, nil
+}
+
+// RewrapDataKey - метод перешифровывает ключ данных секрета хранилища owner с ключа хранилища
+// oldKey на newKey (содержимое и метаданные, зашифрованные ключом данных, не меняются)
+func (i *SecretInfo) RewrapDataKey(oldKey []byte, newKey []byte, owner string) error {
+	if len(i.DataKey) == 0 {
+		return fmt.Errorf("secret %s has no data key", i.ID)
+	}
+	key, err := i.OpenDataKey(oldKey, owner)
+	if err != nil {
+		return err
+	}
+	defer securemem.Wipe(key)
+	wrapped, err := crypto.EncryptWithAD(newKey, key, DataKeyAD(owner, i.ID))
+	if err != nil {
+		return fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	i.DataKey = wrapped
+	return nil
+}
+
+// DataKeyFor - метод возвращает ключ данных секрета для шифрования содержимого: существующий
+// ключ расшифровывается ключом хранилища vaultKey, а для секрета без собственного ключа создаётся
+// новый (см. NewDataKey). Ключ не меняется при изменении секрета, поэтому ключи, переданные
+// другим пользователям, остаются действительными. Ключ, сохранённый в исходном формате
+// без дополнительных данных, перешифровывается с привязкой к секрету (см. crypto.NeedsUpgrade).
+func (i *SecretInfo) DataKeyFor(vaultKey []byte, owner string) ([]byte, error) {
+	if len(i.DataKey) == 0 {
+		return i.NewDataKey(vaultKey, owner)
+	}
+	key, err := i.OpenDataKey(vaultKey, owner)
+	if err != nil || !crypto.NeedsUpgrade(i.DataKey) {
+		return key, err
+	}
+	wrapped, err := crypto.EncryptWithAD(vaultKey, key, DataKeyAD(owner, i.ID))
+	if err != nil {
+		securemem.Wipe(key)
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	i.DataKey = wrapped
+	return key, nil
+}
+
+// PlainName - метод проверяет, что название секрета хранится на сервере в открытом виде
+// (секрет сохранён до появления зашифрованных метаданных)
+func (i *SecretInfo) PlainName() bool {
+	return len(i.EncryptedMeta) == 0 && i.Name != ""
+}
+
+// Expired - метод проверяет, истёк ли срок действия секрета к моменту now
+func (i *SecretInfo) Expired(now time.Time) bool {
+	return !i.Expires.IsZero() && !now.Before(i.Expires)
+}
+
+// ExpiresWithin - метод проверяет, истекает ли срок действия секрета в ближайшее время d
+func (i *SecretInfo) ExpiresWithin(now time.Time, d time.Duration) bool {
+	return !i.Expires.IsZero() && now.Add(d).After(i.Expires)
 }
 
 func SecretInfoFromProtoMetadata(meta *pb.SecretMetadata) *SecretInfo {
-	return &SecretInfo{
-		ID:      meta.GetId(),
-		Name:    meta.GetName(),
-		Type:    meta.GetType(),
-		Created: meta.GetCreated().AsTime(),
-		Updated: meta.GetUpdated().AsTime(),
+	info := &SecretInfo{
+		ID:            meta.GetId(),
+		OrgID:         meta.GetOrgId(),
+		Name:          meta.GetName(),
+		Type:          meta.GetType(),
+		Created:       meta.GetCreated().AsTime(),
+		Updated:       meta.GetUpdated().AsTime(),
+		ExpirePolicy:  meta.GetExpirePolicy(),
+		Archived:      meta.GetArchived(),
+		EncryptedMeta: meta.GetEncryptedMeta(),
+		DataKey:       meta.GetWrappedKey(),
+		Revision:      meta.GetRevision(),
+		ContentHash:   meta.GetContentHash(),
 	}
+	if meta.GetExpires() != nil {
+		info.Expires = meta.GetExpires().AsTime()
+	}
+	return info
 }
 
 func SecretsResponseToSecretInfo(pbSecrets *pb.GetSecretsResponse) []*SecretInfo {
@@ -42,3 +229,364 @@ func SecretsResponseToSecretInfo(pbSecrets *pb.GetSecretsResponse) []*SecretInfo
 	}
 	return res
 }
+
+// Виды операций пакетного изменения секретов
+const (
+	OperationAdd    = "add"
+	OperationEdit   = "edit"
+	OperationDelete = "delete"
+)
+
+// SecretOperation - модель операции пакетного изменения секретов
+type SecretOperation struct {
+	Kind    string
+	Info    *SecretInfo
+	Content []byte
+}
+
+// ToProto - метод конвертирует операцию в сообщение пакета
+func (o *SecretOperation) ToProto() *pb.SecretOperation {
+	switch o.Kind {
+	case OperationAdd:
+		return &pb.SecretOperation{Op: &pb.SecretOperation_Add{
+			Add: &pb.AddSecretRequest{Meta: o.Info.ToProtoMetadata(), Content: o.Content}}}
+	case OperationEdit:
+		return &pb.SecretOperation{Op: &pb.SecretOperation_Edit{
+			Edit: &pb.EditSecretRequest{Meta: o.Info.ToProtoMetadata(), Content: o.Content}}}
+	case OperationDelete:
+		return &pb.SecretOperation{Op: &pb.SecretOperation_Delete{
+			Delete: &pb.DeleteSecretRequest{Meta: &pb.SecretMetadata{Id: o.Info.ID}}}}
+	default:
+		return &pb.SecretOperation{}
+	}
+}
+
+// SecretOperationResult - модель результата операции пакета (Error пустой при успехе)
+type SecretOperationResult struct {
+	Info  *SecretInfo
+	Error string
+}
+
+// SecretOperationResultFromProto - метод конвертирует результат операции пакета в модель
+func SecretOperationResultFromProto(result *pb.SecretOperationResult) *SecretOperationResult {
+	res := &SecretOperationResult{Error: result.GetError()}
+	if result.GetMeta() != nil {
+		res.Info = SecretInfoFromProtoMetadata(result.GetMeta())
+	}
+	return res
+}
+
+// AttachmentInfo - модель информации о вложении секрета
+type AttachmentInfo struct {
+	ID       string
+	SecretID string
+	Name     string
+	Size     int64
+	Created  time.Time
+	// название, зашифрованное ключом данных секрета (пусто у вложений, добавленных до
+	// шифрования ключом данных: их содержимое зашифровано ключом хранилища, название открыто)
+	Meta []byte
+}
+
+// NewAttachmentInfo - метод создаёт описание нового вложения секрета sid размером size.
+// Идентификатор выбирается на клиенте, так как входит в дополнительные аутентифицируемые данные.
+func NewAttachmentInfo(sid string, size int64) *AttachmentInfo {
+	return &AttachmentInfo{ID: uuid.NewString(), SecretID: sid, Size: size}
+}
+
+// AttachmentInfoFromProto - метод конвертирует метаданные вложения в модель
+func AttachmentInfoFromProto(meta *pb.AttachmentMetadata) *AttachmentInfo {
+	return &AttachmentInfo{
+		ID:       meta.GetId(),
+		SecretID: meta.GetSecretId(),
+		Name:     meta.GetName(),
+		Size:     meta.GetSize(),
+		Created:  meta.GetCreated().AsTime(),
+		Meta:     meta.GetEncryptedMeta(),
+	}
+}
+
+// ToProtoMetadata - метод конвертирует модель в метаданные вложения
+func (a *AttachmentInfo) ToProtoMetadata() *pb.AttachmentMetadata {
+	return &pb.AttachmentMetadata{
+		Id:            a.ID,
+		SecretId:      a.SecretID,
+		Name:          a.Name,
+		Size:          a.Size,
+		EncryptedMeta: a.Meta,
+	}
+}
+
+// Seal - метод шифрует название name и содержимое data вложения ключом данных секрета dataKey.
+// Шифротексты привязаны к хранилищу owner, секрету и вложению; открытое название не передаётся.
+func (a *AttachmentInfo) Seal(dataKey []byte, owner string, name string, data []byte) ([]byte, error) {
+	meta, err := crypto.EncryptWithAD(dataKey, []byte(name), AttachmentMetaAD(owner, a.SecretID, a.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt attachment name: %w", err)
+	}
+	content, err := crypto.EncryptWithAD(dataKey, data, AttachmentAD(owner, a.SecretID, a.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt attachment: %w", err)
+	}
+	a.Name = ""
+	a.Meta = meta
+	return content, nil
+}
+
+// OpenName - метод расшифровывает название вложения ключом данных секрета dataKey
+func (a *AttachmentInfo) OpenName(dataKey []byte, owner string) error {
+	if len(a.Meta) == 0 {
+		return nil
+	}
+	name, err := crypto.DecryptWithAD(dataKey, a.Meta, AttachmentMetaAD(owner, a.SecretID, a.ID))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt attachment name: %w", err)
+	}
+	a.Name = string(name)
+	return nil
+}
+
+// Open - метод расшифровывает содержимое вложения ключом данных секрета dataKey
+// (вложение, добавленное до шифрования ключом данных, - ключом хранилища vaultKey)
+func (a *AttachmentInfo) Open(dataKey []byte, vaultKey []byte, owner string, content []byte) ([]byte, error) {
+	if len(a.Meta) == 0 {
+		return crypto.Decrypt(vaultKey, content)
+	}
+	data, err := crypto.DecryptWithAD(dataKey, content, AttachmentAD(owner, a.SecretID, a.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt attachment: %w", err)
+	}
+	return data, nil
+}
+
+// SharedSecretInfo - модель информации о секрете, переданном другим пользователем
+type SharedSecretInfo struct {
+	ID         string
+	Secret     *SecretInfo
+	Owner      string
+	Recipient  string
+	WrappedKey []byte
+	Content    []byte
+	Created    time.Time
+	VaultOwner string // хранилище, к которому привязано шифрование секрета (пусто у копии секрета)
+}
+
+// EncryptionOwner - метод возвращает владельца хранилища, к которому привязано шифрование
+// переданного секрета (копия секрета, переданная до передачи по ссылке, привязана к логину владельца)
+func (s *SharedSecretInfo) EncryptionOwner() string {
+	if s.VaultOwner != "" {
+		return s.VaultOwner
+	}
+	return s.Owner
+}
+
+// SharedSecretInfoFromProto - метод конвертирует сообщение о переданном секрете в модель
+func SharedSecretInfoFromProto(share *pb.SharedSecret) *SharedSecretInfo {
+	return &SharedSecretInfo{
+		ID:         share.GetId(),
+		Secret:     SecretInfoFromProtoMetadata(share.GetMeta()),
+		Owner:      share.GetOwner(),
+		Recipient:  share.GetRecipient(),
+		WrappedKey: share.GetWrappedKey(),
+		Content:    share.GetContent(),
+		Created:    share.GetCreated().AsTime(),
+		VaultOwner: share.GetVaultOwner(),
+	}
+}
+
+// OrganizationInfo - модель информации об организации (командном хранилище)
+type OrganizationInfo struct {
+	ID        string
+	Name      string
+	Role      string
+	VaultKey  []byte
+	Created   time.Time
+	RotateKey bool // из организации удалён участник, ключ хранилища необходимо заменить
+}
+
+// CanManage - метод проверяет, что пользователь может управлять участниками организации
+func (o *OrganizationInfo) CanManage() bool {
+	return o.Role == RoleOwner || o.Role == RoleAdmin
+}
+
+// OrganizationInfoFromProto - метод конвертирует сообщение об организации в модель
+func OrganizationInfoFromProto(org *pb.OrganizationInfo) *OrganizationInfo {
+	return &OrganizationInfo{
+		ID:        org.GetId(),
+		Name:      org.GetName(),
+		Role:      org.GetRole(),
+		VaultKey:  org.GetVaultKey(),
+		Created:   org.GetCreated().AsTime(),
+		RotateKey: org.GetRotateKey(),
+	}
+}
+
+// MemberInfo - модель информации об участнике организации
+type MemberInfo struct {
+	Login   string
+	Role    string
+	Created time.Time
+}
+
+// MemberInfoFromProto - метод конвертирует сообщение об участнике в модель
+func MemberInfoFromProto(member *pb.Member) *MemberInfo {
+	return &MemberInfo{
+		Login:   member.GetLogin(),
+		Role:    member.GetRole(),
+		Created: member.GetCreated().AsTime(),
+	}
+}
+
+// AuthInfo - модель результата регистрации или авторизации пользователя
+type AuthInfo struct {
+	Token      string
+	Salt       string
+	UserID     string           // идентификатор пользователя (входит в аутентифицируемые данные шифрования секретов)
+	KDF        crypto.KDFParams // параметры получения ключа из пароля
+	WrappedKey []byte           // ключ шифрования, зашифрованный ключом из пароля (пусто - используется ключ из пароля)
+	KeyCheck   []byte           // контрольное значение ключа шифрования (пусто - проверка не выполняется)
+}
+
+// keyCheckValue - константа, зашифрованная ключом шифрования в контрольном значении
+var keyCheckValue = []byte("go-pass-keeper key check")
+
+// ErrWrongKey - ключ шифрования не совпадает с контрольным значением (введён неверный секрет)
+var ErrWrongKey = errors.New("wrong encryption key")
+
+// KeyAD - метод формирует дополнительные аутентифицируемые данные ключа шифрования пользователя
+func KeyAD(userID string) []byte {
+	return crypto.AssociatedData("key", userID)
+}
+
+// UnlockKey - метод получает ключ шифрования секретов: ключ из пароля password
+// или, если ключ шифрования сохранён зашифрованным, расшифровывает его ключом из пароля
+func (a *AuthInfo) UnlockKey(password string) ([]byte, error) {
+	kek, err := crypto.DeriveKey(password, a.Salt, a.KDF)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.WrappedKey) == 0 {
+		return kek, nil
+	}
+	key, err := crypto.DecryptWithAD(kek, a.WrappedKey, KeyAD(a.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key: %w", err)
+	}
+	return key, nil
+}
+
+// WrapKey - метод шифрует ключ шифрования секретов key ключом из пароля password,
+// полученным с параметрами kdf (ключ key при этом не меняется, перешифровывать секреты не требуется).
+// Пароль передаётся срезом байтов, чтобы секрет из защищённой памяти не копировался в строку.
+func (a *AuthInfo) WrapKey(password []byte, kdf crypto.KDFParams, key []byte) ([]byte, error) {
+	kek, err := crypto.DeriveKeyBytes(password, a.Salt, kdf)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(kek)
+	return crypto.EncryptWithAD(kek, key, KeyAD(a.UserID))
+}
+
+// KeyCheckAD - метод формирует дополнительные аутентифицируемые данные контрольного значения ключа
+func KeyCheckAD(userID string) []byte {
+	return crypto.AssociatedData("key-check", userID)
+}
+
+// NewKeyCheck - метод формирует контрольное значение ключа шифрования key
+func (a *AuthInfo) NewKeyCheck(key []byte) ([]byte, error) {
+	return crypto.EncryptWithAD(key, keyCheckValue, KeyCheckAD(a.UserID))
+}
+
+// CheckKey - метод проверяет ключ шифрования по контрольному значению
+// (если контрольное значение не задано, ключ считается верным)
+func (a *AuthInfo) CheckKey(key []byte) error {
+	if len(a.KeyCheck) == 0 {
+		return nil
+	}
+	value, err := crypto.DecryptWithAD(key, a.KeyCheck, KeyCheckAD(a.UserID))
+	if err != nil || !bytes.Equal(value, keyCheckValue) {
+		return ErrWrongKey
+	}
+	return nil
+}
+
+// RecoveryAD - метод формирует дополнительные аутентифицируемые данные ключа шифрования,
+// зашифрованного ключом восстановления
+func RecoveryAD(userID string) []byte {
+	return crypto.AssociatedData("recovery", userID)
+}
+
+// WrapRecoveryKey - метод шифрует ключ шифрования секретов key ключом восстановления recoveryKey
+func (a *AuthInfo) WrapRecoveryKey(recoveryKey []byte, key []byte) ([]byte, error) {
+	wrappingKey, err := crypto.RecoveryWrappingKey(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.EncryptWithAD(wrappingKey, key, RecoveryAD(a.UserID))
+}
+
+// RecoverKey - метод расшифровывает ключ шифрования секретов ключом восстановления recoveryKey
+// и проверяет его по контрольному значению
+func (a *AuthInfo) RecoverKey(recoveryKey []byte, wrapped []byte) ([]byte, error) {
+	wrappingKey, err := crypto.RecoveryWrappingKey(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.DecryptWithAD(wrappingKey, wrapped, RecoveryAD(a.UserID))
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	if err := a.CheckKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EscrowAD - метод формирует дополнительные аутентифицируемые данные ключа шифрования,
+// депонированного организации
+func EscrowAD(userID string) []byte {
+	return crypto.AssociatedData("escrow", userID)
+}
+
+// EscrowKey - метод шифрует ключ шифрования секретов key открытым ключом депонирования
+// организации escrowKey (расшифровать его можно только закрытым ключом депонирования)
+func (a *AuthInfo) EscrowKey(escrowKey []byte, key []byte) ([]byte, error) {
+	return crypto.SealKeyWithAD(escrowKey, key, EscrowAD(a.UserID))
+}
+
+// OpenEscrowKey - метод расшифровывает депонированный ключ шифрования закрытым ключом депонирования
+// и проверяет его по контрольному значению
+func (a *AuthInfo) OpenEscrowKey(privateKey []byte, sealed []byte) ([]byte, error) {
+	key, err := crypto.OpenKeyWithAD(privateKey, sealed, EscrowAD(a.UserID))
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	if err := a.CheckKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// KDFToProto - метод преобразования параметров получения ключа в сообщение
+func KDFToProto(kdf crypto.KDFParams) *pb.KdfParams {
+	return &pb.KdfParams{
+		Algorithm:   kdf.Algorithm,
+		Memory:      kdf.Memory,
+		Iterations:  kdf.Iterations,
+		Parallelism: uint32(kdf.Parallelism),
+	}
+}
+
+// KDFFromProto - метод преобразования сообщения в параметры получения ключа
+// (если параметры не переданы - crypto.LegacyKDFParams)
+func KDFFromProto(kdf *pb.KdfParams) crypto.KDFParams {
+	if kdf == nil {
+		return crypto.LegacyKDFParams
+	}
+	return crypto.KDFParams{
+		Algorithm:   kdf.GetAlgorithm(),
+		Memory:      kdf.GetMemory(),
+		Iterations:  kdf.GetIterations(),
+		Parallelism: uint8(min(kdf.GetParallelism(), 255)),
+	}
+}
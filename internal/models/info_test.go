@@ -0,0 +1,411 @@
+package models
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"go-pass-keeper/pkg/crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthInfoUnlockKey(t *testing.T) {
+	const salt = "MDEyMzQ1Njc4OTAxMjM0NQ=="
+	weak := crypto.KDFParams{Algorithm: crypto.KDFAlgorithmArgon2id, Memory: 1024, Iterations: 1, Parallelism: 1}
+	strong := crypto.KDFParams{Algorithm: crypto.KDFAlgorithmArgon2id, Memory: 2048, Iterations: 2, Parallelism: 1}
+
+	// ключ из пароля используется напрямую, пока ключ шифрования не сохранён зашифрованным
+	info := &AuthInfo{Salt: salt, UserID: user_id, KDF: weak}
+	key, err := info.UnlockKey("secret")
+	require.NoError(t, err, "UnlockKey failed")
+	direct, err := crypto.DeriveKey("secret", salt, weak)
+	require.NoError(t, err, "DeriveKey failed")
+	assert.Equal(t, direct, key)
+
+	// после усиления параметров ключ шифрования не меняется
+	wrapped, err := info.WrapKey([]byte("secret"), strong, key)
+	require.NoError(t, err, "WrapKey failed")
+	upgraded := &AuthInfo{Salt: salt, UserID: user_id, KDF: strong, WrappedKey: wrapped}
+	unlocked, err := upgraded.UnlockKey("secret")
+	require.NoError(t, err, "UnlockKey failed")
+	assert.Equal(t, key, unlocked)
+
+	testCases := []struct {
+		TestName string
+		Info     *AuthInfo
+		Password string
+	}{
+		{
+			TestName: "Error. Wrong password",
+			Info:     upgraded,
+			Password: "wrong",
+		},
+		{
+			TestName: "Error. Other user",
+			Info:     &AuthInfo{Salt: salt, UserID: "user-2", KDF: strong, WrappedKey: wrapped},
+			Password: "secret",
+		},
+		{
+			TestName: "Error. Invalid kdf",
+			Info:     &AuthInfo{Salt: salt, UserID: user_id, KDF: crypto.KDFParams{Algorithm: "md5"}},
+			Password: "secret",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, err := tc.Info.UnlockKey(tc.Password)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAuthInfoKeyCheck(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	// без контрольного значения ключ не проверяется
+	info := &AuthInfo{UserID: user_id}
+	require.NoError(t, info.CheckKey(key))
+
+	check, err := info.NewKeyCheck(key)
+	require.NoError(t, err, "NewKeyCheck failed")
+	info.KeyCheck = check
+	require.NoError(t, info.CheckKey(key))
+
+	testCases := []struct {
+		TestName string
+		Info     *AuthInfo
+		Key      []byte
+	}{
+		{
+			TestName: "Error. Wrong key",
+			Info:     info,
+			Key:      []byte("fedcba9876543210fedcba9876543210"),
+		},
+		{
+			TestName: "Error. Other user",
+			Info:     &AuthInfo{UserID: "user-2", KeyCheck: check},
+			Key:      key,
+		},
+		{
+			TestName: "Error. Invalid key size",
+			Info:     info,
+			Key:      []byte("short"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			assert.ErrorIs(t, tc.Info.CheckKey(tc.Key), ErrWrongKey)
+		})
+	}
+}
+
+func TestAuthInfoRecoveryKey(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	recoveryKey, err := crypto.GenerateRecoveryKey()
+	require.NoError(t, err, "GenerateRecoveryKey failed")
+
+	info := &AuthInfo{UserID: user_id}
+	check, err := info.NewKeyCheck(key)
+	require.NoError(t, err, "NewKeyCheck failed")
+	info.KeyCheck = check
+	wrapped, err := info.WrapRecoveryKey(recoveryKey, key)
+	require.NoError(t, err, "WrapRecoveryKey failed")
+
+	recovered, err := info.RecoverKey(recoveryKey, wrapped)
+	require.NoError(t, err, "RecoverKey failed")
+	assert.Equal(t, key, recovered)
+
+	otherKey, err := crypto.GenerateRecoveryKey()
+	require.NoError(t, err, "GenerateRecoveryKey failed")
+	// ключ хранилища, не совпадающий с контрольным значением, не принимается
+	otherWrapped, err := info.WrapRecoveryKey(recoveryKey, []byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err, "WrapRecoveryKey failed")
+
+	testCases := []struct {
+		TestName    string
+		Info        *AuthInfo
+		RecoveryKey []byte
+		Wrapped     []byte
+	}{
+		{
+			TestName:    "Error. Wrong recovery key",
+			Info:        info,
+			RecoveryKey: otherKey,
+			Wrapped:     wrapped,
+		},
+		{
+			TestName:    "Error. Other user",
+			Info:        &AuthInfo{UserID: "user-2"},
+			RecoveryKey: recoveryKey,
+			Wrapped:     wrapped,
+		},
+		{
+			TestName:    "Error. Key check mismatch",
+			Info:        info,
+			RecoveryKey: recoveryKey,
+			Wrapped:     otherWrapped,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, err := tc.Info.RecoverKey(tc.RecoveryKey, tc.Wrapped)
+			assert.ErrorIs(t, err, ErrWrongKey)
+		})
+	}
+}
+
+func TestAuthInfoEscrowKey(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	escrowPublic, escrowPrivate, err := crypto.GenerateKeyPair()
+	require.NoError(t, err, "GenerateKeyPair failed")
+	_, otherPrivate, err := crypto.GenerateKeyPair()
+	require.NoError(t, err, "GenerateKeyPair failed")
+
+	info := &AuthInfo{UserID: user_id}
+	check, err := info.NewKeyCheck(key)
+	require.NoError(t, err, "NewKeyCheck failed")
+	info.KeyCheck = check
+	sealed, err := info.EscrowKey(escrowPublic, key)
+	require.NoError(t, err, "EscrowKey failed")
+
+	opened, err := info.OpenEscrowKey(escrowPrivate, sealed)
+	require.NoError(t, err, "OpenEscrowKey failed")
+	assert.Equal(t, key, opened)
+
+	// ключ хранилища, не совпадающий с контрольным значением, не принимается
+	otherSealed, err := info.EscrowKey(escrowPublic, []byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err, "EscrowKey failed")
+
+	testCases := []struct {
+		TestName   string
+		Info       *AuthInfo
+		PrivateKey []byte
+		Sealed     []byte
+	}{
+		{
+			TestName:   "Error. Wrong escrow private key",
+			Info:       info,
+			PrivateKey: otherPrivate,
+			Sealed:     sealed,
+		},
+		{
+			TestName:   "Error. Other user",
+			Info:       &AuthInfo{UserID: "user-2"},
+			PrivateKey: escrowPrivate,
+			Sealed:     sealed,
+		},
+		{
+			TestName:   "Error. Key check mismatch",
+			Info:       info,
+			PrivateKey: escrowPrivate,
+			Sealed:     otherSealed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, err := tc.Info.OpenEscrowKey(tc.PrivateKey, tc.Sealed)
+			assert.ErrorIs(t, err, ErrWrongKey)
+		})
+	}
+}
+
+func TestSecretInfoDataKey(t *testing.T) {
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+
+	// секрет без собственного ключа зашифрован ключом хранилища
+	legacy := &SecretInfo{ID: "secret-1"}
+	key, err := legacy.OpenDataKey(vaultKey, user_id)
+	require.NoError(t, err, "OpenDataKey failed")
+	assert.Equal(t, vaultKey, key)
+
+	info := &SecretInfo{ID: "secret-1"}
+	dataKey, err := info.NewDataKey(vaultKey, user_id)
+	require.NoError(t, err, "NewDataKey failed")
+	assert.NotEqual(t, vaultKey, dataKey)
+	assert.NotEmpty(t, info.DataKey)
+	assert.Equal(t, info.DataKey, info.ToProtoMetadata().GetWrappedKey())
+
+	key, err = info.OpenDataKey(vaultKey, user_id)
+	require.NoError(t, err, "OpenDataKey failed")
+	assert.Equal(t, dataKey, key)
+
+	// при изменении секрета используется прежний ключ данных
+	wrapped := info.DataKey
+	key, err = info.DataKeyFor(vaultKey, user_id)
+	require.NoError(t, err, "DataKeyFor failed")
+	assert.Equal(t, dataKey, key)
+	assert.Equal(t, wrapped, info.DataKey)
+
+	// ключ данных исходного формата без заголовка перешифровывается с дополнительными данными
+	old := &SecretInfo{ID: "secret-1", DataKey: legacySeal(t, vaultKey, dataKey)}
+	key, err = old.DataKeyFor(vaultKey, user_id)
+	require.NoError(t, err, "DataKeyFor failed")
+	assert.Equal(t, dataKey, key)
+	assert.False(t, crypto.NeedsUpgrade(old.DataKey), "data key should be upgraded")
+	_, err = (&SecretInfo{ID: "secret-2", DataKey: old.DataKey}).OpenDataKey(vaultKey, user_id)
+	require.Error(t, err, "upgraded data key should be bound to the secret")
+
+	// секрету без собственного ключа создаётся новый, а не возвращается ключ хранилища
+	key, err = legacy.DataKeyFor(vaultKey, user_id)
+	require.NoError(t, err, "DataKeyFor failed")
+	assert.NotEqual(t, vaultKey, key)
+	assert.NotEmpty(t, legacy.DataKey)
+
+	// после замены ключа хранилища ключ данных открывается только новым ключом
+	newVaultKey := []byte("fedcba9876543210fedcba9876543210")
+	rotated := &SecretInfo{ID: "secret-1", DataKey: info.DataKey}
+	require.NoError(t, rotated.RewrapDataKey(vaultKey, newVaultKey, user_id), "RewrapDataKey failed")
+	key, err = rotated.OpenDataKey(newVaultKey, user_id)
+	require.NoError(t, err, "OpenDataKey failed")
+	assert.Equal(t, dataKey, key)
+	_, err = rotated.OpenDataKey(vaultKey, user_id)
+	require.Error(t, err)
+	require.Error(t, (&SecretInfo{ID: "secret-3"}).RewrapDataKey(vaultKey, newVaultKey, user_id), "secret without data key")
+
+	testCases := []struct {
+		TestName string
+		Info     *SecretInfo
+		Key      []byte
+		Owner    string
+	}{
+		{
+			TestName: "Error. Wrong vault key",
+			Info:     info,
+			Key:      []byte("fedcba9876543210fedcba9876543210"),
+			Owner:    user_id,
+		},
+		{
+			TestName: "Error. Other owner",
+			Info:     info,
+			Key:      vaultKey,
+			Owner:    "user-2",
+		},
+		{
+			TestName: "Error. Key moved to other secret",
+			Info:     &SecretInfo{ID: "secret-2", DataKey: info.DataKey},
+			Key:      vaultKey,
+			Owner:    user_id,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, err := tc.Info.OpenDataKey(tc.Key, tc.Owner)
+			require.Error(t, err)
+		})
+	}
+}
+
+// legacySeal - метод шифрует данные в исходном формате nonce || ciphertext (AES-256-GCM без заголовка)
+func legacySeal(t *testing.T, key []byte, data []byte) []byte {
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err, "NewCipher failed")
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err, "NewGCM failed")
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err, "rand failed")
+	return gcm.Seal(nonce, nonce, data, nil)
+}
+
+func TestSecretInfoUpgradeContent(t *testing.T) {
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+	info := &SecretInfo{ID: "secret-1", Type: SecretTextType}
+	ad := SecretAD(user_id, info.ID, info.Type)
+
+	// текст, сохранённый как есть ключом хранилища, перешифровывается в контейнере ключом данных
+	content, err := crypto.EncryptWithAD(vaultKey, []byte("text"), ad)
+	require.NoError(t, err, "EncryptWithAD failed")
+	dataKey, err := info.NewDataKey(vaultKey, user_id)
+	require.NoError(t, err, "NewDataKey failed")
+	upgraded, err := info.UpgradeContent(vaultKey, dataKey, user_id, content)
+	require.NoError(t, err, "UpgradeContent failed")
+	_, err = crypto.DecryptWithAD(dataKey, upgraded, ad)
+	require.Error(t, err, "upgraded content must be in container")
+	text := &SecretText{}
+	require.NoError(t, text.Decrypt(dataKey, upgraded, ad), "Decrypt failed")
+	assert.Equal(t, SecretValue("text"), text.Text)
+
+	// заметки из содержимого переносятся в метаданные и удаляются из содержимого
+	password := &SecretInfo{ID: "secret-2", Type: SecretPasswordType}
+	ad = SecretAD(user_id, password.ID, password.Type)
+	content, err = crypto.EncryptWithAD(vaultKey, []byte(`{"login":"user","password":"pass","notes":"заметка"}`), ad)
+	require.NoError(t, err, "EncryptWithAD failed")
+	upgraded, err = password.UpgradeContent(vaultKey, vaultKey, user_id, content)
+	require.NoError(t, err, "UpgradeContent failed")
+	assert.Equal(t, "заметка", password.Notes)
+	secret := &SecretPassword{}
+	require.NoError(t, secret.Decrypt(vaultKey, upgraded, ad), "Decrypt failed")
+	assert.Equal(t, &SecretPassword{Login: "user", Password: SecretValue("pass")}, secret)
+
+	// заметки из метаданных не заменяются заметками из содержимого
+	info = &SecretInfo{Notes: "новая"}
+	extra := &SecretExtra{LegacyNotes: "старая"}
+	assert.True(t, info.MoveNotes(extra))
+	assert.Equal(t, "новая", info.Notes)
+	assert.Empty(t, extra.LegacyNotes)
+	assert.False(t, info.MoveNotes(extra))
+
+	_, err = (&SecretInfo{ID: "secret-3", Type: "unknown"}).UpgradeContent(vaultKey, vaultKey, user_id, content)
+	require.Error(t, err)
+}
+
+func TestAttachmentInfoSeal(t *testing.T) {
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+	dataKey, err := crypto.GenerateDataKey()
+	require.NoError(t, err, "GenerateDataKey failed")
+
+	info := NewAttachmentInfo("secret-1", 4)
+	require.NotEmpty(t, info.ID)
+	content, err := info.Seal(dataKey, user_id, "scan.pdf", []byte("data"))
+	require.NoError(t, err, "Seal failed")
+	assert.Empty(t, info.Name, "name must not be sent in plaintext")
+	assert.NotEmpty(t, info.Meta)
+
+	received := AttachmentInfoFromProto(info.ToProtoMetadata())
+	require.NoError(t, received.OpenName(dataKey, user_id), "OpenName failed")
+	assert.Equal(t, "scan.pdf", received.Name)
+	data, err := received.Open(dataKey, vaultKey, user_id, content)
+	require.NoError(t, err, "Open failed")
+	assert.Equal(t, []byte("data"), data)
+
+	// вложение, добавленное до шифрования ключом данных, расшифровывается ключом хранилища
+	legacyContent, err := crypto.Encrypt(vaultKey, []byte("legacy"))
+	require.NoError(t, err, "Encrypt failed")
+	legacy := &AttachmentInfo{ID: "attachment-2", SecretID: "secret-1", Name: "old.txt"}
+	require.NoError(t, legacy.OpenName(dataKey, user_id))
+	assert.Equal(t, "old.txt", legacy.Name)
+	data, err = legacy.Open(dataKey, vaultKey, user_id, legacyContent)
+	require.NoError(t, err, "Open failed")
+	assert.Equal(t, []byte("legacy"), data)
+
+	testCases := []struct {
+		TestName string
+		Info     *AttachmentInfo
+		Owner    string
+	}{
+		{
+			TestName: "Error. Other owner",
+			Info:     &AttachmentInfo{ID: info.ID, SecretID: info.SecretID, Meta: info.Meta},
+			Owner:    "user-2",
+		},
+		{
+			TestName: "Error. Moved to other secret",
+			Info:     &AttachmentInfo{ID: info.ID, SecretID: "secret-2", Meta: info.Meta},
+			Owner:    user_id,
+		},
+		{
+			TestName: "Error. Swapped with other attachment",
+			Info:     &AttachmentInfo{ID: "attachment-3", SecretID: info.SecretID, Meta: info.Meta},
+			Owner:    user_id,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			require.Error(t, tc.Info.OpenName(dataKey, tc.Owner))
+			_, err := tc.Info.Open(dataKey, vaultKey, tc.Owner, content)
+			require.Error(t, err)
+		})
+	}
+}
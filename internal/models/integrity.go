@@ -0,0 +1,202 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// VaultStateVersion - текущая версия формата подписанного состояния хранилища
+const VaultStateVersion = 1
+
+var (
+	ErrVaultSignature = errors.New("invalid vault state signature")
+	ErrVaultRoot      = errors.New("vault state root mismatch")
+)
+
+// VaultEntry - запись о секрете в подписанном состоянии хранилища
+type VaultEntry struct {
+	ID       string `json:"id"`
+	Revision int64  `json:"revision"`
+	Hash     []byte `json:"hash,omitempty"` // SHA-256 содержимого, зашифрованного на клиенте
+	// момент удаления секрета сервером по истечении срока (unix, 0 - секрет не удаляется)
+	DeleteAt int64 `json:"delete_at,omitempty"`
+}
+
+// VaultEntryFromInfo - метод формирует запись о секрете по его описанию и хешу содержимого hash
+func VaultEntryFromInfo(info *SecretInfo, hash []byte) VaultEntry {
+	entry := VaultEntry{ID: info.ID, Revision: info.Revision, Hash: hash}
+	if info.ExpirePolicy == ExpireDelete && !info.Expires.IsZero() {
+		entry.DeleteAt = info.Expires.Unix()
+	}
+	return entry
+}
+
+// leaf - метод вычисляет лист дерева Меркла для записи
+func (e VaultEntry) leaf() []byte {
+	return crypto.MerkleLeaf(crypto.AssociatedData("vault-entry", e.ID,
+		strconv.FormatInt(e.Revision, 10), string(e.Hash), strconv.FormatInt(e.DeleteAt, 10)))
+}
+
+// VaultState - состояние личного хранилища: записи о секретах (упорядочены по идентификатору),
+// корень дерева Меркла над ними и номер состояния. Состояние подписывается на клиенте
+// ключом, полученным из ключа хранилища, и хранится на сервере.
+type VaultState struct {
+	Version  int          `json:"version"`
+	Sequence int64        `json:"sequence"`
+	Entries  []VaultEntry `json:"entries"`
+	Root     []byte       `json:"root"`
+}
+
+// NewVaultState - метод формирует состояние хранилища по списку секретов и хешам их содержимого
+func NewVaultState(secrets []*SecretInfo) *VaultState {
+	s := &VaultState{Version: VaultStateVersion, Entries: make([]VaultEntry, 0, len(secrets))}
+	for _, secret := range secrets {
+		s.Set(VaultEntryFromInfo(secret, secret.ContentHash))
+	}
+	return s
+}
+
+// find - метод возвращает позицию записи с идентификатором id (или позицию для её вставки)
+func (s *VaultState) find(id string) (int, bool) {
+	i := sort.Search(len(s.Entries), func(i int) bool { return s.Entries[i].ID >= id })
+	return i, i < len(s.Entries) && s.Entries[i].ID == id
+}
+
+// Set - метод добавляет или заменяет запись о секрете
+func (s *VaultState) Set(entry VaultEntry) {
+	i, ok := s.find(entry.ID)
+	if ok {
+		s.Entries[i] = entry
+		return
+	}
+	s.Entries = append(s.Entries, VaultEntry{})
+	copy(s.Entries[i+1:], s.Entries[i:])
+	s.Entries[i] = entry
+}
+
+// Remove - метод удаляет запись о секрете
+func (s *VaultState) Remove(id string) {
+	if i, ok := s.find(id); ok {
+		s.Entries = append(s.Entries[:i], s.Entries[i+1:]...)
+	}
+}
+
+// Entry - метод возвращает запись о секрете
+func (s *VaultState) Entry(id string) (VaultEntry, bool) {
+	i, ok := s.find(id)
+	if !ok {
+		return VaultEntry{}, false
+	}
+	return s.Entries[i], true
+}
+
+// MerkleRoot - метод вычисляет корень дерева Меркла над записями состояния
+func (s *VaultState) MerkleRoot() []byte {
+	leaves := make([][]byte, len(s.Entries))
+	for i, entry := range s.Entries {
+		leaves[i] = entry.leaf()
+	}
+	return crypto.MerkleRoot(leaves)
+}
+
+// Seal - метод вычисляет корень и подписывает состояние хранилища пользователя userID
+// ключом хранилища key (возвращает подпись и состояние в одном значении для сервера)
+func (s *VaultState) Seal(key []byte, userID string) ([]byte, error) {
+	s.Version = VaultStateVersion
+	s.Root = s.MerkleRoot()
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode vault state: %w", err)
+	}
+	integrityKey, err := crypto.IntegrityKey(key)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(integrityKey)
+	signature := crypto.SignIntegrity(integrityKey, vaultStateAD(userID, data))
+	return append(signature, data...), nil
+}
+
+// OpenVaultState - метод проверяет подпись состояния хранилища пользователя userID,
+// полученного с сервера, и корень дерева Меркла над его записями
+func OpenVaultState(key []byte, userID string, blob []byte) (*VaultState, error) {
+	if len(blob) < crypto.IntegritySize {
+		return nil, ErrVaultSignature
+	}
+	signature, data := blob[:crypto.IntegritySize], blob[crypto.IntegritySize:]
+	integrityKey, err := crypto.IntegrityKey(key)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(integrityKey)
+	if !crypto.VerifyIntegrity(integrityKey, vaultStateAD(userID, data), signature) {
+		return nil, ErrVaultSignature
+	}
+	s := &VaultState{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to decode vault state: %w", err)
+	}
+	if s.Version != VaultStateVersion {
+		return nil, fmt.Errorf("unsupported vault state version %d", s.Version)
+	}
+	if !bytes.Equal(s.MerkleRoot(), s.Root) {
+		return nil, ErrVaultRoot
+	}
+	return s, nil
+}
+
+// vaultStateAD - метод формирует подписываемые данные состояния хранилища пользователя userID
+func vaultStateAD(userID string, data []byte) []byte {
+	return crypto.AssociatedData("vault-state", userID, string(data))
+}
+
+// VaultReport - результат сверки списка секретов на сервере с подписанным состоянием хранилища
+type VaultReport struct {
+	Missing    []string // секреты из состояния, которых нет на сервере
+	RolledBack []string // секреты с ревизией старше подписанной
+	Injected   []string // секреты, изменённые или добавленные не через клиента
+}
+
+// OK - метод проверяет, что расхождений не найдено
+func (r VaultReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.RolledBack) == 0 && len(r.Injected) == 0
+}
+
+// Verify - метод сверяет список секретов личного хранилища на сервере (действующих и архивных)
+// с подписанным состоянием. Секрет, удалённый сервером по истечении срока к моменту now,
+// расхождением не считается. Хеш содержимого сравнивается, только если он известен обеим сторонам.
+func (s *VaultState) Verify(secrets []*SecretInfo, now time.Time) VaultReport {
+	var report VaultReport
+	server := make(map[string]*SecretInfo, len(secrets))
+	for _, secret := range secrets {
+		server[secret.ID] = secret
+	}
+	for _, entry := range s.Entries {
+		secret, ok := server[entry.ID]
+		delete(server, entry.ID)
+		switch {
+		case !ok:
+			if entry.DeleteAt == 0 || now.Unix() < entry.DeleteAt {
+				report.Missing = append(report.Missing, entry.ID)
+			}
+		case secret.Revision < entry.Revision:
+			report.RolledBack = append(report.RolledBack, entry.ID)
+		case secret.Revision > entry.Revision:
+			report.Injected = append(report.Injected, entry.ID)
+		case len(entry.Hash) != 0 && len(secret.ContentHash) != 0 && !bytes.Equal(entry.Hash, secret.ContentHash):
+			report.Injected = append(report.Injected, entry.ID)
+		}
+	}
+	for id := range server {
+		report.Injected = append(report.Injected, id)
+	}
+	sort.Strings(report.Injected)
+	return report
+}
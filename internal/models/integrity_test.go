@@ -0,0 +1,114 @@
+package models
+
+import (
+	"go-pass-keeper/pkg/crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultStateSeal(t *testing.T) {
+	key, err := crypto.GenerateDataKey()
+	require.NoError(t, err)
+
+	state := NewVaultState([]*SecretInfo{
+		{ID: "b", Revision: 2, ContentHash: crypto.ContentHash([]byte("b"))},
+		{ID: "a", Revision: 1, ContentHash: crypto.ContentHash([]byte("a"))},
+	})
+	state.Sequence = 3
+	blob, err := state.Seal(key, user_id)
+	require.NoError(t, err)
+
+	opened, err := OpenVaultState(key, user_id, blob)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), opened.Sequence)
+	assert.Equal(t, state.Root, opened.Root)
+	assert.Equal(t, "a", opened.Entries[0].ID, "entries must be sorted")
+
+	// состояние чужого хранилища, подписанное другим ключом или изменённое на сервере, не открывается
+	_, err = OpenVaultState(key, "other", blob)
+	assert.ErrorIs(t, err, ErrVaultSignature)
+	otherKey, err := crypto.GenerateDataKey()
+	require.NoError(t, err)
+	_, err = OpenVaultState(otherKey, user_id, blob)
+	assert.ErrorIs(t, err, ErrVaultSignature)
+	tampered := append([]byte{}, blob...)
+	tampered[len(tampered)-2] ^= 1
+	_, err = OpenVaultState(key, user_id, tampered)
+	assert.ErrorIs(t, err, ErrVaultSignature)
+	_, err = OpenVaultState(key, user_id, blob[:10])
+	assert.ErrorIs(t, err, ErrVaultSignature)
+
+	// корень зависит от каждой записи
+	root := opened.MerkleRoot()
+	opened.Set(VaultEntry{ID: "a", Revision: 2, Hash: crypto.ContentHash([]byte("a"))})
+	assert.NotEqual(t, root, opened.MerkleRoot())
+	opened.Remove("a")
+	_, ok := opened.Entry("a")
+	assert.False(t, ok)
+}
+
+func TestVaultStateVerify(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	hash := crypto.ContentHash([]byte("content"))
+	state := &VaultState{}
+	state.Set(VaultEntry{ID: "1", Revision: 2, Hash: hash})
+	state.Set(VaultEntry{ID: "2", Revision: 1, Hash: hash})
+	state.Set(VaultEntry{ID: "3", Revision: 1, Hash: hash, DeleteAt: now.Add(-time.Hour).Unix()})
+
+	testCases := []struct {
+		TestName string
+		Secrets  []*SecretInfo
+		Expected VaultReport
+	}{
+		{
+			TestName: "Success. Same state, expired secret deleted",
+			Secrets: []*SecretInfo{
+				{ID: "1", Revision: 2, ContentHash: hash},
+				{ID: "2", Revision: 1, ContentHash: hash},
+			},
+			Expected: VaultReport{},
+		},
+		{
+			TestName: "Success. Unknown content hash",
+			Secrets: []*SecretInfo{
+				{ID: "1", Revision: 2},
+				{ID: "2", Revision: 1, ContentHash: hash},
+			},
+			Expected: VaultReport{},
+		},
+		{
+			TestName: "Missing secret",
+			Secrets: []*SecretInfo{
+				{ID: "1", Revision: 2, ContentHash: hash},
+			},
+			Expected: VaultReport{Missing: []string{"2"}},
+		},
+		{
+			TestName: "Rolled back secret",
+			Secrets: []*SecretInfo{
+				{ID: "1", Revision: 1, ContentHash: hash},
+				{ID: "2", Revision: 1, ContentHash: hash},
+			},
+			Expected: VaultReport{RolledBack: []string{"1"}},
+		},
+		{
+			TestName: "Injected secrets",
+			Secrets: []*SecretInfo{
+				{ID: "1", Revision: 3, ContentHash: hash},
+				{ID: "2", Revision: 1, ContentHash: crypto.ContentHash([]byte("other"))},
+				{ID: "4", Revision: 1, ContentHash: hash},
+			},
+			Expected: VaultReport{Injected: []string{"1", "2", "4"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			report := state.Verify(tc.Secrets, now)
+			assert.Equal(t, tc.Expected, report)
+			assert.Equal(t, len(tc.Expected.Missing)+len(tc.Expected.RolledBack)+len(tc.Expected.Injected) == 0, report.OK())
+		})
+	}
+}
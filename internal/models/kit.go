@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// EmergencyKit - аварийный комплект: данные для восстановления доступа к хранилищу,
+// если секрет забыт (предназначен для печати и хранения вне компьютера)
+type EmergencyKit struct {
+	Server       string
+	Login        string
+	UserID       string
+	RecoveryCode string
+	Created      time.Time
+}
+
+// Text - метод формирует текст аварийного комплекта для печати
+func (k EmergencyKit) Text() string {
+	var b strings.Builder
+	b.WriteString("GO-PASS-KEEPER - АВАРИЙНЫЙ КОМПЛЕКТ\n")
+	b.WriteString("===================================\n\n")
+	fmt.Fprintf(&b, "Создан:  %s\n", k.Created.Local().Format(time.DateTime))
+	fmt.Fprintf(&b, "Сервер:  %s\n", k.Server)
+	fmt.Fprintf(&b, "Логин:   %s\n", k.Login)
+	fmt.Fprintf(&b, "ID:      %s\n\n", k.UserID)
+	b.WriteString("Ключ восстановления:\n\n")
+	fmt.Fprintf(&b, "    %s\n\n", k.RecoveryCode)
+	b.WriteString("Секрет (впишите от руки): ______________________________\n\n")
+	b.WriteString("Если секрет забыт:\n")
+	b.WriteString("  1. Войдите в приложение с логином и паролем.\n")
+	b.WriteString("  2. В окне «Хранилище заблокировано» нажмите Ctrl+R.\n")
+	b.WriteString("  3. Введите ключ восстановления и новый секрет.\n\n")
+	b.WriteString("Ключ восстановления открывает хранилище без секрета: храните комплект\n")
+	b.WriteString("распечатанным в надёжном месте и удалите файл после печати.\n")
+	b.WriteString("Создание нового ключа восстановления делает этот комплект недействительным.\n")
+	return b.String()
+}
+
+// RecoveryShareKit - часть ключа восстановления для передачи одному из доверенных хранителей
+// (ключ восстанавливается по Threshold частям из Total)
+type RecoveryShareKit struct {
+	Server    string
+	Login     string
+	UserID    string
+	Share     string // код части ключа восстановления
+	Index     int
+	Total     int
+	Threshold int
+	Created   time.Time
+}
+
+// Text - метод формирует текст части ключа восстановления для печати
+// (при qr код части дополнительно выводится QR-кодом)
+func (k RecoveryShareKit) Text(qr bool) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "GO-PASS-KEEPER - ЧАСТЬ КЛЮЧА ВОССТАНОВЛЕНИЯ %d ИЗ %d\n", k.Index, k.Total)
+	b.WriteString("==============================================\n\n")
+	fmt.Fprintf(&b, "Создана: %s\n", k.Created.Local().Format(time.DateTime))
+	fmt.Fprintf(&b, "Сервер:  %s\n", k.Server)
+	fmt.Fprintf(&b, "Логин:   %s\n", k.Login)
+	fmt.Fprintf(&b, "ID:      %s\n\n", k.UserID)
+	fmt.Fprintf(&b, "Часть ключа восстановления (нужно %d из %d):\n\n", k.Threshold, k.Total)
+	fmt.Fprintf(&b, "    %s\n\n", k.Share)
+	if qr {
+		code, err := qrcode.New(k.Share, qrcode.Medium)
+		if err != nil {
+			return "", fmt.Errorf("failed to create QR code: %w", err)
+		}
+		b.WriteString(code.ToSmallString(false))
+		b.WriteString("\n")
+	}
+	b.WriteString("Если секрет забыт:\n")
+	fmt.Fprintf(&b, "  1. Соберите любые %d части у хранителей.\n", k.Threshold)
+	b.WriteString("  2. Войдите в приложение, в окне «Хранилище заблокировано» нажмите Ctrl+R, затем Ctrl+T.\n")
+	b.WriteString("  3. Введите части (Ctrl+A - добавить часть) и новый секрет.\n\n")
+	b.WriteString("Одна часть не открывает хранилище. Храните её распечатанной отдельно от других частей.\n")
+	b.WriteString("Создание нового ключа восстановления делает все части недействительными.\n")
+	return b.String(), nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmergencyKitText(t *testing.T) {
+	kit := EmergencyKit{
+		Server:       "localhost:8080",
+		Login:        "alice",
+		UserID:       user_id,
+		RecoveryCode: "GAYT-EMZU-GQ3D-OOBZ",
+		Created:      time.Date(2025, 10, 18, 9, 0, 0, 0, time.Local),
+	}
+	text := kit.Text()
+	for _, expected := range []string{
+		"Сервер:  localhost:8080",
+		"Логин:   alice",
+		"ID:      " + user_id,
+		"    GAYT-EMZU-GQ3D-OOBZ\n",
+		"Создан:  2025-10-18 09:00:00",
+	} {
+		assert.Contains(t, text, expected)
+	}
+}
+
+func TestRecoveryShareKitText(t *testing.T) {
+	kit := RecoveryShareKit{
+		Server:    "localhost:8080",
+		Login:     "alice",
+		UserID:    user_id,
+		Share:     "AMBA-GAYT-EMZU",
+		Index:     2,
+		Total:     5,
+		Threshold: 3,
+		Created:   time.Date(2025, 10, 20, 9, 0, 0, 0, time.Local),
+	}
+	text, err := kit.Text(false)
+	assert.NoError(t, err)
+	for _, expected := range []string{
+		"ЧАСТЬ КЛЮЧА ВОССТАНОВЛЕНИЯ 2 ИЗ 5",
+		"Логин:   alice",
+		"(нужно 3 из 5)",
+		"    AMBA-GAYT-EMZU\n",
+	} {
+		assert.Contains(t, text, expected)
+	}
+	assert.NotContains(t, text, "█")
+
+	withQR, err := kit.Text(true)
+	assert.NoError(t, err)
+	assert.Contains(t, withQR, "█")
+}
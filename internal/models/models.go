@@ -1,6 +1,8 @@
 package models
 
 import (
+	"database/sql"
+	"go-pass-keeper/pkg/crypto"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,19 +10,160 @@ import (
 
 // UserData - модель пользователя из БД
 type UserData struct {
-	ID       uuid.UUID
-	Login    string
-	Password string
-	Salt     string
+	ID          uuid.UUID
+	Login       string
+	Password    string
+	Salt        string
+	KDF         crypto.KDFParams // параметры получения ключа из пароля
+	WrappedKey  []byte           // ключ шифрования, зашифрованный ключом из пароля (пусто - используется ключ из пароля)
+	SRPSalt     []byte           // соль проверочного значения пароля SRP
+	Verifier    []byte           // проверочное значение пароля SRP (пусто - вход по хешу bcrypt)
+	KeyCheck    []byte           // контрольное значение ключа шифрования (пусто - проверка не выполняется)
+	RecoveryKey []byte           // ключ шифрования, зашифрованный ключом восстановления (пусто - не задан)
+	EscrowKey   []byte           // ключ шифрования, депонированный организации (пусто - не депонирован)
+	VaultRoot   []byte           // состояние личного хранилища, подписанное на клиенте (пусто - не задано)
+	VaultSeq    int64            // номер подписанного состояния личного хранилища
+	PublicKey   []byte
+	PrivateKey  []byte
+	Disabled    bool         // учётная запись заблокирована администратором
+	LogoutAt    sql.NullTime // момент последнего принудительного выхода
+	Generation  int64        // поколение токенов: токены другого поколения недействительны
+	Created     time.Time
+}
+
+// UserStats - модель пользователя со статистикой использования из БД
+type UserStats struct {
+	ID            uuid.UUID
+	Login         string
+	Disabled      bool
+	Secrets       int64 // количество секретов
+	ContentSize   int64 // суммарный размер содержимого секретов (байт)
+	Shares        int64 // количество переданных другим пользователям секретов
+	Organizations int64 // количество организаций пользователя
+	Created       time.Time
+	LastActivity  sql.NullTime // время последнего изменения секретов
 }
 
 // SecretData - модель секрета  из БД
 type SecretData struct {
-	ID      uuid.UUID
-	UserID  uuid.UUID
-	Name    string
-	Type    string
-	Created time.Time
-	Updated time.Time
-	Content []byte
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	OrgID        uuid.NullUUID // организация, которой принадлежит секрет (пусто для личного хранилища)
+	Name         string
+	Type         string
+	Created      time.Time
+	Updated      time.Time
+	Content      []byte
+	Expires      sql.NullTime // срок действия секрета (пусто - бессрочный)
+	ExpirePolicy string       // действие по истечении срока (ExpireFlag, ExpireArchive, ExpireDelete)
+	Archived     bool         // секрет перемещён в архив по истечении срока
+	SearchIndex  [][]byte     // слепой индекс: ключевые хеши слов названия и тегов (nil - не изменять, пустой - очистить)
+	Meta         []byte       // метаданные (название, заметки, теги), зашифрованные на клиенте
+	WrappedKey   []byte       // ключ данных секрета, зашифрованный на клиенте ключом хранилища
+	Revision     int64        // ревизия секрета (увеличивается при каждом изменении)
+	ContentHash  []byte       // SHA-256 содержимого, зашифрованного на клиенте (пусто - не вычислен)
+}
+
+// Действия с секретом по истечении срока действия
+const (
+	ExpireFlag    = "flag"    // секрет только помечается как истёкший
+	ExpireArchive = "archive" // секрет перемещается в архив
+	ExpireDelete  = "delete"  // секрет удаляется
+)
+
+// ValidExpirePolicy - метод проверяет допустимость действия по истечении срока
+func ValidExpirePolicy(policy string) bool {
+	switch policy {
+	case ExpireFlag, ExpireArchive, ExpireDelete:
+		return true
+	}
+	return false
+}
+
+// ShareData - модель секрета, переданного другому пользователю, из БД
+type ShareData struct {
+	ID          uuid.UUID
+	SecretID    uuid.UUID
+	OwnerID     uuid.UUID
+	Owner       string
+	RecipientID uuid.UUID
+	Recipient   string
+	Name        string
+	Type        string
+	WrappedKey  []byte
+	Content     []byte
+	Meta        []byte // метаданные секрета (у копии секрета - зашифрованные ключом передачи)
+	Created     time.Time
+	// хранилище (организация или пользователь), к которому привязано шифрование секрета
+	// (пусто у копии секрета, переданной до передачи по ссылке)
+	VaultOwner string
+}
+
+// AttachmentData - модель вложения секрета из БД
+type AttachmentData struct {
+	ID       uuid.UUID
+	SecretID uuid.UUID
+	Name     string
+	Size     int64
+	Content  []byte
+	Meta     []byte // название, зашифрованное на клиенте (nil у вложений, добавленных ранее)
+	Created  time.Time
+}
+
+// SecretBackup - секрет личного хранилища вместе с вложениями для выгрузки и восстановления учётной записи
+type SecretBackup struct {
+	Secret      *SecretData       // секрет со слепым индексом
+	Attachments []*AttachmentData // вложения секрета
+}
+
+// Роли участников организации
+const (
+	RoleOwner    = "owner"    // владелец: полный доступ, не может быть удалён
+	RoleAdmin    = "admin"    // администратор: управление участниками и секретами
+	RoleMember   = "member"   // участник: чтение и изменение секретов
+	RoleReadOnly = "readonly" // только чтение секретов
+)
+
+// OrganizationData - модель организации из БД (с ролью и ключом хранилища текущего пользователя)
+type OrganizationData struct {
+	ID        uuid.UUID
+	Name      string
+	Role      string
+	VaultKey  []byte
+	Created   time.Time
+	RotateKey bool // из организации удалён участник, ключ хранилища необходимо заменить
+}
+
+// MemberData - модель участника организации из БД
+type MemberData struct {
+	OrgID    uuid.UUID
+	UserID   uuid.UUID
+	Login    string
+	Role     string
+	VaultKey []byte // ключ хранилища, зашифрованный открытым ключом участника
+	Created  time.Time
+}
+
+// ValidRole - метод проверяет, что роль известна
+func ValidRole(role string) bool {
+	switch role {
+	case RoleOwner, RoleAdmin, RoleMember, RoleReadOnly:
+		return true
+	}
+	return false
+}
+
+// CanRead - метод проверяет, что участник может читать секреты организации
+func (m *MemberData) CanRead() bool {
+	return ValidRole(m.Role)
+}
+
+// CanWrite - метод проверяет, что участник может изменять секреты организации
+func (m *MemberData) CanWrite() bool {
+	return m.Role == RoleOwner || m.Role == RoleAdmin || m.Role == RoleMember
+}
+
+// CanManage - метод проверяет, что участник может управлять составом организации
+func (m *MemberData) CanManage() bool {
+	return m.Role == RoleOwner || m.Role == RoleAdmin
 }
@@ -2,8 +2,14 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+	"net/url"
+	"slices"
+	"time"
+	"unicode/utf8"
 )
 
 const (
@@ -13,49 +19,224 @@ const (
 	SecretBinaryType   = "binary"
 )
 
+// Типы дополнительных полей секрета
+const (
+	FieldText   = "text"   // произвольный текст
+	FieldHidden = "hidden" // скрываемое значение (ответ на контрольный вопрос, PIN)
+	FieldURL    = "url"    // адрес сайта
+	FieldDate   = "date"   // дата в формате ГГГГ-ММ-ДД
+)
+
+// FieldTypes - список типов дополнительных полей в порядке переключения
+var FieldTypes = []string{FieldText, FieldHidden, FieldURL, FieldDate}
+
+// SecretValue - секретное значение содержимого (в JSON - строка). Значение хранится в срезе байтов,
+// поэтому, в отличие от неизменяемой строки, обнуляется методом Wipe после использования
+type SecretValue []byte
+
+// MarshalJSON - метод кодирует значение строкой JSON (значение без экранируемых символов
+// копируется напрямую, без промежуточной строки)
+func (v SecretValue) MarshalJSON() ([]byte, error) {
+	if !utf8.Valid(v) || slices.ContainsFunc(v, needsEscape) {
+		return json.Marshal(string(v))
+	}
+	data := make([]byte, 0, len(v)+2)
+	data = append(data, '"')
+	data = append(data, v...)
+	return append(data, '"'), nil
+}
+
+// UnmarshalJSON - метод декодирует значение из строки JSON (строка без экранирования
+// копируется напрямую, без промежуточной строки)
+func (v *SecretValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*v = nil
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' && !slices.Contains(data[1:len(data)-1], '\\') {
+		*v = append(SecretValue{}, data[1:len(data)-1]...)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*v = SecretValue(s)
+	return nil
+}
+
+// Wipe - метод обнуляет значение
+func (v SecretValue) Wipe() {
+	securemem.Wipe(v)
+}
+
+// needsEscape - метод проверяет, экранируется ли байт в строке JSON
+func needsEscape(c byte) bool {
+	return c < 0x20 || c == '"' || c == '\\'
+}
+
+// CustomField - дополнительное поле секрета
+type CustomField struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value SecretValue `json:"value"`
+}
+
+// Validate - метод проверяет имя, тип и значение дополнительного поля
+func (f CustomField) Validate() error {
+	if f.Name == "" {
+		return errors.New("empty field name")
+	}
+	switch f.Type {
+	case FieldText, FieldHidden:
+	case FieldURL:
+		if len(f.Value) == 0 {
+			return nil
+		}
+		if _, err := url.ParseRequestURI(string(f.Value)); err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+	case FieldDate:
+		if len(f.Value) == 0 {
+			return nil
+		}
+		if _, err := time.Parse(time.DateOnly, string(f.Value)); err != nil {
+			return fmt.Errorf("invalid date: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown field type %q", f.Type)
+	}
+	return nil
+}
+
+// SecretExtra - дополнительные поля, общие для всех типов секретов (шифруются вместе с содержимым).
+// Заметки хранятся только в зашифрованных метаданных секрета (см. SecretMeta).
+// Поля не обязательны: содержимое, зашифрованное до их появления, читается без изменений
+type SecretExtra struct {
+	Fields []CustomField `json:"fields,omitempty"`
+	// заметки из содержимого секретов, сохранённых до появления метаданных
+	// (только чтение: переносятся в метаданные, см. SecretInfo.MoveNotes)
+	LegacyNotes string `json:"notes,omitempty"`
+}
+
+// Validate - метод проверяет дополнительные поля
+func (e *SecretExtra) Validate() error {
+	for i, field := range e.Fields {
+		if err := field.Validate(); err != nil {
+			return fmt.Errorf("field %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Clone - метод возвращает копию дополнительных полей с собственными значениями
+// (копия не обнуляется при обнулении исходных полей)
+func (e *SecretExtra) Clone() SecretExtra {
+	clone := SecretExtra{LegacyNotes: e.LegacyNotes}
+	for _, field := range e.Fields {
+		field.Value = append(SecretValue{}, field.Value...)
+		clone.Fields = append(clone.Fields, field)
+	}
+	return clone
+}
+
+// wipe - метод обнуляет значения дополнительных полей
+func (e *SecretExtra) wipe() {
+	for _, field := range e.Fields {
+		field.Value.Wipe()
+	}
+}
+
+// payloadVersion - текущая версия контейнера содержимого текстового и бинарного секрета
+const payloadVersion = 1
+
+// payloadContainer - версионированный контейнер содержимого текстового и бинарного секрета
+// с дополнительными полями. Контейнер шифруется с признаком в аутентифицируемых данных (см. payloadAD),
+// поэтому отличается от текста и данных прежнего формата, зашифрованных как есть, при расшифровке,
+// а не по содержимому
+type payloadContainer struct {
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
 // SecretPassword - данные логин/пароль
 type SecretPassword struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login    string      `json:"login"`
+	Password SecretValue `json:"password"`
+	SecretExtra
 }
 
 // SecretCard - данные банковская карта
 type SecretCard struct {
-	Number string `json:"number"`
-	Date   string `json:"date"`
-	CVV    string `json:"cvv"`
-	Owner  string `json:"owner"`
+	Number SecretValue `json:"number"`
+	Date   string      `json:"date"`
+	CVV    SecretValue `json:"cvv"`
+	Owner  string      `json:"owner"`
+	SecretExtra
 }
 
 // SecretCrypter - интерфейс для обобщения типов секретных данных
+// (шифрование с дополнительными аутентифицируемыми данными, см. SecretAD;
+// промежуточные открытые данные обнуляются, Wipe обнуляет секретные значения после использования)
 type SecretCrypter interface {
-	Encrypt([]byte) ([]byte, error)
-	Decrypt([]byte, []byte) error
+	Encrypt(key []byte, ad []byte) ([]byte, error)
+	Decrypt(key []byte, content []byte, ad []byte) error
+	Wipe()
+}
+
+// SecretAD - метод формирует дополнительные аутентифицируемые данные содержимого секрета:
+// владелец хранилища (пользователь или организация), идентификатор и тип секрета.
+// Подмена содержимого одного секрета другим или изменение типа на сервере
+// обнаруживается при расшифровке.
+func SecretAD(owner string, id string, kind string) []byte {
+	return crypto.AssociatedData("content", owner, id, kind)
+}
+
+// MetaAD - метод формирует дополнительные аутентифицируемые данные метаданных секрета
+func MetaAD(owner string, id string, kind string) []byte {
+	return crypto.AssociatedData("meta", owner, id, kind)
+}
+
+// DataKeyAD - метод формирует дополнительные аутентифицируемые данные ключа данных секрета
+func DataKeyAD(owner string, id string) []byte {
+	return crypto.AssociatedData("data-key", owner, id)
+}
+
+// AttachmentAD - метод формирует дополнительные аутентифицируемые данные содержимого вложения
+func AttachmentAD(owner string, secretID string, id string) []byte {
+	return crypto.AssociatedData("attachment", owner, secretID, id)
+}
+
+// AttachmentMetaAD - метод формирует дополнительные аутентифицируемые данные названия вложения
+func AttachmentMetaAD(owner string, secretID string, id string) []byte {
+	return crypto.AssociatedData("attachment-meta", owner, secretID, id)
 }
 
 // NewSecretPassword - базовый конструктор
 func NewSecretPassword(login, password string) *SecretPassword {
 	return &SecretPassword{
 		Login:    login,
-		Password: password,
+		Password: SecretValue(password),
 	}
 }
 
 // Encrypt - метод шифрует данные пароля и логина
-func (sp *SecretPassword) Encrypt(key []byte) ([]byte, error) {
+func (sp *SecretPassword) Encrypt(key []byte, ad []byte) ([]byte, error) {
 	data, err := json.Marshal(sp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal: %w", err)
 	}
-	return crypto.Encrypt(key, data)
+	defer securemem.Wipe(data)
+	return crypto.EncryptWithAD(key, data, ad)
 }
 
 // Decrypt - метод рашифровывает данные пароля и логина
-func (sp *SecretPassword) Decrypt(key []byte, content []byte) error {
-	data, err := crypto.Decrypt(key, content)
+func (sp *SecretPassword) Decrypt(key []byte, content []byte, ad []byte) error {
+	data, err := crypto.DecryptWithAD(key, content, ad)
 	if err != nil {
 		return err
 	}
+	defer securemem.Wipe(data)
 	err = json.Unmarshal(data, sp)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal: %w", err)
@@ -63,31 +244,40 @@ func (sp *SecretPassword) Decrypt(key []byte, content []byte) error {
 	return nil
 }
 
+// Wipe - метод обнуляет пароль и значения дополнительных полей и удаляет данные
+func (sp *SecretPassword) Wipe() {
+	sp.Password.Wipe()
+	sp.SecretExtra.wipe()
+	*sp = SecretPassword{}
+}
+
 // NewSecretCard - базовый конструктор
 func NewSecretCard(number, date, cvv, owner string) *SecretCard {
 	return &SecretCard{
-		Number: number,
+		Number: SecretValue(number),
 		Date:   date,
-		CVV:    cvv,
+		CVV:    SecretValue(cvv),
 		Owner:  owner,
 	}
 }
 
 // Encrypt - метод шифрует данные кредитной карты
-func (sc *SecretCard) Encrypt(key []byte) ([]byte, error) {
+func (sc *SecretCard) Encrypt(key []byte, ad []byte) ([]byte, error) {
 	data, err := json.Marshal(sc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal: %w", err)
 	}
-	return crypto.Encrypt(key, data)
+	defer securemem.Wipe(data)
+	return crypto.EncryptWithAD(key, data, ad)
 }
 
 // Decrypt - метод рашифровывает данные кредитной карты
-func (sc *SecretCard) Decrypt(key []byte, content []byte) error {
-	data, err := crypto.Decrypt(key, content)
+func (sc *SecretCard) Decrypt(key []byte, content []byte, ad []byte) error {
+	data, err := crypto.DecryptWithAD(key, content, ad)
 	if err != nil {
 		return err
 	}
+	defer securemem.Wipe(data)
 	err = json.Unmarshal(data, sc)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal: %w", err)
@@ -95,36 +285,55 @@ func (sc *SecretCard) Decrypt(key []byte, content []byte) error {
 	return nil
 }
 
+// Wipe - метод обнуляет номер и CVV карты и значения дополнительных полей и удаляет данные
+func (sc *SecretCard) Wipe() {
+	sc.Number.Wipe()
+	sc.CVV.Wipe()
+	sc.SecretExtra.wipe()
+	*sc = SecretCard{}
+}
+
 // SecretText - текстовые данные
 type SecretText struct {
-	Text string
+	Text SecretValue `json:"text"`
+	SecretExtra
 }
 
 // NewSecretText - базовый конструктор
 func NewSecretText(text string) *SecretText {
 	return &SecretText{
-		Text: text,
+		Text: SecretValue(text),
 	}
 }
 
-// Encrypt - метод шифрует текстовые данные
-func (sc *SecretText) Encrypt(key []byte) ([]byte, error) {
-	return crypto.Encrypt(key, []byte(sc.Text))
+// Encrypt - метод шифрует текстовые данные в контейнере
+func (sc *SecretText) Encrypt(key []byte, ad []byte) ([]byte, error) {
+	return encryptPayload(key, ad, sc)
 }
 
 // Decrypt - метод рашифровывает текстовые данные
-func (sc *SecretText) Decrypt(key []byte, content []byte) error {
-	data, err := crypto.Decrypt(key, content)
-	if err != nil {
+// (текст прежнего формата, зашифрованный как есть, читается без дополнительных полей)
+func (sc *SecretText) Decrypt(key []byte, content []byte, ad []byte) error {
+	*sc = SecretText{}
+	data, err := decryptPayload(key, content, ad, sc)
+	if err != nil || data == nil {
 		return err
 	}
-	sc.Text = string(data)
+	sc.Text = data
 	return nil
 }
 
+// Wipe - метод обнуляет текст и значения дополнительных полей и удаляет данные
+func (sc *SecretText) Wipe() {
+	sc.Text.Wipe()
+	sc.SecretExtra.wipe()
+	*sc = SecretText{}
+}
+
 // SecretBinary - бинарные данные
 type SecretBinary struct {
-	Blob []byte
+	Blob []byte `json:"blob"`
+	SecretExtra
 }
 
 // NewSecretBinary- базовый конструктор
@@ -134,17 +343,125 @@ func NewSecretBinary(bin []byte) *SecretBinary {
 	}
 }
 
-// Encrypt - метод шифрует бинарные данные
-func (sc *SecretBinary) Encrypt(key []byte) ([]byte, error) {
-	return crypto.Encrypt(key, sc.Blob)
+// Encrypt - метод шифрует бинарные данные в контейнере
+func (sc *SecretBinary) Encrypt(key []byte, ad []byte) ([]byte, error) {
+	return encryptPayload(key, ad, sc)
 }
 
 // Decrypt - метод рашифровывает бинарные данные
-func (sc *SecretBinary) Decrypt(key []byte, content []byte) error {
-	data, err := crypto.Decrypt(key, content)
-	if err != nil {
+// (данные прежнего формата, зашифрованные как есть, читаются без дополнительных полей)
+func (sc *SecretBinary) Decrypt(key []byte, content []byte, ad []byte) error {
+	*sc = SecretBinary{}
+	data, err := decryptPayload(key, content, ad, sc)
+	if err != nil || data == nil {
 		return err
 	}
 	sc.Blob = data
 	return nil
 }
+
+// Wipe - метод обнуляет бинарные данные и значения дополнительных полей и удаляет данные
+func (sc *SecretBinary) Wipe() {
+	securemem.Wipe(sc.Blob)
+	sc.SecretExtra.wipe()
+	*sc = SecretBinary{}
+}
+
+// payloadAD - метод дополняет аутентифицируемые данные содержимого секрета признаком контейнера
+func payloadAD(ad []byte) []byte {
+	return append(append([]byte{}, ad...), crypto.AssociatedData("payload")...)
+}
+
+// encryptPayload - метод шифрует секрет в формате JSON в версионированном контейнере
+func encryptPayload(key []byte, ad []byte, secret any) ([]byte, error) {
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	defer securemem.Wipe(data)
+	plain, err := json.Marshal(payloadContainer{Version: payloadVersion, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	defer securemem.Wipe(plain)
+	return crypto.EncryptWithAD(key, plain, payloadAD(ad))
+}
+
+// decryptPayload - метод расшифровывает содержимое секрета: контейнер разбирается в secret (возвращается nil),
+// содержимое прежнего формата, зашифрованное как есть, возвращается в открытом виде
+func decryptPayload(key []byte, content []byte, ad []byte, secret any) ([]byte, error) {
+	plain, err := crypto.DecryptWithAD(key, content, payloadAD(ad))
+	if err != nil {
+		// содержимое прежнего формата зашифровано без признака контейнера
+		return crypto.DecryptWithAD(key, content, ad)
+	}
+	defer securemem.Wipe(plain)
+	var container payloadContainer
+	if err := json.Unmarshal(plain, &container); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+	defer securemem.Wipe(container.Data)
+	if container.Version != payloadVersion {
+		return nil, fmt.Errorf("unsupported payload version %d", container.Version)
+	}
+	if err := json.Unmarshal(container.Data, secret); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+	return nil, nil
+}
+
+// NewSecretPayload - метод создаёт пустое содержимое секрета типа kind для расшифровки
+func NewSecretPayload(kind string) (SecretCrypter, *SecretExtra, error) {
+	switch kind {
+	case SecretPasswordType:
+		s := &SecretPassword{}
+		return s, &s.SecretExtra, nil
+	case SecretCardType:
+		s := &SecretCard{}
+		return s, &s.SecretExtra, nil
+	case SecretTextType:
+		s := &SecretText{}
+		return s, &s.SecretExtra, nil
+	case SecretBinaryType:
+		s := &SecretBinary{}
+		return s, &s.SecretExtra, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown secret type %q", kind)
+	}
+}
+
+// SecretMeta - метаданные секрета (название, заметки, теги), шифруемые на клиенте ключом хранилища
+type SecretMeta struct {
+	Name  string   `json:"name"`
+	Notes string   `json:"notes,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Encrypt - метод шифрует метаданные секрета
+func (sm *SecretMeta) Encrypt(key []byte, ad []byte) ([]byte, error) {
+	data, err := json.Marshal(sm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	defer securemem.Wipe(data)
+	return crypto.EncryptWithAD(key, data, ad)
+}
+
+// Decrypt - метод расшифровывает метаданные секрета
+func (sm *SecretMeta) Decrypt(key []byte, content []byte, ad []byte) error {
+	data, err := crypto.DecryptWithAD(key, content, ad)
+	if err != nil {
+		return err
+	}
+	defer securemem.Wipe(data)
+	*sm = SecretMeta{}
+	if err := json.Unmarshal(data, sm); err != nil {
+		return fmt.Errorf("failed to unmarshal: %w", err)
+	}
+	return nil
+}
+
+// Wipe - метод удаляет метаданные секрета
+func (sm *SecretMeta) Wipe() {
+	*sm = SecretMeta{}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"testing"
 
 	"go-pass-keeper/pkg/crypto"
@@ -10,6 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// user_id - владелец хранилища тестовых секретов
+const user_id = "c0a8012e-0000-4000-8000-000000000001"
+
+// testAD - дополнительные аутентифицируемые данные тестовых секретов
+var testAD = SecretAD(user_id, "secret-1", SecretPasswordType)
+
 func TestSecretPassword(t *testing.T) {
 
 	testCases := []struct {
@@ -48,13 +55,13 @@ func TestSecretPassword(t *testing.T) {
 			sp := NewSecretPassword(tc.InputLogin, tc.InputPassword)
 
 			// Тестируем Encrypt
-			encrypted, err := sp.Encrypt(key)
+			encrypted, err := sp.Encrypt(key, testAD)
 			require.NoError(t, err, "Encrypt failed")
 
 			// Тестируем Decrypt
 			if err == nil && encrypted != nil {
 				newSP := &SecretPassword{}
-				err = newSP.Decrypt(key, encrypted)
+				err = newSP.Decrypt(key, encrypted, testAD)
 				require.NoError(t, err, "Decrypt failed")
 				assert.Equal(t, sp, newSP, "SecretPassword not equal")
 			}
@@ -110,13 +117,13 @@ func TestSecretCard(t *testing.T) {
 			sc := NewSecretCard(tc.InputNumber, tc.InputDate, tc.InputCVV, tc.InputOwner)
 
 			// Тестируем Encrypt
-			encrypted, err := sc.Encrypt(key)
+			encrypted, err := sc.Encrypt(key, testAD)
 			require.NoError(t, err, "Encrypt failed")
 
 			// Тестируем Decrypt
 			if err == nil && encrypted != nil {
 				newSC := &SecretCard{}
-				err = newSC.Decrypt(key, encrypted)
+				err = newSC.Decrypt(key, encrypted, testAD)
 				require.NoError(t, err, "Decrypt failed")
 				assert.Equal(t, sc, newSC, "SecretCard not equal")
 			}
@@ -156,13 +163,13 @@ func TestSecretText(t *testing.T) {
 			st := NewSecretText(tc.testText)
 
 			// Тестируем Encrypt
-			encrypted, err := st.Encrypt(key)
+			encrypted, err := st.Encrypt(key, testAD)
 			require.NoError(t, err, "Encrypt failed")
 
 			// Тестируем Decrypt
 			if err == nil && encrypted != nil {
 				newST := &SecretText{}
-				err = newST.Decrypt(key, encrypted)
+				err = newST.Decrypt(key, encrypted, testAD)
 				require.NoError(t, err, "Decrypt failed")
 				assert.Equal(t, st, newST, "SecretText not equal")
 			}
@@ -199,13 +206,13 @@ func TestSecretBinary(t *testing.T) {
 			sb := NewSecretBinary(tc.Binary)
 
 			// Тестируем Encrypt
-			encrypted, err := sb.Encrypt(key)
+			encrypted, err := sb.Encrypt(key, testAD)
 			require.NoError(t, err, "Encrypt failed")
 
 			// Тестируем Decrypt
 			if err == nil && encrypted != nil {
 				newSB := &SecretBinary{}
-				err = newSB.Decrypt(key, encrypted)
+				err = newSB.Decrypt(key, encrypted, testAD)
 				require.NoError(t, err, "Decrypt failed")
 				assert.Equal(t, sb, newSB, "SecretBinary not equal")
 			}
@@ -213,6 +220,266 @@ func TestSecretBinary(t *testing.T) {
 	}
 }
 
+func TestSecretWipe(t *testing.T) {
+	blob := []byte("binary data")
+	password := SecretValue("password")
+	answer := SecretValue("кошка")
+	card := NewSecretCard("4111111111111111", "12/30", "123", "OWNER")
+	number, cvv := card.Number, card.CVV
+	text := NewSecretText("text")
+	content := text.Text
+	testCases := []struct {
+		TestName string
+		Secret   SecretCrypter
+		Empty    SecretCrypter
+	}{
+		{
+			TestName: "Password",
+			Secret:   &SecretPassword{Login: "login", Password: password, SecretExtra: SecretExtra{Fields: []CustomField{{Name: "Ответ", Type: FieldHidden, Value: answer}}}},
+			Empty:    &SecretPassword{},
+		},
+		{
+			TestName: "Card",
+			Secret:   card,
+			Empty:    &SecretCard{},
+		},
+		{
+			TestName: "Text",
+			Secret:   text,
+			Empty:    &SecretText{},
+		},
+		{
+			TestName: "Binary",
+			Secret:   NewSecretBinary(blob),
+			Empty:    &SecretBinary{},
+		},
+		{
+			TestName: "Meta",
+			Secret:   &SecretMeta{Name: "name", Tags: []string{"tag"}},
+			Empty:    &SecretMeta{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.Secret.Wipe()
+			assert.Equal(t, tc.Empty, tc.Secret)
+		})
+	}
+	assert.Equal(t, make([]byte, len(blob)), blob, "binary data must be zeroed")
+	for _, value := range []SecretValue{password, answer, number, cvv, content} {
+		assert.Equal(t, make(SecretValue, len(value)), value, "secret values must be zeroed")
+	}
+}
+
+func TestSecretValueJSON(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Value    SecretValue
+		Expected string
+	}{
+		{TestName: "#1 Plain", Value: SecretValue("pass word"), Expected: `"pass word"`},
+		{TestName: "#2 Unicode", Value: SecretValue("кошка"), Expected: `"кошка"`},
+		{TestName: "#3 Escaped", Value: SecretValue("a\"b\\c\n"), Expected: `"a\"b\\c\n"`},
+		{TestName: "#4 HTML", Value: SecretValue("<a&b>"), Expected: `"\u003ca\u0026b\u003e"`},
+		{TestName: "#5 Empty", Value: SecretValue(""), Expected: `""`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			field := CustomField{Name: "name", Type: FieldHidden, Value: tc.Value}
+			data, err := json.Marshal(field)
+			require.NoError(t, err)
+			assert.Equal(t, `{"name":"name","type":"hidden","value":`+tc.Expected+`}`, string(data))
+
+			var decoded CustomField
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, field, decoded)
+		})
+	}
+
+	var value SecretValue
+	require.NoError(t, json.Unmarshal([]byte("null"), &value))
+	assert.Nil(t, value)
+	assert.Error(t, json.Unmarshal([]byte("1"), &value))
+}
+
+func TestSecretExtra(t *testing.T) {
+	extra := SecretExtra{
+		Fields: []CustomField{
+			{Name: "Сайт", Type: FieldURL, Value: SecretValue("https://example.com")},
+			{Name: "Ответ", Type: FieldHidden, Value: SecretValue("кошка")},
+		},
+	}
+
+	testCases := []struct {
+		TestName string
+		Secret   SecretCrypter
+		Empty    SecretCrypter
+	}{
+		{
+			TestName: "Success. Password with extra",
+			Secret:   &SecretPassword{Login: "user", Password: SecretValue("pass"), SecretExtra: extra},
+			Empty:    &SecretPassword{},
+		},
+		{
+			TestName: "Success. Card with extra",
+			Secret:   &SecretCard{Number: SecretValue("1234"), Date: "12/25", CVV: SecretValue("123"), Owner: "Морозов", SecretExtra: extra},
+			Empty:    &SecretCard{},
+		},
+		{
+			TestName: "Success. Text with extra",
+			Secret:   &SecretText{Text: SecretValue("text"), SecretExtra: extra},
+			Empty:    &SecretText{},
+		},
+		{
+			TestName: "Success. Binary with extra",
+			Secret:   &SecretBinary{Blob: generateRandomBytes(100), SecretExtra: extra},
+			Empty:    &SecretBinary{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			key, err := crypto.MakeCryptoKey("secret", "salt")
+			require.NoError(t, err, "CryptoKey failed")
+
+			encrypted, err := tc.Secret.Encrypt(key, testAD)
+			require.NoError(t, err, "Encrypt failed")
+
+			err = tc.Empty.Decrypt(key, encrypted, testAD)
+			require.NoError(t, err, "Decrypt failed")
+			assert.Equal(t, tc.Secret, tc.Empty)
+		})
+	}
+}
+
+func TestSecretExtraLegacy(t *testing.T) {
+	key, err := crypto.MakeCryptoKey("secret", "salt")
+	require.NoError(t, err, "CryptoKey failed")
+
+	testCases := []struct {
+		TestName string
+		Content  []byte
+		Secret   SecretCrypter
+		Expected SecretCrypter
+	}{
+		{
+			TestName: "Success. Password without extra",
+			Content:  []byte(`{"login":"user","password":"pass"}`),
+			Secret:   &SecretPassword{},
+			Expected: &SecretPassword{Login: "user", Password: SecretValue("pass")},
+		},
+		{
+			TestName: "Success. Card without extra",
+			Content:  []byte(`{"number":"1234","date":"12/25","cvv":"123","owner":"owner"}`),
+			Secret:   &SecretCard{},
+			Expected: &SecretCard{Number: SecretValue("1234"), Date: "12/25", CVV: SecretValue("123"), Owner: "owner"},
+		},
+		{
+			TestName: "Success. Password with notes in content",
+			Content:  []byte(`{"login":"user","password":"pass","notes":"заметка"}`),
+			Secret:   &SecretPassword{},
+			Expected: &SecretPassword{Login: "user", Password: SecretValue("pass"), SecretExtra: SecretExtra{LegacyNotes: "заметка"}},
+		},
+		{
+			TestName: "Success. Raw text",
+			Content:  []byte(`{"text":"json-like text"}`),
+			Secret:   &SecretText{},
+			Expected: &SecretText{Text: SecretValue(`{"text":"json-like text"}`)},
+		},
+		{
+			TestName: "Success. Raw text like container",
+			Content:  []byte("\x00gpk-extra\n{\"version\":1,\"data\":{\"text\":\"x\"}}"),
+			Secret:   &SecretText{},
+			Expected: &SecretText{Text: SecretValue("\x00gpk-extra\n{\"version\":1,\"data\":{\"text\":\"x\"}}")},
+		},
+		{
+			TestName: "Success. Raw binary",
+			Content:  []byte("blob"),
+			Secret:   &SecretBinary{},
+			Expected: &SecretBinary{Blob: []byte("blob")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			encrypted, err := crypto.EncryptWithAD(key, tc.Content, testAD)
+			require.NoError(t, err, "EncryptWithAD failed")
+
+			err = tc.Secret.Decrypt(key, encrypted, testAD)
+			require.NoError(t, err, "Decrypt failed")
+			assert.Equal(t, tc.Expected, tc.Secret)
+		})
+	}
+}
+
+func TestSecretPayloadVersion(t *testing.T) {
+	key, err := crypto.MakeCryptoKey("secret", "salt")
+	require.NoError(t, err, "CryptoKey failed")
+
+	encrypted, err := crypto.EncryptWithAD(key, []byte(`{"version":2,"data":{"text":"text"}}`), payloadAD(testAD))
+	require.NoError(t, err, "EncryptWithAD failed")
+	err = (&SecretText{}).Decrypt(key, encrypted, testAD)
+	require.EqualError(t, err, "unsupported payload version 2")
+
+	// контейнер не читается с аутентифицируемыми данными другого секрета
+	encrypted, err = NewSecretText("text").Encrypt(key, testAD)
+	require.NoError(t, err, "Encrypt failed")
+	require.Error(t, (&SecretText{}).Decrypt(key, encrypted, SecretAD(user_id, "secret-2", SecretTextType)))
+}
+
+func TestCustomFieldValidate(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Field         CustomField
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Text field",
+			Field:    CustomField{Name: "Вопрос", Type: FieldText, Value: SecretValue("Любимый цвет")},
+		},
+		{
+			TestName: "Success. Empty url",
+			Field:    CustomField{Name: "Сайт", Type: FieldURL},
+		},
+		{
+			TestName: "Success. Date field",
+			Field:    CustomField{Name: "Выдан", Type: FieldDate, Value: SecretValue("2025-10-12")},
+		},
+		{
+			TestName:      "Error. Empty name",
+			Field:         CustomField{Type: FieldText},
+			ExpectedError: "empty field name",
+		},
+		{
+			TestName:      "Error. Invalid url",
+			Field:         CustomField{Name: "Сайт", Type: FieldURL, Value: SecretValue("example")},
+			ExpectedError: "invalid url",
+		},
+		{
+			TestName:      "Error. Invalid date",
+			Field:         CustomField{Name: "Выдан", Type: FieldDate, Value: SecretValue("12.10.2025")},
+			ExpectedError: "invalid date",
+		},
+		{
+			TestName:      "Error. Unknown type",
+			Field:         CustomField{Name: "Поле", Type: "number"},
+			ExpectedError: "unknown field type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			err := tc.Field.Validate()
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 // Генерация случайных байт
 func generateRandomBytes(size int) []byte {
 	bytes := make([]byte, size)
@@ -222,3 +489,110 @@ func generateRandomBytes(size int) []byte {
 	}
 	return bytes
 }
+
+func TestSecretMeta(t *testing.T) {
+	key, err := crypto.MakeCryptoKey("secret", "salt")
+	require.NoError(t, err, "CryptoKey failed")
+	otherKey, err := crypto.MakeCryptoKey("other", "salt")
+	require.NoError(t, err, "CryptoKey failed")
+
+	testCases := []struct {
+		TestName string
+		Info     *SecretInfo
+	}{
+		{
+			TestName: "Success. Seal and open name",
+			Info:     &SecretInfo{ID: "1", Name: "Почта", Type: SecretPasswordType},
+		},
+		{
+			TestName: "Success. Seal and open name, notes and tags",
+			Info:     &SecretInfo{ID: "2", Name: "Банк", Type: SecretCardType, Notes: "основная карта", Tags: []string{"финансы", "семья"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			require.NoError(t, tc.Info.SealMeta(key, user_id), "SealMeta failed")
+			require.NotEmpty(t, tc.Info.EncryptedMeta)
+
+			// открытое название не передаётся на сервер
+			meta := tc.Info.ToProtoMetadata()
+			assert.Empty(t, meta.GetName())
+			assert.Equal(t, tc.Info.EncryptedMeta, meta.GetEncryptedMeta())
+
+			opened := SecretInfoFromProtoMetadata(meta)
+			assert.False(t, opened.PlainName())
+			require.NoError(t, opened.OpenMeta(key, user_id), "OpenMeta failed")
+			assert.Equal(t, tc.Info.Name, opened.Name)
+			assert.Equal(t, tc.Info.Notes, opened.Notes)
+			assert.Equal(t, tc.Info.Tags, opened.Tags)
+
+			assert.Error(t, SecretInfoFromProtoMetadata(meta).OpenMeta(otherKey, user_id))
+		})
+	}
+}
+
+func TestSecretMetaLegacy(t *testing.T) {
+	key, err := crypto.MakeCryptoKey("secret", "salt")
+	require.NoError(t, err, "CryptoKey failed")
+
+	// секрет, сохранённый до появления зашифрованных метаданных
+	info := &SecretInfo{ID: "1", Name: "Почта", Type: SecretPasswordType}
+	assert.True(t, info.PlainName())
+	assert.Equal(t, "Почта", info.ToProtoMetadata().GetName())
+
+	require.NoError(t, info.OpenMeta(key, user_id))
+	assert.Equal(t, "Почта", info.Name)
+}
+
+func TestSecretAD(t *testing.T) {
+	key, err := crypto.MakeCryptoKey("secret", "salt")
+	require.NoError(t, err, "CryptoKey failed")
+
+	encrypted, err := NewSecretPassword("login", "pass").Encrypt(key, SecretAD(user_id, "secret-1", SecretPasswordType))
+	require.NoError(t, err, "Encrypt failed")
+
+	testCases := []struct {
+		TestName      string
+		AD            []byte
+		ExpectedError bool
+	}{
+		{
+			TestName: "Success. Same owner, id and type",
+			AD:       SecretAD(user_id, "secret-1", SecretPasswordType),
+		},
+		{
+			TestName:      "Error. Content of another secret",
+			AD:            SecretAD(user_id, "secret-2", SecretPasswordType),
+			ExpectedError: true,
+		},
+		{
+			TestName:      "Error. Secret of another owner",
+			AD:            SecretAD("other", "secret-1", SecretPasswordType),
+			ExpectedError: true,
+		},
+		{
+			TestName:      "Error. Changed type",
+			AD:            SecretAD(user_id, "secret-1", SecretTextType),
+			ExpectedError: true,
+		},
+		{
+			TestName:      "Error. Metadata instead of content",
+			AD:            MetaAD(user_id, "secret-1", SecretPasswordType),
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			sp := &SecretPassword{}
+			err := sp.Decrypt(key, encrypted, tc.AD)
+			if tc.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err, "Decrypt failed")
+			assert.Equal(t, "login", sp.Login)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/storage"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"slices"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Admin - модель сервиса администрирования пользователей.
+// Доступен только администраторам сервера: их логины из настроек сопоставляются с учётными
+// записями при запуске (см. ResolveAdmins), поэтому логин, зарегистрированный позже, прав не получает.
+type Admin struct {
+	pb.UnimplementedAdminServer
+
+	users  storage.User
+	admins []uuid.UUID // идентификаторы администраторов сервера
+}
+
+// NewAdmin - метод создания сервиса администрирования
+func NewAdmin(u storage.User, admins []uuid.UUID) *Admin {
+	return &Admin{
+		users:  u,
+		admins: admins,
+	}
+}
+
+// ListUsers - метод получения списка пользователей со статистикой использования
+func (s *Admin) ListUsers(ctx context.Context, request *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	list, err := s.users.List(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.ListUsersResponse{}
+	for _, u := range list {
+		stats := &pb.UserStats{
+			Id:            u.ID.String(),
+			Login:         u.Login,
+			Disabled:      u.Disabled,
+			Secrets:       u.Secrets,
+			ContentSize:   u.ContentSize,
+			Shares:        u.Shares,
+			Organizations: u.Organizations,
+			Created:       timestamppb.New(u.Created),
+		}
+		if u.LastActivity.Valid {
+			stats.LastActivity = timestamppb.New(u.LastActivity.Time)
+		}
+		resp.Users = append(resp.Users, stats)
+	}
+	return resp, nil
+}
+
+// DisableUser - метод блокировки учётной записи
+func (s *Admin) DisableUser(ctx context.Context, request *pb.DisableUserRequest) (*pb.DisableUserResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.users.SetDisabled(ctx, request.GetLogin(), true); err != nil {
+		return nil, adminStorageError(err)
+	}
+	return &pb.DisableUserResponse{}, nil
+}
+
+// EnableUser - метод разблокировки учётной записи
+func (s *Admin) EnableUser(ctx context.Context, request *pb.EnableUserRequest) (*pb.EnableUserResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.users.SetDisabled(ctx, request.GetLogin(), false); err != nil {
+		return nil, adminStorageError(err)
+	}
+	return &pb.EnableUserResponse{}, nil
+}
+
+// ForceLogout - метод принудительного завершения всех сеансов пользователя
+func (s *Admin) ForceLogout(ctx context.Context, request *pb.ForceLogoutRequest) (*pb.ForceLogoutResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.users.ForceLogout(ctx, request.GetLogin()); err != nil {
+		return nil, adminStorageError(err)
+	}
+	return &pb.ForceLogoutResponse{}, nil
+}
+
+// RegisterService - метод регистрации сервиса
+func (s *Admin) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterAdminServer(r, s)
+}
+
+// requireAdmin - метод проверяет, что запрос выполняет администратор: идентификатор пользователя
+// из контекста должен входить в список администраторов сервера
+func (s *Admin) requireAdmin(ctx context.Context) error {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !slices.Contains(s.admins, uid) {
+		return status.Error(codes.PermissionDenied, "admin role required")
+	}
+	return nil
+}
+
+// ResolveAdmins - метод сопоставляет логины администраторов из настроек сервера с учётными записями.
+// Незарегистрированный логин - ошибка: иначе права администратора получил бы тот, кто первым его займёт.
+func ResolveAdmins(ctx context.Context, u storage.User, logins []string) ([]uuid.UUID, error) {
+	admins := make([]uuid.UUID, 0, len(logins))
+	for _, login := range logins {
+		user, err := u.GetByLogin(ctx, login)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, fmt.Errorf("admin login %q is not registered", login)
+			}
+			return nil, fmt.Errorf("failed to resolve admin login %q: %w", login, err)
+		}
+		admins = append(admins, user.ID)
+	}
+	return admins, nil
+}
+
+// adminStorageError - метод конвертирует ошибку хранилища в ошибку grpc
+func adminStorageError(err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/storage/mocks"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 5, 9, 0, 0, 0, time.UTC)
+	activity := time.Date(2025, time.October, 6, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Responce      *pb.ListUsersResponse
+		Admin         bool
+	}{
+		{
+			TestName: "Success. List users #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().List(gomock.Any()).Return([]*models.UserStats{
+					{ID: uuid.MustParse(user_uuid), Login: "alice", Secrets: 2, ContentSize: 128, Shares: 1, Organizations: 1, Created: created, LastActivity: sql.NullTime{Time: activity, Valid: true}},
+					{ID: uuid.MustParse(recipient_uuid), Login: "bob", Disabled: true, Created: created},
+				}, nil)
+			},
+			ExpectedError: nil,
+			Responce: &pb.ListUsersResponse{Users: []*pb.UserStats{
+				{Id: user_uuid, Login: "alice", Secrets: 2, ContentSize: 128, Shares: 1, Organizations: 1, Created: timestamppb.New(created), LastActivity: timestamppb.New(activity)},
+				{Id: recipient_uuid, Login: "bob", Disabled: true, Created: timestamppb.New(created)},
+			}},
+			Admin: true,
+		},
+		{
+			TestName: "Error. List users undefined error #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().List(gomock.Any()).Return(nil, errors.New("failed to get users:"))
+			},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to get users:"),
+			Responce:      nil,
+			Admin:         true,
+		},
+		{
+			TestName:      "Error. List users without admin role #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = admin role required"),
+			Responce:      nil,
+			Admin:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			admins := []uuid.UUID{uuid.MustParse(recipient_uuid)}
+			if tc.Admin {
+				admins = append(admins, uuid.MustParse(user_uuid))
+			}
+
+			s := NewAdmin(mockUsers, admins)
+			ctx := usercontext.SetUserId(context.Background(), uuid.MustParse(user_uuid))
+
+			resp, err := s.ListUsers(ctx, &pb.ListUsersRequest{})
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Call          func(s *Admin, ctx context.Context) error
+		ExpectedError error
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Disable user #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetDisabled(gomock.Any(), "bob", true).Return(nil)
+			},
+			Call: func(s *Admin, ctx context.Context) error {
+				_, err := s.DisableUser(ctx, &pb.DisableUserRequest{Login: "bob"})
+				return err
+			},
+			ExpectedError: nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Success. Enable user #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetDisabled(gomock.Any(), "bob", false).Return(nil)
+			},
+			Call: func(s *Admin, ctx context.Context) error {
+				_, err := s.EnableUser(ctx, &pb.EnableUserRequest{Login: "bob"})
+				return err
+			},
+			ExpectedError: nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Disable unknown user #3",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetDisabled(gomock.Any(), "nobody", true).Return(storage.ErrNotFound)
+			},
+			Call: func(s *Admin, ctx context.Context) error {
+				_, err := s.DisableUser(ctx, &pb.DisableUserRequest{Login: "nobody"})
+				return err
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Success. Force logout #4",
+			SetupMocks: func() {
+				mockUsers.EXPECT().ForceLogout(gomock.Any(), "bob").Return(nil)
+			},
+			Call: func(s *Admin, ctx context.Context) error {
+				_, err := s.ForceLogout(ctx, &pb.ForceLogoutRequest{Login: "bob"})
+				return err
+			},
+			ExpectedError: nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:   "Error. Force logout unknown admin #5",
+			SetupMocks: func() {},
+			Call: func(s *Admin, ctx context.Context) error {
+				_, err := s.ForceLogout(ctx, &pb.ForceLogoutRequest{Login: "bob"})
+				return err
+			},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			UserId:        uuid.Nil,
+		},
+		{
+			TestName:   "Error. Not an admin #6",
+			SetupMocks: func() {},
+			Call: func(s *Admin, ctx context.Context) error {
+				_, err := s.ForceLogout(ctx, &pb.ForceLogoutRequest{Login: "bob"})
+				return err
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = admin role required"),
+			UserId:        uuid.MustParse(recipient_uuid),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewAdmin(mockUsers, []uuid.UUID{uuid.MustParse(user_uuid)})
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			err := tc.Call(s, ctx)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestResolveAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Logins        []string
+		Expected      []uuid.UUID
+		ExpectedError error
+	}{
+		{
+			TestName: "Success. Resolve admins #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "root").Return(&models.UserData{ID: uuid.MustParse(user_uuid), Login: "root"}, nil)
+			},
+			Logins:   []string{"root"},
+			Expected: []uuid.UUID{uuid.MustParse(user_uuid)},
+		},
+		{
+			TestName: "Error. Unregistered admin login #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "root").Return(nil, storage.ErrNotFound)
+			},
+			Logins:        []string{"root"},
+			ExpectedError: errors.New(`admin login "root" is not registered`),
+		},
+		{
+			TestName: "Error. Storage error #3",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "root").Return(nil, errors.New("failed to get user"))
+			},
+			Logins:        []string{"root"},
+			ExpectedError: errors.New(`failed to resolve admin login "root": failed to get user`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			admins, err := ResolveAdmins(context.Background(), mockUsers, tc.Logins)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if !slices.Equal(admins, tc.Expected) {
+				t.Errorf("Expected admins %v, got %v", tc.Expected, admins)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxAttachmentSize - максимальный размер зашифрованного вложения
+// (ограничен размером сообщения gRPC по умолчанию)
+const maxAttachmentSize = 3 << 20
+
+// Attachment - модель сервиса вложений секретов.
+// Название и содержимое вложений шифруются на клиенте ключом данных секрета.
+// Права на вложения совпадают с правами на секрет: чтение для просмотра, изменение для добавления и удаления.
+type Attachment struct {
+	pb.UnimplementedAttachmentServer
+
+	secrets     storage.Secret
+	orgs        storage.Organization
+	attachments storage.Attachment
+}
+
+// NewAttachment - метод создания сервиса вложений
+func NewAttachment(s storage.Secret, o storage.Organization, a storage.Attachment) *Attachment {
+	return &Attachment{
+		secrets:     s,
+		orgs:        o,
+		attachments: a,
+	}
+}
+
+// AddAttachment - метод добавления вложения к секрету
+func (s *Attachment) AddAttachment(ctx context.Context, request *pb.AddAttachmentRequest) (*pb.AddAttachmentResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	sid, err := uuid.Parse(request.GetMeta().GetSecretId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// идентификатор нового вложения может быть выбран клиентом
+	aid := uuid.Nil
+	if id := request.GetMeta().GetId(); id != "" {
+		if aid, err = uuid.Parse(id); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	meta := request.GetMeta().GetEncryptedMeta()
+	if (request.GetMeta().GetName() == "" && len(meta) == 0) || len(request.GetContent()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty attachment")
+	}
+	if len(request.GetContent()) > maxAttachmentSize {
+		return nil, status.Error(codes.InvalidArgument, "attachment is too large")
+	}
+	if len(meta) > maxMetaSize {
+		return nil, status.Error(codes.InvalidArgument, "attachment metadata is too large")
+	}
+	if err := secretAccessByID(ctx, s.secrets, s.orgs, uid, sid, true); err != nil {
+		return nil, err
+	}
+	attachment, err := s.attachments.Add(ctx, &models.AttachmentData{
+		ID:       aid,
+		SecretID: sid,
+		Name:     request.GetMeta().GetName(),
+		Size:     request.GetMeta().GetSize(),
+		Content:  request.GetContent(),
+		Meta:     meta,
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.AddAttachmentResponse{Meta: attachmentMetadata(attachment)}, nil
+}
+
+// GetAttachments - метод получения списка вложений секрета
+func (s *Attachment) GetAttachments(ctx context.Context, request *pb.GetAttachmentsRequest) (*pb.GetAttachmentsResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	sid, err := uuid.Parse(request.GetSecretId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := secretAccessByID(ctx, s.secrets, s.orgs, uid, sid, false); err != nil {
+		return nil, err
+	}
+	list, err := s.attachments.List(ctx, sid)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.GetAttachmentsResponse{}
+	for _, attachment := range list {
+		resp.Attachments = append(resp.Attachments, attachmentMetadata(attachment))
+	}
+	return resp, nil
+}
+
+// GetAttachment - метод получения вложения с содержимым
+func (s *Attachment) GetAttachment(ctx context.Context, request *pb.GetAttachmentRequest) (*pb.GetAttachmentResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	attachment, err := s.load(ctx, request.GetMeta().GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := secretAccessByID(ctx, s.secrets, s.orgs, uid, attachment.SecretID, false); err != nil {
+		return nil, err
+	}
+	return &pb.GetAttachmentResponse{Meta: attachmentMetadata(attachment), Content: attachment.Content}, nil
+}
+
+// DeleteAttachment - метод удаления вложения
+func (s *Attachment) DeleteAttachment(ctx context.Context, request *pb.DeleteAttachmentRequest) (*pb.DeleteAttachmentResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	attachment, err := s.load(ctx, request.GetMeta().GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := secretAccessByID(ctx, s.secrets, s.orgs, uid, attachment.SecretID, true); err != nil {
+		return nil, err
+	}
+	if err := s.attachments.Delete(ctx, attachment.ID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteAttachmentResponse{Meta: &pb.AttachmentMetadata{Id: attachment.ID.String(), SecretId: attachment.SecretID.String()}}, nil
+}
+
+func (s *Attachment) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterAttachmentServer(r, s)
+}
+
+// load - метод загружает вложение по идентификатору
+func (s *Attachment) load(ctx context.Context, id string) (*models.AttachmentData, error) {
+	aid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	attachment, err := s.attachments.Get(ctx, aid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return attachment, nil
+}
+
+// attachmentMetadata - метод формирует описание вложения для ответа клиенту
+func attachmentMetadata(attachment *models.AttachmentData) *pb.AttachmentMetadata {
+	return &pb.AttachmentMetadata{
+		Id:            attachment.ID.String(),
+		SecretId:      attachment.SecretID.String(),
+		Name:          attachment.Name,
+		Size:          attachment.Size,
+		Created:       timestamppb.New(attachment.Created),
+		EncryptedMeta: attachment.Meta,
+	}
+}
@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/storage/mocks"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const attachment_uuid = "2d6f4a8b-3c1e-4f5a-9b7d-0e1f2a3b4c5d"
+
+func TestAddAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	mockAttachments := mocks.NewMockAttachment(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 11, 9, 0, 0, 0, time.UTC)
+	ownSecret := &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.AddAttachmentRequest
+		Responce      *pb.AddAttachmentResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Add attachment #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.AttachmentData) (*models.AttachmentData, error) {
+					return &models.AttachmentData{ID: uuid.MustParse(attachment_uuid), SecretID: m.SecretID, Name: m.Name, Size: m.Size, Created: created}, nil
+				})
+			},
+			ExpectedError: nil,
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{SecretId: secret_uuid, Name: "codes.pdf", Size: 5}, Content: []byte("0x100")},
+			Responce:      &pb.AddAttachmentResponse{Meta: &pb.AttachmentMetadata{Id: attachment_uuid, SecretId: secret_uuid, Name: "codes.pdf", Size: 5, Created: timestamppb.New(created)}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add empty attachment #2",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = empty attachment"),
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{SecretId: secret_uuid, Name: "codes.pdf"}},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add attachment to foreign secret #3",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.New()}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = secret belongs to another user"),
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{SecretId: secret_uuid, Name: "codes.pdf"}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add attachment undefined error #4",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to add attachment:"))
+			},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to add attachment:"),
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{SecretId: secret_uuid, Name: "codes.pdf"}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add attachment unknown user #5",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{SecretId: secret_uuid, Name: "codes.pdf"}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+		{
+			TestName: "Success. Add attachment with encrypted name #6",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.AttachmentData) (*models.AttachmentData, error) {
+					return &models.AttachmentData{ID: m.ID, SecretID: m.SecretID, Size: m.Size, Meta: m.Meta, Created: created}, nil
+				})
+			},
+			ExpectedError: nil,
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid, SecretId: secret_uuid, Size: 5, EncryptedMeta: []byte("0x200")}, Content: []byte("0x100")},
+			Responce:      &pb.AddAttachmentResponse{Meta: &pb.AttachmentMetadata{Id: attachment_uuid, SecretId: secret_uuid, Size: 5, Created: timestamppb.New(created), EncryptedMeta: []byte("0x200")}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add attachment with existing id #7",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil, storage.ErrAlreadyExists)
+			},
+			ExpectedError: errors.New("rpc error: code = AlreadyExists desc = already exists"),
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid, SecretId: secret_uuid, EncryptedMeta: []byte("0x200")}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add attachment invalid id #8",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid UUID length: 3"),
+			Request:       &pb.AddAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: "bad", SecretId: secret_uuid, EncryptedMeta: []byte("0x200")}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewAttachment(mockSecrets, mockOrgs, mockAttachments)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.AddAttachment(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestGetAttachments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	mockAttachments := mocks.NewMockAttachment(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 11, 9, 0, 0, 0, time.UTC)
+	ownSecret := &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.GetAttachmentsRequest
+		Responce      *pb.GetAttachmentsResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Get attachments #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().List(gomock.Any(), uuid.MustParse(secret_uuid)).Return([]*models.AttachmentData{
+					{ID: uuid.MustParse(attachment_uuid), SecretID: uuid.MustParse(secret_uuid), Name: "codes.pdf", Size: 5, Created: created},
+				}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.GetAttachmentsRequest{SecretId: secret_uuid},
+			Responce: &pb.GetAttachmentsResponse{Attachments: []*pb.AttachmentMetadata{
+				{Id: attachment_uuid, SecretId: secret_uuid, Name: "codes.pdf", Size: 5, Created: timestamppb.New(created)},
+			}},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Get attachments secret not exists #2",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.GetAttachmentsRequest{SecretId: secret_uuid},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Get attachments invalid secret #3",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid UUID length: 3"),
+			Request:       &pb.GetAttachmentsRequest{SecretId: "bad"},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewAttachment(mockSecrets, mockOrgs, mockAttachments)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.GetAttachments(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestGetAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	mockAttachments := mocks.NewMockAttachment(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 11, 9, 0, 0, 0, time.UTC)
+	attachment := &models.AttachmentData{ID: uuid.MustParse(attachment_uuid), SecretID: uuid.MustParse(secret_uuid), Name: "codes.pdf", Size: 5, Content: []byte("0x100"), Created: created}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.GetAttachmentRequest
+		Responce      *pb.GetAttachmentResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Get attachment #1",
+			SetupMocks: func() {
+				mockAttachments.EXPECT().Get(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(attachment, nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.GetAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid}},
+			Responce: &pb.GetAttachmentResponse{
+				Meta:    &pb.AttachmentMetadata{Id: attachment_uuid, SecretId: secret_uuid, Name: "codes.pdf", Size: 5, Created: timestamppb.New(created)},
+				Content: []byte("0x100")},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Get attachment of foreign secret #2",
+			SetupMocks: func() {
+				mockAttachments.EXPECT().Get(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(attachment, nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.New()}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = secret belongs to another user"),
+			Request:       &pb.GetAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid}},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Get attachment not exists #3",
+			SetupMocks: func() {
+				mockAttachments.EXPECT().Get(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.GetAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid}},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewAttachment(mockSecrets, mockOrgs, mockAttachments)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.GetAttachment(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	mockAttachments := mocks.NewMockAttachment(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	attachment := &models.AttachmentData{ID: uuid.MustParse(attachment_uuid), SecretID: uuid.MustParse(secret_uuid), Name: "codes.pdf"}
+	ownSecret := &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.DeleteAttachmentRequest
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Delete attachment #1",
+			SetupMocks: func() {
+				mockAttachments.EXPECT().Get(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(attachment, nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().Delete(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.DeleteAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Delete attachment undefined error #2",
+			SetupMocks: func() {
+				mockAttachments.EXPECT().Get(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(attachment, nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockAttachments.EXPECT().Delete(gomock.Any(), uuid.MustParse(attachment_uuid)).Return(errors.New("failed to delete attachment:"))
+			},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to delete attachment:"),
+			Request:       &pb.DeleteAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Delete attachment unknown user #3",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.DeleteAttachmentRequest{Meta: &pb.AttachmentMetadata{Id: attachment_uuid}},
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewAttachment(mockSecrets, mockOrgs, mockAttachments)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			_, err := s.DeleteAttachment(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/storage"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Escrow - модель сервиса депонирования ключей для учётных записей, управляемых организацией.
+// Пользователь по желанию дополнительно шифрует ключ хранилища открытым ключом депонирования,
+// заданным на сервере; закрытый ключ хранится вне сервера у оператора организации.
+type Escrow struct {
+	pb.UnimplementedEscrowServer
+
+	users     storage.User
+	publicKey []byte // открытый ключ депонирования (пусто - депонирование не настроено)
+}
+
+// NewEscrow - метод создания сервиса депонирования ключей
+func NewEscrow(u storage.User, publicKey []byte) *Escrow {
+	return &Escrow{
+		users:     u,
+		publicKey: publicKey,
+	}
+}
+
+// GetEscrowKey - метод возвращает открытый ключ депонирования организации
+func (s *Escrow) GetEscrowKey(ctx context.Context, request *pb.GetEscrowKeyRequest) (*pb.GetEscrowKeyResponse, error) {
+	if _, err := usercontext.GetUserId(ctx); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(s.publicKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "escrow is not configured")
+	}
+	return &pb.GetEscrowKeyResponse{PublicKey: s.publicKey}, nil
+}
+
+// SetEscrowKey - метод сохраняет ключ шифрования пользователя, зашифрованный на клиенте
+// открытым ключом депонирования
+func (s *Escrow) SetEscrowKey(ctx context.Context, request *pb.SetEscrowKeyRequest) (*pb.SetEscrowKeyResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(s.publicKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "escrow is not configured")
+	}
+	if len(request.GetEscrowKey()) == 0 || len(request.GetEscrowKey()) > maxWrappedKeySize {
+		return nil, status.Error(codes.InvalidArgument, "invalid escrow key")
+	}
+	if err := s.users.SetEscrowKey(ctx, uid, request.GetEscrowKey()); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetEscrowKeyResponse{}, nil
+}
+
+// RegisterService - метод регистрации сервиса
+func (s *Escrow) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterEscrowServer(r, s)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/storage/mocks"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetEscrowKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		PublicKey     []byte
+		ExpectedError error
+		Responce      *pb.GetEscrowKeyResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName:      "Success. Get escrow key #1",
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: nil,
+			Responce:      &pb.GetEscrowKeyResponse{PublicKey: []byte("escrow public key")},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Escrow is not configured #2",
+			PublicKey:     nil,
+			ExpectedError: errors.New("rpc error: code = FailedPrecondition desc = escrow is not configured"),
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Get escrow key unknown user #3",
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			s := NewEscrow(mockUsers, tc.PublicKey)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.GetEscrowKey(ctx, &pb.GetEscrowKeyRequest{})
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestSetEscrowKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		PublicKey     []byte
+		ExpectedError error
+		Request       *pb.SetEscrowKeyRequest
+		Responce      *pb.SetEscrowKeyResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Set escrow key #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetEscrowKey(gomock.Any(), uuid.MustParse(user_uuid), []byte("sealed")).Return(nil)
+			},
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: nil,
+			Request:       &pb.SetEscrowKeyRequest{EscrowKey: []byte("sealed")},
+			Responce:      &pb.SetEscrowKeyResponse{},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Escrow is not configured #2",
+			SetupMocks:    func() {},
+			PublicKey:     nil,
+			ExpectedError: errors.New("rpc error: code = FailedPrecondition desc = escrow is not configured"),
+			Request:       &pb.SetEscrowKeyRequest{EscrowKey: []byte("sealed")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set empty escrow key #3",
+			SetupMocks:    func() {},
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid escrow key"),
+			Request:       &pb.SetEscrowKeyRequest{},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set too large escrow key #4",
+			SetupMocks:    func() {},
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid escrow key"),
+			Request:       &pb.SetEscrowKeyRequest{EscrowKey: make([]byte, maxWrappedKeySize+1)},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Set escrow key user not found #5",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetEscrowKey(gomock.Any(), uuid.MustParse(user_uuid), []byte("sealed")).Return(storage.ErrNotFound)
+			},
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.SetEscrowKeyRequest{EscrowKey: []byte("sealed")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set escrow key unknown user #6",
+			SetupMocks:    func() {},
+			PublicKey:     []byte("escrow public key"),
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SetEscrowKeyRequest{EscrowKey: []byte("sealed")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewEscrow(mockUsers, tc.PublicKey)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.SetEscrowKey(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
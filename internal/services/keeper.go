@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"go-pass-keeper/internal/models"
 	"go-pass-keeper/internal/storage"
@@ -9,23 +10,53 @@ import (
 	"go-pass-keeper/pkg/usercontext"
 
 	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxBatch - максимальное количество операций в одном пакете
+const maxBatch = 1000
+
+// maxSearchTokens - максимальное количество значений слепого индекса секрета (и поискового запроса)
+const maxSearchTokens = 64
+
+// searchIndex - метод возвращает слепой индекс из метаданных изменяемого секрета. Индекс строится
+// по зашифрованным метаданным и заменяется вместе с ними: если метаданные переданы без индекса
+// (в названии и тегах не осталось слов), прежний индекс очищается. Без зашифрованных метаданных
+// (прежние клиенты) индекс не изменяется.
+func searchIndex(meta *pb.SecretMetadata) [][]byte {
+	index := meta.GetSearchIndex()
+	if index == nil && len(meta.GetEncryptedMeta()) != 0 {
+		return [][]byte{}
+	}
+	return index
+}
+
+// maxMetaSize - максимальный размер зашифрованных метаданных секрета
+const maxMetaSize = 64 << 10
+
+// maxWrappedKeySize - максимальный размер зашифрованного ключа данных секрета
+const maxWrappedKeySize = 256
+
+// errBatchAborted - ошибка операции пакета, отменяющая транзакцию
+var errBatchAborted = errors.New("batch aborted")
+
 // Keeper - модель сервиса секретов
 type Keeper struct {
 	pb.UnimplementedKeeperServer
 
 	secrets storage.Secret
+	orgs    storage.Organization
 }
 
 // NewKeeper - метод создания сервиса работы с секретами
-func NewKeeper(s storage.Secret) *Keeper {
+func NewKeeper(s storage.Secret, o storage.Organization) *Keeper {
 	return &Keeper{
 		secrets: s,
+		orgs:    o,
 	}
 }
 
@@ -36,10 +67,43 @@ func (s *Keeper) AddSecret(ctx context.Context, request *pb.AddSecretRequest) (*
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 	m := &models.SecretData{
-		UserID:  uid,
-		Name:    request.GetMeta().GetName(),
-		Type:    request.GetMeta().GetType(),
-		Content: request.GetContent(),
+		UserID:       uid,
+		Name:         request.GetMeta().GetName(),
+		Type:         request.GetMeta().GetType(),
+		Content:      request.GetContent(),
+		Expires:      expires(request.GetMeta()),
+		ExpirePolicy: request.GetMeta().GetExpirePolicy(),
+		SearchIndex:  request.GetMeta().GetSearchIndex(),
+		Meta:         request.GetMeta().GetEncryptedMeta(),
+		WrappedKey:   request.GetMeta().GetWrappedKey(),
+	}
+	if m.ExpirePolicy != "" && !models.ValidExpirePolicy(m.ExpirePolicy) {
+		return nil, status.Error(codes.InvalidArgument, "unknown expire policy")
+	}
+	// идентификатор может быть выбран клиентом: он входит в аутентифицируемые данные шифрования
+	if request.GetMeta().GetId() != "" {
+		if m.ID, err = uuid.Parse(request.GetMeta().GetId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid secret id")
+		}
+	}
+	if len(m.SearchIndex) > maxSearchTokens {
+		return nil, status.Error(codes.InvalidArgument, "too many search tokens")
+	}
+	if len(m.Meta) > maxMetaSize {
+		return nil, status.Error(codes.InvalidArgument, "encrypted metadata is too large")
+	}
+	if len(m.WrappedKey) > maxWrappedKeySize {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is too large")
+	}
+	if request.GetMeta().GetOrgId() != "" {
+		member, err := memberOf(ctx, s.orgs, request.GetMeta().GetOrgId(), uid)
+		if err != nil {
+			return nil, err
+		}
+		if !member.CanWrite() {
+			return nil, status.Error(codes.PermissionDenied, "insufficient role")
+		}
+		m.OrgID = uuid.NullUUID{UUID: member.OrgID, Valid: true}
 	}
 	secret, err := s.secrets.Add(ctx, m)
 	if err != nil {
@@ -48,17 +112,18 @@ func (s *Keeper) AddSecret(ctx context.Context, request *pb.AddSecretRequest) (*
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.AddSecretResponse{Meta: &pb.SecretMetadata{
-		Id:      secret.ID.String(),
-		Name:    m.Name,
-		Type:    m.Type,
-		Created: timestamppb.New(secret.Created),
-		Updated: timestamppb.New(secret.Updated)}}, nil
+	meta := metadata(secret)
+	meta.Name = m.Name
+	meta.Type = m.Type
+	meta.OrgId = orgID(m)
+	meta.EncryptedMeta = m.Meta
+	meta.WrappedKey = m.WrappedKey
+	return &pb.AddSecretResponse{Meta: meta}, nil
 }
 
 // GetSecret - метод для получения секрета пользователя
 func (s *Keeper) GetSecret(ctx context.Context, request *pb.GetSecretRequest) (*pb.GetSecretResponse, error) {
-	_, err := usercontext.GetUserId(ctx)
+	uid, err := usercontext.GetUserId(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
@@ -73,18 +138,15 @@ func (s *Keeper) GetSecret(ctx context.Context, request *pb.GetSecretRequest) (*
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.GetSecretResponse{Meta: &pb.SecretMetadata{
-		Id:      secret.ID.String(),
-		Name:    secret.Name,
-		Type:    secret.Type,
-		Created: timestamppb.New(secret.Created),
-		Updated: timestamppb.New(secret.Updated)},
-		Content: secret.Content}, nil
+	if err := s.checkAccess(ctx, uid, secret, false); err != nil {
+		return nil, err
+	}
+	return &pb.GetSecretResponse{Meta: metadata(secret), Content: secret.Content}, nil
 }
 
 // DeleteSecret - метод удаления секрета пользователя
 func (s *Keeper) DeleteSecret(ctx context.Context, request *pb.DeleteSecretRequest) (*pb.DeleteSecretResponse, error) {
-	_, err := usercontext.GetUserId(ctx)
+	uid, err := usercontext.GetUserId(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
@@ -92,6 +154,9 @@ func (s *Keeper) DeleteSecret(ctx context.Context, request *pb.DeleteSecretReque
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if err := s.checkAccessByID(ctx, uid, sid, true); err != nil {
+		return nil, err
+	}
 	if err := s.secrets.Delete(ctx, sid); err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
@@ -109,7 +174,19 @@ func (s *Keeper) GetSecrets(ctx context.Context, request *pb.GetSecretsRequest)
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	list, err := s.secrets.List(ctx, uid)
+	var list []*models.SecretData
+	if request.GetOrgId() != "" {
+		member, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+		if err != nil {
+			return nil, err
+		}
+		if !member.CanRead() {
+			return nil, status.Error(codes.PermissionDenied, "insufficient role")
+		}
+		list, err = s.secrets.ListByOrganization(ctx, member.OrgID, request.GetArchived())
+	} else {
+		list, err = s.secrets.List(ctx, uid, request.GetArchived())
+	}
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
@@ -119,13 +196,7 @@ func (s *Keeper) GetSecrets(ctx context.Context, request *pb.GetSecretsRequest)
 
 	resp := &pb.GetSecretsResponse{}
 	for _, secret := range list {
-		resp.Secrets = append(resp.Secrets, &pb.SecretMetadata{
-			Id:      secret.ID.String(),
-			Name:    secret.Name,
-			Type:    secret.Type,
-			Created: timestamppb.New(secret.Created),
-			Updated: timestamppb.New(secret.Updated),
-		})
+		resp.Secrets = append(resp.Secrets, metadata(secret))
 	}
 
 	return resp, nil
@@ -141,12 +212,27 @@ func (s *Keeper) EditSecret(ctx context.Context, request *pb.EditSecretRequest)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if err := s.checkAccessByID(ctx, uid, sid, true); err != nil {
+		return nil, err
+	}
 	m := &models.SecretData{
-		ID:      sid,
-		UserID:  uid,
-		Name:    request.GetMeta().GetName(),
-		Type:    request.GetMeta().GetType(),
-		Content: request.GetContent(),
+		ID:          sid,
+		UserID:      uid,
+		Name:        request.GetMeta().GetName(),
+		Type:        request.GetMeta().GetType(),
+		Content:     request.GetContent(),
+		SearchIndex: searchIndex(request.GetMeta()),
+		Meta:        request.GetMeta().GetEncryptedMeta(),
+		WrappedKey:  request.GetMeta().GetWrappedKey(),
+	}
+	if len(m.SearchIndex) > maxSearchTokens {
+		return nil, status.Error(codes.InvalidArgument, "too many search tokens")
+	}
+	if len(m.Meta) > maxMetaSize {
+		return nil, status.Error(codes.InvalidArgument, "encrypted metadata is too large")
+	}
+	if len(m.WrappedKey) > maxWrappedKeySize {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is too large")
 	}
 	secret, err := s.secrets.Edit(ctx, m)
 	if err != nil {
@@ -155,14 +241,227 @@ func (s *Keeper) EditSecret(ctx context.Context, request *pb.EditSecretRequest)
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.EditSecretResponse{Meta: &pb.SecretMetadata{
-		Id:      secret.ID.String(),
-		Name:    m.Name,
-		Type:    m.Type,
-		Created: timestamppb.New(secret.Created),
-		Updated: timestamppb.New(secret.Updated)}}, nil
+	meta := metadata(secret)
+	meta.Name = m.Name
+	meta.Type = m.Type
+	meta.EncryptedMeta = m.Meta
+	meta.WrappedKey = m.WrappedKey
+	return &pb.EditSecretResponse{Meta: meta}, nil
+}
+
+// SetExpiration - метод изменения срока действия секрета и действия по его истечении
+func (s *Keeper) SetExpiration(ctx context.Context, request *pb.SetExpirationRequest) (*pb.SetExpirationResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	sid, err := uuid.Parse(request.GetMeta().GetId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	m := &models.SecretData{
+		ID:           sid,
+		Expires:      expires(request.GetMeta()),
+		ExpirePolicy: request.GetMeta().GetExpirePolicy(),
+	}
+	if m.ExpirePolicy != "" && !models.ValidExpirePolicy(m.ExpirePolicy) {
+		return nil, status.Error(codes.InvalidArgument, "unknown expire policy")
+	}
+	if err := s.checkAccessByID(ctx, uid, sid, true); err != nil {
+		return nil, err
+	}
+	secret, err := s.secrets.SetExpiration(ctx, m)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetExpirationResponse{Meta: metadata(secret)}, nil
+}
+
+// BatchSecrets - метод выполнения пакета операций добавления, изменения и удаления секретов.
+// Пакет выполняется в одной транзакции: при ошибке любой операции изменения отменяются,
+// а в результатах указывается операция, вызвавшая отказ.
+func (s *Keeper) BatchSecrets(ctx context.Context, request *pb.BatchSecretsRequest) (*pb.BatchSecretsResponse, error) {
+	if _, err := usercontext.GetUserId(ctx); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ops := request.GetOperations()
+	if len(ops) == 0 || len(ops) > maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "batch must contain from 1 to %d operations", maxBatch)
+	}
+
+	results := make([]*pb.SecretOperationResult, len(ops))
+	err := s.secrets.InTx(ctx, func(tx storage.Secret) error {
+		k := &Keeper{secrets: tx, orgs: s.orgs}
+		for i, op := range ops {
+			meta, err := k.apply(ctx, op)
+			if err != nil {
+				st := status.Convert(err)
+				results[i] = &pb.SecretOperationResult{Code: int32(st.Code()), Error: st.Message()}
+				return errBatchAborted
+			}
+			results[i] = &pb.SecretOperationResult{Meta: meta}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchAborted) {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err != nil {
+		for i, result := range results {
+			switch {
+			case result == nil:
+				results[i] = &pb.SecretOperationResult{Code: int32(codes.Aborted), Error: "not executed"}
+			case result.GetError() == "":
+				results[i] = &pb.SecretOperationResult{Code: int32(codes.Aborted), Error: "rolled back"}
+			}
+		}
+	}
+	return &pb.BatchSecretsResponse{Committed: err == nil, Results: results}, nil
+}
+
+// SearchSecrets - метод поиска действующих секретов по слепому индексу: клиент передаёт
+// ключевые хеши слов запроса, сервер возвращает секреты, в индексе которых есть все значения
+func (s *Keeper) SearchSecrets(ctx context.Context, request *pb.SearchSecretsRequest) (*pb.SearchSecretsResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	tokens := request.GetTokens()
+	if len(tokens) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty search query")
+	}
+	if len(tokens) > maxSearchTokens {
+		return nil, status.Error(codes.InvalidArgument, "too many search tokens")
+	}
+
+	var list []*models.SecretData
+	if request.GetOrgId() != "" {
+		member, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+		if err != nil {
+			return nil, err
+		}
+		if !member.CanRead() {
+			return nil, status.Error(codes.PermissionDenied, "insufficient role")
+		}
+		list, err = s.secrets.SearchByOrganization(ctx, member.OrgID, tokens)
+	} else {
+		list, err = s.secrets.Search(ctx, uid, tokens)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.SearchSecretsResponse{}
+	for _, secret := range list {
+		resp.Secrets = append(resp.Secrets, metadata(secret))
+	}
+	return resp, nil
+}
+
+// apply - метод выполняет одну операцию пакета (возвращает метаданные секрета)
+func (s *Keeper) apply(ctx context.Context, op *pb.SecretOperation) (*pb.SecretMetadata, error) {
+	switch op := op.GetOp().(type) {
+	case *pb.SecretOperation_Add:
+		resp, err := s.AddSecret(ctx, op.Add)
+		return resp.GetMeta(), err
+	case *pb.SecretOperation_Edit:
+		resp, err := s.EditSecret(ctx, op.Edit)
+		return resp.GetMeta(), err
+	case *pb.SecretOperation_Delete:
+		resp, err := s.DeleteSecret(ctx, op.Delete)
+		return resp.GetMeta(), err
+	default:
+		return nil, status.Error(codes.InvalidArgument, "empty operation")
+	}
 }
 
 func (s *Keeper) RegisterService(r grpc.ServiceRegistrar) {
 	pb.RegisterKeeperServer(r, s)
 }
+
+// RegisterGateway - метод регистрации обработчиков REST/JSON шлюза
+func (s *Keeper) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
+	return pb.RegisterKeeperHandlerFromEndpoint(ctx, mux, endpoint, opts)
+}
+
+// checkAccessByID - метод загружает секрет и проверяет права пользователя на него
+func (s *Keeper) checkAccessByID(ctx context.Context, uid uuid.UUID, sid uuid.UUID, write bool) error {
+	return secretAccessByID(ctx, s.secrets, s.orgs, uid, sid, write)
+}
+
+// checkAccess - метод проверяет права пользователя на секрет
+func (s *Keeper) checkAccess(ctx context.Context, uid uuid.UUID, secret *models.SecretData, write bool) error {
+	return secretAccess(ctx, s.orgs, uid, secret, write)
+}
+
+// secretAccessByID - метод загружает секрет и проверяет права пользователя на него
+func secretAccessByID(ctx context.Context, secrets storage.Secret, orgs storage.Organization, uid uuid.UUID, sid uuid.UUID, write bool) error {
+	secret, err := secrets.Get(ctx, sid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return secretAccess(ctx, orgs, uid, secret, write)
+}
+
+// secretAccess - метод проверяет права пользователя на секрет.
+// Личный секрет доступен только владельцу, секрет организации - участникам согласно роли.
+func secretAccess(ctx context.Context, orgs storage.Organization, uid uuid.UUID, secret *models.SecretData, write bool) error {
+	if !secret.OrgID.Valid {
+		if secret.UserID != uid {
+			return status.Error(codes.PermissionDenied, "secret belongs to another user")
+		}
+		return nil
+	}
+	member, err := memberOf(ctx, orgs, secret.OrgID.UUID.String(), uid)
+	if err != nil {
+		return err
+	}
+	if (write && !member.CanWrite()) || !member.CanRead() {
+		return status.Error(codes.PermissionDenied, "insufficient role")
+	}
+	return nil
+}
+
+// orgID - метод возвращает идентификатор организации секрета (пустой для личного хранилища)
+func orgID(secret *models.SecretData) string {
+	if !secret.OrgID.Valid {
+		return ""
+	}
+	return secret.OrgID.UUID.String()
+}
+
+// metadata - метод формирует описание секрета для ответа клиенту
+func metadata(secret *models.SecretData) *pb.SecretMetadata {
+	meta := &pb.SecretMetadata{
+		Id:            secret.ID.String(),
+		Name:          secret.Name,
+		Type:          secret.Type,
+		OrgId:         orgID(secret),
+		Created:       timestamppb.New(secret.Created),
+		Updated:       timestamppb.New(secret.Updated),
+		ExpirePolicy:  secret.ExpirePolicy,
+		Archived:      secret.Archived,
+		EncryptedMeta: secret.Meta,
+		WrappedKey:    secret.WrappedKey,
+		Revision:      secret.Revision,
+		ContentHash:   secret.ContentHash,
+	}
+	if secret.Expires.Valid {
+		meta.Expires = timestamppb.New(secret.Expires.Time)
+	}
+	return meta
+}
+
+// expires - метод возвращает срок действия секрета из запроса (если задан)
+func expires(meta *pb.SecretMetadata) sql.NullTime {
+	if meta.GetExpires() == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: meta.GetExpires().AsTime(), Valid: true}
+}
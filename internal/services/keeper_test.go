@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"go-pass-keeper/internal/grpcserver/config"
 	"go-pass-keeper/internal/models"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,8 +25,9 @@ func TestNewKeeper(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 		mockSecrets := mocks.NewMockSecret(ctrl)
+		mockOrgs := mocks.NewMockOrganization(ctrl)
 
-		k := NewKeeper(mockSecrets)
+		k := NewKeeper(mockSecrets, mockOrgs)
 		if k == nil {
 			t.Errorf("Expected Keeper to be initialized with Token handler")
 		}
@@ -41,6 +44,7 @@ func TestAddSecret(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
 	config := config.DefaultConfig()
 
 	if err := logger.Initialize(config.LogLevel); err != nil {
@@ -94,13 +98,73 @@ func TestAddSecret(t *testing.T) {
 			Responce:      nil,
 			UserId:        uuid.Nil,
 		},
+		{
+			TestName: "Success. Add secret with encrypted metadata #5",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Add(gomock.Any(), &models.SecretData{UserID: uuid.MustParse(user_uuid), Type: "binary", Content: []byte("0x100"), Meta: []byte("meta")}).
+					Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Type: "binary", EncryptedMeta: []byte("meta")}, Content: []byte("0x100")},
+			Responce:      &pb.AddSecretResponse{Meta: &pb.SecretMetadata{Id: secret_uuid, Type: "binary", EncryptedMeta: []byte("meta"), Created: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)), Updated: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC))}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add secret metadata too large #6",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = encrypted metadata is too large"),
+			Request:       &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Type: "binary", EncryptedMeta: make([]byte, maxMetaSize+1)}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Success. Add secret with client id #7",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Add(gomock.Any(), &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid), Type: "binary", Content: []byte("0x100")}).
+					Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Type: "binary", Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid, Type: "binary"}, Content: []byte("0x100")},
+			Responce:      &pb.AddSecretResponse{Meta: &pb.SecretMetadata{Id: secret_uuid, Type: "binary", Created: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)), Updated: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC))}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add secret invalid client id #8",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid secret id"),
+			Request:       &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Id: "not-uuid", Type: "binary"}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Success. Add secret with wrapped data key #9",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Add(gomock.Any(), &models.SecretData{UserID: uuid.MustParse(user_uuid), Type: "binary", Content: []byte("0x100"), WrappedKey: []byte("key")}).
+					Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), WrappedKey: []byte("key"), Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Type: "binary", WrappedKey: []byte("key")}, Content: []byte("0x100")},
+			Responce:      &pb.AddSecretResponse{Meta: &pb.SecretMetadata{Id: secret_uuid, Type: "binary", WrappedKey: []byte("key"), Created: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)), Updated: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC))}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Add secret wrapped key too large #10",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = wrapped key is too large"),
+			Request:       &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Type: "binary", WrappedKey: make([]byte, maxWrappedKeySize+1)}, Content: []byte("0x100")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			k := NewKeeper(mockSecrets)
+			k := NewKeeper(mockSecrets, mockOrgs)
 
 			ctx := context.Background()
 			if tc.UserId != uuid.Nil {
@@ -127,6 +191,7 @@ func TestGetSecret(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
 	config := config.DefaultConfig()
 
 	if err := logger.Initialize(config.LogLevel); err != nil {
@@ -145,7 +210,7 @@ func TestGetSecret(t *testing.T) {
 			TestName: "Success. Get secret #1",
 			SetupMocks: func() {
 				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{
-					ID: uuid.MustParse(secret_uuid), Name: "Big secret", Type: "binary", Content: []byte("0x100"), Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC),
+					ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid), Name: "Big secret", Type: "binary", Content: []byte("0x100"), Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC),
 				}, nil)
 			},
 			ExpectedError: nil,
@@ -188,7 +253,7 @@ func TestGetSecret(t *testing.T) {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			k := NewKeeper(mockSecrets)
+			k := NewKeeper(mockSecrets, mockOrgs)
 
 			ctx := context.Background()
 			if tc.UserId != uuid.Nil {
@@ -215,6 +280,7 @@ func TestDeleteSecret(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
 	config := config.DefaultConfig()
 
 	if err := logger.Initialize(config.LogLevel); err != nil {
@@ -231,6 +297,7 @@ func TestDeleteSecret(t *testing.T) {
 		{
 			TestName: "Success. Delete secret #1",
 			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
 				mockSecrets.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			ExpectedError: nil,
@@ -240,6 +307,7 @@ func TestDeleteSecret(t *testing.T) {
 		{
 			TestName: "Error. Delete secret already exists #2",
 			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
 				mockSecrets.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(storage.ErrNotFound)
 			},
 			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
@@ -249,6 +317,7 @@ func TestDeleteSecret(t *testing.T) {
 		{
 			TestName: "Error. Delete secret undefined error #3",
 			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
 				mockSecrets.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(errors.New("failed to delete secret:"))
 			},
 			ExpectedError: errors.New("rpc error: code = Internal desc = failed to delete secret:"),
@@ -269,7 +338,7 @@ func TestDeleteSecret(t *testing.T) {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			k := NewKeeper(mockSecrets)
+			k := NewKeeper(mockSecrets, mockOrgs)
 
 			ctx := context.Background()
 			if tc.UserId != uuid.Nil {
@@ -293,6 +362,7 @@ func TestGetSecrets(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
 	config := config.DefaultConfig()
 
 	if err := logger.Initialize(config.LogLevel); err != nil {
@@ -310,7 +380,7 @@ func TestGetSecrets(t *testing.T) {
 		{
 			TestName: "Success. Get secrets #1",
 			SetupMocks: func() {
-				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any()).Return([]*models.SecretData{
+				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any(), false).Return([]*models.SecretData{
 					{ID: uuid.MustParse(secret_uuid), Type: "password", Name: "Password", Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)},
 					{ID: uuid.MustParse(user_uuid), Type: "binary", Name: "File", Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)}}, nil)
 			},
@@ -324,7 +394,7 @@ func TestGetSecrets(t *testing.T) {
 		{
 			TestName: "Error. Get secrets already exists #2",
 			SetupMocks: func() {
-				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any(), false).Return(nil, storage.ErrNotFound)
 			},
 			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
 			Request:       &pb.GetSecretsRequest{},
@@ -334,13 +404,27 @@ func TestGetSecrets(t *testing.T) {
 		{
 			TestName: "Error. Get secrets undefined error #3",
 			SetupMocks: func() {
-				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to get secrets:"))
+				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any(), false).Return(nil, errors.New("failed to get secrets:"))
 			},
 			ExpectedError: errors.New("rpc error: code = Internal desc = failed to get secrets:"),
 			Request:       &pb.GetSecretsRequest{},
 			Responce:      nil,
 			UserId:        uuid.MustParse(user_uuid),
 		},
+		{
+			TestName: "Success. Get archived secrets #5",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().List(gomock.Any(), gomock.Any(), true).Return([]*models.SecretData{
+					{ID: uuid.MustParse(secret_uuid), Type: "password", Name: "Password", Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC),
+						Expires: sql.NullTime{Time: time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC), Valid: true}, ExpirePolicy: models.ExpireArchive, Archived: true}}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.GetSecretsRequest{Archived: true},
+			Responce: &pb.GetSecretsResponse{Secrets: []*pb.SecretMetadata{
+				{Id: secret_uuid, Type: "password", Name: "Password", Created: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)), Updated: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)),
+					Expires: timestamppb.New(time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)), ExpirePolicy: models.ExpireArchive, Archived: true}}},
+			UserId: uuid.MustParse(user_uuid),
+		},
 		{
 			TestName: "Error. Get secrets unknown user #4",
 			SetupMocks: func() {
@@ -356,7 +440,7 @@ func TestGetSecrets(t *testing.T) {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			k := NewKeeper(mockSecrets)
+			k := NewKeeper(mockSecrets, mockOrgs)
 
 			ctx := context.Background()
 			if tc.UserId != uuid.Nil {
@@ -383,6 +467,7 @@ func TestEditSecret(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
 	config := config.DefaultConfig()
 
 	if err := logger.Initialize(config.LogLevel); err != nil {
@@ -400,6 +485,7 @@ func TestEditSecret(t *testing.T) {
 		{
 			TestName: "Success. Edit secret #1",
 			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
 				mockSecrets.EXPECT().Edit(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Name: "Big secret", Type: "binary", Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)}, nil)
 			},
 			ExpectedError: nil,
@@ -410,6 +496,7 @@ func TestEditSecret(t *testing.T) {
 		{
 			TestName: "Error. Edit secret not exists #2",
 			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
 				mockSecrets.EXPECT().Edit(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
 			},
 			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
@@ -420,6 +507,7 @@ func TestEditSecret(t *testing.T) {
 		{
 			TestName: "Error. Edit secret undefined error #3",
 			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
 				mockSecrets.EXPECT().Edit(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to edit secret:"))
 			},
 			ExpectedError: errors.New("rpc error: code = Internal desc = failed to edit secret:"),
@@ -436,13 +524,26 @@ func TestEditSecret(t *testing.T) {
 			Responce:      nil,
 			UserId:        uuid.Nil,
 		},
+		{
+			TestName: "Success. Edit secret with encrypted metadata #5",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+				// индекс без слов очищается вместе с заменой метаданных
+				mockSecrets.EXPECT().Edit(gomock.Any(), &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid), Type: "binary", Content: []byte("0x100"), Meta: []byte("meta"), SearchIndex: [][]byte{}}).
+					Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Type: "binary", Meta: []byte("meta"), Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.EditSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid, Type: "binary", EncryptedMeta: []byte("meta")}, Content: []byte("0x100")},
+			Responce:      &pb.EditSecretResponse{Meta: &pb.SecretMetadata{Id: secret_uuid, Type: "binary", EncryptedMeta: []byte("meta"), Created: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)), Updated: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC))}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			k := NewKeeper(mockSecrets)
+			k := NewKeeper(mockSecrets, mockOrgs)
 
 			ctx := context.Background()
 			if tc.UserId != uuid.Nil {
@@ -464,3 +565,482 @@ func TestEditSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestSetExpiration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	expires := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.SetExpirationRequest
+		Responce      *pb.SetExpirationResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Set expiration #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+				mockSecrets.EXPECT().SetExpiration(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Name: "Big secret", Type: "binary",
+					Created: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC), Updated: time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC),
+					Expires: sql.NullTime{Time: expires, Valid: true}, ExpirePolicy: models.ExpireDelete, Meta: []byte("stored meta")}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SetExpirationRequest{Meta: &pb.SecretMetadata{Id: secret_uuid, Name: "Renamed", Expires: timestamppb.New(expires), ExpirePolicy: models.ExpireDelete}},
+			Responce: &pb.SetExpirationResponse{Meta: &pb.SecretMetadata{Id: secret_uuid, Name: "Big secret", Type: "binary",
+				Created: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)), Updated: timestamppb.New(time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)),
+				Expires: timestamppb.New(expires), ExpirePolicy: models.ExpireDelete, EncryptedMeta: []byte("stored meta")}},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Set expiration unknown policy #2",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = unknown expire policy"),
+			Request:       &pb.SetExpirationRequest{Meta: &pb.SecretMetadata{Id: secret_uuid, Expires: timestamppb.New(expires), ExpirePolicy: "burn"}},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Set expiration foreign secret #3",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.New()}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = secret belongs to another user"),
+			Request:       &pb.SetExpirationRequest{Meta: &pb.SecretMetadata{Id: secret_uuid, ExpirePolicy: models.ExpireFlag}},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Set expiration not exists #4",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+				mockSecrets.EXPECT().SetExpiration(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.SetExpirationRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Set expiration unknown user #5",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SetExpirationRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			k := NewKeeper(mockSecrets, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := k.SetExpiration(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestBatchSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.September, 21, 10, 30, 0, 0, time.UTC)
+	inTx := func(ctx context.Context, fn func(storage.Secret) error) error {
+		return fn(mockSecrets)
+	}
+	ops := []*pb.SecretOperation{
+		{Op: &pb.SecretOperation_Add{Add: &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Name: "Password", Type: "password"}, Content: []byte("0x100")}}},
+		{Op: &pb.SecretOperation_Delete{Delete: &pb.DeleteSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}}}},
+		{Op: &pb.SecretOperation_Edit{Edit: &pb.EditSecretRequest{Meta: &pb.SecretMetadata{Id: user_uuid, Name: "File", Type: "binary"}, Content: []byte("0x200")}}},
+	}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.BatchSecretsRequest
+		Responce      *pb.BatchSecretsResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Batch committed #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().InTx(gomock.Any(), gomock.Any()).DoAndReturn(inTx)
+				mockSecrets.EXPECT().Add(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Created: created, Updated: created}, nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+				mockSecrets.EXPECT().Delete(gomock.Any(), uuid.MustParse(secret_uuid)).Return(nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(user_uuid)).Return(&models.SecretData{ID: uuid.MustParse(user_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+				mockSecrets.EXPECT().Edit(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(user_uuid), Created: created, Updated: created}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.BatchSecretsRequest{Operations: ops},
+			Responce: &pb.BatchSecretsResponse{Committed: true, Results: []*pb.SecretOperationResult{
+				{Meta: &pb.SecretMetadata{Id: secret_uuid, Name: "Password", Type: "password", Created: timestamppb.New(created), Updated: timestamppb.New(created)}},
+				{Meta: &pb.SecretMetadata{Id: secret_uuid}},
+				{Meta: &pb.SecretMetadata{Id: user_uuid, Name: "File", Type: "binary", Created: timestamppb.New(created), Updated: timestamppb.New(created)}},
+			}},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Success. Batch rolled back #2",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().InTx(gomock.Any(), gomock.Any()).DoAndReturn(inTx)
+				mockSecrets.EXPECT().Add(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), Created: created, Updated: created}, nil)
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.New()}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.BatchSecretsRequest{Operations: ops},
+			Responce: &pb.BatchSecretsResponse{Committed: false, Results: []*pb.SecretOperationResult{
+				{Code: int32(codes.Aborted), Error: "rolled back"},
+				{Code: int32(codes.PermissionDenied), Error: "secret belongs to another user"},
+				{Code: int32(codes.Aborted), Error: "not executed"},
+			}},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Batch commit failed #3",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().InTx(gomock.Any(), gomock.Any()).Return(errors.New("failed to commit transaction:"))
+			},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to commit transaction:"),
+			Request:       &pb.BatchSecretsRequest{Operations: ops},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Empty batch #4",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = batch must contain from 1 to 1000 operations"),
+			Request:       &pb.BatchSecretsRequest{},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Batch unknown user #5",
+			SetupMocks: func() {
+			},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.BatchSecretsRequest{Operations: ops},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			k := NewKeeper(mockSecrets, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := k.BatchSecrets(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+const org_uuid = "5b0b3d1e-8f43-4a0f-9a55-8e9a3c1c2d11"
+
+func TestSearchSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 12, 9, 0, 0, 0, time.UTC)
+	tokens := [][]byte{[]byte("token-1"), []byte("token-2")}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.SearchSecretsRequest
+		Responce      *pb.SearchSecretsResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Search personal secrets #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Search(gomock.Any(), uuid.MustParse(user_uuid), tokens).Return([]*models.SecretData{
+					{ID: uuid.MustParse(secret_uuid), Type: "password", Name: "GitHub", Created: created, Updated: created}}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SearchSecretsRequest{Tokens: tokens},
+			Responce: &pb.SearchSecretsResponse{Secrets: []*pb.SecretMetadata{
+				{Id: secret_uuid, Type: "password", Name: "GitHub", Created: timestamppb.New(created), Updated: timestamppb.New(created)}}},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Success. Search organization secrets #2",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(
+					&models.MemberData{OrgID: uuid.MustParse(org_uuid), UserID: uuid.MustParse(user_uuid), Role: models.RoleReadOnly}, nil)
+				mockSecrets.EXPECT().SearchByOrganization(gomock.Any(), uuid.MustParse(org_uuid), tokens).Return([]*models.SecretData{}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SearchSecretsRequest{OrgId: org_uuid, Tokens: tokens},
+			Responce:      &pb.SearchSecretsResponse{},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Not a member of organization #3",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = not a member of organization"),
+			Request:       &pb.SearchSecretsRequest{OrgId: org_uuid, Tokens: tokens},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Empty search query #4",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = empty search query"),
+			Request:       &pb.SearchSecretsRequest{},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Storage error #5",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Search(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to get secrets:"))
+			},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to get secrets:"),
+			Request:       &pb.SearchSecretsRequest{Tokens: tokens},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Unknown user #6",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SearchSecretsRequest{Tokens: tokens},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			k := NewKeeper(mockSecrets, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := k.SearchSecrets(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestKeeperOrganizationAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	orgSecret := &models.SecretData{
+		ID:     uuid.MustParse(secret_uuid),
+		UserID: uuid.MustParse(recipient_uuid),
+		OrgID:  uuid.NullUUID{UUID: uuid.MustParse(org_uuid), Valid: true},
+		Name:   "Team secret",
+		Type:   "password",
+	}
+	member := func(role string) *models.MemberData {
+		return &models.MemberData{OrgID: uuid.MustParse(org_uuid), UserID: uuid.MustParse(user_uuid), Role: role}
+	}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Call          func(k *Keeper, ctx context.Context) error
+		ExpectedError error
+	}{
+		{
+			TestName: "Error. Get personal secret of another user #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(recipient_uuid)}, nil)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				_, err := k.GetSecret(ctx, &pb.GetSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}})
+				return err
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = secret belongs to another user"),
+		},
+		{
+			TestName: "Success. Read-only member gets organization secret #2",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(orgSecret, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(member(models.RoleReadOnly), nil)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				_, err := k.GetSecret(ctx, &pb.GetSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}})
+				return err
+			},
+			ExpectedError: nil,
+		},
+		{
+			TestName: "Error. Read-only member deletes organization secret #3",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(orgSecret, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(models.RoleReadOnly), nil)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				_, err := k.DeleteSecret(ctx, &pb.DeleteSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}})
+				return err
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = insufficient role"),
+		},
+		{
+			TestName: "Success. Member edits organization secret #4",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(orgSecret, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(models.RoleMember), nil)
+				mockSecrets.EXPECT().Edit(gomock.Any(), gomock.Any()).Return(orgSecret, nil)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				_, err := k.EditSecret(ctx, &pb.EditSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid, Name: "Team secret"}, Content: []byte("0x100")})
+				return err
+			},
+			ExpectedError: nil,
+		},
+		{
+			TestName: "Error. Not a member adds organization secret #5",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				_, err := k.AddSecret(ctx, &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Name: "Team secret", Type: "password", OrgId: org_uuid}, Content: []byte("0x100")})
+				return err
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = not a member of organization"),
+		},
+		{
+			TestName: "Success. Admin adds organization secret #6",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(models.RoleAdmin), nil)
+				mockSecrets.EXPECT().Add(gomock.Any(), &models.SecretData{
+					UserID:  uuid.MustParse(user_uuid),
+					OrgID:   uuid.NullUUID{UUID: uuid.MustParse(org_uuid), Valid: true},
+					Name:    "Team secret",
+					Type:    "password",
+					Content: []byte("0x100"),
+				}).Return(orgSecret, nil)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				_, err := k.AddSecret(ctx, &pb.AddSecretRequest{Meta: &pb.SecretMetadata{Name: "Team secret", Type: "password", OrgId: org_uuid}, Content: []byte("0x100")})
+				return err
+			},
+			ExpectedError: nil,
+		},
+		{
+			TestName: "Success. List organization secrets #7",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(models.RoleReadOnly), nil)
+				mockSecrets.EXPECT().ListByOrganization(gomock.Any(), uuid.MustParse(org_uuid), false).Return([]*models.SecretData{orgSecret}, nil)
+			},
+			Call: func(k *Keeper, ctx context.Context) error {
+				resp, err := k.GetSecrets(ctx, &pb.GetSecretsRequest{OrgId: org_uuid})
+				if err == nil && resp.GetSecrets()[0].GetOrgId() != org_uuid {
+					return errors.New("organization id not returned")
+				}
+				return err
+			},
+			ExpectedError: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			k := NewKeeper(mockSecrets, mockOrgs)
+			ctx := usercontext.SetUserId(context.Background(), uuid.MustParse(user_uuid))
+
+			err := tc.Call(k, ctx)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+		})
+	}
+}
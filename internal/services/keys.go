@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/pkg/crypto"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxKeyCheckSize - максимальный размер контрольного значения ключа шифрования
+const maxKeyCheckSize = 128
+
+// maxVaultRootSize - максимальный размер подписанного состояния личного хранилища
+// (состояние содержит запись о каждом секрете хранилища)
+const maxVaultRootSize = 1 << 20
+
+// Keys - модель сервиса ключей личного хранилища пользователя. Сервер хранит ключ хранилища
+// только зашифрованным на клиенте (ключом из пароля или ключом восстановления),
+// контрольное значение ключа и подписанное клиентом состояние хранилища.
+type Keys struct {
+	pb.UnimplementedKeysServer
+
+	users storage.User
+}
+
+// NewKeys - метод создания сервиса ключей
+func NewKeys(u storage.User) *Keys {
+	return &Keys{users: u}
+}
+
+// SetKdf - метод смены параметров получения ключа из пароля. Параметры должны быть
+// не слабее параметров по умолчанию, ключ шифрования передаётся зашифрованным ключом из пароля.
+func (s *Keys) SetKdf(ctx context.Context, request *pb.SetKdfRequest) (*pb.SetKdfResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	kdf := models.KDFFromProto(request.GetKdf())
+	if err := kdf.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if kdf.Weaker(crypto.DefaultKDFParams) {
+		return nil, status.Error(codes.InvalidArgument, "kdf parameters are weaker than required")
+	}
+	if len(request.GetWrappedKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty wrapped key")
+	}
+	if err := s.users.SetKDF(ctx, uid, kdf, request.GetWrappedKey()); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetKdfResponse{}, nil
+}
+
+// SetKeyCheck - метод сохраняет контрольное значение ключа шифрования пользователя
+// (константу, зашифрованную на клиенте ключом хранилища)
+func (s *Keys) SetKeyCheck(ctx context.Context, request *pb.SetKeyCheckRequest) (*pb.SetKeyCheckResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(request.GetKeyCheck()) == 0 || len(request.GetKeyCheck()) > maxKeyCheckSize {
+		return nil, status.Error(codes.InvalidArgument, "invalid key check")
+	}
+	if err := s.users.SetKeyCheck(ctx, uid, request.GetKeyCheck()); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetKeyCheckResponse{}, nil
+}
+
+// SetRecoveryKey - метод сохраняет ключ шифрования пользователя, зашифрованный на клиенте
+// ключом восстановления (прежний ключ восстановления перестаёт действовать)
+func (s *Keys) SetRecoveryKey(ctx context.Context, request *pb.SetRecoveryKeyRequest) (*pb.SetRecoveryKeyResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(request.GetRecoveryKey()) == 0 || len(request.GetRecoveryKey()) > maxWrappedKeySize {
+		return nil, status.Error(codes.InvalidArgument, "invalid recovery key")
+	}
+	if err := s.users.SetRecoveryKey(ctx, uid, request.GetRecoveryKey()); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetRecoveryKeyResponse{}, nil
+}
+
+// GetRecoveryKey - метод возвращает ключ шифрования пользователя, зашифрованный ключом восстановления
+func (s *Keys) GetRecoveryKey(ctx context.Context, request *pb.GetRecoveryKeyRequest) (*pb.GetRecoveryKeyResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	wrapped, err := s.users.GetRecoveryKey(ctx, uid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetRecoveryKeyResponse{RecoveryKey: wrapped}, nil
+}
+
+// SetVaultRoot - метод сохраняет состояние личного хранилища, подписанное на клиенте.
+// Состояние заменяется, только если номер сохранённого состояния равен указанному клиентом
+// (иначе хранилище изменено другим клиентом, и состояние нужно получить и проверить заново).
+func (s *Keys) SetVaultRoot(ctx context.Context, request *pb.SetVaultRootRequest) (*pb.SetVaultRootResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(request.GetVaultRoot()) == 0 || len(request.GetVaultRoot()) > maxVaultRootSize {
+		return nil, status.Error(codes.InvalidArgument, "invalid vault root")
+	}
+	if request.GetSequence() <= request.GetPreviousSequence() {
+		return nil, status.Error(codes.InvalidArgument, "vault root sequence must increase")
+	}
+	err = s.users.SetVaultRoot(ctx, uid, request.GetVaultRoot(), request.GetSequence(), request.GetPreviousSequence())
+	if err != nil {
+		if errors.Is(err, storage.ErrConflict) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetVaultRootResponse{}, nil
+}
+
+// GetVaultRoot - метод возвращает состояние личного хранилища, подписанное на клиенте, и его номер
+func (s *Keys) GetVaultRoot(ctx context.Context, request *pb.GetVaultRootRequest) (*pb.GetVaultRootResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	root, seq, err := s.users.GetVaultRoot(ctx, uid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetVaultRootResponse{VaultRoot: root, Sequence: seq}, nil
+}
+
+// RegisterService - метод регистрации сервиса
+func (s *Keys) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterKeysServer(r, s)
+}
@@ -0,0 +1,488 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/storage/mocks"
+	"go-pass-keeper/pkg/crypto"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetKdf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.SetKdfRequest
+		Responce      *pb.SetKdfResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Set kdf #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetKDF(gomock.Any(), uuid.MustParse(user_uuid), crypto.DefaultKDFParams, []byte("wrapped")).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SetKdfRequest{Kdf: models.KDFToProto(crypto.DefaultKDFParams), WrappedKey: []byte("wrapped")},
+			Responce:      &pb.SetKdfResponse{},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set weaker kdf #2",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = kdf parameters are weaker than required"),
+			Request:       &pb.SetKdfRequest{Kdf: models.KDFToProto(crypto.LegacyKDFParams), WrappedKey: []byte("wrapped")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set invalid kdf #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid kdf parameters: unknown algorithm \"md5\""),
+			Request:       &pb.SetKdfRequest{Kdf: &pb.KdfParams{Algorithm: "md5", Memory: 1024, Iterations: 1, Parallelism: 1}, WrappedKey: []byte("wrapped")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set kdf without key #4",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = empty wrapped key"),
+			Request:       &pb.SetKdfRequest{Kdf: models.KDFToProto(crypto.DefaultKDFParams)},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set kdf unknown user #5",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SetKdfRequest{Kdf: models.KDFToProto(crypto.DefaultKDFParams), WrappedKey: []byte("wrapped")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewKeys(mockUsers)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.SetKdf(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestSetKeyCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.SetKeyCheckRequest
+		Responce      *pb.SetKeyCheckResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Set key check #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetKeyCheck(gomock.Any(), uuid.MustParse(user_uuid), []byte("check")).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SetKeyCheckRequest{KeyCheck: []byte("check")},
+			Responce:      &pb.SetKeyCheckResponse{},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set empty key check #2",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid key check"),
+			Request:       &pb.SetKeyCheckRequest{},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set too large key check #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid key check"),
+			Request:       &pb.SetKeyCheckRequest{KeyCheck: make([]byte, maxKeyCheckSize+1)},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Set key check deleted user #4",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetKeyCheck(gomock.Any(), uuid.MustParse(user_uuid), []byte("check")).Return(storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.SetKeyCheckRequest{KeyCheck: []byte("check")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set key check unknown user #5",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SetKeyCheckRequest{KeyCheck: []byte("check")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewKeys(mockUsers)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.SetKeyCheck(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestSetRecoveryKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.SetRecoveryKeyRequest
+		Responce      *pb.SetRecoveryKeyResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Set recovery key #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetRecoveryKey(gomock.Any(), uuid.MustParse(user_uuid), []byte("wrapped")).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SetRecoveryKeyRequest{RecoveryKey: []byte("wrapped")},
+			Responce:      &pb.SetRecoveryKeyResponse{},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set empty recovery key #2",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid recovery key"),
+			Request:       &pb.SetRecoveryKeyRequest{},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set too large recovery key #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid recovery key"),
+			Request:       &pb.SetRecoveryKeyRequest{RecoveryKey: make([]byte, maxWrappedKeySize+1)},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set recovery key unknown user #4",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SetRecoveryKeyRequest{RecoveryKey: []byte("wrapped")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewKeys(mockUsers)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.SetRecoveryKey(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestGetRecoveryKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Responce      *pb.GetRecoveryKeyResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Get recovery key #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetRecoveryKey(gomock.Any(), uuid.MustParse(user_uuid)).Return([]byte("wrapped"), nil)
+			},
+			ExpectedError: nil,
+			Responce:      &pb.GetRecoveryKeyResponse{RecoveryKey: []byte("wrapped")},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Recovery key not set #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetRecoveryKey(gomock.Any(), uuid.MustParse(user_uuid)).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Get recovery key unknown user #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewKeys(mockUsers)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.GetRecoveryKey(ctx, &pb.GetRecoveryKeyRequest{})
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestSetVaultRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.SetVaultRootRequest
+		Responce      *pb.SetVaultRootResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Set vault root #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetVaultRoot(gomock.Any(), uuid.MustParse(user_uuid), []byte("root"), int64(2), int64(1)).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.SetVaultRootRequest{VaultRoot: []byte("root"), Sequence: 2, PreviousSequence: 1},
+			Responce:      &pb.SetVaultRootResponse{},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Vault root changed by another client #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().SetVaultRoot(gomock.Any(), uuid.MustParse(user_uuid), []byte("root"), int64(2), int64(1)).Return(storage.ErrConflict)
+			},
+			ExpectedError: errors.New("rpc error: code = Aborted desc = conflict"),
+			Request:       &pb.SetVaultRootRequest{VaultRoot: []byte("root"), Sequence: 2, PreviousSequence: 1},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set empty vault root #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid vault root"),
+			Request:       &pb.SetVaultRootRequest{Sequence: 1},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set too large vault root #4",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid vault root"),
+			Request:       &pb.SetVaultRootRequest{VaultRoot: make([]byte, maxVaultRootSize+1), Sequence: 1},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Vault root sequence not increased #5",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = vault root sequence must increase"),
+			Request:       &pb.SetVaultRootRequest{VaultRoot: []byte("root"), Sequence: 1, PreviousSequence: 1},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Set vault root unknown user #6",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.SetVaultRootRequest{VaultRoot: []byte("root"), Sequence: 1},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewKeys(mockUsers)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.SetVaultRoot(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestGetVaultRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Responce      *pb.GetVaultRootResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Get vault root #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetVaultRoot(gomock.Any(), uuid.MustParse(user_uuid)).Return([]byte("root"), int64(3), nil)
+			},
+			ExpectedError: nil,
+			Responce:      &pb.GetVaultRootResponse{VaultRoot: []byte("root"), Sequence: 3},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Vault root not set #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetVaultRoot(gomock.Any(), uuid.MustParse(user_uuid)).Return(nil, int64(0), storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Get vault root unknown user #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewKeys(mockUsers)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.GetVaultRoot(ctx, &pb.GetVaultRootRequest{})
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
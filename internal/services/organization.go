@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Organization - модель сервиса организаций (командных хранилищ).
+// Ключ хранилища организации создаётся на клиенте и хранится на сервере
+// только в виде, зашифрованном открытым ключом каждого участника.
+type Organization struct {
+	pb.UnimplementedOrganizationServer
+
+	users storage.User
+	orgs  storage.Organization
+}
+
+// NewOrganization - метод создания сервиса организаций
+func NewOrganization(u storage.User, o storage.Organization) *Organization {
+	return &Organization{
+		users: u,
+		orgs:  o,
+	}
+}
+
+// CreateOrganization - метод создания организации, текущий пользователь становится её владельцем
+func (s *Organization) CreateOrganization(ctx context.Context, request *pb.CreateOrganizationRequest) (*pb.CreateOrganizationResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if request.GetName() == "" || len(request.GetVaultKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty organization name or vault key")
+	}
+	org, err := s.orgs.Create(ctx,
+		&models.OrganizationData{Name: request.GetName()},
+		&models.MemberData{UserID: uid, Role: models.RoleOwner, VaultKey: request.GetVaultKey()},
+	)
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateOrganizationResponse{Organization: organizationToProto(org)}, nil
+}
+
+// GetOrganizations - метод получения организаций, в которых состоит пользователь
+func (s *Organization) GetOrganizations(ctx context.Context, request *pb.GetOrganizationsRequest) (*pb.GetOrganizationsResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	list, err := s.orgs.ListByUser(ctx, uid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.GetOrganizationsResponse{}
+	for _, org := range list {
+		resp.Organizations = append(resp.Organizations, organizationToProto(org))
+	}
+	return resp, nil
+}
+
+// GetMembers - метод получения участников организации
+func (s *Organization) GetMembers(ctx context.Context, request *pb.GetMembersRequest) (*pb.GetMembersResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	current, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.orgs.ListMembers(ctx, current.OrgID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.GetMembersResponse{}
+	for _, member := range list {
+		resp.Members = append(resp.Members, memberToProto(member))
+	}
+	return resp, nil
+}
+
+// AddMember - метод добавления участника в организацию
+func (s *Organization) AddMember(ctx context.Context, request *pb.AddMemberRequest) (*pb.AddMemberResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(request.GetVaultKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty vault key")
+	}
+	current, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrant(current, request.GetRole()); err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetPublicKey(ctx, request.GetLogin())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	member, err := s.orgs.AddMember(ctx, &models.MemberData{
+		OrgID:    current.OrgID,
+		UserID:   user.ID,
+		Login:    user.Login,
+		Role:     request.GetRole(),
+		VaultKey: request.GetVaultKey(),
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.AddMemberResponse{Member: memberToProto(member)}, nil
+}
+
+// UpdateMember - метод изменения роли участника организации
+func (s *Organization) UpdateMember(ctx context.Context, request *pb.UpdateMemberRequest) (*pb.UpdateMemberResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	current, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrant(current, request.GetRole()); err != nil {
+		return nil, err
+	}
+	target, err := s.targetMember(ctx, current, request.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+	target.Role = request.GetRole()
+	member, err := s.orgs.UpdateMember(ctx, target)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.UpdateMemberResponse{Member: memberToProto(member)}, nil
+}
+
+// RemoveMember - метод удаления участника из организации (участник может покинуть организацию сам)
+func (s *Organization) RemoveMember(ctx context.Context, request *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	current, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+	if err != nil {
+		return nil, err
+	}
+	var target *models.MemberData
+	if current.Login == request.GetLogin() {
+		if current.Role == models.RoleOwner {
+			return nil, status.Error(codes.PermissionDenied, "organization owner can't leave organization")
+		}
+		target = current
+	} else {
+		if !current.CanManage() {
+			return nil, status.Error(codes.PermissionDenied, "insufficient role")
+		}
+		target, err = s.targetMember(ctx, current, request.GetLogin())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := s.orgs.DeleteMember(ctx, target.OrgID, target.UserID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RemoveMemberResponse{}, nil
+}
+
+// RotateVaultKey - метод замены ключа хранилища организации (после удаления участника).
+// Новый ключ создаётся на клиенте, сервер сохраняет его для перечисленных участников вместе
+// с ключами данных секретов, перешифрованными новым ключом. Списки должны совпадать с текущими.
+func (s *Organization) RotateVaultKey(ctx context.Context, request *pb.RotateVaultKeyRequest) (*pb.RotateVaultKeyResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	current, err := memberOf(ctx, s.orgs, request.GetOrgId(), uid)
+	if err != nil {
+		return nil, err
+	}
+	if !current.CanManage() {
+		return nil, status.Error(codes.PermissionDenied, "insufficient role")
+	}
+	members := make([]*models.MemberData, 0, len(request.GetMembers()))
+	seen := make(map[string]bool, len(request.GetMembers()))
+	for _, member := range request.GetMembers() {
+		if len(member.GetVaultKey()) == 0 || len(member.GetVaultKey()) > maxWrappedKeySize {
+			return nil, status.Error(codes.InvalidArgument, "invalid vault key")
+		}
+		if seen[member.GetLogin()] {
+			return nil, status.Error(codes.InvalidArgument, "duplicate member")
+		}
+		seen[member.GetLogin()] = true
+		user, err := s.users.GetPublicKey(ctx, member.GetLogin())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		members = append(members, &models.MemberData{OrgID: current.OrgID, UserID: user.ID, Login: user.Login, VaultKey: member.GetVaultKey()})
+	}
+	secrets := make([]*models.SecretData, 0, len(request.GetSecrets()))
+	for _, secret := range request.GetSecrets() {
+		sid, err := uuid.Parse(secret.GetId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(secret.GetWrappedKey()) == 0 || len(secret.GetWrappedKey()) > maxWrappedKeySize {
+			return nil, status.Error(codes.InvalidArgument, "invalid wrapped key")
+		}
+		if len(secret.GetSearchIndex()) > maxSearchTokens {
+			return nil, status.Error(codes.InvalidArgument, "search index is too large")
+		}
+		secrets = append(secrets, &models.SecretData{ID: sid, WrappedKey: secret.GetWrappedKey(), SearchIndex: secret.GetSearchIndex()})
+	}
+	if err := s.orgs.RotateKey(ctx, current.OrgID, members, secrets); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, storage.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "organization members or secrets changed")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RotateVaultKeyResponse{}, nil
+}
+
+// RegisterService - метод регистрации сервиса
+func (s *Organization) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterOrganizationServer(r, s)
+}
+
+// targetMember - метод получения участника, которым управляет текущий пользователь.
+// Владельца изменить нельзя, администраторами управляет только владелец.
+func (s *Organization) targetMember(ctx context.Context, current *models.MemberData, login string) (*models.MemberData, error) {
+	user, err := s.users.GetPublicKey(ctx, login)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	target, err := s.orgs.GetMember(ctx, current.OrgID, user.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if target.Role == models.RoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "can't change organization owner")
+	}
+	if target.Role == models.RoleAdmin && current.Role != models.RoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "insufficient role")
+	}
+	return target, nil
+}
+
+// checkGrant - метод проверяет, что участник может выдать указанную роль
+func checkGrant(current *models.MemberData, role string) error {
+	if !models.ValidRole(role) || role == models.RoleOwner {
+		return status.Error(codes.InvalidArgument, "invalid role")
+	}
+	if !current.CanManage() {
+		return status.Error(codes.PermissionDenied, "insufficient role")
+	}
+	if role == models.RoleAdmin && current.Role != models.RoleOwner {
+		return status.Error(codes.PermissionDenied, "insufficient role")
+	}
+	return nil
+}
+
+// memberOf - метод получения участника организации для текущего пользователя
+func memberOf(ctx context.Context, orgs storage.Organization, orgID string, uid uuid.UUID) (*models.MemberData, error) {
+	oid, err := uuid.Parse(orgID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	member, err := orgs.GetMember(ctx, oid, uid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.PermissionDenied, "not a member of organization")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return member, nil
+}
+
+// organizationToProto - метод конвертирует модель организации в сообщение
+func organizationToProto(org *models.OrganizationData) *pb.OrganizationInfo {
+	return &pb.OrganizationInfo{
+		Id:        org.ID.String(),
+		Name:      org.Name,
+		Role:      org.Role,
+		VaultKey:  org.VaultKey,
+		Created:   timestamppb.New(org.Created),
+		RotateKey: org.RotateKey,
+	}
+}
+
+// memberToProto - метод конвертирует модель участника в сообщение
+func memberToProto(member *models.MemberData) *pb.Member {
+	return &pb.Member{
+		Login:   member.Login,
+		Role:    member.Role,
+		Created: timestamppb.New(member.Created),
+	}
+}
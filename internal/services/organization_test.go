@@ -0,0 +1,425 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/storage/mocks"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 3, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.CreateOrganizationRequest
+		Responce      *pb.CreateOrganizationResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Create organization #1",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().Create(gomock.Any(),
+					&models.OrganizationData{Name: "team"},
+					&models.MemberData{UserID: uuid.MustParse(user_uuid), Role: models.RoleOwner, VaultKey: []byte("key")},
+				).Return(&models.OrganizationData{ID: uuid.MustParse(org_uuid), Name: "team", Role: models.RoleOwner, VaultKey: []byte("key"), Created: created}, nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.CreateOrganizationRequest{Name: "team", VaultKey: []byte("key")},
+			Responce:      &pb.CreateOrganizationResponse{Organization: &pb.OrganizationInfo{Id: org_uuid, Name: "team", Role: models.RoleOwner, VaultKey: []byte("key"), Created: timestamppb.New(created)}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Create organization already exists #2",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrAlreadyExists)
+			},
+			ExpectedError: errors.New("rpc error: code = AlreadyExists desc = already exists"),
+			Request:       &pb.CreateOrganizationRequest{Name: "team", VaultKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Create organization without vault key #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = empty organization name or vault key"),
+			Request:       &pb.CreateOrganizationRequest{Name: "team"},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Create organization unknown user #4",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.CreateOrganizationRequest{Name: "team", VaultKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewOrganization(mockUsers, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.CreateOrganization(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestAddMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 3, 12, 0, 0, 0, time.UTC)
+	current := func(role string) *models.MemberData {
+		return &models.MemberData{OrgID: uuid.MustParse(org_uuid), UserID: uuid.MustParse(user_uuid), Login: "alice", Role: role}
+	}
+	bob := &models.UserData{ID: uuid.MustParse(recipient_uuid), Login: "bob", PublicKey: []byte("public")}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.AddMemberRequest
+		Responce      *pb.AddMemberResponse
+	}{
+		{
+			TestName: "Success. Owner adds admin #1",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(current(models.RoleOwner), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(bob, nil)
+				mockOrgs.EXPECT().AddMember(gomock.Any(), &models.MemberData{
+					OrgID:    uuid.MustParse(org_uuid),
+					UserID:   uuid.MustParse(recipient_uuid),
+					Login:    "bob",
+					Role:     models.RoleAdmin,
+					VaultKey: []byte("key"),
+				}).DoAndReturn(func(_ context.Context, m *models.MemberData) (*models.MemberData, error) {
+					m.Created = created
+					return m, nil
+				})
+			},
+			ExpectedError: nil,
+			Request:       &pb.AddMemberRequest{OrgId: org_uuid, Login: "bob", Role: models.RoleAdmin, VaultKey: []byte("key")},
+			Responce:      &pb.AddMemberResponse{Member: &pb.Member{Login: "bob", Role: models.RoleAdmin, Created: timestamppb.New(created)}},
+		},
+		{
+			TestName: "Error. Admin adds admin #2",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(current(models.RoleAdmin), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = insufficient role"),
+			Request:       &pb.AddMemberRequest{OrgId: org_uuid, Login: "bob", Role: models.RoleAdmin, VaultKey: []byte("key")},
+			Responce:      nil,
+		},
+		{
+			TestName: "Error. Member adds member #3",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(current(models.RoleMember), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = insufficient role"),
+			Request:       &pb.AddMemberRequest{OrgId: org_uuid, Login: "bob", Role: models.RoleMember, VaultKey: []byte("key")},
+			Responce:      nil,
+		},
+		{
+			TestName: "Error. Grant owner role #4",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(current(models.RoleOwner), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid role"),
+			Request:       &pb.AddMemberRequest{OrgId: org_uuid, Login: "bob", Role: models.RoleOwner, VaultKey: []byte("key")},
+			Responce:      nil,
+		},
+		{
+			TestName: "Error. Not a member #5",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = not a member of organization"),
+			Request:       &pb.AddMemberRequest{OrgId: org_uuid, Login: "bob", Role: models.RoleMember, VaultKey: []byte("key")},
+			Responce:      nil,
+		},
+		{
+			TestName: "Error. Member already exists #6",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(current(models.RoleAdmin), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(bob, nil)
+				mockOrgs.EXPECT().AddMember(gomock.Any(), gomock.Any()).Return(nil, storage.ErrAlreadyExists)
+			},
+			ExpectedError: errors.New("rpc error: code = AlreadyExists desc = already exists"),
+			Request:       &pb.AddMemberRequest{OrgId: org_uuid, Login: "bob", Role: models.RoleReadOnly, VaultKey: []byte("key")},
+			Responce:      nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewOrganization(mockUsers, mockOrgs)
+			ctx := usercontext.SetUserId(context.Background(), uuid.MustParse(user_uuid))
+
+			resp, err := s.AddMember(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	member := func(uid string, login string, role string) *models.MemberData {
+		return &models.MemberData{OrgID: uuid.MustParse(org_uuid), UserID: uuid.MustParse(uid), Login: login, Role: role}
+	}
+	bob := &models.UserData{ID: uuid.MustParse(recipient_uuid), Login: "bob", PublicKey: []byte("public")}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.RemoveMemberRequest
+	}{
+		{
+			TestName: "Success. Admin removes member #1",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(member(user_uuid, "alice", models.RoleAdmin), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(bob, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(recipient_uuid)).Return(member(recipient_uuid, "bob", models.RoleMember), nil)
+				mockOrgs.EXPECT().DeleteMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(recipient_uuid)).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.RemoveMemberRequest{OrgId: org_uuid, Login: "bob"},
+		},
+		{
+			TestName: "Error. Admin removes owner #2",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(member(user_uuid, "alice", models.RoleAdmin), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(bob, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(recipient_uuid)).Return(member(recipient_uuid, "bob", models.RoleOwner), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = can't change organization owner"),
+			Request:       &pb.RemoveMemberRequest{OrgId: org_uuid, Login: "bob"},
+		},
+		{
+			TestName: "Success. Read-only member leaves organization #3",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleReadOnly), nil)
+				mockOrgs.EXPECT().DeleteMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.RemoveMemberRequest{OrgId: org_uuid, Login: "alice"},
+		},
+		{
+			TestName: "Error. Owner leaves organization #4",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleOwner), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = organization owner can't leave organization"),
+			Request:       &pb.RemoveMemberRequest{OrgId: org_uuid, Login: "alice"},
+		},
+		{
+			TestName: "Error. Read-only member removes member #5",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleReadOnly), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = insufficient role"),
+			Request:       &pb.RemoveMemberRequest{OrgId: org_uuid, Login: "bob"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewOrganization(mockUsers, mockOrgs)
+			ctx := usercontext.SetUserId(context.Background(), uuid.MustParse(user_uuid))
+
+			_, err := s.RemoveMember(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestRotateVaultKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	member := func(uid string, login string, role string) *models.MemberData {
+		return &models.MemberData{OrgID: uuid.MustParse(org_uuid), UserID: uuid.MustParse(uid), Login: login, Role: role}
+	}
+	alice := &models.UserData{ID: uuid.MustParse(user_uuid), Login: "alice", PublicKey: []byte("public")}
+	request := &pb.RotateVaultKeyRequest{
+		OrgId:   org_uuid,
+		Members: []*pb.MemberVaultKey{{Login: "alice", VaultKey: []byte("wrapped")}},
+		Secrets: []*pb.SecretDataKey{{Id: secret_uuid, WrappedKey: []byte("data-key"), SearchIndex: [][]byte{[]byte("token")}}},
+	}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.RotateVaultKeyRequest
+	}{
+		{
+			TestName: "Success. Admin rotates vault key #1",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(member(user_uuid, "alice", models.RoleAdmin), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "alice").Return(alice, nil)
+				mockOrgs.EXPECT().RotateKey(gomock.Any(), uuid.MustParse(org_uuid), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, members []*models.MemberData, secrets []*models.SecretData) error {
+						if len(members) != 1 || members[0].UserID != alice.ID || string(members[0].VaultKey) != "wrapped" {
+							t.Errorf("unexpected members %v", members)
+						}
+						if len(secrets) != 1 || secrets[0].ID != uuid.MustParse(secret_uuid) || string(secrets[0].WrappedKey) != "data-key" {
+							t.Errorf("unexpected secrets %v", secrets)
+						}
+						return nil
+					})
+			},
+			ExpectedError: nil,
+			Request:       request,
+		},
+		{
+			TestName: "Error. Member rotates vault key #2",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleMember), nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = insufficient role"),
+			Request:       request,
+		},
+		{
+			TestName: "Error. Members changed #3",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleOwner), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "alice").Return(alice, nil)
+				mockOrgs.EXPECT().RotateKey(gomock.Any(), uuid.MustParse(org_uuid), gomock.Any(), gomock.Any()).Return(storage.ErrConflict)
+			},
+			ExpectedError: errors.New("rpc error: code = Aborted desc = organization members or secrets changed"),
+			Request:       request,
+		},
+		{
+			TestName: "Error. Secret without data key #4",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleOwner), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "alice").Return(alice, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid wrapped key"),
+			Request: &pb.RotateVaultKeyRequest{
+				OrgId:   org_uuid,
+				Members: request.GetMembers(),
+				Secrets: []*pb.SecretDataKey{{Id: secret_uuid}},
+			},
+		},
+		{
+			TestName: "Error. Duplicate member #5",
+			SetupMocks: func() {
+				mockOrgs.EXPECT().GetMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(member(user_uuid, "alice", models.RoleOwner), nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "alice").Return(alice, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = duplicate member"),
+			Request: &pb.RotateVaultKeyRequest{
+				OrgId:   org_uuid,
+				Members: append(request.GetMembers(), request.GetMembers()...),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewOrganization(mockUsers, mockOrgs)
+			ctx := usercontext.SetUserId(context.Background(), uuid.MustParse(user_uuid))
+
+			_, err := s.RotateVaultKey(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Share - модель сервиса передачи секретов между пользователями.
+// Сервер хранит только открытые ключи, зашифрованные закрытые ключи
+// и ключи содержимого, зашифрованные для получателя, поэтому открытый текст ему недоступен.
+type Share struct {
+	pb.UnimplementedShareServer
+
+	users   storage.User
+	secrets storage.Secret
+	shares  storage.Share
+	orgs    storage.Organization
+}
+
+// NewShare - метод создания сервиса передачи секретов
+func NewShare(u storage.User, s storage.Secret, sh storage.Share, o storage.Organization) *Share {
+	return &Share{
+		users:   u,
+		secrets: s,
+		shares:  sh,
+		orgs:    o,
+	}
+}
+
+// SetKeyPair - метод сохранения пары ключей пользователя
+func (s *Share) SetKeyPair(ctx context.Context, request *pb.SetKeyPairRequest) (*pb.SetKeyPairResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(request.GetPublicKey()) == 0 || len(request.GetPrivateKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty key pair")
+	}
+	if err := s.users.SetKeys(ctx, uid, request.GetPublicKey(), request.GetPrivateKey()); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetKeyPairResponse{}, nil
+}
+
+// GetKeyPair - метод получения пары ключей пользователя
+func (s *Share) GetKeyPair(ctx context.Context, request *pb.GetKeyPairRequest) (*pb.GetKeyPairResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	u, err := s.users.GetKeys(ctx, uid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetKeyPairResponse{PublicKey: u.PublicKey, PrivateKey: u.PrivateKey}, nil
+}
+
+// GetPublicKey - метод получения открытого ключа пользователя по логину
+func (s *Share) GetPublicKey(ctx context.Context, request *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	_, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	u, err := s.users.GetPublicKey(ctx, request.GetLogin())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetPublicKeyResponse{Login: u.Login, PublicKey: u.PublicKey}, nil
+}
+
+// ShareSecret - метод передачи секрета другому пользователю по ссылке: сохраняется ключ данных
+// секрета, зашифрованный открытым ключом получателя, а получатель видит текущее содержимое секрета
+func (s *Share) ShareSecret(ctx context.Context, request *pb.ShareSecretRequest) (*pb.ShareSecretResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if len(request.GetWrappedKey()) == 0 || len(request.GetWrappedKey()) > maxWrappedKeySize {
+		return nil, status.Error(codes.InvalidArgument, "invalid wrapped key")
+	}
+	secret, err := s.ownSecret(ctx, uid, request.GetMeta().GetId())
+	if err != nil {
+		return nil, err
+	}
+	if len(secret.WrappedKey) == 0 {
+		// без собственного ключа данных секрет зашифрован ключом хранилища, который передавать нельзя
+		return nil, status.Error(codes.FailedPrecondition, "secret has no data key")
+	}
+	recipient, err := s.users.GetPublicKey(ctx, request.GetRecipient())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if recipient.ID == uid {
+		return nil, status.Error(codes.InvalidArgument, "can't share secret with yourself")
+	}
+	share, err := s.shares.Add(ctx, &models.ShareData{
+		SecretID:    secret.ID,
+		OwnerID:     uid,
+		RecipientID: recipient.ID,
+		Recipient:   recipient.Login,
+		Name:        secret.Name,
+		Type:        secret.Type,
+		WrappedKey:  request.GetWrappedKey(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ShareSecretResponse{Share: shareToProto(share, false)}, nil
+}
+
+// ListSharedWithMe - метод получения секретов, переданных пользователю
+func (s *Share) ListSharedWithMe(ctx context.Context, request *pb.ListSharedWithMeRequest) (*pb.ListSharedWithMeResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	list, err := s.shares.ListByRecipient(ctx, uid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.ListSharedWithMeResponse{}
+	for _, share := range list {
+		resp.Shares = append(resp.Shares, shareToProto(share, true))
+	}
+	return resp, nil
+}
+
+// RevokeShare - метод отзыва доступа к секрету у пользователя
+func (s *Share) RevokeShare(ctx context.Context, request *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	uid, err := usercontext.GetUserId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	secret, err := s.ownSecret(ctx, uid, request.GetMeta().GetId())
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := s.users.GetPublicKey(ctx, request.GetRecipient())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.shares.Delete(ctx, secret.ID, recipient.ID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RevokeShareResponse{Meta: request.GetMeta()}, nil
+}
+
+// RegisterService - метод регистрации сервиса
+func (s *Share) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterShareServer(r, s)
+}
+
+// ownSecret - метод получения секрета с проверкой прав пользователя: личный секрет должен
+// принадлежать ему, а секретом организации может распоряжаться только её текущий участник
+// с правом записи
+func (s *Share) ownSecret(ctx context.Context, uid uuid.UUID, id string) (*models.SecretData, error) {
+	sid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	secret, err := s.secrets.Get(ctx, sid)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := secretAccess(ctx, s.orgs, uid, secret, true); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// shareToProto - метод конвертирует модель передачи секрета в сообщение
+func shareToProto(share *models.ShareData, withContent bool) *pb.SharedSecret {
+	res := &pb.SharedSecret{
+		Id: share.ID.String(),
+		Meta: &pb.SecretMetadata{
+			Id:   share.SecretID.String(),
+			Name: share.Name,
+			Type: share.Type,
+		},
+		Owner:     share.Owner,
+		Recipient: share.Recipient,
+		Created:   timestamppb.New(share.Created),
+	}
+	if withContent {
+		res.WrappedKey = share.WrappedKey
+		res.Content = share.Content
+		res.Meta.EncryptedMeta = share.Meta
+		res.VaultOwner = share.VaultOwner
+	}
+	return res
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/storage"
+	"go-pass-keeper/internal/storage/mocks"
+	"go-pass-keeper/pkg/logger"
+	pb "go-pass-keeper/pkg/proto"
+	"go-pass-keeper/pkg/usercontext"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const recipient_uuid = "4b0e8e1c-7f43-4c55-9f61-2f3a8f0d5a11"
+const share_uuid = "9a7c2d4e-0b1f-4e3a-8c5d-6f7e8a9b0c1d"
+
+func TestShareSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockShares := mocks.NewMockShare(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+	config := config.DefaultConfig()
+
+	if err := logger.Initialize(config.LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	created := time.Date(2025, time.October, 1, 10, 30, 0, 0, time.UTC)
+	ownSecret := &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid), Name: "Big secret", Type: "password", WrappedKey: []byte("data key")}
+	recipient := &models.UserData{ID: uuid.MustParse(recipient_uuid), Login: "bob", PublicKey: []byte("public")}
+	orgSecret := &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid), OrgID: uuid.NullUUID{UUID: uuid.MustParse(org_uuid), Valid: true}, WrappedKey: []byte("data key")}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.ShareSecretRequest
+		Responce      *pb.ShareSecretResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Share secret #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), uuid.MustParse(secret_uuid)).Return(ownSecret, nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(recipient, nil)
+				mockShares.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *models.ShareData) (*models.ShareData, error) {
+					m.ID = uuid.MustParse(share_uuid)
+					m.Created = created
+					return m, nil
+				})
+			},
+			ExpectedError: nil,
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob", WrappedKey: []byte("key")},
+			Responce: &pb.ShareSecretResponse{Share: &pb.SharedSecret{
+				Id:        share_uuid,
+				Meta:      &pb.SecretMetadata{Id: secret_uuid, Name: "Big secret", Type: "password"},
+				Recipient: "bob",
+				Created:   timestamppb.New(created),
+			}},
+			UserId: uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Share foreign secret #2",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(recipient_uuid)}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = secret belongs to another user"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Share with unknown recipient #3",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(ownSecret, nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "alice").Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "alice", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Share with yourself #4",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(ownSecret, nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "me").Return(&models.UserData{ID: uuid.MustParse(user_uuid), Login: "me"}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = can't share secret with yourself"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "me", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Share empty key #5",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid wrapped key"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob"},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Share secret without data key #6",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = FailedPrecondition desc = secret has no data key"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Share secret of organization without membership #7",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(orgSecret, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(nil, storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = not a member of organization"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Share secret of organization with read-only role #8",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(orgSecret, nil)
+				mockOrgs.EXPECT().GetMember(gomock.Any(), uuid.MustParse(org_uuid), uuid.MustParse(user_uuid)).Return(&models.MemberData{OrgID: uuid.MustParse(org_uuid), Role: models.RoleReadOnly}, nil)
+			},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = insufficient role"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Share unknown user #9",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Request:       &pb.ShareSecretRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob", WrappedKey: []byte("key")},
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewShare(mockUsers, mockSecrets, mockShares, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.ShareSecret(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestListSharedWithMe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockShares := mocks.NewMockShare(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+
+	created := time.Date(2025, time.October, 1, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Responce      *pb.ListSharedWithMeResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. List shared secrets #1",
+			SetupMocks: func() {
+				mockShares.EXPECT().ListByRecipient(gomock.Any(), uuid.MustParse(recipient_uuid)).Return([]*models.ShareData{
+					{
+						ID:         uuid.MustParse(share_uuid),
+						SecretID:   uuid.MustParse(secret_uuid),
+						Owner:      "alice",
+						Recipient:  "bob",
+						Name:       "Big secret",
+						Type:       "text",
+						WrappedKey: []byte("key"),
+						Content:    []byte("0x100"),
+						Meta:       []byte("meta"),
+						Created:    created,
+						VaultOwner: user_uuid,
+					},
+				}, nil)
+			},
+			ExpectedError: nil,
+			Responce: &pb.ListSharedWithMeResponse{Shares: []*pb.SharedSecret{{
+				Id:         share_uuid,
+				Meta:       &pb.SecretMetadata{Id: secret_uuid, Name: "Big secret", Type: "text", EncryptedMeta: []byte("meta")},
+				Owner:      "alice",
+				Recipient:  "bob",
+				WrappedKey: []byte("key"),
+				Content:    []byte("0x100"),
+				Created:    timestamppb.New(created),
+				VaultOwner: user_uuid,
+			}}},
+			UserId: uuid.MustParse(recipient_uuid),
+		},
+		{
+			TestName: "Error. List shared undefined error #2",
+			SetupMocks: func() {
+				mockShares.EXPECT().ListByRecipient(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to get shares"))
+			},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to get shares"),
+			Responce:      nil,
+			UserId:        uuid.MustParse(recipient_uuid),
+		},
+		{
+			TestName:      "Error. List shared unknown user #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = unknown user"),
+			Responce:      nil,
+			UserId:        uuid.Nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewShare(mockUsers, mockSecrets, mockShares, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.ListSharedWithMe(ctx, &pb.ListSharedWithMeRequest{})
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestRevokeShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+	mockSecrets := mocks.NewMockSecret(ctrl)
+	mockShares := mocks.NewMockShare(ctrl)
+	mockOrgs := mocks.NewMockOrganization(ctrl)
+
+	ownSecret := &models.SecretData{ID: uuid.MustParse(secret_uuid), UserID: uuid.MustParse(user_uuid)}
+	recipient := &models.UserData{ID: uuid.MustParse(recipient_uuid), Login: "bob"}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		ExpectedError error
+		Request       *pb.RevokeShareRequest
+		Responce      *pb.RevokeShareResponse
+		UserId        uuid.UUID
+	}{
+		{
+			TestName: "Success. Revoke share #1",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(ownSecret, nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(recipient, nil)
+				mockShares.EXPECT().Delete(gomock.Any(), uuid.MustParse(secret_uuid), uuid.MustParse(recipient_uuid)).Return(nil)
+			},
+			ExpectedError: nil,
+			Request:       &pb.RevokeShareRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob"},
+			Responce:      &pb.RevokeShareResponse{Meta: &pb.SecretMetadata{Id: secret_uuid}},
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName: "Error. Revoke share not found #2",
+			SetupMocks: func() {
+				mockSecrets.EXPECT().Get(gomock.Any(), gomock.Any()).Return(ownSecret, nil)
+				mockUsers.EXPECT().GetPublicKey(gomock.Any(), "bob").Return(recipient, nil)
+				mockShares.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(storage.ErrNotFound)
+			},
+			ExpectedError: errors.New("rpc error: code = NotFound desc = not found"),
+			Request:       &pb.RevokeShareRequest{Meta: &pb.SecretMetadata{Id: secret_uuid}, Recipient: "bob"},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+		{
+			TestName:      "Error. Revoke share invalid id #3",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid UUID length: 3"),
+			Request:       &pb.RevokeShareRequest{Meta: &pb.SecretMetadata{Id: "bad"}, Recipient: "bob"},
+			Responce:      nil,
+			UserId:        uuid.MustParse(user_uuid),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			s := NewShare(mockUsers, mockSecrets, mockShares, mockOrgs)
+
+			ctx := context.Background()
+			if tc.UserId != uuid.Nil {
+				ctx = usercontext.SetUserId(ctx, tc.UserId)
+			}
+
+			resp, err := s.RevokeShare(ctx, tc.Request)
+
+			if err != nil && tc.ExpectedError == nil {
+				t.Errorf("Expected no error, got: '%v'", err)
+			} else if err == nil && tc.ExpectedError != nil {
+				t.Errorf("Expected error, got none")
+			} else if err != nil && err.Error() != tc.ExpectedError.Error() {
+				t.Errorf("Expected error: '%v', got: '%v'", tc.ExpectedError, err)
+			}
+			if resp.String() != tc.Responce.String() {
+				t.Errorf("Expected responce %v, got %v", tc.Responce.String(), resp.String())
+			}
+		})
+	}
+}
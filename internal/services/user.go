@@ -6,34 +6,166 @@ import (
 	"go-pass-keeper/internal/storage"
 	"go-pass-keeper/pkg/crypto"
 	pb "go-pass-keeper/pkg/proto"
+	"net"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 // tokenBuilder интефрейс для работы с токеном
 type tokenBuilder interface {
-	// BuildJWT - создание токена с ID пользователя
-	BuildJWT(userID string) (string, error)
+	// BuildJWT - создание токена с ID пользователя и поколением его токенов
+	BuildJWT(userID string, generation int64) (string, error)
 }
 
+// loginSessionTTL - время, за которое нужно завершить вход по SRP
+const loginSessionTTL = time.Minute
+
+// loginAttemptsPerPeer - максимальное количество входов по SRP, начатых с одного адреса за loginSessionTTL
+const loginAttemptsPerPeer = 30
+
+// maxSRPValueSize - максимальный размер соли и проверочного значения пароля SRP
+const maxSRPValueSize = 512
+
 // User - модель сервиса пользователей
 type User struct {
 	pb.UnimplementedUserServer
 
-	users storage.User
-	token tokenBuilder
+	users    storage.User
+	token    tokenBuilder
+	sessions *loginSessions
+	seed     []byte // секрет сервера для ответов на вход по SRP без проверочного значения
 }
 
 // NewUser - метод создания сервиса работы с пользователями
-func NewUser(u storage.User, th tokenBuilder) *User {
+// (seed - секрет сервера, из которого получаются ответы на вход по неизвестному логину)
+func NewUser(u storage.User, th tokenBuilder, seed []byte) *User {
 	return &User{
-		users: u,
-		token: th,
+		users:    u,
+		token:    th,
+		sessions: newLoginSessions(),
+		seed:     seed,
+	}
+}
+
+// loginSession - незавершённый вход по SRP
+type loginSession struct {
+	login   string
+	server  *crypto.SRPServer
+	user    *models.UserData // nil - логина нет или у учётной записи нет проверочного значения
+	expires time.Time
+}
+
+// peerAttempts - количество входов, начатых с одного адреса с момента since
+type peerAttempts struct {
+	count int
+	since time.Time
+}
+
+// loginSessions - незавершённые входы по SRP (хранятся в памяти экземпляра сервера,
+// поэтому оба шага входа должны выполняться на одном экземпляре). Для каждого логина
+// хранится только последний начатый вход, а количество входов с одного адреса ограничено.
+type loginSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*loginSession // входы по идентификатору
+	logins   map[string]string        // идентификатор последнего входа по логину
+	peers    map[string]*peerAttempts // начатые входы по адресу клиента
+}
+
+// newLoginSessions - метод создания списка незавершённых входов
+func newLoginSessions() *loginSessions {
+	return &loginSessions{
+		sessions: make(map[string]*loginSession),
+		logins:   make(map[string]string),
+		peers:    make(map[string]*peerAttempts),
+	}
+}
+
+// allow - метод учитывает вход, начатый с адреса peer, и отказывает, если с этого адреса
+// за loginSessionTTL начато больше loginAttemptsPerPeer входов
+func (l *loginSessions) allow(peer string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	attempts, ok := l.peers[peer]
+	if !ok || now.Sub(attempts.since) >= loginSessionTTL {
+		attempts = &peerAttempts{since: now}
+		l.peers[peer] = attempts
+	}
+	if attempts.count >= loginAttemptsPerPeer {
+		return status.Error(codes.ResourceExhausted, "too many login attempts")
+	}
+	attempts.count++
+	return nil
+}
+
+// add - метод сохраняет вход и возвращает его идентификатор: незавершённый вход по тому же логину
+// заменяется новым (просроченные входы и счётчики адресов удаляются)
+func (l *loginSessions) add(session *loginSession) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for id, s := range l.sessions {
+		if now.After(s.expires) {
+			l.remove(id, s)
+		}
+	}
+	for peer, attempts := range l.peers {
+		if now.Sub(attempts.since) >= loginSessionTTL {
+			delete(l.peers, peer)
+		}
+	}
+	if prev, ok := l.logins[session.login]; ok {
+		delete(l.sessions, prev)
+	}
+	id := uuid.NewString()
+	l.sessions[id] = session
+	l.logins[session.login] = id
+	return id
+}
+
+// take - метод извлекает вход по идентификатору (каждый вход завершается не более одного раза)
+func (l *loginSessions) take(id string) (*loginSession, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	session, ok := l.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	l.remove(id, session)
+	if time.Now().After(session.expires) {
+		return nil, false
+	}
+	return session, true
+}
+
+// remove - метод удаляет вход (вызывается под блокировкой)
+func (l *loginSessions) remove(id string, session *loginSession) {
+	delete(l.sessions, id)
+	if l.logins[session.login] == id {
+		delete(l.logins, session.login)
 	}
 }
 
+// peerAddress - метод возвращает адрес клиента без порта (пусто - адрес неизвестен)
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
 // Register - метод обработки запроса регистрации пользователя
 func (s User) Register(ctx context.Context, request *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 
@@ -41,7 +173,16 @@ func (s User) Register(ctx context.Context, request *pb.RegisterRequest) (*pb.Re
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	uid, err := s.users.Add(ctx, &models.UserData{Login: request.GetLogin(), Password: request.GetPassword(), Salt: salt})
+	kdf := crypto.DefaultKDFParams
+	user := &models.UserData{Login: request.GetLogin(), Salt: salt, KDF: kdf, SRPSalt: request.GetSrpSalt(), Verifier: request.GetSrpVerifier()}
+	// пароль серверу не передаётся: учётная запись создаётся только с проверочным значением SRP
+	if len(user.Verifier) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "password verifier is required")
+	}
+	if err := validVerifier(user.SRPSalt, user.Verifier); err != nil {
+		return nil, err
+	}
+	uid, err := s.users.Add(ctx, user)
 	switch err {
 	case nil:
 	case storage.ErrAlreadyExists:
@@ -49,16 +190,24 @@ func (s User) Register(ctx context.Context, request *pb.RegisterRequest) (*pb.Re
 	default:
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	t, err := s.token.BuildJWT(uid.String())
+	t, err := s.token.BuildJWT(uid.String(), 0)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.RegisterResponse{Token: t, Salt: salt}, nil
+	return &pb.RegisterResponse{Token: t, Salt: salt, UserId: uid.String(), Kdf: models.KDFToProto(kdf)}, nil
 }
 
-// Login - метод обработки запроса автооризации пользователя
+// Login - метод обработки однократного переноса учётной записи, созданной до появления SRP: пароль
+// проверяется по хешу, а переданное проверочное значение сохраняется вместо хеша, поэтому следующие
+// входы выполняются только по SRP. Учётные записи с проверочным значением так войти не могут.
 func (s User) Login(ctx context.Context, request *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if len(request.GetSrpVerifier()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "password verifier is required")
+	}
+	if err := validVerifier(request.GetSrpSalt(), request.GetSrpVerifier()); err != nil {
+		return nil, err
+	}
 	u, err := s.users.Get(ctx, request.GetLogin(), request.GetPassword())
 	switch err {
 	case nil:
@@ -67,11 +216,90 @@ func (s User) Login(ctx context.Context, request *pb.LoginRequest) (*pb.LoginRes
 	default:
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	t, err := s.token.BuildJWT(u.ID.String())
+	if u.Disabled {
+		return nil, status.Error(codes.PermissionDenied, "account disabled")
+	}
+	if err := s.users.SetVerifier(ctx, u.ID, request.GetSrpSalt(), request.GetSrpVerifier()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s.loginResponse(u, nil)
+}
+
+// LoginStart - метод обработки первого шага входа по SRP: по открытому значению клиента возвращает
+// соль проверочного значения и открытое значение сервера. Для неизвестного логина и для учётной записи
+// без проверочного значения возвращаются соль и открытое значение поддельного проверочного значения,
+// постоянные для логина (см. crypto.SRPFakeVerifier), а вход завершается так же, как с неверным паролем,
+// чтобы по ответам нельзя было проверить существование логина.
+func (s User) LoginStart(ctx context.Context, request *pb.LoginStartRequest) (*pb.LoginStartResponse, error) {
+	if err := s.sessions.allow(peerAddress(ctx)); err != nil {
+		return nil, err
+	}
+	u, err := s.users.GetByLogin(ctx, request.GetLogin())
+	switch err {
+	case nil:
+	case storage.ErrNotFound:
+		u = nil
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var salt, verifier []byte
+	if u != nil && len(u.Verifier) != 0 {
+		salt, verifier = u.SRPSalt, u.Verifier
+	} else {
+		u = nil
+		salt, verifier = crypto.SRPFakeVerifier(s.seed, request.GetLogin())
+	}
+	server, err := crypto.NewSRPServer(verifier, request.GetPublic())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id := s.sessions.add(&loginSession{login: request.GetLogin(), server: server, user: u, expires: time.Now().Add(loginSessionTTL)})
+	return &pb.LoginStartResponse{SessionId: id, SrpSalt: salt, Public: server.Public()}, nil
+}
+
+// LoginFinish - метод обработки второго шага входа по SRP: проверяет подтверждение клиента
+// и возвращает токен вместе с подтверждением сервера
+func (s User) LoginFinish(ctx context.Context, request *pb.LoginFinishRequest) (*pb.LoginResponse, error) {
+	session, ok := s.sessions.take(request.GetSessionId())
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "login session not found")
+	}
+	proof, err := session.server.Verify(request.GetProof())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if session.user == nil {
+		return nil, status.Error(codes.Unauthenticated, crypto.ErrSRPAuth.Error())
+	}
+	if session.user.Disabled {
+		return nil, status.Error(codes.PermissionDenied, "account disabled")
+	}
+	return s.loginResponse(session.user, proof)
+}
+
+// loginResponse - метод формирует ответ на успешный вход
+func (s User) loginResponse(u *models.UserData, proof []byte) (*pb.LoginResponse, error) {
+	t, err := s.token.BuildJWT(u.ID.String(), u.Generation)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.LoginResponse{Token: t, Salt: u.Salt}, nil
+	return &pb.LoginResponse{
+		Token:       t,
+		Salt:        u.Salt,
+		UserId:      u.ID.String(),
+		Kdf:         models.KDFToProto(u.KDF),
+		WrappedKey:  u.WrappedKey,
+		ServerProof: proof,
+		KeyCheck:    u.KeyCheck,
+	}, nil
+}
+
+// validVerifier - метод проверяет размер соли и проверочного значения пароля SRP
+func validVerifier(salt []byte, verifier []byte) error {
+	if len(salt) == 0 || len(salt) > maxSRPValueSize || len(verifier) > maxSRPValueSize {
+		return status.Error(codes.InvalidArgument, "invalid password verifier")
+	}
+	return nil
 }
 
 // RegisterService - метод регистрации сервиса
@@ -79,6 +307,11 @@ func (s *User) RegisterService(r grpc.ServiceRegistrar) {
 	pb.RegisterUserServer(r, s)
 }
 
+// RegisterGateway - метод регистрации обработчиков REST/JSON шлюза
+func (s *User) RegisterGateway(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error {
+	return pb.RegisterUserHandlerFromEndpoint(ctx, mux, endpoint, opts)
+}
+
 // AuthFuncOverride - метод для кастомной обработки метода авторизации (использую для исключений проверки авторизации по токену)
 func (s *User) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
 	return ctx, nil
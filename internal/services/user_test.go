@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"go-pass-keeper/internal/grpcserver/config"
 	"go-pass-keeper/internal/models"
 	"go-pass-keeper/internal/storage"
 	"go-pass-keeper/internal/token"
+	"go-pass-keeper/pkg/crypto"
 	"go-pass-keeper/pkg/logger"
 	pb "go-pass-keeper/pkg/proto"
+	"net"
 	"testing"
 	"time"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/peer"
 )
 
 func TestNewUser(t *testing.T) {
@@ -33,7 +37,7 @@ func TestNewUser(t *testing.T) {
 			logger.Error("Error token handler", err.Error())
 		}
 
-		u := NewUser(mockUsers, th)
+		u := NewUser(mockUsers, th, testSeed)
 		if u == nil || th == nil {
 			t.Errorf("Expected Users to be initialized with Token handler")
 		}
@@ -45,6 +49,9 @@ func TestNewUser(t *testing.T) {
 
 const uid = "0789b8d9-cef8-4837-be99-ec36fbf5c536"
 
+// testSeed - секрет сервера для ответов на вход по неизвестному логину
+var testSeed = []byte("seed")
+
 func TestRegisterUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -73,7 +80,7 @@ func TestRegisterUser(t *testing.T) {
 				mockUsers.EXPECT().Add(gomock.Any(), gomock.Any()).Return(uuid.MustParse(uid), nil)
 			},
 			ExpectedError: nil,
-			User:          &pb.RegisterRequest{Login: "mda", Password: "test_pass"},
+			User:          &pb.RegisterRequest{Login: "mda", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
 			UserID:        uid,
 		},
 		{
@@ -82,7 +89,7 @@ func TestRegisterUser(t *testing.T) {
 				mockUsers.EXPECT().Add(gomock.Any(), gomock.Any()).Return(uuid.Nil, storage.ErrAlreadyExists)
 			},
 			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = already exists"),
-			User:          &pb.RegisterRequest{Login: "mda", Password: "test_pass"},
+			User:          &pb.RegisterRequest{Login: "mda", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
 		},
 		{
 			TestName: "Error. Register user undefined error #3",
@@ -90,7 +97,30 @@ func TestRegisterUser(t *testing.T) {
 				mockUsers.EXPECT().Add(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("failed to add user"))
 			},
 			ExpectedError: errors.New("rpc error: code = Internal desc = failed to add user"),
-			User:          &pb.RegisterRequest{Login: "mda", Password: "test_pass"},
+			User:          &pb.RegisterRequest{Login: "mda", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
+		},
+		{
+			TestName: "Success. Register user with password verifier #4",
+			SetupMocks: func() {
+				mockUsers.EXPECT().Add(gomock.Any(), gomock.Cond(func(u *models.UserData) bool {
+					return u.Password == "" && string(u.SRPSalt) == "salt" && string(u.Verifier) == "verifier"
+				})).Return(uuid.MustParse(uid), nil)
+			},
+			ExpectedError: nil,
+			User:          &pb.RegisterRequest{Login: "mda", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
+			UserID:        uid,
+		},
+		{
+			TestName:      "Error. Register user without password #5",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = password verifier is required"),
+			User:          &pb.RegisterRequest{Login: "mda"},
+		},
+		{
+			TestName:      "Error. Register user verifier without salt #6",
+			SetupMocks:    func() {},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = invalid password verifier"),
+			User:          &pb.RegisterRequest{Login: "mda", SrpVerifier: []byte("verifier")},
 		},
 	}
 
@@ -98,7 +128,7 @@ func TestRegisterUser(t *testing.T) {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			u := NewUser(mockUsers, th)
+			u := NewUser(mockUsers, th, testSeed)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			defer cancel()
@@ -118,6 +148,7 @@ func TestRegisterUser(t *testing.T) {
 				require.NoError(t, err, "invalid claims")
 
 				assert.Equal(t, tc.UserID, claims.Id, "user ID in claims doesn't match")
+				assert.Equal(t, tc.UserID, resp.GetUserId(), "user ID in response doesn't match")
 			}
 		})
 	}
@@ -145,13 +176,15 @@ func TestLoginUser(t *testing.T) {
 		User          *pb.LoginRequest
 		ExpectedError error
 		UserID        string
+		Generation    int64
 	}{
 		{
 			TestName: "AuthenticateUser Success #1",
 			SetupMocks: func() {
 				mockUsers.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.UserData{ID: uuid.MustParse(uid), Login: "mda"}, nil)
+				mockUsers.EXPECT().SetVerifier(gomock.Any(), uuid.MustParse(uid), []byte("salt"), []byte("verifier")).Return(nil)
 			},
-			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass"},
+			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
 			ExpectedError: nil,
 			UserID:        uid,
 		},
@@ -160,7 +193,7 @@ func TestLoginUser(t *testing.T) {
 			SetupMocks: func() {
 				mockUsers.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound)
 			},
-			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass"},
+			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
 			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = not found"),
 		},
 		{
@@ -168,16 +201,50 @@ func TestLoginUser(t *testing.T) {
 			SetupMocks: func() {
 				mockUsers.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to add user"))
 			},
-			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass"},
+			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
 			ExpectedError: errors.New("rpc error: code = Internal desc = failed to add user"),
 		},
+		{
+			TestName: "AuthenticateUser Disabled #4",
+			SetupMocks: func() {
+				mockUsers.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.UserData{ID: uuid.MustParse(uid), Login: "mda", Disabled: true}, nil)
+			},
+			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = account disabled"),
+		},
+		{
+			TestName: "AuthenticateUser token generation #5",
+			SetupMocks: func() {
+				mockUsers.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.UserData{ID: uuid.MustParse(uid), Login: "root", Generation: 3}, nil)
+				mockUsers.EXPECT().SetVerifier(gomock.Any(), uuid.MustParse(uid), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			User:          &pb.LoginRequest{Login: "root", Password: "test_pass", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
+			ExpectedError: nil,
+			UserID:        uid,
+			Generation:    3,
+		},
+		{
+			TestName:      "AuthenticateUser without password verifier #6",
+			SetupMocks:    func() {},
+			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass"},
+			ExpectedError: errors.New("rpc error: code = InvalidArgument desc = password verifier is required"),
+		},
+		{
+			TestName: "AuthenticateUser migrate to SRP error #7",
+			SetupMocks: func() {
+				mockUsers.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.UserData{ID: uuid.MustParse(uid), Login: "mda"}, nil)
+				mockUsers.EXPECT().SetVerifier(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to set user verifier"))
+			},
+			User:          &pb.LoginRequest{Login: "mda", Password: "test_pass", SrpSalt: []byte("salt"), SrpVerifier: []byte("verifier")},
+			ExpectedError: errors.New("rpc error: code = Internal desc = failed to set user verifier"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.TestName, func(t *testing.T) {
 			tc.SetupMocks()
 
-			u := NewUser(mockUsers, th)
+			u := NewUser(mockUsers, th, testSeed)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			defer cancel()
@@ -197,7 +264,171 @@ func TestLoginUser(t *testing.T) {
 				require.NoError(t, err, "invalid claims")
 
 				assert.Equal(t, tc.UserID, claims.Id, "user ID in claims doesn't match")
+				assert.Equal(t, tc.UserID, resp.GetUserId(), "user ID in response doesn't match")
+				assert.Equal(t, tc.Generation, claims.Generation, "token generation doesn't match")
+			}
+		})
+	}
+}
+
+func TestLoginSRP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsers := mocks.NewMockUser(ctrl)
+
+	config := config.DefaultConfig()
+
+	th, err := token.NewJWT(config.JWTSecret)
+	if err != nil {
+		logger.Error("Error token handler", err.Error())
+	}
+
+	srpSalt, verifier, err := crypto.SRPVerifier("test_pass")
+	require.NoError(t, err, "SRPVerifier failed")
+	user := &models.UserData{ID: uuid.MustParse(uid), Login: "mda", SRPSalt: srpSalt, Verifier: verifier}
+
+	testCases := []struct {
+		TestName      string
+		SetupMocks    func()
+		Password      string
+		ExpectedError error
+		// ошибка первого шага (второй шаг не выполняется)
+		ExpectedStartError error
+	}{
+		{
+			TestName: "Success. Login by SRP #1",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(user, nil)
+			},
+			Password: "test_pass",
+		},
+		{
+			TestName: "Error. Wrong password #2",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(user, nil)
+			},
+			Password:      "wrong",
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = srp authentication failed: client proof mismatch"),
+		},
+		{
+			TestName: "Error. Disabled #3",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(&models.UserData{ID: user.ID, Login: "mda", SRPSalt: srpSalt, Verifier: verifier, Disabled: true}, nil)
+			},
+			Password:      "test_pass",
+			ExpectedError: errors.New("rpc error: code = PermissionDenied desc = account disabled"),
+		},
+		{
+			TestName: "Error. Unknown user #4",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(nil, storage.ErrNotFound)
+			},
+			Password:      "test_pass",
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = srp authentication failed: client proof mismatch"),
+		},
+		{
+			TestName: "Error. Verifier is not set #5",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(&models.UserData{ID: user.ID, Login: "mda"}, nil)
+			},
+			Password:      "test_pass",
+			ExpectedError: errors.New("rpc error: code = Unauthenticated desc = srp authentication failed: client proof mismatch"),
+		},
+		{
+			TestName: "Error. Storage error #6",
+			SetupMocks: func() {
+				mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(nil, errors.New("failed to get user"))
+			},
+			Password:           "test_pass",
+			ExpectedStartError: errors.New("rpc error: code = Internal desc = failed to get user"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			tc.SetupMocks()
+
+			u := NewUser(mockUsers, th, testSeed)
+			ctx := context.Background()
+
+			client, err := crypto.NewSRPClient(tc.Password)
+			require.NoError(t, err, "NewSRPClient failed")
+			start, err := u.LoginStart(ctx, &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+			if tc.ExpectedStartError != nil {
+				require.EqualError(t, err, tc.ExpectedStartError.Error())
+				return
 			}
+			require.NoError(t, err, "LoginStart failed")
+			proof, err := client.Proof(start.GetSrpSalt(), start.GetPublic())
+			require.NoError(t, err, "Proof failed")
+
+			resp, err := u.LoginFinish(ctx, &pb.LoginFinishRequest{SessionId: start.GetSessionId(), Proof: proof})
+			if tc.ExpectedError != nil {
+				require.EqualError(t, err, tc.ExpectedError.Error())
+				return
+			}
+			require.NoError(t, err, "LoginFinish failed")
+			require.NoError(t, client.VerifyServer(resp.GetServerProof()))
+			assert.Equal(t, uid, resp.GetUserId())
+
+			// вход завершается только один раз
+			_, err = u.LoginFinish(ctx, &pb.LoginFinishRequest{SessionId: start.GetSessionId(), Proof: proof})
+			require.EqualError(t, err, "rpc error: code = Unauthenticated desc = login session not found")
 		})
 	}
+
+	t.Run("Unknown user salt is stable #7", func(t *testing.T) {
+		mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(nil, storage.ErrNotFound).Times(2)
+		mockUsers.EXPECT().GetByLogin(gomock.Any(), "other").Return(nil, storage.ErrNotFound)
+
+		u := NewUser(mockUsers, th, testSeed)
+		client, err := crypto.NewSRPClient("test_pass")
+		require.NoError(t, err, "NewSRPClient failed")
+		first, err := u.LoginStart(context.Background(), &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+		require.NoError(t, err, "LoginStart failed")
+		second, err := u.LoginStart(context.Background(), &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+		require.NoError(t, err, "LoginStart failed")
+		assert.Equal(t, first.GetSrpSalt(), second.GetSrpSalt())
+		other, err := u.LoginStart(context.Background(), &pb.LoginStartRequest{Login: "other", Public: client.Public()})
+		require.NoError(t, err, "LoginStart failed")
+		assert.NotEqual(t, first.GetSrpSalt(), other.GetSrpSalt())
+	})
+
+	t.Run("New login session replaces previous #8", func(t *testing.T) {
+		mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(user, nil).Times(2)
+
+		u := NewUser(mockUsers, th, testSeed)
+		client, err := crypto.NewSRPClient("test_pass")
+		require.NoError(t, err, "NewSRPClient failed")
+		first, err := u.LoginStart(context.Background(), &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+		require.NoError(t, err, "LoginStart failed")
+		_, err = u.LoginStart(context.Background(), &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+		require.NoError(t, err, "LoginStart failed")
+
+		proof, err := client.Proof(first.GetSrpSalt(), first.GetPublic())
+		require.NoError(t, err, "Proof failed")
+		_, err = u.LoginFinish(context.Background(), &pb.LoginFinishRequest{SessionId: first.GetSessionId(), Proof: proof})
+		require.EqualError(t, err, "rpc error: code = Unauthenticated desc = login session not found")
+	})
+
+	t.Run("Too many login attempts from peer #9", func(t *testing.T) {
+		mockUsers.EXPECT().GetByLogin(gomock.Any(), gomock.Any()).Return(nil, storage.ErrNotFound).Times(loginAttemptsPerPeer)
+
+		u := NewUser(mockUsers, th, testSeed)
+		client, err := crypto.NewSRPClient("test_pass")
+		require.NoError(t, err, "NewSRPClient failed")
+		attacker := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 5000}})
+		for i := range loginAttemptsPerPeer {
+			_, err := u.LoginStart(attacker, &pb.LoginStartRequest{Login: fmt.Sprintf("user-%d", i), Public: client.Public()})
+			require.NoError(t, err, "LoginStart failed")
+		}
+		_, err = u.LoginStart(attacker, &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+		require.EqualError(t, err, "rpc error: code = ResourceExhausted desc = too many login attempts")
+
+		// входы с других адресов не ограничиваются
+		mockUsers.EXPECT().GetByLogin(gomock.Any(), "mda").Return(user, nil)
+		other := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 5000}})
+		_, err = u.LoginStart(other, &pb.LoginStartRequest{Login: "mda", Public: client.Public()})
+		require.NoError(t, err, "LoginStart failed")
+	})
 }
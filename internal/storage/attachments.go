@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AttachmentStorage - хранилище вложений секретов
+type AttachmentStorage struct {
+	db      *Database      // указатель на базу данных
+	secrets *SecretStorage // хранилище секретов (шифрование на сервере)
+}
+
+// NewAttachmentStorage - метод создаёт подключение к таблице вложений.
+// Название и содержимое вложений шифруются на сервере так же, как у секретов
+// (поверх шифрования на клиенте).
+func NewAttachmentStorage(db *Database, secrets *SecretStorage) *AttachmentStorage {
+	return &AttachmentStorage{db: db, secrets: secrets}
+}
+
+// Add - метод добавляет вложение к секрету
+func (s *AttachmentStorage) Add(ctx context.Context, attachment *models.AttachmentData) (*models.AttachmentData, error) {
+	const query = `
+		INSERT INTO attachments (id, secret_id, name, size, content, key_id, data_key, meta)
+		VALUES (COALESCE($1, uuid_generate_v4()), $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+`
+	env, err := s.secrets.seal(ctx, attachment.Name, attachment.Content)
+	if err != nil {
+		return nil, err
+	}
+	m := &models.AttachmentData{SecretID: attachment.SecretID, Name: attachment.Name, Size: attachment.Size, Meta: attachment.Meta}
+	err = s.db.Pool.QueryRow(ctx, query, nullID(attachment.ID), attachment.SecretID, env.name, attachment.Size, env.content,
+		env.keyID, env.dataKey, attachment.Meta).Scan(&m.ID, &m.Created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+	return m, nil
+}
+
+// Get - метод возвращает вложение с содержимым
+func (s *AttachmentStorage) Get(ctx context.Context, aid uuid.UUID) (*models.AttachmentData, error) {
+	const query = `
+		SELECT id, secret_id, name, size, content, created_at, key_id, data_key, meta FROM attachments
+		WHERE id = $1;
+`
+	var (
+		keyID   sql.NullString
+		dataKey []byte
+	)
+	m := &models.AttachmentData{}
+	err := s.db.Pool.QueryRow(ctx, query, aid).
+		Scan(&m.ID, &m.SecretID, &m.Name, &m.Size, &m.Content, &m.Created, &keyID, &dataKey, &m.Meta)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	if err := s.secrets.unseal(ctx, m.ID, &m.Name, &m.Content, keyID, dataKey); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// List - метод возвращает список вложений секрета (без содержимого)
+func (s *AttachmentStorage) List(ctx context.Context, sid uuid.UUID) ([]*models.AttachmentData, error) {
+	const query = `
+		SELECT id, secret_id, name, size, created_at, key_id, data_key, meta FROM attachments
+		WHERE secret_id = $1 ORDER BY created_at
+`
+	rows, err := s.db.Pool.Query(ctx, query, sid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.AttachmentData, 0)
+	for rows.Next() {
+		var (
+			keyID   sql.NullString
+			dataKey []byte
+		)
+		m := &models.AttachmentData{}
+		if err := rows.Scan(&m.ID, &m.SecretID, &m.Name, &m.Size, &m.Created, &keyID, &dataKey, &m.Meta); err != nil {
+			return nil, fmt.Errorf("failed scan attachment: %w", err)
+		}
+		if err := s.secrets.unseal(ctx, m.ID, &m.Name, &m.Content, keyID, dataKey); err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return res, nil
+}
+
+// Delete - метод удаляет вложение
+func (s *AttachmentStorage) Delete(ctx context.Context, aid uuid.UUID) error {
+	const query = `
+		DELETE FROM attachments
+		WHERE id = $1
+`
+	res, err := s.db.Pool.Exec(ctx, query, aid)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
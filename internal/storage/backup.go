@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// BackupStorage - хранилище для выгрузки и восстановления отдельных учётных записей
+type BackupStorage struct {
+	db      *Database      // указатель на базу данных
+	secrets *SecretStorage // хранилище секретов (шифрование на сервере)
+}
+
+// NewBackupStorage - метод создаёт хранилище резервных копий учётных записей
+func NewBackupStorage(db *Database, secrets *SecretStorage) *BackupStorage {
+	return &BackupStorage{db: db, secrets: secrets}
+}
+
+// Export - метод выгружает учётную запись (с хешем или проверочным значением пароля) и секреты личного хранилища
+// вместе со слепым индексом и вложениями. Секреты передаются в функцию fn по одному, расшифрованными
+// от шифрования на сервере.
+func (s *BackupStorage) Export(ctx context.Context, login string, fn func(*models.SecretBackup) error) (*models.UserData, error) {
+	const (
+		userQuery = `
+		SELECT id, login, COALESCE(password, ''), salt, public_key, private_key, created_at,
+		       kdf_algorithm, kdf_memory, kdf_iterations, kdf_parallelism, wrapped_key, srp_salt, srp_verifier,
+		       key_check, recovery_key, escrow_key, vault_root, vault_root_seq
+		FROM users
+		WHERE login = $1;
+`
+		secretsQuery = `
+		SELECT id FROM secrets
+		WHERE user_id = $1 AND org_id IS NULL ORDER BY created_at
+`
+	)
+	tx, err := s.db.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var salt sql.NullString
+	user := &models.UserData{}
+	err = tx.QueryRow(ctx, userQuery, login).
+		Scan(&user.ID, &user.Login, &user.Password, &salt, &user.PublicKey, &user.PrivateKey, &user.Created,
+			&user.KDF.Algorithm, &user.KDF.Memory, &user.KDF.Iterations, &user.KDF.Parallelism, &user.WrappedKey,
+			&user.SRPSalt, &user.Verifier, &user.KeyCheck, &user.RecoveryKey, &user.EscrowKey, &user.VaultRoot, &user.VaultSeq)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	user.Salt = salt.String
+
+	// секреты выгружаются по одному, чтобы не держать в памяти всё хранилище с вложениями
+	rows, err := tx.Query(ctx, secretsQuery, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets: %w", err)
+	}
+	for _, sid := range ids {
+		backup, err := s.exportSecret(ctx, tx, sid)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(backup); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// exportSecret - метод читает секрет, его слепой индекс и вложения в транзакции выгрузки
+func (s *BackupStorage) exportSecret(ctx context.Context, tx pgx.Tx, sid uuid.UUID) (*models.SecretBackup, error) {
+	const (
+		secretQuery = `
+		SELECT id, user_id, type_secret, name, content, created_at, updated_at, key_id, data_key,
+		       expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision
+		FROM secrets
+		WHERE id = $1
+`
+		indexQuery = `
+		SELECT token FROM secret_index
+		WHERE secret_id = $1 ORDER BY token
+`
+		attachmentsQuery = `
+		SELECT id, secret_id, name, size, content, created_at, key_id, data_key, meta FROM attachments
+		WHERE secret_id = $1 ORDER BY created_at
+`
+	)
+	var (
+		keyID   sql.NullString
+		dataKey []byte
+	)
+	m := &models.SecretData{}
+	if err := tx.QueryRow(ctx, secretQuery, sid).Scan(&m.ID, &m.UserID, &m.Type, &m.Name, &m.Content, &m.Created, &m.Updated,
+		&keyID, &dataKey, &m.Expires, &m.ExpirePolicy, &m.Archived, &m.Meta, &m.WrappedKey, &m.Revision); err != nil {
+		return nil, fmt.Errorf("failed scan secret data: %w", err)
+	}
+	if err := s.secrets.open(ctx, m, keyID, dataKey); err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, indexQuery, sid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret index: %w", err)
+	}
+	if m.SearchIndex, err = pgx.CollectRows(rows, pgx.RowTo[[]byte]); err != nil {
+		return nil, fmt.Errorf("failed to get secret index: %w", err)
+	}
+	backup := &models.SecretBackup{Secret: m}
+	rows, err = tx.Query(ctx, attachmentsQuery, sid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a := &models.AttachmentData{}
+		if err := rows.Scan(&a.ID, &a.SecretID, &a.Name, &a.Size, &a.Content, &a.Created, &keyID, &dataKey, &a.Meta); err != nil {
+			return nil, fmt.Errorf("failed scan attachment data: %w", err)
+		}
+		if err := s.secrets.unseal(ctx, a.ID, &a.Name, &a.Content, keyID, dataKey); err != nil {
+			return nil, err
+		}
+		backup.Attachments = append(backup.Attachments, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return backup, nil
+}
+
+// Import - метод восстанавливает учётную запись (хеш и проверочное значение пароля сохраняются как есть),
+// секреты её личного хранилища, их слепой индекс и вложения в одной транзакции. Идентификаторы из архива
+// сохраняются, так как содержимое секретов привязано к ним при шифровании на клиенте; для старых архивов
+// они создаются заново. Поэтому учётную запись нельзя восстановить под другим логином, пока на сервере
+// есть исходная: в этом случае возвращается ErrConflict.
+func (s *BackupStorage) Import(ctx context.Context, user *models.UserData, secrets []*models.SecretBackup) (uuid.UUID, error) {
+	const (
+		existsQuery = `
+		SELECT login FROM users
+		WHERE id = $1
+`
+		userQuery = `
+		INSERT INTO users (id, login, password, salt, public_key, private_key, created_at,
+		                   kdf_algorithm, kdf_memory, kdf_iterations, kdf_parallelism, wrapped_key, srp_salt, srp_verifier,
+		                   key_check, recovery_key, escrow_key, vault_root, vault_root_seq)
+		VALUES (COALESCE($1, uuid_generate_v4()), $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING id
+`
+		secretQuery = `
+		INSERT INTO secrets (id, user_id, type_secret, name, content, created_at, updated_at, key_id, data_key,
+		                     expires_at, expire_policy, archived_at, meta, wrapped_key, revision, content_hash)
+		VALUES (COALESCE($1, uuid_generate_v4()), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+		        CASE WHEN $12::boolean THEN NOW() END, $13, $14, GREATEST($15, 1), $16)
+		RETURNING id
+`
+		indexQuery = `
+		INSERT INTO secret_index (secret_id, token)
+		SELECT $1, token FROM UNNEST($2::bytea[]) AS token
+		ON CONFLICT DO NOTHING
+`
+		attachmentQuery = `
+		INSERT INTO attachments (id, secret_id, name, size, content, key_id, data_key, meta, created_at)
+		VALUES (COALESCE($1, uuid_generate_v4()), $2, $3, $4, $5, $6, $7, $8, $9)
+`
+	)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if user.ID != uuid.Nil {
+		var login string
+		err := tx.QueryRow(ctx, existsQuery, user.ID).Scan(&login)
+		if err == nil {
+			return uuid.Nil, fmt.Errorf("%w: archived account is registered on this server as %q, "+
+				"its data is bound to the account id and can be restored only after that account is deleted", ErrConflict, login)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("failed to check user: %w", err)
+		}
+	}
+
+	var uid uuid.UUID
+	err = tx.QueryRow(ctx, userQuery, nullID(user.ID), user.Login, user.Password, user.Salt, user.PublicKey, user.PrivateKey, user.Created,
+		user.KDF.Algorithm, user.KDF.Memory, user.KDF.Iterations, user.KDF.Parallelism, user.WrappedKey,
+		user.SRPSalt, user.Verifier, user.KeyCheck, user.RecoveryKey, user.EscrowKey, user.VaultRoot, user.VaultSeq).Scan(&uid)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(string(pgErr.Code)) {
+			return uuid.Nil, ErrAlreadyExists
+		}
+		return uuid.Nil, fmt.Errorf("failed to add user: %w", err)
+	}
+	for _, backup := range secrets {
+		m := backup.Secret
+		env, err := s.secrets.seal(ctx, m.Name, m.Content)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		var sid uuid.UUID
+		if err := tx.QueryRow(ctx, secretQuery, nullID(m.ID), uid, m.Type, env.name, env.content, m.Created, m.Updated, env.keyID, env.dataKey,
+			m.Expires, expirePolicy(m.ExpirePolicy), m.Archived, m.Meta, m.WrappedKey, m.Revision, crypto.ContentHash(m.Content)).Scan(&sid); err != nil {
+			return uuid.Nil, importError("secret", err)
+		}
+		if len(m.SearchIndex) != 0 {
+			if _, err := tx.Exec(ctx, indexQuery, sid, m.SearchIndex); err != nil {
+				return uuid.Nil, fmt.Errorf("failed to add secret index: %w", err)
+			}
+		}
+		for _, a := range backup.Attachments {
+			env, err := s.secrets.seal(ctx, a.Name, a.Content)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			if _, err := tx.Exec(ctx, attachmentQuery, nullID(a.ID), sid, env.name, a.Size, env.content, env.keyID, env.dataKey,
+				a.Meta, a.Created); err != nil {
+				return uuid.Nil, importError("attachment", err)
+			}
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+	return uid, nil
+}
+
+// importError - метод конвертирует ошибку добавления записи при восстановлении
+// (идентификатор из архива уже занят - ErrAlreadyExists)
+func importError(kind string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return fmt.Errorf("%w: %s id from the archive is in use", ErrAlreadyExists, kind)
+	}
+	return fmt.Errorf("failed to add %s: %w", kind, err)
+}
@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+
+	"github.com/google/uuid"
+)
+
+// envelope - зашифрованные поля записи секрета и обёрнутый ключ данных
+type envelope struct {
+	name    string         // название (base64 шифротекста, если запись зашифрована)
+	content []byte         // содержимое
+	keyID   sql.NullString // идентификатор мастер-ключа (NULL - запись не зашифрована)
+	dataKey []byte         // ключ данных, зашифрованный мастер-ключом
+}
+
+// seal - метод шифрует название и содержимое секрета новым ключом данных.
+// Если менеджер ключей не задан, данные сохраняются как есть.
+func (s *SecretStorage) seal(ctx context.Context, name string, content []byte) (*envelope, error) {
+	if s.keys == nil {
+		return &envelope{name: name, content: content}, nil
+	}
+	dataKey, err := crypto.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	sealedName, err := crypto.Encrypt(dataKey, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret name: %w", err)
+	}
+	sealedContent, err := crypto.Encrypt(dataKey, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret content: %w", err)
+	}
+	wrapped, keyID, err := s.keys.Wrap(ctx, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &envelope{
+		name:    base64.StdEncoding.EncodeToString(sealedName),
+		content: sealedContent,
+		keyID:   sql.NullString{String: keyID, Valid: true},
+		dataKey: wrapped,
+	}, nil
+}
+
+// open - метод расшифровывает название и содержимое (если оно загружено) секрета
+func (s *SecretStorage) open(ctx context.Context, m *models.SecretData, keyID sql.NullString, wrapped []byte) error {
+	return s.unseal(ctx, m.ID, &m.Name, &m.Content, keyID, wrapped)
+}
+
+// unseal - метод расшифровывает название и содержимое (если оно загружено) записи id
+func (s *SecretStorage) unseal(ctx context.Context, id uuid.UUID, name *string, content *[]byte, keyID sql.NullString, wrapped []byte) error {
+	if !keyID.Valid {
+		return nil
+	}
+	if s.keys == nil {
+		return fmt.Errorf("record %s is encrypted, but master key is not configured", id)
+	}
+	dataKey, err := s.keys.Unwrap(ctx, keyID.String, wrapped)
+	if err != nil {
+		return err
+	}
+	sealedName, err := base64.StdEncoding.DecodeString(*name)
+	if err != nil {
+		return fmt.Errorf("failed to decode record name: %w", err)
+	}
+	plainName, err := crypto.Decrypt(dataKey, sealedName)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt record name: %w", err)
+	}
+	*name = string(plainName)
+	if *content != nil {
+		plainContent, err := crypto.Decrypt(dataKey, *content)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt record content: %w", err)
+		}
+		*content = plainContent
+	}
+	return nil
+}
+
+// Rewrap - метод перешифровывает ключи данных не более batch записей (секретов, затем вложений)
+// текущим мастер-ключом (записи, сохранённые без шифрования, шифруются целиком).
+// Возвращает количество обработанных записей.
+func (s *SecretStorage) Rewrap(ctx context.Context, batch int) (int, error) {
+	if s.keys == nil {
+		return 0, nil
+	}
+	n, err := s.rewrapTable(ctx, "secrets", batch)
+	if err != nil || n == batch {
+		return n, err
+	}
+	m, err := s.rewrapTable(ctx, "attachments", batch-n)
+	return n + m, err
+}
+
+// rewrapTable - метод перешифровывает не более batch записей таблицы table
+// (таблица должна содержать поля name, content, key_id и data_key)
+func (s *SecretStorage) rewrapTable(ctx context.Context, table string, batch int) (int, error) {
+	var (
+		selectQuery = `
+		SELECT id, name, content, key_id, data_key FROM ` + table + `
+		WHERE key_id IS NULL OR key_id <> $1
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+`
+		updateKeyQuery = `
+		UPDATE ` + table + `
+		SET key_id = $2, data_key = $3
+		WHERE id = $1
+`
+		updateAllQuery = `
+		UPDATE ` + table + `
+		SET name = $2, content = $3, key_id = $4, data_key = $5
+		WHERE id = $1
+`
+	)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, selectQuery, s.keys.KeyID(), batch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get secrets to rewrap: %w", err)
+	}
+	type record struct {
+		secret  models.SecretData
+		keyID   sql.NullString
+		dataKey []byte
+	}
+	records := make([]*record, 0, batch)
+	for rows.Next() {
+		r := &record{}
+		if err := rows.Scan(&r.secret.ID, &r.secret.Name, &r.secret.Content, &r.keyID, &r.dataKey); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed scan secret data: %w", err)
+		}
+		records = append(records, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to get secrets to rewrap: %w", err)
+	}
+
+	for _, r := range records {
+		if !r.keyID.Valid {
+			env, err := s.seal(ctx, r.secret.Name, r.secret.Content)
+			if err != nil {
+				return 0, err
+			}
+			if _, err := tx.Exec(ctx, updateAllQuery, r.secret.ID, env.name, env.content, env.keyID, env.dataKey); err != nil {
+				return 0, fmt.Errorf("failed to encrypt secret: %w", err)
+			}
+			continue
+		}
+		dataKey, err := s.keys.Unwrap(ctx, r.keyID.String, r.dataKey)
+		if err != nil {
+			return 0, err
+		}
+		wrapped, keyID, err := s.keys.Wrap(ctx, dataKey)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(ctx, updateKeyQuery, r.secret.ID, keyID, wrapped); err != nil {
+			return 0, fmt.Errorf("failed to rewrap secret: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit rewrap: %w", err)
+	}
+	return len(records), nil
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Действия оператора с депонированным ключом (записываются в журнал)
+const (
+	escrowActionExport = "export"
+	escrowActionApply  = "apply"
+)
+
+// EscrowStorage - хранилище депонированных ключей для команд оператора.
+// Каждое использование депонированного ключа записывается в журнал escrow_audit
+// в той же транзакции, что и само действие. Оператор определяется по роли, под которой
+// команда подключилась к базе данных (её подлинность проверяет Postgres), поэтому каждому
+// оператору следует выдать отдельную роль.
+type EscrowStorage struct {
+	db *Database // указатель на базу данных
+}
+
+// NewEscrowStorage - метод создаёт хранилище депонированных ключей
+func NewEscrowStorage(db *Database) *EscrowStorage {
+	return &EscrowStorage{db: db}
+}
+
+// Export - метод извлекает депонированный ключ пользователя по логину и записывает выгрузку в журнал
+// (возвращает пользователя и роль оператора, записанную в журнал)
+func (s *EscrowStorage) Export(ctx context.Context, login string) (*models.UserData, string, error) {
+	const query = `
+		SELECT id, login, escrow_key, key_check FROM users
+		WHERE login = $1 AND escrow_key IS NOT NULL;
+`
+	user := &models.UserData{}
+	var operator string
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, query, login).Scan(&user.ID, &user.Login, &user.EscrowKey, &user.KeyCheck)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to get user escrow key: %w", err)
+		}
+		operator, err = addEscrowAudit(ctx, tx, user.ID, user.Login, escrowActionExport, "")
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return user, operator, nil
+}
+
+// HasRecoveryKey - метод проверяет, задан ли ключ восстановления пользователя с депонированным ключом
+// (используется для подтверждения замены ключа оператором)
+func (s *EscrowStorage) HasRecoveryKey(ctx context.Context, uid uuid.UUID, login string) (bool, error) {
+	const query = `
+		SELECT recovery_key IS NOT NULL FROM users
+		WHERE id = $1 AND login = $2 AND escrow_key IS NOT NULL;
+`
+	var exists bool
+	if err := s.db.Pool.QueryRow(ctx, query, uid, login).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrNotFound
+		}
+		return false, fmt.Errorf("failed to get user recovery key: %w", err)
+	}
+	return exists, nil
+}
+
+// Apply - метод сохраняет ключ восстановления, выданный оператором по депонированному ключу,
+// и записывает действие в журнал с отметкой о замене прежнего ключа (возвращает роль оператора)
+func (s *EscrowStorage) Apply(ctx context.Context, uid uuid.UUID, login string, recoveryKey []byte) (string, error) {
+	const (
+		lockQuery = `
+		SELECT recovery_key IS NOT NULL FROM users
+		WHERE id = $1 AND login = $2 AND escrow_key IS NOT NULL
+		FOR UPDATE;
+`
+		updateQuery = `
+		UPDATE users
+		SET recovery_key = $2
+		WHERE id = $1;
+`
+	)
+	var operator string
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		var replaced bool
+		if err := tx.QueryRow(ctx, lockQuery, uid, login).Scan(&replaced); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to get user recovery key: %w", err)
+		}
+		if _, err := tx.Exec(ctx, updateQuery, uid, recoveryKey); err != nil {
+			return fmt.Errorf("failed to set user recovery key: %w", err)
+		}
+		details := "recovery key set"
+		if replaced {
+			details = "previous recovery key replaced"
+		}
+		var err error
+		operator, err = addEscrowAudit(ctx, tx, uid, login, escrowActionApply, details)
+		return err
+	})
+	return operator, err
+}
+
+// inTx - метод выполняет fn в транзакции
+func (s *EscrowStorage) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// addEscrowAudit - метод записывает действие оператора с депонированным ключом в журнал.
+// Оператор - роль текущего подключения к базе данных (возвращается для вывода и пакета).
+func addEscrowAudit(ctx context.Context, tx pgx.Tx, uid uuid.UUID, login string, action string, details string) (string, error) {
+	const query = `
+		INSERT INTO escrow_audit (user_id, login, operator, action, details)
+		VALUES ($1, $2, session_user, $3, $4)
+		RETURNING operator;
+`
+	var operator string
+	if err := tx.QueryRow(ctx, query, uid, login, action, details).Scan(&operator); err != nil {
+		return "", fmt.Errorf("failed to record escrow audit: %w", err)
+	}
+	return operator, nil
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+ADD COLUMN public_key BYTEA DEFAULT NULL,
+ADD COLUMN private_key BYTEA DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS shares
+(
+    id           UUID                 DEFAULT uuid_generate_v4() NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    secret_id    UUID        NOT NULL,
+    owner_id     UUID        NOT NULL,
+    recipient_id UUID        NOT NULL,
+    wrapped_key  BYTEA       NOT NULL,
+    content      BYTEA       NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT unique_share UNIQUE (secret_id, recipient_id),
+    CONSTRAINT foreign_key_secret FOREIGN KEY (secret_id) REFERENCES secrets (id) ON DELETE CASCADE,
+    CONSTRAINT foreign_key_owner FOREIGN KEY (owner_id) REFERENCES users (id),
+    CONSTRAINT foreign_key_recipient FOREIGN KEY (recipient_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_shares_recipient_id ON shares (recipient_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS shares;
+ALTER TABLE users
+DROP COLUMN IF EXISTS public_key,
+DROP COLUMN IF EXISTS private_key;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organizations
+(
+    id         UUID                 DEFAULT uuid_generate_v4() NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    name       TEXT        NOT NULL UNIQUE,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS memberships
+(
+    org_id     UUID        NOT NULL,
+    user_id    UUID        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    role       VARCHAR(32) NOT NULL,
+    vault_key  BYTEA       NOT NULL,
+    PRIMARY KEY (org_id, user_id),
+    CONSTRAINT foreign_key_membership_org FOREIGN KEY (org_id) REFERENCES organizations (id) ON DELETE CASCADE,
+    CONSTRAINT foreign_key_membership_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_memberships_user_id ON memberships (user_id);
+
+ALTER TABLE secrets
+ADD COLUMN org_id UUID DEFAULT NULL,
+ADD CONSTRAINT foreign_key_secret_org FOREIGN KEY (org_id) REFERENCES organizations (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_secrets_org_id ON secrets (org_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_secrets_org_id;
+ALTER TABLE secrets
+DROP CONSTRAINT IF EXISTS foreign_key_secret_org,
+DROP COLUMN IF EXISTS org_id;
+DROP TABLE IF EXISTS memberships;
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN logout_at TIMESTAMPTZ DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+DROP COLUMN IF EXISTS logout_at,
+DROP COLUMN IF EXISTS disabled;
+-- +goose StatementEnd
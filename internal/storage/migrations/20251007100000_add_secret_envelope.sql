@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE secrets
+ALTER COLUMN name TYPE TEXT,
+ADD COLUMN key_id TEXT DEFAULT NULL,
+ADD COLUMN data_key BYTEA DEFAULT NULL;
+CREATE INDEX IF NOT EXISTS idx_secrets_key_id ON secrets (key_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM secrets WHERE key_id IS NOT NULL) THEN
+        RAISE EXCEPTION 'secrets are encrypted with the master key and can not be rolled back';
+    END IF;
+END $$;
+DROP INDEX IF EXISTS idx_secrets_key_id;
+ALTER TABLE secrets
+DROP COLUMN IF EXISTS data_key,
+DROP COLUMN IF EXISTS key_id,
+ALTER COLUMN name TYPE VARCHAR(255);
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE secrets
+ADD COLUMN expires_at TIMESTAMPTZ DEFAULT NULL,
+ADD COLUMN expire_policy VARCHAR(16) NOT NULL DEFAULT 'flag',
+ADD COLUMN archived_at TIMESTAMPTZ DEFAULT NULL;
+CREATE INDEX IF NOT EXISTS idx_secrets_expires_at ON secrets (expires_at) WHERE expires_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_secrets_expires_at;
+ALTER TABLE secrets
+DROP COLUMN IF EXISTS archived_at,
+DROP COLUMN IF EXISTS expire_policy,
+DROP COLUMN IF EXISTS expires_at;
+-- +goose StatementEnd
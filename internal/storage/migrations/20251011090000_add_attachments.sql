@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id         UUID                 DEFAULT uuid_generate_v4() NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    secret_id  UUID        NOT NULL,
+    name       TEXT        NOT NULL,
+    size       BIGINT      NOT NULL DEFAULT 0,
+    content    BYTEA       NOT NULL,
+    key_id     TEXT                 DEFAULT NULL,
+    data_key   BYTEA                DEFAULT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT foreign_key_secret FOREIGN KEY (secret_id) REFERENCES secrets (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_secret_id ON attachments (secret_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_key_id ON attachments (key_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attachments;
+-- +goose StatementEnd
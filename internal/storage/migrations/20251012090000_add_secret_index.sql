@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS secret_index
+(
+    secret_id UUID  NOT NULL,
+    token     BYTEA NOT NULL,
+    PRIMARY KEY (secret_id, token),
+    CONSTRAINT foreign_key_secret FOREIGN KEY (secret_id) REFERENCES secrets (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_secret_index_token ON secret_index (token);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS secret_index;
+-- +goose StatementEnd
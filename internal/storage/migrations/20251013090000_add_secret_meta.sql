@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS meta BYTEA DEFAULT NULL;
+ALTER TABLE shares ADD COLUMN IF NOT EXISTS meta BYTEA DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE shares DROP COLUMN IF EXISTS meta;
+ALTER TABLE secrets DROP COLUMN IF EXISTS meta;
+-- +goose StatementEnd
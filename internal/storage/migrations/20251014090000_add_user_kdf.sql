@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- параметры получения ключа из пароля (по умолчанию - прежние параметры scrypt)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_algorithm TEXT NOT NULL DEFAULT 'scrypt';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_memory INTEGER NOT NULL DEFAULT 32768;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_iterations INTEGER NOT NULL DEFAULT 32768;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_parallelism INTEGER NOT NULL DEFAULT 1;
+-- ключ шифрования, зашифрованный ключом из пароля (NULL - используется ключ из пароля)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS wrapped_key BYTEA DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS wrapped_key;
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_parallelism;
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_iterations;
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_memory;
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_algorithm;
+-- +goose StatementEnd
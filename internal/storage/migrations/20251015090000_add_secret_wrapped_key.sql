@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ключ данных секрета, зашифрованный на клиенте ключом хранилища (NULL - содержимое зашифровано ключом хранилища)
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS wrapped_key BYTEA DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE secrets DROP COLUMN IF EXISTS wrapped_key;
+-- +goose StatementEnd
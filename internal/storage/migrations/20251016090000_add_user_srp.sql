@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- соль и проверочное значение пароля SRP-6a (сервер не получает пароль при входе)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS srp_salt BYTEA DEFAULT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS srp_verifier BYTEA DEFAULT NULL;
+-- хеш bcrypt остаётся только у учётных записей, ещё не перешедших на SRP
+ALTER TABLE users ALTER COLUMN password DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE users SET password = '' WHERE password IS NULL;
+ALTER TABLE users ALTER COLUMN password SET NOT NULL;
+ALTER TABLE users DROP COLUMN IF EXISTS srp_verifier;
+ALTER TABLE users DROP COLUMN IF EXISTS srp_salt;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- контрольное значение ключа: константа, зашифрованная на клиенте ключом хранилища,
+-- позволяет обнаружить неверный секрет сразу после входа
+ALTER TABLE users ADD COLUMN IF NOT EXISTS key_check BYTEA DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS key_check;
+-- +goose StatementEnd
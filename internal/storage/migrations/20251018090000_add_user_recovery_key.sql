@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ключ хранилища, зашифрованный на клиенте ключом восстановления (необязательный)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS recovery_key BYTEA DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_key;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ключ хранилища, зашифрованный на клиенте открытым ключом депонирования организации (необязательный)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS escrow_key BYTEA DEFAULT NULL;
+
+-- журнал использования депонированных ключей операторами
+CREATE TABLE IF NOT EXISTS escrow_audit
+(
+    id         UUID                 DEFAULT uuid_generate_v4() NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    user_id    UUID        NOT NULL,
+    operator   TEXT        NOT NULL,
+    action     TEXT        NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT foreign_key_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_escrow_audit_user ON escrow_audit (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS escrow_audit;
+ALTER TABLE users DROP COLUMN IF EXISTS escrow_key;
+-- +goose StatementEnd
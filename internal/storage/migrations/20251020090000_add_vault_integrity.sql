@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ревизия содержимого секрета (увеличивается при каждом изменении) и SHA-256 содержимого,
+-- зашифрованного на клиенте: клиент подписывает их в состоянии хранилища
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS content_hash BYTEA DEFAULT NULL;
+-- содержимое, зашифрованное на сервере (key_id), хешируется при следующем изменении секрета
+UPDATE secrets SET content_hash = digest(content, 'sha256') WHERE key_id IS NULL;
+
+-- состояние личного хранилища, подписанное на клиенте, и его номер
+ALTER TABLE users ADD COLUMN IF NOT EXISTS vault_root BYTEA DEFAULT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS vault_root_seq BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS vault_root_seq;
+ALTER TABLE users DROP COLUMN IF EXISTS vault_root;
+ALTER TABLE secrets DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE secrets DROP COLUMN IF EXISTS revision;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- секрет передаётся по ссылке: получателю выдаётся текущее содержимое секрета владельца,
+-- копия содержимого хранится только у записей, переданных ранее
+ALTER TABLE shares ALTER COLUMN content DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM shares WHERE content IS NULL;
+ALTER TABLE shares ALTER COLUMN content SET NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- название и содержимое вложения шифруются на клиенте ключом данных секрета,
+-- открытое название остаётся только у вложений, добавленных ранее
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS meta BYTEA DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM attachments WHERE meta IS NOT NULL;
+ALTER TABLE attachments DROP COLUMN IF EXISTS meta;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- признак замены ключа хранилища: устанавливается при удалении участника
+-- и снимается после перешифрования ключа для оставшихся участников
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS rotate_key BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE organizations DROP COLUMN IF EXISTS rotate_key;
+-- +goose StatementEnd
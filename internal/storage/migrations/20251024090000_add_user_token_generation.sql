@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- поколение токенов: принудительный выход увеличивает его, и токены прежних поколений
+-- отклоняются (сравнение по времени выпуска не различает вход в ту же секунду)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token_generation BIGINT NOT NULL DEFAULT 0;
+-- токены пользователей, уже выведенных принудительно, выпущены без поколения
+UPDATE users SET token_generation = 1 WHERE logout_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS token_generation;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- журнал депонирования хранится и после удаления пользователя: внешний ключ с каскадным удалением
+-- снимается, логин пользователя сохраняется в самой записи
+ALTER TABLE escrow_audit DROP CONSTRAINT IF EXISTS foreign_key_user;
+ALTER TABLE escrow_audit ADD COLUMN IF NOT EXISTS login TEXT NOT NULL DEFAULT '';
+UPDATE escrow_audit a SET login = u.login FROM users u WHERE u.id = a.user_id;
+-- подробности действия (например, заменён ли прежний ключ восстановления)
+ALTER TABLE escrow_audit ADD COLUMN IF NOT EXISTS details TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE escrow_audit DROP COLUMN IF EXISTS details;
+ALTER TABLE escrow_audit DROP COLUMN IF EXISTS login;
+-- записи об удалённых пользователях сохраняются: ограничение не проверяется для существующих строк
+ALTER TABLE escrow_audit ADD CONSTRAINT foreign_key_user FOREIGN KEY (user_id) REFERENCES users (id)
+    ON DELETE CASCADE NOT VALID;
+-- +goose StatementEnd
@@ -12,6 +12,8 @@ package mocks
 import (
 	context "context"
 	models "go-pass-keeper/internal/models"
+	storage "go-pass-keeper/internal/storage"
+	crypto "go-pass-keeper/pkg/crypto"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -57,6 +59,20 @@ func (mr *MockUserMockRecorder) Add(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUser)(nil).Add), ctx, user)
 }
 
+// ForceLogout mocks base method.
+func (m *MockUser) ForceLogout(ctx context.Context, login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", ctx, login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockUserMockRecorder) ForceLogout(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockUser)(nil).ForceLogout), ctx, login)
+}
+
 // Get mocks base method.
 func (m *MockUser) Get(ctx context.Context, login, password string) (*models.UserData, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +88,224 @@ func (mr *MockUserMockRecorder) Get(ctx, login, password any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUser)(nil).Get), ctx, login, password)
 }
 
+// GetByLogin mocks base method.
+func (m *MockUser) GetByLogin(ctx context.Context, login string) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLogin", ctx, login)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByLogin indicates an expected call of GetByLogin.
+func (mr *MockUserMockRecorder) GetByLogin(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*MockUser)(nil).GetByLogin), ctx, login)
+}
+
+// GetKeys mocks base method.
+func (m *MockUser) GetKeys(ctx context.Context, uid uuid.UUID) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", ctx, uid)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockUserMockRecorder) GetKeys(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockUser)(nil).GetKeys), ctx, uid)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockUser) GetPublicKey(ctx context.Context, login string) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", ctx, login)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockUserMockRecorder) GetPublicKey(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockUser)(nil).GetPublicKey), ctx, login)
+}
+
+// GetRecoveryKey mocks base method.
+func (m *MockUser) GetRecoveryKey(ctx context.Context, uid uuid.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecoveryKey", ctx, uid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryKey indicates an expected call of GetRecoveryKey.
+func (mr *MockUserMockRecorder) GetRecoveryKey(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryKey", reflect.TypeOf((*MockUser)(nil).GetRecoveryKey), ctx, uid)
+}
+
+// GetStatus mocks base method.
+func (m *MockUser) GetStatus(ctx context.Context, uid uuid.UUID) (*models.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx, uid)
+	ret0, _ := ret[0].(*models.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockUserMockRecorder) GetStatus(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockUser)(nil).GetStatus), ctx, uid)
+}
+
+// GetVaultRoot mocks base method.
+func (m *MockUser) GetVaultRoot(ctx context.Context, uid uuid.UUID) ([]byte, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultRoot", ctx, uid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVaultRoot indicates an expected call of GetVaultRoot.
+func (mr *MockUserMockRecorder) GetVaultRoot(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultRoot", reflect.TypeOf((*MockUser)(nil).GetVaultRoot), ctx, uid)
+}
+
+// List mocks base method.
+func (m *MockUser) List(ctx context.Context) ([]*models.UserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*models.UserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUser)(nil).List), ctx)
+}
+
+// SetDisabled mocks base method.
+func (m *MockUser) SetDisabled(ctx context.Context, login string, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", ctx, login, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockUserMockRecorder) SetDisabled(ctx, login, disabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockUser)(nil).SetDisabled), ctx, login, disabled)
+}
+
+// SetEscrowKey mocks base method.
+func (m *MockUser) SetEscrowKey(ctx context.Context, uid uuid.UUID, sealed []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEscrowKey", ctx, uid, sealed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEscrowKey indicates an expected call of SetEscrowKey.
+func (mr *MockUserMockRecorder) SetEscrowKey(ctx, uid, sealed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEscrowKey", reflect.TypeOf((*MockUser)(nil).SetEscrowKey), ctx, uid, sealed)
+}
+
+// SetKDF mocks base method.
+func (m *MockUser) SetKDF(ctx context.Context, uid uuid.UUID, kdf crypto.KDFParams, wrapped []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKDF", ctx, uid, kdf, wrapped)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKDF indicates an expected call of SetKDF.
+func (mr *MockUserMockRecorder) SetKDF(ctx, uid, kdf, wrapped any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKDF", reflect.TypeOf((*MockUser)(nil).SetKDF), ctx, uid, kdf, wrapped)
+}
+
+// SetKeyCheck mocks base method.
+func (m *MockUser) SetKeyCheck(ctx context.Context, uid uuid.UUID, check []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyCheck", ctx, uid, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyCheck indicates an expected call of SetKeyCheck.
+func (mr *MockUserMockRecorder) SetKeyCheck(ctx, uid, check any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyCheck", reflect.TypeOf((*MockUser)(nil).SetKeyCheck), ctx, uid, check)
+}
+
+// SetKeys mocks base method.
+func (m *MockUser) SetKeys(ctx context.Context, uid uuid.UUID, public, private []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeys", ctx, uid, public, private)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeys indicates an expected call of SetKeys.
+func (mr *MockUserMockRecorder) SetKeys(ctx, uid, public, private any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeys", reflect.TypeOf((*MockUser)(nil).SetKeys), ctx, uid, public, private)
+}
+
+// SetRecoveryKey mocks base method.
+func (m *MockUser) SetRecoveryKey(ctx context.Context, uid uuid.UUID, wrapped []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecoveryKey", ctx, uid, wrapped)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecoveryKey indicates an expected call of SetRecoveryKey.
+func (mr *MockUserMockRecorder) SetRecoveryKey(ctx, uid, wrapped any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecoveryKey", reflect.TypeOf((*MockUser)(nil).SetRecoveryKey), ctx, uid, wrapped)
+}
+
+// SetVaultRoot mocks base method.
+func (m *MockUser) SetVaultRoot(ctx context.Context, uid uuid.UUID, root []byte, seq, prev int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVaultRoot", ctx, uid, root, seq, prev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVaultRoot indicates an expected call of SetVaultRoot.
+func (mr *MockUserMockRecorder) SetVaultRoot(ctx, uid, root, seq, prev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVaultRoot", reflect.TypeOf((*MockUser)(nil).SetVaultRoot), ctx, uid, root, seq, prev)
+}
+
+// SetVerifier mocks base method.
+func (m *MockUser) SetVerifier(ctx context.Context, uid uuid.UUID, salt, verifier []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVerifier", ctx, uid, salt, verifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVerifier indicates an expected call of SetVerifier.
+func (mr *MockUserMockRecorder) SetVerifier(ctx, uid, salt, verifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerifier", reflect.TypeOf((*MockUser)(nil).SetVerifier), ctx, uid, salt, verifier)
+}
+
 // MockSecret is a mock of Secret interface.
 type MockSecret struct {
 	ctrl     *gomock.Controller
@@ -155,17 +389,384 @@ func (mr *MockSecretMockRecorder) Get(ctx, sid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSecret)(nil).Get), ctx, sid)
 }
 
+// InTx mocks base method.
+func (m *MockSecret) InTx(ctx context.Context, fn func(storage.Secret) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockSecretMockRecorder) InTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockSecret)(nil).InTx), ctx, fn)
+}
+
 // List mocks base method.
-func (m *MockSecret) List(ctx context.Context, uid uuid.UUID) ([]*models.SecretData, error) {
+func (m *MockSecret) List(ctx context.Context, uid uuid.UUID, archived bool) ([]*models.SecretData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, uid)
+	ret := m.ctrl.Call(m, "List", ctx, uid, archived)
 	ret0, _ := ret[0].([]*models.SecretData)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockSecretMockRecorder) List(ctx, uid any) *gomock.Call {
+func (mr *MockSecretMockRecorder) List(ctx, uid, archived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSecret)(nil).List), ctx, uid, archived)
+}
+
+// ListByOrganization mocks base method.
+func (m *MockSecret) ListByOrganization(ctx context.Context, oid uuid.UUID, archived bool) ([]*models.SecretData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrganization", ctx, oid, archived)
+	ret0, _ := ret[0].([]*models.SecretData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrganization indicates an expected call of ListByOrganization.
+func (mr *MockSecretMockRecorder) ListByOrganization(ctx, oid, archived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrganization", reflect.TypeOf((*MockSecret)(nil).ListByOrganization), ctx, oid, archived)
+}
+
+// Search mocks base method.
+func (m *MockSecret) Search(ctx context.Context, uid uuid.UUID, tokens [][]byte) ([]*models.SecretData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, uid, tokens)
+	ret0, _ := ret[0].([]*models.SecretData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSecretMockRecorder) Search(ctx, uid, tokens any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSecret)(nil).Search), ctx, uid, tokens)
+}
+
+// SearchByOrganization mocks base method.
+func (m *MockSecret) SearchByOrganization(ctx context.Context, oid uuid.UUID, tokens [][]byte) ([]*models.SecretData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByOrganization", ctx, oid, tokens)
+	ret0, _ := ret[0].([]*models.SecretData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByOrganization indicates an expected call of SearchByOrganization.
+func (mr *MockSecretMockRecorder) SearchByOrganization(ctx, oid, tokens any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByOrganization", reflect.TypeOf((*MockSecret)(nil).SearchByOrganization), ctx, oid, tokens)
+}
+
+// SetExpiration mocks base method.
+func (m_2 *MockSecret) SetExpiration(ctx context.Context, m *models.SecretData) (*models.SecretData, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SetExpiration", ctx, m)
+	ret0, _ := ret[0].(*models.SecretData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExpiration indicates an expected call of SetExpiration.
+func (mr *MockSecretMockRecorder) SetExpiration(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiration", reflect.TypeOf((*MockSecret)(nil).SetExpiration), ctx, m)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m_2 *MockAttachment) Add(ctx context.Context, m *models.AttachmentData) (*models.AttachmentData, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Add", ctx, m)
+	ret0, _ := ret[0].(*models.AttachmentData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAttachmentMockRecorder) Add(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAttachment)(nil).Add), ctx, m)
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(ctx context.Context, aid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, aid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), ctx, aid)
+}
+
+// Get mocks base method.
+func (m *MockAttachment) Get(ctx context.Context, aid uuid.UUID) (*models.AttachmentData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, aid)
+	ret0, _ := ret[0].(*models.AttachmentData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAttachmentMockRecorder) Get(ctx, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAttachment)(nil).Get), ctx, aid)
+}
+
+// List mocks base method.
+func (m *MockAttachment) List(ctx context.Context, sid uuid.UUID) ([]*models.AttachmentData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, sid)
+	ret0, _ := ret[0].([]*models.AttachmentData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAttachmentMockRecorder) List(ctx, sid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAttachment)(nil).List), ctx, sid)
+}
+
+// MockShare is a mock of Share interface.
+type MockShare struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareMockRecorder
+	isgomock struct{}
+}
+
+// MockShareMockRecorder is the mock recorder for MockShare.
+type MockShareMockRecorder struct {
+	mock *MockShare
+}
+
+// NewMockShare creates a new mock instance.
+func NewMockShare(ctrl *gomock.Controller) *MockShare {
+	mock := &MockShare{ctrl: ctrl}
+	mock.recorder = &MockShareMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShare) EXPECT() *MockShareMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m_2 *MockShare) Add(ctx context.Context, m *models.ShareData) (*models.ShareData, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Add", ctx, m)
+	ret0, _ := ret[0].(*models.ShareData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockShareMockRecorder) Add(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockShare)(nil).Add), ctx, m)
+}
+
+// Delete mocks base method.
+func (m *MockShare) Delete(ctx context.Context, sid, recipient uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, sid, recipient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockShareMockRecorder) Delete(ctx, sid, recipient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockShare)(nil).Delete), ctx, sid, recipient)
+}
+
+// ListByRecipient mocks base method.
+func (m *MockShare) ListByRecipient(ctx context.Context, uid uuid.UUID) ([]*models.ShareData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRecipient", ctx, uid)
+	ret0, _ := ret[0].([]*models.ShareData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRecipient indicates an expected call of ListByRecipient.
+func (mr *MockShareMockRecorder) ListByRecipient(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRecipient", reflect.TypeOf((*MockShare)(nil).ListByRecipient), ctx, uid)
+}
+
+// MockOrganization is a mock of Organization interface.
+type MockOrganization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationMockRecorder is the mock recorder for MockOrganization.
+type MockOrganizationMockRecorder struct {
+	mock *MockOrganization
+}
+
+// NewMockOrganization creates a new mock instance.
+func NewMockOrganization(ctrl *gomock.Controller) *MockOrganization {
+	mock := &MockOrganization{ctrl: ctrl}
+	mock.recorder = &MockOrganizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganization) EXPECT() *MockOrganizationMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m_2 *MockOrganization) AddMember(ctx context.Context, m *models.MemberData) (*models.MemberData, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "AddMember", ctx, m)
+	ret0, _ := ret[0].(*models.MemberData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationMockRecorder) AddMember(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganization)(nil).AddMember), ctx, m)
+}
+
+// Create mocks base method.
+func (m *MockOrganization) Create(ctx context.Context, org *models.OrganizationData, owner *models.MemberData) (*models.OrganizationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, org, owner)
+	ret0, _ := ret[0].(*models.OrganizationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationMockRecorder) Create(ctx, org, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganization)(nil).Create), ctx, org, owner)
+}
+
+// DeleteMember mocks base method.
+func (m *MockOrganization) DeleteMember(ctx context.Context, oid, uid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, oid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockOrganizationMockRecorder) DeleteMember(ctx, oid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockOrganization)(nil).DeleteMember), ctx, oid, uid)
+}
+
+// GetMember mocks base method.
+func (m *MockOrganization) GetMember(ctx context.Context, oid, uid uuid.UUID) (*models.MemberData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, oid, uid)
+	ret0, _ := ret[0].(*models.MemberData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockOrganizationMockRecorder) GetMember(ctx, oid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockOrganization)(nil).GetMember), ctx, oid, uid)
+}
+
+// ListByUser mocks base method.
+func (m *MockOrganization) ListByUser(ctx context.Context, uid uuid.UUID) ([]*models.OrganizationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, uid)
+	ret0, _ := ret[0].([]*models.OrganizationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockOrganizationMockRecorder) ListByUser(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockOrganization)(nil).ListByUser), ctx, uid)
+}
+
+// ListMembers mocks base method.
+func (m *MockOrganization) ListMembers(ctx context.Context, oid uuid.UUID) ([]*models.MemberData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, oid)
+	ret0, _ := ret[0].([]*models.MemberData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockOrganizationMockRecorder) ListMembers(ctx, oid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockOrganization)(nil).ListMembers), ctx, oid)
+}
+
+// RotateKey mocks base method.
+func (m *MockOrganization) RotateKey(ctx context.Context, oid uuid.UUID, members []*models.MemberData, secrets []*models.SecretData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey", ctx, oid, members, secrets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockOrganizationMockRecorder) RotateKey(ctx, oid, members, secrets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockOrganization)(nil).RotateKey), ctx, oid, members, secrets)
+}
+
+// UpdateMember mocks base method.
+func (m_2 *MockOrganization) UpdateMember(ctx context.Context, m *models.MemberData) (*models.MemberData, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateMember", ctx, m)
+	ret0, _ := ret[0].(*models.MemberData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockOrganizationMockRecorder) UpdateMember(ctx, m any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSecret)(nil).List), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockOrganization)(nil).UpdateMember), ctx, m)
 }
@@ -0,0 +1,352 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// OrganizationStorage - хранилище организаций и их участников
+type OrganizationStorage struct {
+	db *Database // указатель на базу данных
+}
+
+// NewOrganizationStorage - метод создаёт подключение к таблицам организаций
+func NewOrganizationStorage(db *Database) *OrganizationStorage {
+	return &OrganizationStorage{db: db}
+}
+
+// Create - метод создаёт организацию и добавляет в неё владельца (в одной транзакции)
+func (s *OrganizationStorage) Create(ctx context.Context, org *models.OrganizationData, owner *models.MemberData) (*models.OrganizationData, error) {
+	const (
+		insertOrg = `
+		INSERT INTO organizations (name)
+		VALUES ($1)
+		RETURNING id, created_at
+`
+		insertMember = `
+		INSERT INTO memberships (org_id, user_id, role, vault_key)
+		VALUES ($1, $2, $3, $4)
+`
+	)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	m := &models.OrganizationData{Name: org.Name, Role: owner.Role, VaultKey: owner.VaultKey}
+	err = tx.QueryRow(ctx, insertOrg, org.Name).Scan(&m.ID, &m.Created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(string(pgErr.Code)) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to add organization: %w", err)
+	}
+	if _, err := tx.Exec(ctx, insertMember, m.ID, owner.UserID, owner.Role, owner.VaultKey); err != nil {
+		return nil, fmt.Errorf("failed to add organization owner: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit organization: %w", err)
+	}
+	return m, nil
+}
+
+// ListByUser - метод возвращает список организаций пользователя с его ролью и ключом хранилища
+func (s *OrganizationStorage) ListByUser(ctx context.Context, uid uuid.UUID) ([]*models.OrganizationData, error) {
+	const query = `
+		SELECT o.id, o.name, m.role, m.vault_key, o.created_at, o.rotate_key
+		FROM memberships m
+		JOIN organizations o ON o.id = m.org_id
+		WHERE m.user_id = $1 ORDER BY o.name
+`
+	rows, err := s.db.Pool.Query(ctx, query, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.OrganizationData, 0)
+	for rows.Next() {
+		m := &models.OrganizationData{}
+		if err := rows.Scan(&m.ID, &m.Name, &m.Role, &m.VaultKey, &m.Created, &m.RotateKey); err != nil {
+			return res, fmt.Errorf("failed scan organization data: %w", err)
+		}
+		res = append(res, m)
+	}
+
+	return res, nil
+}
+
+// GetMember - метод возвращает участника организации
+func (s *OrganizationStorage) GetMember(ctx context.Context, oid uuid.UUID, uid uuid.UUID) (*models.MemberData, error) {
+	const query = `
+		SELECT m.org_id, m.user_id, u.login, m.role, m.vault_key, m.created_at
+		FROM memberships m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1 AND m.user_id = $2
+`
+	m := &models.MemberData{}
+	err := s.db.Pool.QueryRow(ctx, query, oid, uid).
+		Scan(&m.OrgID, &m.UserID, &m.Login, &m.Role, &m.VaultKey, &m.Created)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	return m, nil
+}
+
+// ListMembers - метод возвращает список участников организации
+func (s *OrganizationStorage) ListMembers(ctx context.Context, oid uuid.UUID) ([]*models.MemberData, error) {
+	const query = `
+		SELECT m.org_id, m.user_id, u.login, m.role, m.created_at
+		FROM memberships m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1 ORDER BY u.login
+`
+	rows, err := s.db.Pool.Query(ctx, query, oid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.MemberData, 0)
+	for rows.Next() {
+		m := &models.MemberData{}
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Login, &m.Role, &m.Created); err != nil {
+			return res, fmt.Errorf("failed scan member data: %w", err)
+		}
+		res = append(res, m)
+	}
+
+	return res, nil
+}
+
+// AddMember - метод добавляет участника в организацию
+func (s *OrganizationStorage) AddMember(ctx context.Context, member *models.MemberData) (*models.MemberData, error) {
+	const query = `
+		INSERT INTO memberships (org_id, user_id, role, vault_key)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+`
+	m := *member
+	err := s.db.Pool.QueryRow(ctx, query, member.OrgID, member.UserID, member.Role, member.VaultKey).Scan(&m.Created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(string(pgErr.Code)) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+	return &m, nil
+}
+
+// UpdateMember - метод изменяет роль участника организации
+func (s *OrganizationStorage) UpdateMember(ctx context.Context, member *models.MemberData) (*models.MemberData, error) {
+	const query = `
+		UPDATE memberships
+		SET role = $3
+		WHERE org_id = $1 AND user_id = $2
+		RETURNING created_at
+`
+	m := *member
+	err := s.db.Pool.QueryRow(ctx, query, member.OrgID, member.UserID, member.Role).Scan(&m.Created)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+	return &m, nil
+}
+
+// DeleteMember - метод удаляет участника из организации и отмечает, что ключ хранилища
+// организации необходимо заменить (удалённому участнику ключ известен)
+func (s *OrganizationStorage) DeleteMember(ctx context.Context, oid uuid.UUID, uid uuid.UUID) error {
+	const query = `
+		WITH deleted AS (
+			DELETE FROM memberships
+			WHERE org_id = $1 AND user_id = $2
+			RETURNING org_id
+		)
+		UPDATE organizations
+		SET rotate_key = TRUE
+		WHERE id IN (SELECT org_id FROM deleted);
+`
+	res, err := s.db.Pool.Exec(ctx, query, oid, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete member: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RotateKey - метод заменяет ключ хранилища организации в одной транзакции: сохраняет новый ключ,
+// зашифрованный для каждого участника, ключи данных секретов, зашифрованные новым ключом,
+// и их слепые индексы, после чего снимает признак замены ключа. Списки участников и секретов
+// должны совпадать с текущими, а у всех секретов и вложений должны быть собственные ключи данных
+// (иначе ErrConflict: содержимое, зашифрованное прежним ключом хранилища, стало бы недоступно).
+func (s *OrganizationStorage) RotateKey(ctx context.Context, oid uuid.UUID, members []*models.MemberData, secrets []*models.SecretData) error {
+	const (
+		lockOrg = `
+		SELECT id FROM organizations
+		WHERE id = $1
+		FOR UPDATE
+`
+		selectMembers = `
+		SELECT user_id FROM memberships
+		WHERE org_id = $1
+		FOR UPDATE
+`
+		selectSecrets = `
+		SELECT id, wrapped_key IS NOT NULL AND length(wrapped_key) > 0 FROM secrets
+		WHERE org_id = $1
+		FOR UPDATE
+`
+		countLegacyAttachments = `
+		SELECT COUNT(*) FROM attachments a
+		JOIN secrets s ON s.id = a.secret_id
+		WHERE s.org_id = $1 AND a.meta IS NULL
+`
+		updateMember = `
+		UPDATE memberships
+		SET vault_key = $3
+		WHERE org_id = $1 AND user_id = $2
+`
+		updateSecret = `
+		UPDATE secrets
+		SET wrapped_key = $2, revision = revision + 1
+		WHERE id = $1
+`
+		deleteIndex = `
+		DELETE FROM secret_index
+		WHERE secret_id = $1
+`
+		insertIndex = `
+		INSERT INTO secret_index (secret_id, token)
+		SELECT $1, token FROM UNNEST($2::bytea[]) AS token
+		ON CONFLICT DO NOTHING
+`
+		clearFlag = `
+		UPDATE organizations
+		SET rotate_key = FALSE
+		WHERE id = $1
+`
+	)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id uuid.UUID
+	if err := tx.QueryRow(ctx, lockOrg, oid).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to lock organization: %w", err)
+	}
+
+	current := make(map[uuid.UUID]bool)
+	rows, err := tx.Query(ctx, selectMembers, oid)
+	if err != nil {
+		return fmt.Errorf("failed to get members: %w", err)
+	}
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed scan member data: %w", err)
+		}
+		current[uid] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get members: %w", err)
+	}
+	if len(current) != len(members) {
+		return ErrConflict
+	}
+	for _, member := range members {
+		if !current[member.UserID] {
+			return ErrConflict
+		}
+	}
+
+	withKey := make(map[uuid.UUID]bool)
+	rows, err = tx.Query(ctx, selectSecrets, oid)
+	if err != nil {
+		return fmt.Errorf("failed to get secrets: %w", err)
+	}
+	for rows.Next() {
+		var (
+			sid    uuid.UUID
+			hasKey bool
+		)
+		if err := rows.Scan(&sid, &hasKey); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed scan secret data: %w", err)
+		}
+		withKey[sid] = hasKey
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get secrets: %w", err)
+	}
+	if len(withKey) != len(secrets) {
+		return ErrConflict
+	}
+	for _, secret := range secrets {
+		if hasKey, ok := withKey[secret.ID]; !ok || !hasKey {
+			return ErrConflict
+		}
+	}
+	var legacy int
+	if err := tx.QueryRow(ctx, countLegacyAttachments, oid).Scan(&legacy); err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+	if legacy != 0 {
+		return ErrConflict
+	}
+
+	for _, member := range members {
+		if _, err := tx.Exec(ctx, updateMember, oid, member.UserID, member.VaultKey); err != nil {
+			return fmt.Errorf("failed to update member key: %w", err)
+		}
+	}
+	for _, secret := range secrets {
+		if _, err := tx.Exec(ctx, updateSecret, secret.ID, secret.WrappedKey); err != nil {
+			return fmt.Errorf("failed to update secret key: %w", err)
+		}
+		if _, err := tx.Exec(ctx, deleteIndex, secret.ID); err != nil {
+			return fmt.Errorf("failed to clear secret index: %w", err)
+		}
+		if _, err := tx.Exec(ctx, insertIndex, secret.ID, secret.SearchIndex); err != nil {
+			return fmt.Errorf("failed to update secret index: %w", err)
+		}
+	}
+	if _, err := tx.Exec(ctx, clearFlag, oid); err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit key rotation: %w", err)
+	}
+	return nil
+}
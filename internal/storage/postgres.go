@@ -4,9 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
@@ -21,6 +25,16 @@ type Database struct {
 const (
 	CheckExist     = `SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname =$1)`
 	CreateDatabase = `CREATE DATABASE %s`
+	// MigrationLock - ключ рекомендательной блокировки, под которой выполняются миграции
+	MigrationLock = 0x67706b6d6967 // "gpkmig"
+)
+
+// Команды управления миграциями
+const (
+	MigrateUp      = "up"
+	MigrateDown    = "down"
+	MigrateStatus  = "status"
+	MigrateVersion = "version"
 )
 
 // Создание хранилища
@@ -36,13 +50,20 @@ func NewDatabase(dsn string) (*Database, error) {
 	return &Database{Pool: pool, config: cfg.ConnConfig, dsn: dsn}, nil
 }
 
-// Инициализация хранилища (создание БД, миграция)
+// Инициализация хранилища (создание БД, миграция до последней версии)
 func (s *Database) Initialize() error {
+	return s.Migrate(context.Background(), MigrateUp)
+}
 
-	if err := s.CreateDatabase(context.Background()); err != nil {
-		return fmt.Errorf("error create database: %w", err)
+// Migrate - метод выполняет команду управления миграциями хранилища
+// (перед миграцией до последней версии база данных создаётся при необходимости)
+func (s *Database) Migrate(ctx context.Context, command string) error {
+	if command == MigrateUp {
+		if err := s.CreateDatabase(ctx); err != nil {
+			return fmt.Errorf("error create database: %w", err)
+		}
 	}
-	if err := Migration(s.dsn); err != nil {
+	if err := Migration(ctx, s.dsn, command); err != nil {
 		return fmt.Errorf("error migrate database: %w", err)
 	}
 
@@ -52,7 +73,88 @@ func (s *Database) Initialize() error {
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
-func Migration(DatabaseDSN string) error {
+// ErrSchemaVersion - версия схемы базы данных не совпадает с последней миграцией программы
+var ErrSchemaVersion = errors.New("unexpected database schema version")
+
+// CheckSchema - метод проверяет без выполнения миграций, что схема базы данных приведена
+// к последней миграции программы. Используется служебными командами, которые не должны
+// изменять схему рабочей базы (миграции выполняются сервером или командой migrate).
+func (s *Database) CheckSchema(ctx context.Context) error {
+	expected, err := latestMigration()
+	if err != nil {
+		return err
+	}
+	current, err := s.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current != expected {
+		return fmt.Errorf("%w: %d, expected %d (run 'migrate up' with the matching server version)",
+			ErrSchemaVersion, current, expected)
+	}
+	return nil
+}
+
+// latestMigration - метод возвращает версию последней встроенной миграции
+func latestMigration() (int64, error) {
+	files, err := fs.Glob(embedMigrations, "migrations/*.sql")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	var latest int64
+	for _, file := range files {
+		version, err := goose.NumericComponent(file)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration %s: %w", file, err)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
+
+// schemaVersion - метод читает текущую версию схемы из таблицы goose, не создавая её
+// (последняя запись о каждой миграции указывает, применена она или откачена)
+func (s *Database) schemaVersion(ctx context.Context) (int64, error) {
+	const query = `SELECT version_id, is_applied FROM goose_db_version ORDER BY id DESC;`
+	rows, err := s.Pool.Query(ctx, query)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			return 0, fmt.Errorf("%w: database schema is not initialized", ErrSchemaVersion)
+		}
+		return 0, fmt.Errorf("failed to get database schema version: %w", err)
+	}
+	defer rows.Close()
+	skipped := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		var applied bool
+		if err := rows.Scan(&version, &applied); err != nil {
+			return 0, fmt.Errorf("failed to get database schema version: %w", err)
+		}
+		if skipped[version] {
+			continue
+		}
+		if applied {
+			return version, nil
+		}
+		skipped[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to get database schema version: %w", err)
+	}
+	return 0, nil
+}
+
+// Migration - метод выполняет команду управления миграциями (up, down, status, version).
+// Команда выполняется под рекомендательной блокировкой Postgres, чтобы несколько
+// одновременно запускаемых экземпляров сервера не выполняли миграции параллельно.
+func Migration(ctx context.Context, DatabaseDSN string, command string) error {
+	switch command {
+	case MigrateUp, MigrateDown, MigrateStatus, MigrateVersion:
+	default:
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
 
 	db, err := sql.Open("pgx", DatabaseDSN)
 	if err != nil {
@@ -66,7 +168,18 @@ func Migration(DatabaseDSN string) error {
 		return fmt.Errorf("goose set dialect error: %w ", err)
 	}
 
-	if err := goose.Up(db, "migrations"); err != nil {
+	// блокировка удерживается отдельным соединением до окончания миграции
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("open migration lock connection error: %w ", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, MigrationLock); err != nil {
+		return fmt.Errorf("acquire migration lock error: %w ", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, MigrationLock)
+
+	if err := goose.RunContext(ctx, command, db, "migrations"); err != nil {
 		return fmt.Errorf("goose run migrations error:  %w ", err)
 	}
 	return nil
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"go-pass-keeper/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Search - метод возвращает действующие секреты личного хранилища пользователя,
+// слепой индекс которых содержит все значения tokens
+func (s *SecretStorage) Search(ctx context.Context, uid uuid.UUID, tokens [][]byte) ([]*models.SecretData, error) {
+	const SQL = `
+		SELECT id, user_id, org_id, type_secret, name, created_at, updated_at, key_id, data_key,
+		       expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision, content_hash
+		FROM secrets
+		WHERE user_id = $1 AND org_id IS NULL AND archived_at IS NULL AND id IN (
+			SELECT secret_id FROM secret_index
+			WHERE token = ANY($2)
+			GROUP BY secret_id
+			HAVING COUNT(DISTINCT token) = $3
+		)
+`
+	return s.list(ctx, SQL, uid, tokens, distinct(tokens))
+}
+
+// SearchByOrganization - метод возвращает действующие секреты хранилища организации,
+// слепой индекс которых содержит все значения tokens
+func (s *SecretStorage) SearchByOrganization(ctx context.Context, oid uuid.UUID, tokens [][]byte) ([]*models.SecretData, error) {
+	const SQL = `
+		SELECT id, user_id, org_id, type_secret, name, created_at, updated_at, key_id, data_key,
+		       expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision, content_hash
+		FROM secrets
+		WHERE org_id = $1 AND archived_at IS NULL AND id IN (
+			SELECT secret_id FROM secret_index
+			WHERE token = ANY($2)
+			GROUP BY secret_id
+			HAVING COUNT(DISTINCT token) = $3
+		)
+`
+	return s.list(ctx, SQL, oid, tokens, distinct(tokens))
+}
+
+// setIndex - метод заменяет слепой индекс секрета (nil оставляет индекс без изменений,
+// пустой список очищает его)
+func (s *SecretStorage) setIndex(ctx context.Context, sid uuid.UUID, tokens [][]byte) error {
+	const (
+		deleteQuery = `
+		DELETE FROM secret_index
+		WHERE secret_id = $1
+`
+		insertQuery = `
+		INSERT INTO secret_index (secret_id, token)
+		SELECT $1, token FROM UNNEST($2::bytea[]) AS token
+		ON CONFLICT DO NOTHING
+`
+	)
+	if tokens == nil {
+		return nil
+	}
+	if _, err := s.conn().Exec(ctx, deleteQuery, sid); err != nil {
+		return fmt.Errorf("failed to clear secret index: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	if _, err := s.conn().Exec(ctx, insertQuery, sid, tokens); err != nil {
+		return fmt.Errorf("failed to update secret index: %w", err)
+	}
+	return nil
+}
+
+// distinct - метод возвращает количество различных значений в списке
+func distinct(tokens [][]byte) int {
+	seen := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		seen[string(token)] = struct{}{}
+	}
+	return len(seen)
+}
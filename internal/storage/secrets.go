@@ -5,33 +5,54 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"go-pass-keeper/internal/kms"
 	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // UserStorage - хранилище секретов пользователей
 type SecretStorage struct {
-	db *Database // указатель на базу данных
+	db   *Database      // указатель на базу данных
+	keys kms.KeyManager // менеджер мастер-ключей (nil - шифрование на сервере выключено)
+	tx   pgx.Tx         // транзакция единицы работы (nil - запросы выполняются через пул)
 }
 
-// NewUserStorage - метод создаёт подключение к таблице пользователей
-func NewSecretStorage(db *Database) *SecretStorage {
-	return &SecretStorage{db: db}
+// NewUserStorage - метод создаёт подключение к таблице пользователей.
+// Если задан менеджер ключей, название и содержимое секретов хранятся зашифрованными.
+func NewSecretStorage(db *Database, keys kms.KeyManager) *SecretStorage {
+	return &SecretStorage{db: db, keys: keys}
 }
 
 // Add - метод добавляет секрет пользователя в хранилище
+// (идентификатор, выбранный клиентом, сохраняется; если он не задан - формируется базой)
 func (s *SecretStorage) Add(ctx context.Context, secret *models.SecretData) (*models.SecretData, error) {
 	const query = `
-		INSERT INTO secrets (user_id, type_secret, name, content)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at, updated_at
+		INSERT INTO secrets (id, user_id, org_id, type_secret, name, content, key_id, data_key, expires_at, expire_policy, meta, wrapped_key, content_hash)
+		VALUES (COALESCE($11, uuid_generate_v4()), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $12, $13)
+		RETURNING id, org_id, created_at, updated_at, expires_at, expire_policy, revision, content_hash
 `
+	env, err := s.seal(ctx, secret.Name, secret.Content)
+	if err != nil {
+		return nil, err
+	}
 	m := &models.SecretData{}
-	err := s.db.Pool.QueryRow(ctx, query, secret.UserID, secret.Type, secret.Name, secret.Content).Scan(&m.ID, &m.Created, &m.Updated)
+	err = s.InTx(ctx, func(tx Secret) error {
+		conn := tx.(*SecretStorage)
+		err := conn.conn().QueryRow(ctx, query, secret.UserID, secret.OrgID, secret.Type, env.name, env.content, env.keyID, env.dataKey,
+			secret.Expires, expirePolicy(secret.ExpirePolicy), secret.Meta, nullID(secret.ID), secret.WrappedKey, crypto.ContentHash(secret.Content)).
+			Scan(&m.ID, &m.OrgID, &m.Created, &m.Updated, &m.Expires, &m.ExpirePolicy, &m.Revision, &m.ContentHash)
+		if err != nil {
+			return err
+		}
+		return conn.setIndex(ctx, m.ID, secret.SearchIndex)
+	})
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(string(pgErr.Code)) {
@@ -46,17 +67,28 @@ func (s *SecretStorage) Add(ctx context.Context, secret *models.SecretData) (*mo
 // Get - получение записи с секретом (возвращает модель секрета)
 func (s *SecretStorage) Get(ctx context.Context, sid uuid.UUID) (*models.SecretData, error) {
 	const query = `
-		SELECT id, type_secret, name, content, created_at, updated_at FROM secrets
+		SELECT id, user_id, org_id, type_secret, name, content, created_at, updated_at, key_id, data_key,
+		       expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision, content_hash
+		FROM secrets
 		WHERE id = $1;
 `
+	var (
+		keyID   sql.NullString
+		dataKey []byte
+	)
 	m := &models.SecretData{}
-	err := s.db.Pool.QueryRow(ctx, query, sid.String()).Scan(&m.ID, &m.Type, &m.Name, &m.Content, &m.Created, &m.Updated)
+	err := s.conn().QueryRow(ctx, query, sid.String()).
+		Scan(&m.ID, &m.UserID, &m.OrgID, &m.Type, &m.Name, &m.Content, &m.Created, &m.Updated, &keyID, &dataKey,
+			&m.Expires, &m.ExpirePolicy, &m.Archived, &m.Meta, &m.WrappedKey, &m.Revision, &m.ContentHash)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get secret: %w", err)
 	}
+	if err := s.open(ctx, m, keyID, dataKey); err != nil {
+		return nil, err
+	}
 	return m, nil
 }
 
@@ -66,7 +98,7 @@ func (s *SecretStorage) Delete(ctx context.Context, sid uuid.UUID) error {
 		DELETE FROM secrets
 		WHERE id = $1;
 `
-	res, err := s.db.Pool.Exec(ctx, query, sid)
+	res, err := s.conn().Exec(ctx, query, sid)
 	if err != nil {
 		return fmt.Errorf("failed to delete secret: %w", err)
 	}
@@ -76,64 +108,133 @@ func (s *SecretStorage) Delete(ctx context.Context, sid uuid.UUID) error {
 	return nil
 }
 
-// List - метод возвращает список секретов пользователя
-func (s *SecretStorage) List(ctx context.Context, uid uuid.UUID) ([]*models.SecretData, error) {
+// List - метод возвращает список действующих (или архивных) секретов личного хранилища пользователя
+func (s *SecretStorage) List(ctx context.Context, uid uuid.UUID, archived bool) ([]*models.SecretData, error) {
+	const SQL = `
+		SELECT id, user_id, org_id, type_secret, name, created_at, updated_at, key_id, data_key,
+		       expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision, content_hash
+		FROM secrets
+		WHERE user_id = $1 AND org_id IS NULL AND (archived_at IS NOT NULL) = $2
+`
+	return s.list(ctx, SQL, uid, archived)
+}
+
+// ListByOrganization - метод возвращает список действующих (или архивных) секретов хранилища организации
+func (s *SecretStorage) ListByOrganization(ctx context.Context, oid uuid.UUID, archived bool) ([]*models.SecretData, error) {
 	const SQL = `
-		SELECT id, user_id, type_secret, name, created_at, updated_at FROM secrets
-		WHERE user_id = $1 ORDER BY name
+		SELECT id, user_id, org_id, type_secret, name, created_at, updated_at, key_id, data_key,
+		       expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision, content_hash
+		FROM secrets
+		WHERE org_id = $1 AND (archived_at IS NOT NULL) = $2
 `
-	rows, err := s.db.Pool.Query(ctx, SQL, uid)
+	return s.list(ctx, SQL, oid, archived)
+}
+
+// list - метод выполняет запрос списка секретов (список упорядочен по названию
+// после расшифровки, так как в базе названия могут храниться зашифрованными)
+func (s *SecretStorage) list(ctx context.Context, SQL string, args ...any) ([]*models.SecretData, error) {
+	rows, err := s.conn().Query(ctx, SQL, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get secrets: %w", err)
 	}
+	defer rows.Close()
+
 	res := make([]*models.SecretData, 0)
 
 	for rows.Next() {
 		var (
 			id          uuid.UUID
 			user_id     uuid.UUID
+			org_id      uuid.NullUUID
 			type_secret string
 			name        string
 			created     time.Time
 			updated     time.Time
+			key_id      sql.NullString
+			data_key    []byte
+			expires     sql.NullTime
+			policy      string
+			archive     bool
+			meta        []byte
+			wrapped     []byte
+			revision    int64
+			hash        []byte
 		)
 		err := rows.Scan(
 			&id,
 			&user_id,
+			&org_id,
 			&type_secret,
 			&name,
 			&created,
 			&updated,
+			&key_id,
+			&data_key,
+			&expires,
+			&policy,
+			&archive,
+			&meta,
+			&wrapped,
+			&revision,
+			&hash,
 		)
 		if err != nil {
 			return res, fmt.Errorf("failed scan secret data: %w", err)
 		}
-		res = append(res, &models.SecretData{
-			ID:      id,
-			UserID:  user_id,
-			Name:    name,
-			Type:    type_secret,
-			Created: created,
-			Updated: updated})
+		m := &models.SecretData{
+			ID:           id,
+			UserID:       user_id,
+			OrgID:        org_id,
+			Name:         name,
+			Type:         type_secret,
+			Created:      created,
+			Updated:      updated,
+			Expires:      expires,
+			ExpirePolicy: policy,
+			Archived:     archive,
+			Meta:         meta,
+			WrappedKey:   wrapped,
+			Revision:     revision,
+			ContentHash:  hash}
+		if err := s.open(ctx, m, key_id, data_key); err != nil {
+			return res, err
+		}
+		res = append(res, m)
 	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
 
 	return res, nil
 }
 
-// Edit - метод изменяет запись секрета (возвращает модель секрета)
+// Edit - метод изменяет запись секрета и увеличивает его ревизию (возвращает модель секрета)
 func (s *SecretStorage) Edit(ctx context.Context, secret *models.SecretData) (*models.SecretData, error) {
 	const query = `
 		UPDATE secrets 
-		SET name = $2, content = $3, updated_at = CURRENT_TIMESTAMP
+		SET name = $2, content = $3, key_id = $4, data_key = $5, meta = $6, wrapped_key = $7, content_hash = $8,
+		    revision = revision + 1, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
-		RETURNING id, user_id, type_secret, name, content, created_at, updated_at;
+		RETURNING id, user_id, org_id, type_secret, created_at, updated_at, expires_at, expire_policy, archived_at IS NOT NULL, revision, content_hash;
 `
-	m := &models.SecretData{}
-	err := s.db.Pool.QueryRow(ctx, query, secret.ID, secret.Name, secret.Content).
-		Scan(&m.ID, &m.UserID, &m.Type, &m.Name, &m.Content, &m.Created, &m.Updated)
+	env, err := s.seal(ctx, secret.Name, secret.Content)
+	if err != nil {
+		return nil, err
+	}
+	m := &models.SecretData{Name: secret.Name, Content: secret.Content, Meta: secret.Meta, WrappedKey: secret.WrappedKey}
+	err = s.InTx(ctx, func(tx Secret) error {
+		conn := tx.(*SecretStorage)
+		err := conn.conn().QueryRow(ctx, query, secret.ID, env.name, env.content, env.keyID, env.dataKey, secret.Meta, secret.WrappedKey,
+			crypto.ContentHash(secret.Content)).
+			Scan(&m.ID, &m.UserID, &m.OrgID, &m.Type, &m.Created, &m.Updated, &m.Expires, &m.ExpirePolicy, &m.Archived, &m.Revision, &m.ContentHash)
+		if err != nil {
+			return err
+		}
+		return conn.setIndex(ctx, m.ID, secret.SearchIndex)
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -143,3 +244,72 @@ func (s *SecretStorage) Edit(ctx context.Context, secret *models.SecretData) (*m
 
 	return m, nil
 }
+
+// SetExpiration - метод изменяет срок действия секрета и действие по его истечении (ревизия секрета увеличивается).
+// Если новый срок не истёк, секрет возвращается из архива.
+func (s *SecretStorage) SetExpiration(ctx context.Context, secret *models.SecretData) (*models.SecretData, error) {
+	const query = `
+		UPDATE secrets
+		SET expires_at = $2, expire_policy = $3, revision = revision + 1,
+		    archived_at = CASE WHEN $2::timestamptz IS NULL OR $2::timestamptz > NOW() THEN NULL ELSE archived_at END
+		WHERE id = $1
+		RETURNING id, user_id, org_id, type_secret, name, created_at, updated_at, key_id, data_key,
+		          expires_at, expire_policy, archived_at IS NOT NULL, meta, wrapped_key, revision, content_hash;
+`
+	var (
+		keyID   sql.NullString
+		dataKey []byte
+	)
+	m := &models.SecretData{}
+	err := s.conn().QueryRow(ctx, query, secret.ID, secret.Expires, expirePolicy(secret.ExpirePolicy)).
+		Scan(&m.ID, &m.UserID, &m.OrgID, &m.Type, &m.Name, &m.Created, &m.Updated, &keyID, &dataKey,
+			&m.Expires, &m.ExpirePolicy, &m.Archived, &m.Meta, &m.WrappedKey, &m.Revision, &m.ContentHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set secret expiration: %w", err)
+	}
+	if err := s.open(ctx, m, keyID, dataKey); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Expire - метод применяет действие к секретам, срок действия которых истёк к моменту now
+// (удаление или перемещение в архив). Возвращает количество удалённых и архивированных секретов.
+func (s *SecretStorage) Expire(ctx context.Context, now time.Time) (int64, int64, error) {
+	const (
+		deleteQuery = `
+		DELETE FROM secrets
+		WHERE expires_at <= $1 AND expire_policy = $2
+`
+		archiveQuery = `
+		UPDATE secrets
+		SET archived_at = $1
+		WHERE expires_at <= $1 AND expire_policy = $2 AND archived_at IS NULL
+`
+	)
+	deleted, err := s.conn().Exec(ctx, deleteQuery, now, models.ExpireDelete)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to delete expired secrets: %w", err)
+	}
+	archived, err := s.conn().Exec(ctx, archiveQuery, now, models.ExpireArchive)
+	if err != nil {
+		return deleted.RowsAffected(), 0, fmt.Errorf("failed to archive expired secrets: %w", err)
+	}
+	return deleted.RowsAffected(), archived.RowsAffected(), nil
+}
+
+// expirePolicy - метод возвращает действие по истечении срока (по умолчанию - пометка)
+func expirePolicy(policy string) string {
+	if policy == "" {
+		return models.ExpireFlag
+	}
+	return policy
+}
+
+// nullID - метод возвращает идентификатор для вставки (пустой заменяется значением по умолчанию в базе)
+func nullID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ShareStorage - хранилище секретов, переданных другим пользователям
+type ShareStorage struct {
+	db      *Database      // указатель на базу данных
+	secrets *SecretStorage // хранилище секретов (названия секретов шифруются на сервере)
+}
+
+// NewShareStorage - метод создаёт подключение к таблице переданных секретов
+func NewShareStorage(db *Database, secrets *SecretStorage) *ShareStorage {
+	return &ShareStorage{db: db, secrets: secrets}
+}
+
+// Add - метод добавляет запись о передаче секрета по ссылке: сохраняется только ключ данных секрета,
+// зашифрованный для получателя (повторная передача заменяет ключ, копия содержимого прежней передачи удаляется)
+func (s *ShareStorage) Add(ctx context.Context, share *models.ShareData) (*models.ShareData, error) {
+	const query = `
+		INSERT INTO shares (secret_id, owner_id, recipient_id, wrapped_key, content, meta)
+		VALUES ($1, $2, $3, $4, NULL, NULL)
+		ON CONFLICT (secret_id, recipient_id)
+		DO UPDATE SET wrapped_key = EXCLUDED.wrapped_key, content = NULL, meta = NULL, created_at = NOW()
+		RETURNING id, created_at
+`
+	m := *share
+	err := s.db.Pool.QueryRow(ctx, query, share.SecretID, share.OwnerID, share.RecipientID, share.WrappedKey).
+		Scan(&m.ID, &m.Created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add share: %w", err)
+	}
+	return &m, nil
+}
+
+// ListByRecipient - метод возвращает список секретов, переданных пользователю, с текущим содержимым
+// и метаданными секретов (у копий, переданных ранее, - с сохранённой копией). Список упорядочен
+// по названию после расшифровки, так как в базе названия могут храниться зашифрованными.
+func (s *ShareStorage) ListByRecipient(ctx context.Context, uid uuid.UUID) ([]*models.ShareData, error) {
+	const query = `
+		SELECT sh.id, sh.secret_id, sh.owner_id, o.login, sh.recipient_id, r.login,
+		       s.name, s.type_secret, s.user_id, s.org_id, s.content, s.meta, s.key_id, s.data_key,
+		       sh.wrapped_key, sh.content, sh.meta, sh.created_at
+		FROM shares sh
+		JOIN secrets s ON s.id = sh.secret_id
+		JOIN users o ON o.id = sh.owner_id
+		JOIN users r ON r.id = sh.recipient_id
+		WHERE sh.recipient_id = $1
+`
+	rows, err := s.db.Pool.Query(ctx, query, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get shares: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.ShareData, 0)
+	for rows.Next() {
+		var (
+			userID  uuid.UUID
+			orgID   uuid.NullUUID
+			content []byte
+			meta    []byte
+			keyID   sql.NullString
+			dataKey []byte
+		)
+		m := &models.ShareData{}
+		err := rows.Scan(
+			&m.ID,
+			&m.SecretID,
+			&m.OwnerID,
+			&m.Owner,
+			&m.RecipientID,
+			&m.Recipient,
+			&m.Name,
+			&m.Type,
+			&userID,
+			&orgID,
+			&content,
+			&meta,
+			&keyID,
+			&dataKey,
+			&m.WrappedKey,
+			&m.Content,
+			&m.Meta,
+			&m.Created,
+		)
+		if err != nil {
+			return res, fmt.Errorf("failed scan share data: %w", err)
+		}
+		if err := s.secrets.unseal(ctx, m.SecretID, &m.Name, &content, keyID, dataKey); err != nil {
+			return res, err
+		}
+		if m.Content == nil {
+			m.Content, m.Meta, m.VaultOwner = content, meta, userID.String()
+			if orgID.Valid {
+				m.VaultOwner = orgID.UUID.String()
+			}
+		}
+		res = append(res, m)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// Delete - метод удаляет запись о передаче секрета пользователю
+func (s *ShareStorage) Delete(ctx context.Context, sid uuid.UUID, recipient uuid.UUID) error {
+	const query = `
+		DELETE FROM shares
+		WHERE secret_id = $1 AND recipient_id = $2;
+`
+	res, err := s.db.Pool.Exec(ctx, query, sid, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to delete share: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
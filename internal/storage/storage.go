@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +14,38 @@ type User interface {
 	Add(ctx context.Context, user *models.UserData) (uuid.UUID, error)
 	// Get - получение пользователя (возвращает модель пользователя)
 	Get(ctx context.Context, login string, password string) (*models.UserData, error)
+	// GetByLogin - получение пользователя по логину для входа по SRP (возвращает модель пользователя)
+	GetByLogin(ctx context.Context, login string) (*models.UserData, error)
+	// SetVerifier - сохранение проверочного значения пароля SRP (хеш bcrypt удаляется)
+	SetVerifier(ctx context.Context, uid uuid.UUID, salt []byte, verifier []byte) error
+	// SetKeys - сохранение пары ключей пользователя (закрытый ключ хранится в зашифрованном виде)
+	SetKeys(ctx context.Context, uid uuid.UUID, public []byte, private []byte) error
+	// SetKDF - смена параметров получения ключа из пароля и зашифрованного им ключа шифрования
+	SetKDF(ctx context.Context, uid uuid.UUID, kdf crypto.KDFParams, wrapped []byte) error
+	// SetKeyCheck - сохранение контрольного значения ключа шифрования (зашифровано на клиенте)
+	SetKeyCheck(ctx context.Context, uid uuid.UUID, check []byte) error
+	// SetRecoveryKey - сохранение ключа шифрования, зашифрованного ключом восстановления
+	SetRecoveryKey(ctx context.Context, uid uuid.UUID, wrapped []byte) error
+	// GetRecoveryKey - получение ключа шифрования, зашифрованного ключом восстановления
+	GetRecoveryKey(ctx context.Context, uid uuid.UUID) ([]byte, error)
+	// SetVaultRoot - замена подписанного на клиенте состояния личного хранилища, если текущий номер состояния равен prev
+	SetVaultRoot(ctx context.Context, uid uuid.UUID, root []byte, seq int64, prev int64) error
+	// GetVaultRoot - получение подписанного на клиенте состояния личного хранилища и его номера
+	GetVaultRoot(ctx context.Context, uid uuid.UUID) ([]byte, int64, error)
+	// SetEscrowKey - сохранение ключа шифрования, зашифрованного открытым ключом депонирования организации
+	SetEscrowKey(ctx context.Context, uid uuid.UUID, sealed []byte) error
+	// GetKeys - получение пары ключей пользователя (возвращает модель пользователя)
+	GetKeys(ctx context.Context, uid uuid.UUID) (*models.UserData, error)
+	// GetPublicKey - получение открытого ключа пользователя по логину (возвращает модель пользователя)
+	GetPublicKey(ctx context.Context, login string) (*models.UserData, error)
+	// GetStatus - получение состояния учётной записи (блокировка, принудительный выход)
+	GetStatus(ctx context.Context, uid uuid.UUID) (*models.UserData, error)
+	// List - список пользователей со статистикой использования
+	List(ctx context.Context) ([]*models.UserStats, error)
+	// SetDisabled - блокировка (разблокировка) учётной записи по логину
+	SetDisabled(ctx context.Context, login string, disabled bool) error
+	// ForceLogout - принудительный выход: токены, выпущенные ранее, становятся недействительными
+	ForceLogout(ctx context.Context, login string) error
 }
 type Secret interface {
 	// Add - добавление записи с секретом (возвращает модель секрета)
@@ -21,13 +54,60 @@ type Secret interface {
 	Get(ctx context.Context, sid uuid.UUID) (*models.SecretData, error)
 	// Delete - удаление записи с секретом
 	Delete(ctx context.Context, sid uuid.UUID) error
-	// List - список записей с секретами (возвращает модель информаций о секретах)
-	List(ctx context.Context, uid uuid.UUID) ([]*models.SecretData, error)
+	// List - список записей с действующими (или архивными) секретами личного хранилища (возвращает модель информаций о секретах)
+	List(ctx context.Context, uid uuid.UUID, archived bool) ([]*models.SecretData, error)
+	// ListByOrganization - список записей с действующими (или архивными) секретами хранилища организации
+	ListByOrganization(ctx context.Context, oid uuid.UUID, archived bool) ([]*models.SecretData, error)
 	// Edit - изменение записи с секретом (возвращает модель секрета)
 	Edit(ctx context.Context, m *models.SecretData) (*models.SecretData, error)
+	// SetExpiration - изменение срока действия секрета (возвращает модель секрета)
+	SetExpiration(ctx context.Context, m *models.SecretData) (*models.SecretData, error)
+	// Search - поиск действующих секретов личного хранилища по слепому индексу (совпадение всех значений)
+	Search(ctx context.Context, uid uuid.UUID, tokens [][]byte) ([]*models.SecretData, error)
+	// SearchByOrganization - поиск действующих секретов хранилища организации по слепому индексу
+	SearchByOrganization(ctx context.Context, oid uuid.UUID, tokens [][]byte) ([]*models.SecretData, error)
+	// InTx - выполнение группы операций в одной транзакции (при ошибке fn изменения отменяются)
+	InTx(ctx context.Context, fn func(tx Secret) error) error
+}
+type Attachment interface {
+	// Add - добавление вложения к секрету (возвращает модель вложения без содержимого)
+	Add(ctx context.Context, m *models.AttachmentData) (*models.AttachmentData, error)
+	// Get - получение вложения с содержимым
+	Get(ctx context.Context, aid uuid.UUID) (*models.AttachmentData, error)
+	// List - список вложений секрета (без содержимого)
+	List(ctx context.Context, sid uuid.UUID) ([]*models.AttachmentData, error)
+	// Delete - удаление вложения
+	Delete(ctx context.Context, aid uuid.UUID) error
+}
+type Share interface {
+	// Add - добавление (или обновление) записи о передаче секрета (возвращает модель передачи)
+	Add(ctx context.Context, m *models.ShareData) (*models.ShareData, error)
+	// ListByRecipient - список секретов, переданных пользователю (возвращает модели передачи)
+	ListByRecipient(ctx context.Context, uid uuid.UUID) ([]*models.ShareData, error)
+	// Delete - удаление записи о передаче секрета
+	Delete(ctx context.Context, sid uuid.UUID, recipient uuid.UUID) error
+}
+type Organization interface {
+	// Create - создание организации с владельцем (возвращает модель организации)
+	Create(ctx context.Context, org *models.OrganizationData, owner *models.MemberData) (*models.OrganizationData, error)
+	// ListByUser - список организаций, в которых состоит пользователь
+	ListByUser(ctx context.Context, uid uuid.UUID) ([]*models.OrganizationData, error)
+	// GetMember - получение участника организации (возвращает модель участника)
+	GetMember(ctx context.Context, oid uuid.UUID, uid uuid.UUID) (*models.MemberData, error)
+	// ListMembers - список участников организации
+	ListMembers(ctx context.Context, oid uuid.UUID) ([]*models.MemberData, error)
+	// AddMember - добавление участника в организацию (возвращает модель участника)
+	AddMember(ctx context.Context, m *models.MemberData) (*models.MemberData, error)
+	// UpdateMember - изменение роли участника (возвращает модель участника)
+	UpdateMember(ctx context.Context, m *models.MemberData) (*models.MemberData, error)
+	// DeleteMember - удаление участника из организации (ключ хранилища отмечается для замены)
+	DeleteMember(ctx context.Context, oid uuid.UUID, uid uuid.UUID) error
+	// RotateKey - замена ключа хранилища организации для всех участников и секретов
+	RotateKey(ctx context.Context, oid uuid.UUID, members []*models.MemberData, secrets []*models.SecretData) error
 }
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	ErrConflict      = errors.New("conflict")
 )
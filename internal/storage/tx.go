@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// querier - общий интерфейс пула соединений и транзакции
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// conn - метод возвращает текущую транзакцию единицы работы (или пул соединений вне её)
+func (s *SecretStorage) conn() querier {
+	if s.tx != nil {
+		return s.tx
+	}
+	return s.db.Pool
+}
+
+// InTx - метод выполняет fn как единицу работы: все операции хранилища, переданного в fn,
+// выполняются в одной транзакции, которая фиксируется только при успешном завершении fn.
+// Вложенный вызов присоединяется к уже открытой транзакции.
+func (s *SecretStorage) InTx(ctx context.Context, fn func(tx Secret) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&SecretStorage{db: s.db, keys: s.keys, tx: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
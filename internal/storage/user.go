@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/crypto"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
@@ -25,12 +26,13 @@ func NewUserStorage(db *Database) *UserStorage {
 // Add - метод добавляет пользователя в хранилище
 func (s *UserStorage) Add(ctx context.Context, user *models.UserData) (uuid.UUID, error) {
 	const query = `
-		INSERT INTO users (login, password, salt)
-		VALUES ($1, crypt($2, gen_salt('bf')), $3)
+		INSERT INTO users (login, password, salt, kdf_algorithm, kdf_memory, kdf_iterations, kdf_parallelism, srp_salt, srp_verifier)
+		VALUES ($1, crypt(NULLIF($2, ''), gen_salt('bf')), $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 `
 	var uid uuid.UUID
-	err := s.db.Pool.QueryRow(ctx, query, user.Login, user.Password, user.Salt).Scan(&uid)
+	err := s.db.Pool.QueryRow(ctx, query, user.Login, user.Password, user.Salt,
+		user.KDF.Algorithm, user.KDF.Memory, user.KDF.Iterations, user.KDF.Parallelism, user.SRPSalt, user.Verifier).Scan(&uid)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(string(pgErr.Code)) {
@@ -45,12 +47,15 @@ func (s *UserStorage) Add(ctx context.Context, user *models.UserData) (uuid.UUID
 // Get - метод извлекает пользователя из хранилища с использованием логина и пароля
 func (s *UserStorage) Get(ctx context.Context, login string, password string) (*models.UserData, error) {
 	const query = `
-		SELECT id, login, salt FROM users
+		SELECT id, login, salt, disabled, kdf_algorithm, kdf_memory, kdf_iterations, kdf_parallelism, wrapped_key,
+		       key_check, token_generation FROM users
 		WHERE login = $1 AND password = crypt($2, password);
 `
 	user := &models.UserData{}
 
-	err := s.db.Pool.QueryRow(ctx, query, login, password).Scan(&user.ID, &user.Login, &user.Salt)
+	err := s.db.Pool.QueryRow(ctx, query, login, password).Scan(&user.ID, &user.Login, &user.Salt, &user.Disabled,
+		&user.KDF.Algorithm, &user.KDF.Memory, &user.KDF.Iterations, &user.KDF.Parallelism, &user.WrappedKey,
+		&user.KeyCheck, &user.Generation)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -60,3 +65,316 @@ func (s *UserStorage) Get(ctx context.Context, login string, password string) (*
 
 	return user, nil
 }
+
+// GetByLogin - метод извлекает пользователя из хранилища по логину (для входа по SRP)
+func (s *UserStorage) GetByLogin(ctx context.Context, login string) (*models.UserData, error) {
+	const query = `
+		SELECT id, login, salt, disabled, kdf_algorithm, kdf_memory, kdf_iterations, kdf_parallelism, wrapped_key,
+		       srp_salt, srp_verifier, key_check, token_generation FROM users
+		WHERE login = $1;
+`
+	user := &models.UserData{}
+
+	err := s.db.Pool.QueryRow(ctx, query, login).Scan(&user.ID, &user.Login, &user.Salt, &user.Disabled,
+		&user.KDF.Algorithm, &user.KDF.Memory, &user.KDF.Iterations, &user.KDF.Parallelism, &user.WrappedKey,
+		&user.SRPSalt, &user.Verifier, &user.KeyCheck, &user.Generation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetVerifier - метод сохраняет проверочное значение пароля SRP и удаляет хеш bcrypt
+// (после этого вход с передачей пароля невозможен)
+func (s *UserStorage) SetVerifier(ctx context.Context, uid uuid.UUID, salt []byte, verifier []byte) error {
+	const query = `
+		UPDATE users
+		SET srp_salt = $2, srp_verifier = $3, password = NULL
+		WHERE id = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, salt, verifier)
+	if err != nil {
+		return fmt.Errorf("failed to set user verifier: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetKeys - метод сохраняет пару ключей пользователя
+func (s *UserStorage) SetKeys(ctx context.Context, uid uuid.UUID, public []byte, private []byte) error {
+	const query = `
+		UPDATE users
+		SET public_key = $2, private_key = $3
+		WHERE id = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, public, private)
+	if err != nil {
+		return fmt.Errorf("failed to set user keys: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetKDF - метод сохраняет параметры получения ключа из пароля и ключ шифрования,
+// зашифрованный ключом, полученным с этими параметрами
+func (s *UserStorage) SetKDF(ctx context.Context, uid uuid.UUID, kdf crypto.KDFParams, wrapped []byte) error {
+	const query = `
+		UPDATE users
+		SET kdf_algorithm = $2, kdf_memory = $3, kdf_iterations = $4, kdf_parallelism = $5, wrapped_key = $6
+		WHERE id = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, kdf.Algorithm, kdf.Memory, kdf.Iterations, kdf.Parallelism, wrapped)
+	if err != nil {
+		return fmt.Errorf("failed to set user kdf: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetKeyCheck - метод сохраняет контрольное значение ключа шифрования пользователя
+func (s *UserStorage) SetKeyCheck(ctx context.Context, uid uuid.UUID, check []byte) error {
+	const query = `
+		UPDATE users
+		SET key_check = $2
+		WHERE id = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, check)
+	if err != nil {
+		return fmt.Errorf("failed to set user key check: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetRecoveryKey - метод сохраняет ключ шифрования пользователя, зашифрованный ключом восстановления
+func (s *UserStorage) SetRecoveryKey(ctx context.Context, uid uuid.UUID, wrapped []byte) error {
+	const query = `
+		UPDATE users
+		SET recovery_key = $2
+		WHERE id = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, wrapped)
+	if err != nil {
+		return fmt.Errorf("failed to set user recovery key: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetRecoveryKey - метод извлекает ключ шифрования пользователя, зашифрованный ключом восстановления
+func (s *UserStorage) GetRecoveryKey(ctx context.Context, uid uuid.UUID) ([]byte, error) {
+	const query = `
+		SELECT recovery_key FROM users
+		WHERE id = $1 AND recovery_key IS NOT NULL;
+`
+	var wrapped []byte
+	err := s.db.Pool.QueryRow(ctx, query, uid).Scan(&wrapped)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user recovery key: %w", err)
+	}
+	return wrapped, nil
+}
+
+// SetVaultRoot - метод заменяет подписанное на клиенте состояние личного хранилища, если номер
+// сохранённого состояния равен prev (иначе состояние изменено другим клиентом и возвращается ErrConflict)
+func (s *UserStorage) SetVaultRoot(ctx context.Context, uid uuid.UUID, root []byte, seq int64, prev int64) error {
+	const query = `
+		UPDATE users
+		SET vault_root = $2, vault_root_seq = $3
+		WHERE id = $1 AND vault_root_seq = $4;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, root, seq, prev)
+	if err != nil {
+		return fmt.Errorf("failed to set user vault root: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+// GetVaultRoot - метод извлекает подписанное на клиенте состояние личного хранилища и его номер
+func (s *UserStorage) GetVaultRoot(ctx context.Context, uid uuid.UUID) ([]byte, int64, error) {
+	const query = `
+		SELECT vault_root, vault_root_seq FROM users
+		WHERE id = $1 AND vault_root IS NOT NULL;
+`
+	var (
+		root []byte
+		seq  int64
+	)
+	err := s.db.Pool.QueryRow(ctx, query, uid).Scan(&root, &seq)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to get user vault root: %w", err)
+	}
+	return root, seq, nil
+}
+
+// SetEscrowKey - метод сохраняет ключ шифрования пользователя, зашифрованный открытым ключом депонирования
+func (s *UserStorage) SetEscrowKey(ctx context.Context, uid uuid.UUID, sealed []byte) error {
+	const query = `
+		UPDATE users
+		SET escrow_key = $2
+		WHERE id = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, uid, sealed)
+	if err != nil {
+		return fmt.Errorf("failed to set user escrow key: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetKeys - метод извлекает пару ключей пользователя
+func (s *UserStorage) GetKeys(ctx context.Context, uid uuid.UUID) (*models.UserData, error) {
+	const query = `
+		SELECT id, login, public_key, private_key FROM users
+		WHERE id = $1 AND public_key IS NOT NULL;
+`
+	user := &models.UserData{}
+
+	err := s.db.Pool.QueryRow(ctx, query, uid).Scan(&user.ID, &user.Login, &user.PublicKey, &user.PrivateKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user keys: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetPublicKey - метод извлекает открытый ключ пользователя по логину
+func (s *UserStorage) GetPublicKey(ctx context.Context, login string) (*models.UserData, error) {
+	const query = `
+		SELECT id, login, public_key FROM users
+		WHERE login = $1 AND public_key IS NOT NULL;
+`
+	user := &models.UserData{}
+
+	err := s.db.Pool.QueryRow(ctx, query, login).Scan(&user.ID, &user.Login, &user.PublicKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user public key: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetStatus - метод возвращает состояние учётной записи пользователя
+func (s *UserStorage) GetStatus(ctx context.Context, uid uuid.UUID) (*models.UserData, error) {
+	const query = `
+		SELECT id, login, disabled, logout_at, token_generation FROM users
+		WHERE id = $1;
+`
+	user := &models.UserData{}
+	err := s.db.Pool.QueryRow(ctx, query, uid).Scan(&user.ID, &user.Login, &user.Disabled, &user.LogoutAt, &user.Generation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user status: %w", err)
+	}
+	return user, nil
+}
+
+// List - метод возвращает список пользователей со статистикой использования
+func (s *UserStorage) List(ctx context.Context) ([]*models.UserStats, error) {
+	const query = `
+		SELECT u.id, u.login, u.disabled, u.created_at,
+		       (SELECT COUNT(*) FROM secrets s WHERE s.user_id = u.id),
+		       (SELECT COALESCE(SUM(LENGTH(s.content)), 0) FROM secrets s WHERE s.user_id = u.id),
+		       (SELECT COUNT(*) FROM shares sh WHERE sh.owner_id = u.id),
+		       (SELECT COUNT(*) FROM memberships m WHERE m.user_id = u.id),
+		       (SELECT MAX(s.updated_at) FROM secrets s WHERE s.user_id = u.id)
+		FROM users u ORDER BY u.login
+`
+	rows, err := s.db.Pool.Query(ctx, query)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]*models.UserStats, 0)
+	for rows.Next() {
+		m := &models.UserStats{}
+		err := rows.Scan(
+			&m.ID,
+			&m.Login,
+			&m.Disabled,
+			&m.Created,
+			&m.Secrets,
+			&m.ContentSize,
+			&m.Shares,
+			&m.Organizations,
+			&m.LastActivity,
+		)
+		if err != nil {
+			return res, fmt.Errorf("failed scan user stats: %w", err)
+		}
+		res = append(res, m)
+	}
+
+	return res, nil
+}
+
+// SetDisabled - метод блокирует (разблокирует) учётную запись пользователя
+func (s *UserStorage) SetDisabled(ctx context.Context, login string, disabled bool) error {
+	const query = `
+		UPDATE users
+		SET disabled = $2
+		WHERE login = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, login, disabled)
+	if err != nil {
+		return fmt.Errorf("failed to set user disabled: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ForceLogout - метод отзывает все ранее выпущенные токены пользователя (увеличивает поколение токенов)
+func (s *UserStorage) ForceLogout(ctx context.Context, login string) error {
+	const query = `
+		UPDATE users
+		SET logout_at = NOW(), token_generation = token_generation + 1
+		WHERE login = $1;
+`
+	res, err := s.db.Pool.Exec(ctx, query, login)
+	if err != nil {
+		return fmt.Errorf("failed to force logout: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
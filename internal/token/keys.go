@@ -0,0 +1,152 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Key - асимметричный ключ подписи (проверки) токенов.
+// Поддерживаются Ed25519 (EdDSA) и ECDSA P-256 (ES256).
+type Key struct {
+	ID      string            // идентификатор ключа (kid), отпечаток открытого ключа по RFC 7638
+	Method  jwt.SigningMethod // алгоритм подписи
+	Private crypto.Signer     // закрытый ключ (nil - ключ только для проверки)
+	Public  crypto.PublicKey  // открытый ключ
+}
+
+// NewKey - метод создания ключа по закрытому или открытому ключу
+func NewKey(k any) (*Key, error) {
+	key := &Key{}
+	switch k := k.(type) {
+	case ed25519.PrivateKey:
+		key.Private, key.Public = k, k.Public()
+	case *ecdsa.PrivateKey:
+		key.Private, key.Public = k, k.Public()
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+		key.Public = k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", k)
+	}
+	switch pub := key.Public.(type) {
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported curve %s", pub.Curve.Params().Name)
+		}
+		key.Method = jwt.SigningMethodES256
+	}
+	thumbprint, err := key.thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	key.ID = thumbprint
+	return key, nil
+}
+
+// LoadKey - метод загрузки ключа из PEM файла (PKCS#8, SEC 1 или PKIX)
+func LoadKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM key %s", path)
+	}
+	var k any
+	switch block.Type {
+	case "PRIVATE KEY":
+		k, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		k, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		k, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %s: %w", path, err)
+	}
+	return NewKey(k)
+}
+
+// JWK - открытый ключ в формате JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+// JWKS - набор открытых ключей для проверки токенов
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK - метод возвращает открытый ключ в формате JWK
+func (k *Key) JWK() JWK {
+	jwk := JWK{Kid: k.ID, Alg: k.Method.Alg(), Use: "sig"}
+	switch pub := k.Public.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty, jwk.Crv = "OKP", "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty, jwk.Crv = "EC", pub.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	}
+	return jwk
+}
+
+// thumbprint - метод вычисляет отпечаток открытого ключа по RFC 7638
+func (k *Key) thumbprint() (string, error) {
+	jwk := k.JWK()
+	// обязательные поля в лексикографическом порядке
+	var members any
+	if jwk.Kty == "EC" {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("failed to build key thumbprint: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// ServeHTTP - метод публикации набора открытых ключей в формате JSON
+func (s JWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "max-age=300")
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,167 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519Key(t *testing.T) *Key {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := NewKey(priv)
+	require.NoError(t, err)
+	return key
+}
+
+func newES256Key(t *testing.T) *Key {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := NewKey(priv)
+	require.NoError(t, err)
+	return key
+}
+
+func TestKeyJWT(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  *Key
+		alg  string
+	}{
+		{
+			name: "Ed25519 #1",
+			key:  newEd25519Key(t),
+			alg:  "EdDSA",
+		},
+		{
+			name: "ES256 #2",
+			key:  newES256Key(t),
+			alg:  "ES256",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			th, err := NewKeyJWT(tc.key)
+			require.NoError(t, err)
+
+			tokenString, err := th.BuildJWT(userID, 2)
+			require.NoError(t, err)
+
+			claims, err := th.ParseJWT(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.Id)
+			assert.Equal(t, int64(2), claims.Generation)
+
+			jwks := th.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tc.key.ID, jwks.Keys[0].Kid)
+			assert.Equal(t, tc.alg, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	newKey := newES256Key(t)
+
+	oldHandler, err := NewKeyJWT(oldKey)
+	require.NoError(t, err)
+	oldToken, err := oldHandler.BuildJWT(userID, 0)
+	require.NoError(t, err)
+
+	// после ротации старые токены продолжают проверяться
+	rotated, err := NewKeyJWT(newKey, oldKey)
+	require.NoError(t, err)
+	claims, err := rotated.ParseJWT(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.Id)
+	assert.Len(t, rotated.JWKS().Keys, 2)
+
+	// после удаления старого ключа токены перестают приниматься
+	withoutOld, err := NewKeyJWT(newKey)
+	require.NoError(t, err)
+	_, err = withoutOld.ParseJWT(oldToken)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown signing key")
+
+	// токен с общим секретом не принимается при асимметричной подписи
+	hmac, err := NewJWT("valid-secret-key")
+	require.NoError(t, err)
+	hmacToken, err := hmac.BuildJWT(userID, 0)
+	require.NoError(t, err)
+	_, err = rotated.ParseJWT(hmacToken)
+	require.Error(t, err)
+
+	// ключ только для проверки не может подписывать
+	verifyOnly, err := NewKey(oldKey.Public)
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID, verifyOnly.ID)
+	_, err = NewKeyJWT(verifyOnly)
+	require.Error(t, err)
+}
+
+func TestLoadKey(t *testing.T) {
+	dir := t.TempDir()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "jwt.pem")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	der, err = x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+	pubPath := filepath.Join(dir, "jwt.pub")
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	badPath := filepath.Join(dir, "bad.pem")
+	require.NoError(t, os.WriteFile(badPath, []byte("not a key"), 0600))
+
+	signKey, err := LoadKey(privPath)
+	require.NoError(t, err)
+	assert.NotNil(t, signKey.Private)
+
+	verifyKey, err := LoadKey(pubPath)
+	require.NoError(t, err)
+	assert.Nil(t, verifyKey.Private)
+	assert.Equal(t, signKey.ID, verifyKey.ID)
+
+	_, err = LoadKey(badPath)
+	assert.Error(t, err)
+
+	_, err = LoadKey(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+}
+
+func TestSecret(t *testing.T) {
+	hs, err := NewJWT("secret")
+	require.NoError(t, err)
+	ed, err := NewKeyJWT(newEd25519Key(t))
+	require.NoError(t, err)
+	es, err := NewKeyJWT(newES256Key(t))
+	require.NoError(t, err)
+
+	for _, th := range []*JWT{hs, ed, es} {
+		secret, err := th.Secret("login")
+		require.NoError(t, err)
+		assert.Len(t, secret, 32)
+		// секрет не меняется между вызовами и зависит от назначения
+		same, err := th.Secret("login")
+		require.NoError(t, err)
+		assert.Equal(t, secret, same)
+		other, err := th.Secret("other")
+		require.NoError(t, err)
+		assert.NotEqual(t, secret, other)
+	}
+}
@@ -1,7 +1,11 @@
 package token
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -9,9 +13,12 @@ import (
 
 // JWT - структура для создания JWT токены
 type JWT struct {
-	secretKey []byte
+	secretKey []byte          // общий секрет HMAC (используется, если не задан ключ подписи)
+	signKey   *Key            // асимметричный ключ подписи
+	keys      map[string]*Key // ключи проверки по идентификатору (kid)
 }
 
+// NewJWT - метод создания обработчика токенов с подписью общим секретом (HS256)
 func NewJWT(secretKey string) (*JWT, error) {
 	if len(secretKey) == 0 {
 		return nil, fmt.Errorf("empty secret key")
@@ -21,28 +28,58 @@ func NewJWT(secretKey string) (*JWT, error) {
 	}, nil
 }
 
+// NewKeyJWT - метод создания обработчика токенов с асимметричной подписью.
+// Токены подписываются ключом signKey, а проверяются им и дополнительными ключами verifyKeys
+// (например, предыдущими ключами подписи на время ротации).
+func NewKeyJWT(signKey *Key, verifyKeys ...*Key) (*JWT, error) {
+	if signKey == nil || signKey.Private == nil {
+		return nil, fmt.Errorf("empty signing key")
+	}
+	j := &JWT{
+		signKey: signKey,
+		keys:    map[string]*Key{signKey.ID: signKey},
+	}
+	for _, key := range verifyKeys {
+		if _, ok := j.keys[key.ID]; !ok {
+			j.keys[key.ID] = key
+		}
+	}
+	return j, nil
+}
+
 // JWTClaims описание записей в токене JWT
 type JWTClaims struct {
 	jwt.StandardClaims
+	Generation int64 `json:"gen,omitempty"` // поколение токенов пользователя на момент выпуска
 }
 
 // JWTExpire - время жизни токена
 const JWTExpire = time.Hour * 3
 
 // BuildJWT - метод для формирования JWT токена с добавлением UUID пользователя
-func (j *JWT) BuildJWT(userID string) (string, error) {
+// и текущего поколения его токенов
+func (j *JWT) BuildJWT(userID string, generation int64) (string, error) {
 	now := time.Now()
 	exp := now.Add(JWTExpire)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
+	method, key := jwt.SigningMethod(jwt.SigningMethodHS256), any(j.secretKey)
+	if j.signKey != nil {
+		method, key = j.signKey.Method, j.signKey.Private
+	}
+	token := jwt.NewWithClaims(method, JWTClaims{
 		StandardClaims: jwt.StandardClaims{
 			Id:        userID,
 			ExpiresAt: exp.Unix(),
 			IssuedAt:  now.Unix(),
 			NotBefore: now.Unix(),
 		},
+		Generation: generation,
 	})
 
-	tokenString, err := token.SignedString(j.secretKey)
+	if j.signKey != nil {
+		token.Header["kid"] = j.signKey.ID
+	}
+
+	tokenString, err := token.SignedString(key)
 	if err != nil {
 		return "", err
 	}
@@ -54,13 +91,7 @@ func (j *JWT) BuildJWT(userID string) (string, error) {
 func (j *JWT) ParseJWT(token string) (*JWTClaims, error) {
 	claims := &JWTClaims{}
 
-	jwtToken, err := jwt.ParseWithClaims(token, claims,
-		func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-			}
-			return j.secretKey, nil
-		})
+	jwtToken, err := jwt.ParseWithClaims(token, claims, j.verifyKey)
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +101,53 @@ func (j *JWT) ParseJWT(token string) (*JWTClaims, error) {
 	return claims, nil
 }
 
+// verifyKey - метод выбора ключа проверки подписи по заголовкам токена
+func (j *JWT) verifyKey(t *jwt.Token) (interface{}, error) {
+	if j.signKey == nil {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return j.secretKey, nil
+	}
+	kid, _ := t.Header["kid"].(string)
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	return key.Public, nil
+}
+
+// JWKS - метод возвращает открытые ключи проверки токенов (пустой набор для HMAC)
+func (j *JWT) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(j.keys))}
+	for _, key := range j.keys {
+		jwks.Keys = append(jwks.Keys, key.JWK())
+	}
+	sort.Slice(jwks.Keys, func(a, b int) bool {
+		return jwks.Keys[a].Kid < jwks.Keys[b].Kid
+	})
+	return jwks
+}
+
+// Secret - метод получает из общего секрета или закрытого ключа подписи токенов секрет сервера
+// для назначения label (при замене ключа подписи секрет меняется)
+func (j *JWT) Secret(label string) ([]byte, error) {
+	material := j.secretKey
+	if j.signKey != nil {
+		der, err := x509.MarshalPKCS8PrivateKey(j.signKey.Private)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal signing key: %w", err)
+		}
+		material = der
+	}
+	mac := hmac.New(sha256.New, material)
+	mac.Write([]byte(label))
+	return mac.Sum(nil), nil
+}
+
 // DecodeUserId - метод извлечения ID пользователя из токена
 func (j *JWT) DecodeUserId(token string) (string, error) {
 	claims, err := j.ParseJWT(token)
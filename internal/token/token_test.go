@@ -47,7 +47,7 @@ func TestBuildJWT(t *testing.T) {
 				return
 			}
 			// Вызываем тестируемую функцию
-			tokenString, err := th.BuildJWT(tc.userID)
+			tokenString, err := th.BuildJWT(tc.userID, 0)
 
 			// Проверяем ожидаемую ошибку
 			if tc.wantError {
@@ -75,7 +75,7 @@ func TestParseJWT(t *testing.T) {
 	validUserID := "mda"
 	th, err := NewJWT("valid-secret-key")
 	require.NoError(t, err, "failed to create token handler")
-	validToken, err := th.BuildJWT(validUserID)
+	validToken, err := th.BuildJWT(validUserID, 0)
 	require.NoError(t, err, "failed to create valid test token")
 
 	testCases := []struct {
@@ -142,7 +142,7 @@ func TestJWT_DecodeUserId(t *testing.T) {
 		{
 			TestName: "Success. Decode valid token",
 			SetupMocks: func() string {
-				token, err := j.BuildJWT(userID)
+				token, err := j.BuildJWT(userID, 0)
 				require.NoError(t, err)
 				return token
 			},
@@ -154,7 +154,7 @@ func TestJWT_DecodeUserId(t *testing.T) {
 			SetupMocks: func() string {
 				// Создаем токен с другим секретом
 				otherJWT, _ := NewJWT("different-secret-key")
-				token, err := otherJWT.BuildJWT(userID)
+				token, err := otherJWT.BuildJWT(userID, 0)
 				require.NoError(t, err)
 				return token
 			},
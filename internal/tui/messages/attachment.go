@@ -0,0 +1,36 @@
+package messages
+
+import (
+	"go-pass-keeper/internal/models"
+)
+
+// AttachmentsRefreshMsg - сообщение с обновленным списком вложений секрета
+type AttachmentsRefreshMsg struct {
+	SecretID    string
+	Name        string
+	Attachments []*models.AttachmentInfo
+}
+
+// AddAttachmentMsg - сообщение для добавления файла во вложения секрета
+type AddAttachmentMsg struct {
+	SecretID string
+	Path     string
+}
+
+// SaveAttachmentMsg - сообщение для сохранения вложения на диск
+type SaveAttachmentMsg struct {
+	ID   string
+	Path string
+}
+
+// DeleteAttachmentMsg - сообщение для удаления вложения
+type DeleteAttachmentMsg struct {
+	SecretID string
+	ID       string
+}
+
+// AttachmentCancelMsg - сообщение с выходом из окна вложений
+type AttachmentCancelMsg struct{}
+
+// AttachmentStatusMsg - сообщение с результатом операции над вложением
+type AttachmentStatusMsg string
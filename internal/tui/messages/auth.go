@@ -1,8 +1,45 @@
 package messages
 
+import (
+	"go-pass-keeper/internal/grpcclient/settings"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+)
+
 // AuthSuccess - сообщение об успешной аутентификации
 type AuthSuccessMsg struct {
-	Username string
-	Token    string
-	Salt     string
+	Username   string
+	Token      string
+	Salt       string
+	UserID     string
+	KDF        crypto.KDFParams // параметры получения ключа из пароля
+	WrappedKey []byte           // ключ шифрования, зашифрованный ключом из пароля
+	KeyCheck   []byte           // контрольное значение ключа шифрования
+}
+
+// KeyWrappedMsg - сообщение о сохранении ключа хранилища, зашифрованного ключом из пароля
+// (при усилении параметров получения ключа или смене секрета)
+type KeyWrappedMsg struct {
+	KDF        crypto.KDFParams
+	WrappedKey []byte
+	Connection *settings.Settings // новые настройки (без секрета), если ключ перешифрован при смене секрета
+	Secret     *securemem.Buffer  // новый секрет при смене секрета
+}
+
+// UnlockSecretMsg - запрос на открытие хранилища секретом, введённым после неверного секрета
+type UnlockSecretMsg struct {
+	Secret string
+}
+
+// WrongSecretMsg - сообщение о неверном секрете (ключ хранилища не совпал с контрольным значением
+// или не расшифровал закрытый ключ пользователя)
+type WrongSecretMsg struct{}
+
+// SecretConfirmedMsg - сообщение об открытии хранилища введённым секретом
+// (поле секрета в окне настроек очищается)
+type SecretConfirmedMsg struct{}
+
+// KeyCheckSavedMsg - сообщение о сохранении контрольного значения ключа хранилища
+type KeyCheckSavedMsg struct {
+	KeyCheck []byte
 }
@@ -29,4 +29,8 @@ type SecretViewMsg struct {
 // SecretRefreshMsg - сообщение с обновленным списком секретов
 type SecretRefreshMsg struct {
 	Secrets []*models.SecretInfo
+	Query   string // поисковый запрос, по которому получен список (пусто - полный список)
 }
+
+// NamesMigratedMsg - сообщение о количестве секретов, названия которых перенесены в зашифрованные метаданные
+type NamesMigratedMsg int
@@ -0,0 +1,17 @@
+package messages
+
+// EscrowKeyMsg - сообщение с открытым ключом депонирования организации, полученным с сервера
+type EscrowKeyMsg struct {
+	PublicKey []byte
+}
+
+// EnrollEscrowMsg - запрос на депонирование ключа хранилища (ключ организации подтверждён по отпечатку)
+type EnrollEscrowMsg struct {
+	PublicKey []byte
+}
+
+// EscrowEnrolledMsg - сообщение о депонировании ключа хранилища
+type EscrowEnrolledMsg struct{}
+
+// EscrowCancelMsg - сообщение с выходом из окна депонирования ключа
+type EscrowCancelMsg struct{}
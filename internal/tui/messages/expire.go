@@ -0,0 +1,16 @@
+package messages
+
+import (
+	"time"
+)
+
+// SetExpirationMsg - сообщение для изменения срока действия секрета
+type SetExpirationMsg struct {
+	ID      string
+	Name    string
+	Expires time.Time // нулевое значение - бессрочно
+	Policy  string
+}
+
+// ExpireCancelMsg - сообщение с отменой изменения срока действия
+type ExpireCancelMsg struct{}
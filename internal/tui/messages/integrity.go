@@ -0,0 +1,13 @@
+package messages
+
+import (
+	"go-pass-keeper/internal/models"
+)
+
+// VaultVerifiedMsg - сообщение с результатом проверки личного хранилища по подписанному состоянию
+type VaultVerifiedMsg struct {
+	State    *models.VaultState // проверенное подписанное состояние (nil - состояние не открыто)
+	Sequence int64              // номер состояния на сервере
+	Warning  string             // найденные расхождения (пусто - хранилище не изменено в обход клиента)
+	Status   string             // результат операции с состоянием (подписано впервые, принято пользователем)
+}
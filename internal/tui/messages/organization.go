@@ -0,0 +1,46 @@
+package messages
+
+import (
+	"go-pass-keeper/internal/models"
+)
+
+// VaultsRefreshMsg - сообщение с обновленным списком организаций пользователя
+type VaultsRefreshMsg struct {
+	Organizations []*models.OrganizationInfo
+}
+
+// VaultSelectMsg - сообщение с выбором хранилища (nil - личное хранилище)
+type VaultSelectMsg struct {
+	Organization *models.OrganizationInfo
+}
+
+// VaultCancelMsg - сообщение с отменой выбора хранилища
+type VaultCancelMsg struct{}
+
+// CreateOrganizationMsg - сообщение для создания организации
+type CreateOrganizationMsg struct {
+	Name string
+}
+
+// AddMemberMsg - сообщение для добавления участника в организацию
+type AddMemberMsg struct {
+	Organization *models.OrganizationInfo
+	Login        string
+	Role         string
+}
+
+// RemoveMemberMsg - сообщение для удаления участника из организации
+type RemoveMemberMsg struct {
+	Organization *models.OrganizationInfo
+	Login        string
+}
+
+// RotateVaultKeyMsg - сообщение для замены ключа хранилища организации (после удаления участника)
+type RotateVaultKeyMsg struct {
+	Organization *models.OrganizationInfo
+}
+
+// VaultKeyRotatedMsg - сообщение с организацией после замены ключа хранилища
+type VaultKeyRotatedMsg struct {
+	Organization *models.OrganizationInfo
+}
@@ -0,0 +1,51 @@
+package messages
+
+import (
+	"go-pass-keeper/pkg/crypto"
+)
+
+// CreateRecoveryKeyMsg - запрос на создание ключа восстановления (прежний перестаёт действовать)
+type CreateRecoveryKeyMsg struct{}
+
+// RecoveryKeyCreatedMsg - сообщение с кодом созданного ключа восстановления (показывается один раз)
+type RecoveryKeyCreatedMsg struct {
+	Code string
+}
+
+// SaveEmergencyKitMsg - запрос на сохранение аварийного комплекта в файл
+type SaveEmergencyKitMsg struct {
+	Code string
+	Path string
+}
+
+// SaveRecoverySharesMsg - запрос на разделение ключа восстановления на части
+// и сохранение каждой части в отдельный файл
+type SaveRecoverySharesMsg struct {
+	Code      string
+	Total     int
+	Threshold int
+	Path      string // путь к файлам частей (к имени добавляется номер части)
+	QR        bool   // добавить в файлы QR-коды частей
+}
+
+// RecoveryStatusMsg - сообщение с результатом операции с ключом восстановления
+type RecoveryStatusMsg string
+
+// RecoveryCancelMsg - сообщение с выходом из окна ключа восстановления
+type RecoveryCancelMsg struct{}
+
+// RecoverVaultMsg - запрос на восстановление доступа к хранилищу ключом восстановления
+// с установкой нового секрета
+type RecoverVaultMsg struct {
+	RecoveryKey []byte
+	Secret      string
+}
+
+// VaultRecoveredMsg - сообщение о восстановлении доступа к хранилищу: ключ хранилища
+// сохранён на сервере зашифрованным ключом из нового секрета
+type VaultRecoveredMsg struct {
+	Key        []byte
+	KDF        crypto.KDFParams
+	WrappedKey []byte
+	Secret     string
+}
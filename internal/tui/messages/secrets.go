@@ -3,8 +3,10 @@ package messages
 import (
 	"fmt"
 	"go-pass-keeper/internal/models"
+	"go-pass-keeper/pkg/securemem"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 )
 
 // Тип переменной состояние перехода
@@ -17,7 +19,7 @@ const (
 
 // EncryptConverter - интерфейс для расшифровки сообщений
 type EncryptConverter interface {
-	ToModel([]byte) (*models.SecretInfo, []byte, error)
+	ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error)
 }
 
 // SecretPassword - модель с данными логин/пароль
@@ -26,6 +28,9 @@ type SecretPassword struct {
 	Type     string
 	Login    string
 	Password string
+	Extra    models.SecretExtra // дополнительные поля
+	Notes    string             // заметки (хранятся в зашифрованных метаданных)
+	Tags     []string           // теги (хранятся в зашифрованных метаданных)
 }
 
 // AddSecretPasswordMsg - сообщение для добавления данными логин/пароль
@@ -34,31 +39,37 @@ type AddSecretPasswordMsg struct {
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *AddSecretPasswordMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *AddSecretPasswordMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretPassword(msg.Data.Login, msg.Data.Password)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(uuid.NewString(), msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // EditSecretPasswordMsg - сообщение для редактирования секрета (логин/пароль)
 type EditSecretPasswordMsg struct {
-	ID   string
-	Data SecretPassword
+	ID      string
+	Data    SecretPassword
+	DataKey []byte // ключ данных секрета, зашифрованный ключом хранилища (сохраняется при изменении)
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *EditSecretPasswordMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *EditSecretPasswordMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretPassword(msg.Data.Login, msg.Data.Password)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(msg.ID, msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	info.DataKey = msg.DataKey
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{ID: msg.ID, Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // GetSecretPasswordMsg - сообщение для получения данных логин/пароль
@@ -68,14 +79,15 @@ type GetSecretPasswordMsg struct {
 }
 
 // FromModel - метод формирует информацию о секрете, расшифрованный контент и формирует сообщение
-func (msg *GetSecretPasswordMsg) FromModel(key []byte, info *models.SecretInfo, content []byte) error {
+func (msg *GetSecretPasswordMsg) FromModel(key []byte, owner string, info *models.SecretInfo, content []byte) error {
 	secret := &models.SecretPassword{}
-	err := secret.Decrypt(key, content)
+	err := secret.Decrypt(key, content, models.SecretAD(owner, info.ID, info.Type))
 	if err != nil {
 		return fmt.Errorf("failed to decrypt data: %w", err)
 	}
+	defer secret.Wipe()
 	msg.ID = info.ID
-	msg.Data = SecretPassword{Name: info.Name, Type: info.Type, Login: secret.Login, Password: secret.Password}
+	msg.Data = SecretPassword{Name: info.Name, Type: info.Type, Login: secret.Login, Password: string(secret.Password), Extra: secret.SecretExtra.Clone(), Notes: secretNotes(info, &secret.SecretExtra), Tags: info.Tags}
 	return nil
 }
 
@@ -87,6 +99,9 @@ type SecretCard struct {
 	Date   string
 	CVV    string
 	Owner  string
+	Extra  models.SecretExtra // дополнительные поля
+	Notes  string             // заметки (хранятся в зашифрованных метаданных)
+	Tags   []string           // теги (хранятся в зашифрованных метаданных)
 }
 
 // AddSecretCardMsg - сообщение для добавления с данными карты
@@ -95,31 +110,37 @@ type AddSecretCardMsg struct {
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *AddSecretCardMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *AddSecretCardMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretCard(msg.Data.Number, msg.Data.Date, msg.Data.CVV, msg.Data.Owner)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(uuid.NewString(), msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // EditSecretCardMsg - сообщение для редактирования данных карты
 type EditSecretCardMsg struct {
-	ID   string
-	Data SecretCard
+	ID      string
+	Data    SecretCard
+	DataKey []byte // ключ данных секрета, зашифрованный ключом хранилища (сохраняется при изменении)
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *EditSecretCardMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *EditSecretCardMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretCard(msg.Data.Number, msg.Data.Date, msg.Data.CVV, msg.Data.Owner)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(msg.ID, msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	info.DataKey = msg.DataKey
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{ID: msg.ID, Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // GetSecretCardMsg - сообщение для получения данных карты
@@ -129,22 +150,26 @@ type GetSecretCardMsg struct {
 }
 
 // FromModel - метод формирует информацию о секрете, расшифрованный контент и формирует сообщение
-func (msg *GetSecretCardMsg) FromModel(key []byte, info *models.SecretInfo, content []byte) error {
+func (msg *GetSecretCardMsg) FromModel(key []byte, owner string, info *models.SecretInfo, content []byte) error {
 	secret := &models.SecretCard{}
-	err := secret.Decrypt(key, content)
+	err := secret.Decrypt(key, content, models.SecretAD(owner, info.ID, info.Type))
 	if err != nil {
 		return fmt.Errorf("failed to decrypt data: %w", err)
 	}
+	defer secret.Wipe()
 	msg.ID = info.ID
-	msg.Data = SecretCard{Name: info.Name, Type: info.Type, Number: secret.Number, CVV: secret.CVV, Date: secret.Date, Owner: secret.Owner}
+	msg.Data = SecretCard{Name: info.Name, Type: info.Type, Number: string(secret.Number), CVV: string(secret.CVV), Date: secret.Date, Owner: secret.Owner, Extra: secret.SecretExtra.Clone(), Notes: secretNotes(info, &secret.SecretExtra), Tags: info.Tags}
 	return nil
 }
 
 // SecretText - модель с текстовыми данными
 type SecretText struct {
-	Name string
-	Type string
-	Text string
+	Name  string
+	Type  string
+	Text  string
+	Extra models.SecretExtra // дополнительные поля
+	Notes string             // заметки (хранятся в зашифрованных метаданных)
+	Tags  []string           // теги (хранятся в зашифрованных метаданных)
 }
 
 // AddSecretTextMsg - сообщение для добавления секрета с текстовыми данными
@@ -153,31 +178,37 @@ type AddSecretTextMsg struct {
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *AddSecretTextMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *AddSecretTextMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretText(msg.Data.Text)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(uuid.NewString(), msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // EditSecretTextMsg - сообщение для изменения секрета с текстовыми данными
 type EditSecretTextMsg struct {
-	ID   string
-	Data SecretText
+	ID      string
+	Data    SecretText
+	DataKey []byte // ключ данных секрета, зашифрованный ключом хранилища (сохраняется при изменении)
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *EditSecretTextMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *EditSecretTextMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretText(msg.Data.Text)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(msg.ID, msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	info.DataKey = msg.DataKey
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{ID: msg.ID, Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // GetSecretTextMsg - сообщение для получения секрета с  текстовыми данными
@@ -187,22 +218,26 @@ type GetSecretTextMsg struct {
 }
 
 // FromModel - метод формирует информацию о секрете, расшифрованный контент и формирует сообщение
-func (msg *GetSecretTextMsg) FromModel(key []byte, info *models.SecretInfo, content []byte) error {
+func (msg *GetSecretTextMsg) FromModel(key []byte, owner string, info *models.SecretInfo, content []byte) error {
 	secret := &models.SecretText{}
-	err := secret.Decrypt(key, content)
+	err := secret.Decrypt(key, content, models.SecretAD(owner, info.ID, info.Type))
 	if err != nil {
 		return fmt.Errorf("failed to decrypt data: %w", err)
 	}
+	defer secret.Wipe()
 	msg.ID = info.ID
-	msg.Data = SecretText{Name: info.Name, Type: info.Type, Text: secret.Text}
+	msg.Data = SecretText{Name: info.Name, Type: info.Type, Text: string(secret.Text), Extra: secret.SecretExtra.Clone(), Notes: secretNotes(info, &secret.SecretExtra), Tags: info.Tags}
 	return nil
 }
 
 // SecretBinary - модель с бинарными данными
 type SecretBinary struct {
-	Name string
-	Type string
-	Blob []byte
+	Name  string
+	Type  string
+	Blob  []byte
+	Extra models.SecretExtra // дополнительные поля
+	Notes string             // заметки (хранятся в зашифрованных метаданных)
+	Tags  []string           // теги (хранятся в зашифрованных метаданных)
 }
 
 // AddSecretBinaryMsg - сообщение для добавления секрета с бинарными данными
@@ -211,31 +246,37 @@ type AddSecretBinaryMsg struct {
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *AddSecretBinaryMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *AddSecretBinaryMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretBinary(msg.Data.Blob)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(uuid.NewString(), msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // EditSecretBinaryMsg - сообщение для изменения секрета с бинарными данными
 type EditSecretBinaryMsg struct {
-	ID   string
-	Data SecretBinary
+	ID      string
+	Data    SecretBinary
+	DataKey []byte // ключ данных секрета, зашифрованный ключом хранилища (сохраняется при изменении)
 }
 
 // ToModel - метод формирует информацию о секрете и шифрованный контент
-func (msg *EditSecretBinaryMsg) ToModel(key []byte) (*models.SecretInfo, []byte, error) {
+func (msg *EditSecretBinaryMsg) ToModel(key []byte, owner string) (*models.SecretInfo, []byte, error) {
 
 	secret := models.NewSecretBinary(msg.Data.Blob)
-	data, err := secret.Encrypt(key)
+	secret.SecretExtra = models.SecretExtra{Fields: msg.Data.Extra.Fields}
+	info := newSecretInfo(msg.ID, msg.Data.Name, msg.Data.Type, msg.Data.Notes, msg.Data.Tags)
+	info.DataKey = msg.DataKey
+	data, err := encryptContent(key, owner, info, secret)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return &models.SecretInfo{ID: msg.ID, Name: msg.Data.Name, Type: msg.Data.Type}, data, nil
+	return info, data, nil
 }
 
 // GetSecretBinaryMsg - сообщение для получения секрета с бинарными данными
@@ -245,44 +286,81 @@ type GetSecretBinaryMsg struct {
 }
 
 // FromModel - метод формирует информацию о секрете, расшифрованный контент и формирует сообщение
-func (msg *GetSecretBinaryMsg) FromModel(key []byte, info *models.SecretInfo, content []byte) error {
+func (msg *GetSecretBinaryMsg) FromModel(key []byte, owner string, info *models.SecretInfo, content []byte) error {
 	secret := &models.SecretBinary{}
-	err := secret.Decrypt(key, content)
+	err := secret.Decrypt(key, content, models.SecretAD(owner, info.ID, info.Type))
 	if err != nil {
 		return fmt.Errorf("failed to decrypt data: %w", err)
 	}
 	msg.ID = info.ID
-	msg.Data = SecretBinary{Name: info.Name, Type: info.Type, Blob: secret.Blob}
+	msg.Data = SecretBinary{Name: info.Name, Type: info.Type, Blob: secret.Blob, Extra: secret.SecretExtra, Notes: secretNotes(info, &secret.SecretExtra), Tags: info.Tags}
 	return nil
 }
 
-// ToMessage - метод формирует сообщение на основе информации о секрете
-func ToMessage(key []byte, info *models.SecretInfo, content []byte) tea.Msg {
+// newSecretInfo - метод формирует информацию о секрете (заметки и теги хранятся в метаданных секрета)
+func newSecretInfo(id string, name string, kind string, notes string, tags []string) *models.SecretInfo {
+	return &models.SecretInfo{ID: id, Name: name, Type: kind, Notes: notes, Tags: tags}
+}
+
+// encryptContent - метод шифрует содержимое секрета ключом данных секрета (у нового секрета
+// ключ создаётся и шифруется ключом хранилища key, изменённый секрет шифруется прежним ключом)
+func encryptContent(key []byte, owner string, info *models.SecretInfo, secret models.SecretCrypter) ([]byte, error) {
+	defer secret.Wipe()
+	dataKey, err := info.DataKeyFor(key, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(dataKey)
+	return secret.Encrypt(dataKey, models.SecretAD(owner, info.ID, info.Type))
+}
+
+// secretNotes - метод возвращает заметки секрета из метаданных (у секретов, сохранённых до появления
+// метаданных, - из содержимого: при сохранении секрета они переносятся в метаданные)
+func secretNotes(info *models.SecretInfo, extra *models.SecretExtra) string {
+	moved := *info
+	moved.MoveNotes(extra)
+	return moved.Notes
+}
+
+// ToMessage - метод формирует сообщение на основе информации о секрете хранилища owner
+// (содержимое и зашифрованные метаданные расшифровываются ключом данных секрета, см. SecretInfo.OpenDataKey)
+func ToMessage(vaultKey []byte, owner string, info *models.SecretInfo, content []byte) tea.Msg {
+	key, err := info.OpenDataKey(vaultKey, owner)
+	if err != nil {
+		return ErrorMsg(fmt.Sprintf("Ошибка разбора сообщения: %s", err.Error()))
+	}
+	if len(info.DataKey) != 0 {
+		// собственный ключ данных секрета (не ключ хранилища) не нужен после расшифровки
+		defer securemem.Wipe(key)
+	}
+	if err := info.OpenMeta(key, owner); err != nil {
+		return ErrorMsg(fmt.Sprintf("Ошибка разбора сообщения: %s", err.Error()))
+	}
 	switch info.Type {
 	case models.SecretPasswordType:
 		msg := GetSecretPasswordMsg{ID: info.ID}
-		err := msg.FromModel(key, info, content)
+		err := msg.FromModel(key, owner, info, content)
 		if err != nil {
 			return ErrorMsg(fmt.Sprintf("Ошибка разбора сообщения: %s", err.Error()))
 		}
 		return msg
 	case models.SecretCardType:
 		msg := GetSecretCardMsg{ID: info.ID}
-		err := msg.FromModel(key, info, content)
+		err := msg.FromModel(key, owner, info, content)
 		if err != nil {
 			return ErrorMsg(fmt.Sprintf("Ошибка разбора сообщения: %s", err.Error()))
 		}
 		return msg
 	case models.SecretTextType:
 		msg := GetSecretTextMsg{ID: info.ID}
-		err := msg.FromModel(key, info, content)
+		err := msg.FromModel(key, owner, info, content)
 		if err != nil {
 			return ErrorMsg(fmt.Sprintf("Ошибка разбора сообщения: %s", err.Error()))
 		}
 		return msg
 	case models.SecretBinaryType:
 		msg := GetSecretBinaryMsg{ID: info.ID}
-		err := msg.FromModel(key, info, content)
+		err := msg.FromModel(key, owner, info, content)
 		if err != nil {
 			return ErrorMsg(fmt.Sprintf("Ошибка разбора сообщения: %s", err.Error()))
 		}
@@ -0,0 +1,33 @@
+package messages
+
+import (
+	"go-pass-keeper/internal/models"
+)
+
+// KeyPairLoadedMsg - сообщение с расшифрованным закрытым ключом пользователя
+type KeyPairLoadedMsg struct {
+	PrivateKey []byte
+}
+
+// ShareSecretMsg - сообщение для передачи секрета другому пользователю
+type ShareSecretMsg struct {
+	ID        string
+	Recipient string
+}
+
+// RevokeShareMsg - сообщение для отзыва доступа пользователя к секрету
+type RevokeShareMsg struct {
+	ID        string
+	Recipient string
+}
+
+// SecretShareCancelMsg - сообщение с отменой передачи секрета
+type SecretShareCancelMsg struct{}
+
+// ShareStatusMsg - сообщение с результатом передачи (отзыва) секрета
+type ShareStatusMsg string
+
+// SharedRefreshMsg - сообщение с обновленным списком переданных пользователю секретов
+type SharedRefreshMsg struct {
+	Shares []*models.SharedSecretInfo
+}
@@ -53,7 +53,7 @@ func NewAppModel(config *config.Config, version string) AppModel {
 		state:    MainState,
 		auth:     NewAuthModel(connection),
 		register: NewRegisterModel(connection),
-		secrets:  NewViewerModel(connection),
+		secrets:  NewViewerModel(connection, config),
 		settings: NewSettingsModel(connection),
 		focused:  0,
 		username: "",
@@ -79,6 +79,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyCtrlC:
+			m.secrets.Close()
 			return m, tea.Quit
 		case tea.KeyEsc:
 			// Обработка ESC в зависимости от текущего состояния
@@ -95,6 +96,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case MainState:
 				// Выход из приложения
+				m.secrets.Close()
 				return m, tea.Quit
 			}
 		}
@@ -103,6 +105,26 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = MainState
 		m.username = msg.Username
 		m.token = msg.Token
+		var cmd tea.Cmd
+		m, cmd = m.handleSecretUpdate(msg)
+		// хранилище не открылось секретом из настроек - запрашивается секрет
+		if m.secrets.Locked() {
+			m.state = SecretState
+		} else {
+			m.settings = m.settings.ClearSecret()
+		}
+		return m, cmd
+
+	case messages.WrongSecretMsg:
+		m.state = SecretState
+		return m.handleSecretUpdate(msg)
+
+	case messages.SecretConfirmedMsg:
+		m.settings = m.settings.ClearSecret()
+		return m, nil
+
+	case messages.VaultRecoveredMsg:
+		m.settings = m.settings.ClearSecret()
 		return m.handleSecretUpdate(msg)
 
 	case messages.ErrorMsg:
@@ -111,6 +133,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.auth.err = msg
 		case RegisterState:
 			m.register.err = msg
+		case SecretState:
+			m.secrets.err = msg
+			m.secrets.status = ""
+		case SettingsState:
+			m.settings.err = msg
 		}
 		return m, nil
 
@@ -119,9 +146,30 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case messages.ConfigUpdatedMsg:
+		// при смене секрета ключ хранилища сначала перешифровывается на сервере,
+		// настройки сохраняются после успешного перешифрования
+		if cmd := m.secrets.ChangeSecret(msg.Connection); cmd != nil {
+			return m, cmd
+		}
+		// секрет в файл настроек не попадает и до открытия хранилища остаётся только в памяти
+		// (пустое поле секрета - секрет не меняется: открытое хранилище хранит его в защищённой памяти)
 		m.config.Save(&msg.Connection)
+		if m.secrets.cryptoKey != nil {
+			msg.Connection.Secret = ""
+		}
+		*m.secrets.settings = msg.Connection
 		m.state = MainState
 		return m, nil
+
+	case messages.KeyWrappedMsg:
+		if msg.Connection != nil {
+			m.config.Save(msg.Connection)
+			m.settings = m.settings.ClearSecret()
+			m.state = MainState
+		}
+		updatedModel, cmd := m.secrets.Update(msg)
+		m.secrets = updatedModel
+		return m, cmd
 	}
 
 	// Обновление текущего состояния
@@ -333,6 +381,7 @@ func (m AppModel) handleMainUpdate(msg tea.Msg) (AppModel, tea.Cmd) {
 				return m, m.auth.inputs[0].Focus()
 			case RegisterButton:
 				m.state = RegisterState
+				m.register = m.register.SetSecret(m.secrets.Secret())
 				return m, m.register.inputs[0].Focus()
 			case SecretButton:
 				if m.isAuthorized() {
@@ -0,0 +1,284 @@
+package models
+
+import (
+	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+	"io"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxAttachmentFileSize - максимальный размер прикрепляемого файла (сервер принимает
+// зашифрованное вложение до 3 МБ, запас оставлен под заголовок шифротекста)
+const maxAttachmentFileSize = 3<<20 - 1<<10
+
+// AttachmentsModel - модель окна вложений секрета
+type AttachmentsModel struct {
+	table       table.Model
+	pathInput   textinput.Model
+	attachments []*models.AttachmentInfo
+	windowSize  tea.WindowSizeMsg
+	sid         string // id секрета
+	name        string // название секрета
+	status      string // результат последней операции
+}
+
+// NewAttachmentsModel - метод создания окна вложений
+func NewAttachmentsModel() AttachmentsModel {
+	model := AttachmentsModel{
+		table: createAttachmentsTable(),
+	}
+
+	model.pathInput = textinput.New()
+	model.pathInput.Placeholder = "Путь к файлу"
+	model.pathInput.CharLimit = 256
+	model.pathInput.TextStyle = styles.FocusedStyle
+	model.pathInput.PromptStyle = styles.FocusedStyle
+
+	model.pathInput.Focus()
+
+	return model
+}
+
+// Init - метод инициализации текущего окна
+func (m AttachmentsModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// SetAttachments - метод устанавливает секрет и список его вложений
+func (m AttachmentsModel) SetAttachments(sid string, name string, attachments []*models.AttachmentInfo) AttachmentsModel {
+	if m.sid != sid {
+		m.pathInput.SetValue("")
+	}
+	m.sid = sid
+	m.name = name
+	m.attachments = attachments
+	m.table.SetRows(createAttachmentsTableRows(attachments))
+	return m
+}
+
+// WithStatus - метод устанавливает строку с результатом последней операции
+func (m AttachmentsModel) WithStatus(status string) AttachmentsModel {
+	m.status = status
+	return m
+}
+
+// Update - метод обновления текущего окна
+func (m AttachmentsModel) Update(msg tea.Msg) (AttachmentsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			return m, m.attemptAdd(m.pathInput.Value())
+		case "ctrl+s":
+			return m, m.attemptSave(m.pathInput.Value())
+		case "ctrl+d":
+			return m, m.attemptDelete()
+		case "up", "down":
+			var cmd tea.Cmd
+			m.table, cmd = m.table.Update(msg)
+			return m, cmd
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.AttachmentCancelMsg{}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.pathInput, cmd = m.pathInput.Update(msg)
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m AttachmentsModel) View() string {
+	buttons := lipgloss.JoinHorizontal(
+		lipgloss.Center,
+		styles.ButtonStyle.Render("Enter - Прикрепить"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render("Ctrl+S - Сохранить"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render("Ctrl+D - Удалить"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render("ESC - Назад"),
+	)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(m.windowSize.Width-10).
+			Render("📎 Вложения"),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Render("Секрет: "+m.name),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		styles.TableStyle.
+			Width(m.table.Width()).
+			Render(m.table.View()),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.InputLabelStyle.Render("📁 Файл:"),
+			styles.FocusedInputFieldStyle.Width(60).Render(m.pathInput.View()),
+		),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		buttons,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		m.status,
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render("↑/↓: выбор вложения • файл шифруется ключом секрета до отправки на сервер"),
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// selected - метод возвращает выбранное в таблице вложение
+func (m AttachmentsModel) selected() *models.AttachmentInfo {
+	idx := m.table.Cursor()
+	if idx < 0 || idx >= len(m.attachments) {
+		return nil
+	}
+	return m.attachments[idx]
+}
+
+// attemptAdd - метод обработки добавления вложения
+func (m AttachmentsModel) attemptAdd(path string) tea.Cmd {
+	return func() tea.Msg {
+		if len(path) == 0 {
+			return messages.ErrorMsg("Необходимо задать путь к файлу")
+		}
+		return messages.AddAttachmentMsg{SecretID: m.sid, Path: path}
+	}
+}
+
+// attemptSave - метод обработки сохранения вложения на диск
+// (если путь не задан, файл сохраняется в текущий каталог под исходным именем)
+func (m AttachmentsModel) attemptSave(path string) tea.Cmd {
+	return func() tea.Msg {
+		attachment := m.selected()
+		if attachment == nil {
+			return messages.ErrorMsg("Необходимо выбрать вложение")
+		}
+		if len(path) == 0 {
+			path = attachment.Name
+		}
+		return messages.SaveAttachmentMsg{ID: attachment.ID, Path: path}
+	}
+}
+
+// attemptDelete - метод обработки удаления вложения
+func (m AttachmentsModel) attemptDelete() tea.Cmd {
+	return func() tea.Msg {
+		attachment := m.selected()
+		if attachment == nil {
+			return messages.ErrorMsg("Необходимо выбрать вложение")
+		}
+		return messages.DeleteAttachmentMsg{SecretID: m.sid, ID: attachment.ID}
+	}
+}
+
+// createAttachmentsTable - метод формирования модели таблицы вложений
+func createAttachmentsTable() table.Model {
+	columns := []table.Column{
+		{Title: "Файл", Width: 50},
+		{Title: "Размер", Width: 15},
+		{Title: "Добавлен", Width: 20},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(8),
+		table.WithWidth(90),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = styles.TableHeaderStyle.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true)
+
+	s.Selected = styles.TableSelectedStyle.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	t.SetStyles(s)
+
+	return t
+}
+
+// createAttachmentsTableRows - метод формирования строк в таблице вложений
+func createAttachmentsTableRows(attachments []*models.AttachmentInfo) []table.Row {
+	rows := make([]table.Row, len(attachments))
+	for i, attachment := range attachments {
+		rows[i] = table.Row{
+			attachment.Name,
+			fmt.Sprintf("%d байт", attachment.Size),
+			attachment.Created.Local().Format(time.DateTime),
+		}
+	}
+	return rows
+}
+
+// readAttachment - метод читает прикрепляемый файл, не загружая в память файлы
+// больше допустимого размера
+func readAttachment(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !stat.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	if stat.Size() > maxAttachmentFileSize {
+		return nil, fmt.Errorf("file is too large (max %d bytes)", maxAttachmentFileSize)
+	}
+	// размер файла может измениться после проверки
+	data, err := io.ReadAll(io.LimitReader(file, maxAttachmentFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxAttachmentFileSize {
+		return nil, fmt.Errorf("file is too large (max %d bytes)", maxAttachmentFileSize)
+	}
+	return data, nil
+}
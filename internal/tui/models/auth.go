@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-pass-keeper/internal/grpcclient"
 	"go-pass-keeper/internal/grpcclient/settings"
+	"go-pass-keeper/internal/models"
 	"go-pass-keeper/internal/tui/messages"
 	"go-pass-keeper/internal/tui/styles"
 	"time"
@@ -21,6 +22,8 @@ type AuthModel struct {
 	err        messages.ErrorMsg
 	windowSize tea.WindowSizeMsg
 	connection *settings.Settings
+	// ожидается подтверждение переноса учётной записи, созданной до входа по SRP
+	confirmMigrate bool
 }
 
 // NewAuthModel - метод для создания окна авторизации пользователя
@@ -107,9 +110,22 @@ func (m AuthModel) Update(msg tea.Msg) (AuthModel, tea.Cmd) {
 		case "enter":
 			username := m.inputs[0].Value()
 			password := m.inputs[1].Value()
+			if m.confirmMigrate {
+				m.confirmMigrate = false
+				return m, m.attemptMigrateLogin(username, password)
+			}
 			return m, m.attemptLogin(username, password)
 
+		case "ctrl+o":
+			// перенос выполняется один раз и только по явному подтверждению: пароль передаётся серверу
+			m.confirmMigrate = true
+			return m, nil
+
 		case "esc":
+			if m.confirmMigrate {
+				m.confirmMigrate = false
+				return m, nil
+			}
 			return m, func() tea.Msg {
 				return messages.GotoMainPageMsg{}
 			}
@@ -198,6 +214,18 @@ func (m AuthModel) View() string {
 		),
 	)
 
+	// Подтверждение переноса учётной записи
+	if m.confirmMigrate {
+		content = lipgloss.JoinVertical(
+			lipgloss.Center,
+			content,
+			lipgloss.NewStyle().Height(1).Render(""),
+			styles.ErrorStyle.Width(60).Render("Перенос учётной записи, созданной до входа без передачи пароля: "+
+				"пароль будет однократно передан серверу, после чего вход выполняется только без его передачи."),
+			styles.HelpStyle.Render("Enter: перенести • ESC: отмена"),
+		)
+	}
+
 	// Сообщение об ошибке
 	if m.err != "" {
 		content = lipgloss.JoinVertical(
@@ -213,7 +241,7 @@ func (m AuthModel) View() string {
 		lipgloss.Center,
 		content,
 		lipgloss.NewStyle().Height(1).Render(""),
-		styles.HelpStyle.Render("Tab: переключение полей • Enter: подтвердить • ESC: назад"),
+		styles.HelpStyle.Render("Tab: переключение полей • Enter: подтвердить • Ctrl+O: перенос старой учётной записи • ESC: назад"),
 	)
 
 	return styles.ContainerStyle.
@@ -232,6 +260,16 @@ func (m AuthModel) View() string {
 
 // attemptLogin - метод обработки прохождения авторизации пользователя
 func (m AuthModel) attemptLogin(username string, password string) tea.Cmd {
+	return m.authorize(username, password, (*grpcclient.UserClient).Login)
+}
+
+// attemptMigrateLogin - метод обработки подтверждённого переноса учётной записи, созданной до входа по SRP
+func (m AuthModel) attemptMigrateLogin(username string, password string) tea.Cmd {
+	return m.authorize(username, password, (*grpcclient.UserClient).MigrateLogin)
+}
+
+// authorize - метод авторизации пользователя способом login
+func (m AuthModel) authorize(username string, password string, login func(*grpcclient.UserClient, string, string) (*models.AuthInfo, error)) tea.Cmd {
 	return func() tea.Msg {
 		if username == "" || password == "" {
 			return messages.ErrorMsg("заполните все поля")
@@ -245,10 +283,18 @@ func (m AuthModel) attemptLogin(username string, password string) tea.Cmd {
 		if err := client.Connect(ctx); err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.connection.ServerAddress(), err.Error()))
 		}
-		token, salt, err := client.Login(username, password)
+		info, err := login(client, username, password)
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка авторизации пользователя %s: %s", username, err.Error()))
 		}
-		return messages.AuthSuccessMsg{Token: token, Username: username, Salt: salt}
+		return messages.AuthSuccessMsg{
+			Token:      info.Token,
+			Username:   username,
+			Salt:       info.Salt,
+			UserID:     info.UserID,
+			KDF:        info.KDF,
+			WrappedKey: info.WrappedKey,
+			KeyCheck:   info.KeyCheck,
+		}
 	}
 }
@@ -13,6 +13,7 @@ import (
 // BankCardSecretModel - модель окна создания/просмотра секрета (банковская карта)
 type BankCardSecretModel struct {
 	cardInputs []textinput.Model
+	extra      ExtraFieldsModel // заметки и дополнительные поля (индексы после cardInputs)
 	focused    int
 	windowSize tea.WindowSizeMsg
 	isEditMode bool   // Флаг режима редактирования
@@ -53,6 +54,8 @@ func NewBankCardSecretModel() BankCardSecretModel {
 		model.cardInputs[i] = t
 	}
 
+	model.extra = NewExtraFieldsModel()
+
 	model.cardInputs[0].Focus()
 	model.cardInputs[0].PromptStyle = styles.FocusedStyle
 	model.cardInputs[0].TextStyle = styles.FocusedStyle
@@ -85,7 +88,8 @@ func (m BankCardSecretModel) Update(msg tea.Msg) (BankCardSecretModel, tea.Cmd)
 		m.cardInputs[2].SetValue(msg.Data.Date)
 		m.cardInputs[3].SetValue(msg.Data.CVV)
 		m.cardInputs[4].SetValue(msg.Data.Owner)
-		return m, nil
+		m.extra = m.extra.SetExtra(msg.Data.Extra).SetNotes(msg.Data.Notes).SetTags(msg.Data.Tags)
+		return m.focusField(0)
 
 	case tea.KeyMsg:
 		// Режим редактирования
@@ -93,31 +97,37 @@ func (m BankCardSecretModel) Update(msg tea.Msg) (BankCardSecretModel, tea.Cmd)
 		case "tab", "shift+tab", "up", "down":
 			s := msg.String()
 
-			// Сбрасываем фокус со всех полей
-			for i := range m.cardInputs {
-				m.cardInputs[i].Blur()
-				m.cardInputs[i].PromptStyle = styles.BlurredStyle
-				m.cardInputs[i].TextStyle = styles.BlurredStyle
-			}
-
+			focused := m.focused
 			if s == "up" || s == "shift+tab" {
-				m.focused--
+				focused--
 			} else {
-				m.focused++
+				focused++
 			}
 
-			if m.focused > 4 {
-				m.focused = 0
-			} else if m.focused < 0 {
-				m.focused = 4
+			last := len(m.cardInputs) + m.extra.Len() - 1
+			if focused > last {
+				focused = 0
+			} else if focused < 0 {
+				focused = last
 			}
+			return m.focusField(focused)
+
+		case "ctrl+n": // Добавление дополнительного поля
+			var idx int
+			m.extra, idx = m.extra.AddField()
+			return m.focusField(len(m.cardInputs) + idx)
+
+		case "ctrl+x": // Удаление дополнительного поля
+			var removed bool
+			m.extra, removed = m.extra.RemoveField(m.focused - len(m.cardInputs))
+			if removed {
+				return m.focusField(len(m.cardInputs))
+			}
+			return m, nil
 
-			// Устанавливаем фокус только на активное поле
-			cmds = append(cmds, m.cardInputs[m.focused].Focus())
-			m.cardInputs[m.focused].PromptStyle = styles.FocusedStyle
-			m.cardInputs[m.focused].TextStyle = styles.FocusedStyle
-
-			return m, tea.Batch(cmds...)
+		case "ctrl+t": // Переключение типа дополнительного поля
+			m.extra = m.extra.CycleType(m.focused - len(m.cardInputs))
+			return m, nil
 
 		case "enter":
 			name := m.cardInputs[0].Value()
@@ -125,11 +135,12 @@ func (m BankCardSecretModel) Update(msg tea.Msg) (BankCardSecretModel, tea.Cmd)
 			date := m.cardInputs[2].Value()
 			cvv := m.cardInputs[3].Value()
 			owner := m.cardInputs[4].Value()
+			extra, notes, tags := m.extra.Extra(), m.extra.Notes(), m.extra.Tags()
 			if m.isEditMode {
 				m.isEditMode = false
-				return m, m.attemptEditSecret(m.sid, name, number, date, cvv, owner)
+				return m, m.attemptEditSecret(m.sid, name, number, date, cvv, owner, extra, notes, tags)
 			}
-			return m, m.attemptAddSecret(name, number, date, cvv, owner)
+			return m, m.attemptAddSecret(name, number, date, cvv, owner, extra, notes, tags)
 
 		case "esc":
 			m.isEditMode = false
@@ -146,6 +157,12 @@ func (m BankCardSecretModel) Update(msg tea.Msg) (BankCardSecretModel, tea.Cmd)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+	} else {
+		var cmd tea.Cmd
+		m.extra, cmd = m.extra.Update(m.focused-len(m.cardInputs), msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 
 	return m, tea.Batch(cmds...)
@@ -160,6 +177,7 @@ func (m BankCardSecretModel) View() string {
 		m.renderInputField("🔒 CVV код:", m.cardInputs[3], 3),
 		m.renderInputField("👤 Владелец:", m.cardInputs[4], 4),
 	}
+	fields = append(fields, m.extra.Views(m.focused-len(m.cardInputs))...)
 
 	// Заголовок в зависимости от режима
 	title := "💳 Банковская карта"
@@ -185,6 +203,13 @@ func (m BankCardSecretModel) View() string {
 		lipgloss.NewStyle().Height(1).Render(""),
 
 		buttons,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render(extraFieldsHint),
 	)
 
 	return styles.ContainerStyle.
@@ -201,6 +226,29 @@ func (m BankCardSecretModel) View() string {
 		)
 }
 
+// focusField - метод переносит фокус на поле ввода с индексом index
+func (m BankCardSecretModel) focusField(index int) (BankCardSecretModel, tea.Cmd) {
+	// Сбрасываем фокус со всех полей
+	for i := range m.cardInputs {
+		m.cardInputs[i].Blur()
+		m.cardInputs[i].PromptStyle = styles.BlurredStyle
+		m.cardInputs[i].TextStyle = styles.BlurredStyle
+	}
+	m.extra = m.extra.Blur()
+
+	m.focused = index
+	if m.focused >= len(m.cardInputs) {
+		var cmd tea.Cmd
+		m.extra, cmd = m.extra.Focus(m.focused - len(m.cardInputs))
+		return m, cmd
+	}
+
+	// Устанавливаем фокус только на активное поле
+	m.cardInputs[m.focused].PromptStyle = styles.FocusedStyle
+	m.cardInputs[m.focused].TextStyle = styles.FocusedStyle
+	return m, m.cardInputs[m.focused].Focus()
+}
+
 // renderInputField - метод для отрисовки полей ввода
 func (m BankCardSecretModel) renderInputField(label string, input textinput.Model, index int) string {
 	var inputStyle lipgloss.Style
@@ -232,7 +280,7 @@ func (m BankCardSecretModel) renderInputField(label string, input textinput.Mode
 }
 
 // attemptAddSecret - метод обработки добавления секрета
-func (m BankCardSecretModel) attemptAddSecret(name string, number string, date string, cvv string, owner string) tea.Cmd {
+func (m BankCardSecretModel) attemptAddSecret(name string, number string, date string, cvv string, owner string, extra models.SecretExtra, notes string, tags []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(name) == 0 {
 			return messages.ErrorMsg("Необходимо задать имя секрета")
@@ -249,6 +297,9 @@ func (m BankCardSecretModel) attemptAddSecret(name string, number string, date s
 		if len(owner) == 0 {
 			return messages.ErrorMsg("Необходимо задать владельца карты")
 		}
+		if err := extra.Validate(); err != nil {
+			return messages.ErrorMsg("Некорректное дополнительное поле: " + err.Error())
+		}
 		return messages.AddSecretCardMsg{
 			Data: messages.SecretCard{
 				Name:   name,
@@ -257,13 +308,16 @@ func (m BankCardSecretModel) attemptAddSecret(name string, number string, date s
 				CVV:    cvv,
 				Date:   date,
 				Owner:  owner,
+				Extra:  extra,
+				Notes:  notes,
+				Tags:   tags,
 			},
 		}
 	}
 }
 
 // attemptEditSecret - метод обработки изменения секрета
-func (m BankCardSecretModel) attemptEditSecret(sid string, name string, number string, date string, cvv string, owner string) tea.Cmd {
+func (m BankCardSecretModel) attemptEditSecret(sid string, name string, number string, date string, cvv string, owner string, extra models.SecretExtra, notes string, tags []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(name) == 0 {
 			return messages.ErrorMsg("Необходимо задать имя секрета")
@@ -280,6 +334,9 @@ func (m BankCardSecretModel) attemptEditSecret(sid string, name string, number s
 		if len(owner) == 0 {
 			return messages.ErrorMsg("Необходимо задать владельца карты")
 		}
+		if err := extra.Validate(); err != nil {
+			return messages.ErrorMsg("Некорректное дополнительное поле: " + err.Error())
+		}
 		return messages.EditSecretCardMsg{
 			ID: sid,
 			Data: messages.SecretCard{
@@ -289,6 +346,9 @@ func (m BankCardSecretModel) attemptEditSecret(sid string, name string, number s
 				CVV:    cvv,
 				Date:   date,
 				Owner:  owner,
+				Extra:  extra,
+				Notes:  notes,
+				Tags:   tags,
 			},
 		}
 	}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+	"go-pass-keeper/pkg/crypto"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EscrowModel - модель окна депонирования ключа хранилища организации: показ отпечатка
+// открытого ключа депонирования для сверки и подтверждение депонирования
+type EscrowModel struct {
+	windowSize tea.WindowSizeMsg
+	publicKey  []byte // открытый ключ депонирования (пусто - ещё не получен)
+	enrolled   bool   // ключ хранилища депонирован
+	status     string // результат последней операции
+}
+
+// NewEscrowModel - метод создания окна депонирования ключа
+func NewEscrowModel() EscrowModel {
+	return EscrowModel{}
+}
+
+// Init - метод инициализации текущего окна
+func (m EscrowModel) Init() tea.Cmd {
+	return nil
+}
+
+// SetPublicKey - метод устанавливает открытый ключ депонирования (пустой - ключ запрашивается)
+func (m EscrowModel) SetPublicKey(publicKey []byte) EscrowModel {
+	m.publicKey = publicKey
+	m.enrolled = false
+	return m
+}
+
+// SetEnrolled - метод отмечает, что ключ хранилища депонирован
+func (m EscrowModel) SetEnrolled() EscrowModel {
+	m.enrolled = true
+	return m
+}
+
+// WithStatus - метод устанавливает строку с результатом последней операции
+func (m EscrowModel) WithStatus(status string) EscrowModel {
+	m.status = status
+	return m
+}
+
+// Update - метод обновления текущего окна
+func (m EscrowModel) Update(msg tea.Msg) (EscrowModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if len(m.publicKey) == 0 || m.enrolled {
+				return m, nil
+			}
+			publicKey := m.publicKey
+			return m, func() tea.Msg {
+				return messages.EnrollEscrowMsg{PublicKey: publicKey}
+			}
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.EscrowCancelMsg{}
+			}
+		}
+	}
+	return m, nil
+}
+
+// View - метод отрисовки текущего состояния
+func (m EscrowModel) View() string {
+	var body, buttons string
+	switch {
+	case len(m.publicKey) == 0:
+		body = lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Render("Получение ключа депонирования организации...")
+		buttons = styles.ButtonStyle.Render("ESC - Отмена")
+	case m.enrolled:
+		body = lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Render("Ключ хранилища депонирован. При утере секрета обратитесь к администратору организации.")
+		buttons = styles.ButtonStyle.Render("ESC - Закрыть")
+	default:
+		body = lipgloss.JoinVertical(
+			lipgloss.Center,
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Ключ хранилища будет дополнительно зашифрован ключом организации."),
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Администратор организации сможет восстановить доступ к хранилищу."),
+			lipgloss.NewStyle().Height(1).Render(""),
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Сверьте отпечаток ключа с администратором организации:"),
+			lipgloss.NewStyle().Height(1).Render(""),
+			styles.TitleStyle.Render(crypto.KeyFingerprint(m.publicKey)),
+		)
+		buttons = lipgloss.JoinHorizontal(
+			lipgloss.Center,
+			styles.ButtonStyle.Render("Enter - Депонировать"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("ESC - Отмена"),
+		)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(60).
+			Render("🏢 Депонирование ключа"),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		body,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+		m.status,
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		buttons,
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
@@ -0,0 +1,201 @@
+package models
+
+import (
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Индексы полей окна срока действия
+const (
+	expireDateIndex = iota
+	expirePolicyIndex
+)
+
+// ExpireSecretModel - модель окна изменения срока действия секрета
+type ExpireSecretModel struct {
+	dateInput   textinput.Model
+	policyInput textinput.Model
+	focused     int
+	windowSize  tea.WindowSizeMsg
+	sid         string // id секрета
+	name        string // название секрета
+}
+
+// NewExpireSecretModel - метод создания модели окна срока действия секрета
+func NewExpireSecretModel() ExpireSecretModel {
+	model := ExpireSecretModel{}
+
+	model.dateInput = textinput.New()
+	model.dateInput.Placeholder = "ГГГГ-ММ-ДД (пусто - бессрочно)"
+	model.dateInput.CharLimit = 10
+
+	model.policyInput = textinput.New()
+	model.policyInput.Placeholder = "flag, archive или delete"
+	model.policyInput.CharLimit = 16
+
+	return model.focus(expireDateIndex)
+}
+
+// Init - метод инициализации текущего окна
+func (m ExpireSecretModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// SetSecret - метод устанавливает секрет и его текущий срок действия
+func (m ExpireSecretModel) SetSecret(info *models.SecretInfo) ExpireSecretModel {
+	m.sid = info.ID
+	m.name = info.Name
+	m.dateInput.SetValue("")
+	if !info.Expires.IsZero() {
+		m.dateInput.SetValue(info.Expires.Local().Format(time.DateOnly))
+	}
+	m.policyInput.SetValue(info.ExpirePolicy)
+	return m.focus(expireDateIndex)
+}
+
+// Update - метод обновления текущего окна
+func (m ExpireSecretModel) Update(msg tea.Msg) (ExpireSecretModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "shift+tab", "up", "down":
+			if m.focused == expireDateIndex {
+				return m.focus(expirePolicyIndex), textinput.Blink
+			}
+			return m.focus(expireDateIndex), textinput.Blink
+		case "enter":
+			return m, m.attemptExpire(m.dateInput.Value(), m.policyInput.Value())
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.ExpireCancelMsg{}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.focused == expireDateIndex {
+		m.dateInput, cmd = m.dateInput.Update(msg)
+	} else {
+		m.policyInput, cmd = m.policyInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m ExpireSecretModel) View() string {
+	buttons := lipgloss.JoinHorizontal(
+		lipgloss.Center,
+		styles.ButtonStyle.Render("Enter - Сохранить"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render("ESC - Отмена"),
+	)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(40).
+			Render("⏳ Срок действия"),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Render("Секрет: "+m.name),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderInputField("📅 Истекает:", m.dateInput, expireDateIndex),
+			m.renderInputField("⚙️ По истечении:", m.policyInput, expirePolicyIndex),
+		),
+
+		lipgloss.NewStyle().Height(2).Render(""),
+
+		buttons,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render("flag - только отметить • archive - перенести в архив • delete - удалить"),
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// focus - метод устанавливает фокус на поле ввода
+func (m ExpireSecretModel) focus(index int) ExpireSecretModel {
+	m.focused = index
+	inputs := map[int]*textinput.Model{
+		expireDateIndex:   &m.dateInput,
+		expirePolicyIndex: &m.policyInput,
+	}
+	for i, input := range inputs {
+		if i == index {
+			input.Focus()
+			input.PromptStyle = styles.FocusedStyle
+			input.TextStyle = styles.FocusedStyle
+		} else {
+			input.Blur()
+			input.PromptStyle = styles.BlurredStyle
+			input.TextStyle = styles.BlurredStyle
+		}
+	}
+	return m
+}
+
+// renderInputField - метод для отрисовки полей ввода
+func (m ExpireSecretModel) renderInputField(label string, input textinput.Model, index int) string {
+	inputStyle := styles.InputFieldStyle
+	if index == m.focused {
+		inputStyle = styles.FocusedInputFieldStyle
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.InputLabelStyle.Render(label),
+		inputStyle.Width(40).Render(input.View()),
+	)
+}
+
+// attemptExpire - метод обработки изменения срока действия (срок задаётся датой, секрет
+// истекает в начале указанного дня по местному времени)
+func (m ExpireSecretModel) attemptExpire(date string, policy string) tea.Cmd {
+	return func() tea.Msg {
+		if policy == "" {
+			policy = models.ExpireFlag
+		}
+		if !models.ValidExpirePolicy(policy) {
+			return messages.ErrorMsg("Неизвестное действие по истечении: " + policy)
+		}
+		var expires time.Time
+		if date != "" {
+			t, err := time.ParseInLocation(time.DateOnly, date, time.Local)
+			if err != nil {
+				return messages.ErrorMsg("Дата должна быть в формате ГГГГ-ММ-ДД")
+			}
+			expires = t
+		}
+		return messages.SetExpirationMsg{ID: m.sid, Name: m.name, Expires: expires, Policy: policy}
+	}
+}
@@ -0,0 +1,280 @@
+package models
+
+import (
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/styles"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// extraFieldsHint - подсказка по работе с дополнительными полями
+const extraFieldsHint = "Ctrl+N: добавить поле • Ctrl+T: тип поля • Ctrl+X: удалить поле"
+
+// customFieldInput - поля ввода одного дополнительного поля секрета
+type customFieldInput struct {
+	kind  string
+	name  textinput.Model
+	value textinput.Model
+}
+
+// ExtraFieldsModel - модель ввода заметок, тегов и дополнительных полей секрета.
+// Поля ввода нумеруются подряд: 0 - заметки, 1 - теги, далее пары (название, значение) для каждого поля
+type ExtraFieldsModel struct {
+	notes  textinput.Model
+	tags   textinput.Model
+	fields []customFieldInput
+}
+
+// NewExtraFieldsModel - метод создания модели заметок, тегов и дополнительных полей
+func NewExtraFieldsModel() ExtraFieldsModel {
+	model := ExtraFieldsModel{}
+
+	model.notes = textinput.New()
+	model.notes.Placeholder = "Заметки"
+	model.notes.CharLimit = 500
+	model.notes.TextStyle = styles.BlurredStyle
+	model.notes.PromptStyle = styles.BlurredStyle
+
+	model.tags = textinput.New()
+	model.tags.Placeholder = "Теги через запятую"
+	model.tags.CharLimit = 200
+	model.tags.TextStyle = styles.BlurredStyle
+	model.tags.PromptStyle = styles.BlurredStyle
+
+	return model
+}
+
+// Len - метод возвращает количество полей ввода
+func (m ExtraFieldsModel) Len() int {
+	return 2 + 2*len(m.fields)
+}
+
+// SetExtra - метод заполняет поля ввода дополнительными полями секрета
+func (m ExtraFieldsModel) SetExtra(extra models.SecretExtra) ExtraFieldsModel {
+	m.fields = make([]customFieldInput, 0, len(extra.Fields))
+	for _, field := range extra.Fields {
+		input := newCustomFieldInput(field.Type)
+		input.name.SetValue(field.Name)
+		input.value.SetValue(string(field.Value))
+		m.fields = append(m.fields, input)
+	}
+	return m
+}
+
+// SetNotes - метод заполняет поле ввода заметок
+func (m ExtraFieldsModel) SetNotes(notes string) ExtraFieldsModel {
+	m.notes.SetValue(notes)
+	return m
+}
+
+// Notes - метод возвращает введённые заметки
+func (m ExtraFieldsModel) Notes() string {
+	return m.notes.Value()
+}
+
+// SetTags - метод заполняет поле ввода тегов
+func (m ExtraFieldsModel) SetTags(tags []string) ExtraFieldsModel {
+	m.tags.SetValue(strings.Join(tags, ", "))
+	return m
+}
+
+// Tags - метод возвращает введённые теги (пустые значения пропускаются)
+func (m ExtraFieldsModel) Tags() []string {
+	var tags []string
+	for _, tag := range strings.Split(m.tags.Value(), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Extra - метод возвращает введённые дополнительные поля (пустые поля пропускаются)
+func (m ExtraFieldsModel) Extra() models.SecretExtra {
+	extra := models.SecretExtra{}
+	for _, field := range m.fields {
+		if field.name.Value() == "" && field.value.Value() == "" {
+			continue
+		}
+		extra.Fields = append(extra.Fields, models.CustomField{
+			Name:  field.name.Value(),
+			Type:  field.kind,
+			Value: models.SecretValue(field.value.Value()),
+		})
+	}
+	return extra
+}
+
+// Blur - метод снимает фокус со всех полей ввода
+func (m ExtraFieldsModel) Blur() ExtraFieldsModel {
+	for i := 0; i < m.Len(); i++ {
+		input := m.input(i)
+		input.Blur()
+		input.PromptStyle = styles.BlurredStyle
+		input.TextStyle = styles.BlurredStyle
+	}
+	return m
+}
+
+// Focus - метод устанавливает фокус на поле ввода с индексом i
+func (m ExtraFieldsModel) Focus(i int) (ExtraFieldsModel, tea.Cmd) {
+	input := m.input(i)
+	if input == nil {
+		return m, nil
+	}
+	input.PromptStyle = styles.FocusedStyle
+	input.TextStyle = styles.FocusedStyle
+	return m, input.Focus()
+}
+
+// Update - метод передает сообщение полю ввода с индексом i
+func (m ExtraFieldsModel) Update(i int, msg tea.Msg) (ExtraFieldsModel, tea.Cmd) {
+	input := m.input(i)
+	if input == nil {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	*input, cmd = input.Update(msg)
+	return m, cmd
+}
+
+// AddField - метод добавляет текстовое поле и возвращает индекс поля ввода его названия
+func (m ExtraFieldsModel) AddField() (ExtraFieldsModel, int) {
+	m.fields = append(m.fields, newCustomFieldInput(models.FieldText))
+	return m, m.Len() - 2
+}
+
+// RemoveField - метод удаляет дополнительное поле, которому принадлежит поле ввода i
+func (m ExtraFieldsModel) RemoveField(i int) (ExtraFieldsModel, bool) {
+	idx := (i - 2) / 2
+	if i < 2 || idx >= len(m.fields) {
+		return m, false
+	}
+	m.fields = append(m.fields[:idx:idx], m.fields[idx+1:]...)
+	return m, true
+}
+
+// CycleType - метод переключает тип дополнительного поля, которому принадлежит поле ввода i
+func (m ExtraFieldsModel) CycleType(i int) ExtraFieldsModel {
+	idx := (i - 2) / 2
+	if i < 2 || idx >= len(m.fields) {
+		return m
+	}
+	next := models.FieldTypes[0]
+	for j, kind := range models.FieldTypes {
+		if kind == m.fields[idx].kind && j+1 < len(models.FieldTypes) {
+			next = models.FieldTypes[j+1]
+		}
+	}
+	m.fields[idx].setKind(next)
+	return m
+}
+
+// Views - метод отрисовки полей ввода (focused - индекс активного поля или -1)
+func (m ExtraFieldsModel) Views(focused int) []string {
+	views := []string{
+		renderExtraInput("🗒️ Заметки:", m.notes, focused == 0, false),
+		renderExtraInput("🏷️ Теги:", m.tags, focused == 1, false),
+	}
+	for i, field := range m.fields {
+		label := "➕ Поле (" + fieldTypeLabel(field.kind) + "):"
+		views = append(views, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			renderExtraInput(label, field.name, focused == 2+2*i, false),
+			" ",
+			renderExtraInput("Значение:", field.value, focused == 3+2*i, field.kind == models.FieldHidden),
+		))
+	}
+	return views
+}
+
+// input - метод возвращает указатель на поле ввода с индексом i
+func (m *ExtraFieldsModel) input(i int) *textinput.Model {
+	switch i {
+	case 0:
+		return &m.notes
+	case 1:
+		return &m.tags
+	}
+	idx := (i - 2) / 2
+	if i < 0 || idx >= len(m.fields) {
+		return nil
+	}
+	if i%2 == 0 {
+		return &m.fields[idx].name
+	}
+	return &m.fields[idx].value
+}
+
+// newCustomFieldInput - метод создания полей ввода дополнительного поля
+func newCustomFieldInput(kind string) customFieldInput {
+	input := customFieldInput{
+		name:  textinput.New(),
+		value: textinput.New(),
+	}
+	input.name.Placeholder = "Название"
+	input.name.CharLimit = 50
+	input.value.CharLimit = 200
+	for _, t := range []*textinput.Model{&input.name, &input.value} {
+		t.TextStyle = styles.BlurredStyle
+		t.PromptStyle = styles.BlurredStyle
+	}
+	input.setKind(kind)
+	return input
+}
+
+// setKind - метод устанавливает тип дополнительного поля
+func (f *customFieldInput) setKind(kind string) {
+	f.kind = kind
+	f.value.EchoMode = textinput.EchoNormal
+	switch kind {
+	case models.FieldHidden:
+		f.value.Placeholder = "Скрытое значение"
+		f.value.EchoMode = textinput.EchoPassword
+	case models.FieldURL:
+		f.value.Placeholder = "https://"
+	case models.FieldDate:
+		f.value.Placeholder = "ГГГГ-ММ-ДД"
+	default:
+		f.value.Placeholder = "Значение"
+	}
+}
+
+// fieldTypeLabel - метод возвращает название типа дополнительного поля
+func fieldTypeLabel(kind string) string {
+	switch kind {
+	case models.FieldHidden:
+		return "скрытое"
+	case models.FieldURL:
+		return "ссылка"
+	case models.FieldDate:
+		return "дата"
+	default:
+		return "текст"
+	}
+}
+
+// renderExtraInput - метод отрисовки поля ввода (скрытые значения маскируются и без фокуса)
+func renderExtraInput(label string, input textinput.Model, focused bool, hidden bool) string {
+	inputStyle := styles.InputFieldStyle
+	fieldView := input.Value()
+	if hidden {
+		fieldView = strings.Repeat("•", len([]rune(fieldView)))
+	}
+	if focused {
+		inputStyle = styles.FocusedInputFieldStyle
+		fieldView = input.View()
+	}
+	if fieldView == "" {
+		fieldView = " "
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.InputLabelStyle.Render(label),
+		inputStyle.Render(fieldView),
+	) + "\n"
+}
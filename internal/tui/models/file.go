@@ -4,6 +4,7 @@ import (
 	"go-pass-keeper/internal/models"
 	"go-pass-keeper/internal/tui/messages"
 	"go-pass-keeper/internal/tui/styles"
+	"go-pass-keeper/pkg/securemem"
 	"os"
 	"path/filepath"
 
@@ -16,9 +17,12 @@ import (
 type FileSecretModel struct {
 	filePathInput textinput.Model
 	windowSize    tea.WindowSizeMsg
-	isEditMode    bool   // Флаг режима редактирования
-	sid           string // id для редактирования
-	secretData    []byte // Данные
+	isEditMode    bool               // Флаг режима редактирования
+	sid           string             // id для редактирования
+	secretData    []byte             // Данные
+	extra         models.SecretExtra // дополнительные поля (сохраняются при изменении)
+	notes         string             // заметки (сохраняются при изменении)
+	tags          []string           // теги (сохраняются при изменении)
 }
 
 // NewFileSecretModel - метод создания модель окна секрета (файл)
@@ -57,6 +61,9 @@ func (m FileSecretModel) Update(msg tea.Msg) (FileSecretModel, tea.Cmd) {
 		m.isEditMode = true
 		m.sid = msg.ID
 		m.secretData = msg.Data.Blob
+		m.extra = msg.Data.Extra
+		m.notes = msg.Data.Notes
+		m.tags = msg.Data.Tags
 		// Заполняем поле данными для просмотра
 		m.filePathInput.SetValue(msg.Data.Name)
 		return m, nil
@@ -76,6 +83,9 @@ func (m FileSecretModel) Update(msg tea.Msg) (FileSecretModel, tea.Cmd) {
 
 		case "esc":
 			m.isEditMode = false
+			// расшифрованные данные просмотренного файла не нужны после закрытия окна
+			securemem.Wipe(m.secretData)
+			m.secretData = nil
 			return m, func() tea.Msg {
 				return messages.SecretAddCancelMsg{}
 			}
@@ -209,9 +219,12 @@ func (m FileSecretModel) attemptEditSecret(sid string, filename string) tea.Cmd
 			return messages.EditSecretBinaryMsg{
 				ID: sid,
 				Data: messages.SecretBinary{
-					Name: filepath.Base(filename),
-					Type: models.SecretBinaryType,
-					Blob: content,
+					Name:  filepath.Base(filename),
+					Type:  models.SecretBinaryType,
+					Blob:  content,
+					Extra: m.extra,
+					Notes: m.notes,
+					Tags:  m.tags,
 				},
 			}
 		}
@@ -15,6 +15,7 @@ type LoginSecretModel struct {
 	nameInput     textinput.Model
 	loginInput    textinput.Model
 	passwordInput textinput.Model
+	extra         ExtraFieldsModel // заметки и дополнительные поля
 	focused       int
 	windowSize    tea.WindowSizeMsg
 	isEditMode    bool   // Флаг режима редактирования
@@ -26,6 +27,7 @@ const (
 	fieldNameIndex = iota
 	fieldLoginIndex
 	fieldPasswordIndex
+	fieldExtraIndex // первое поле ввода заметок и дополнительных полей
 )
 
 // NewFileSecretModel - метод создания модель окна секрета (логин/пароль)
@@ -53,6 +55,8 @@ func NewLoginSecretModel() LoginSecretModel {
 	model.passwordInput.TextStyle = styles.BlurredStyle
 	model.passwordInput.PromptStyle = styles.BlurredStyle
 
+	model.extra = NewExtraFieldsModel()
+
 	model.nameInput.Focus()
 
 	return model
@@ -80,7 +84,8 @@ func (m LoginSecretModel) Update(msg tea.Msg) (LoginSecretModel, tea.Cmd) {
 		m.nameInput.SetValue(msg.Data.Name)
 		m.loginInput.SetValue(msg.Data.Login)
 		m.passwordInput.SetValue(msg.Data.Password)
-		return m, nil
+		m.extra = m.extra.SetExtra(msg.Data.Extra).SetNotes(msg.Data.Notes).SetTags(msg.Data.Tags)
+		return m.focusField(fieldNameIndex)
 
 	case tea.KeyMsg:
 		// Режим редактирования
@@ -88,53 +93,46 @@ func (m LoginSecretModel) Update(msg tea.Msg) (LoginSecretModel, tea.Cmd) {
 		case "tab", "shift+tab", "up", "down":
 			s := msg.String()
 
-			// Сбрасываем стили всех полей
-			m.nameInput.Blur()
-			m.loginInput.Blur()
-			m.passwordInput.Blur()
-			m.nameInput.PromptStyle = styles.BlurredStyle
-			m.nameInput.TextStyle = styles.BlurredStyle
-			m.loginInput.PromptStyle = styles.BlurredStyle
-			m.loginInput.TextStyle = styles.BlurredStyle
-			m.passwordInput.PromptStyle = styles.BlurredStyle
-			m.passwordInput.TextStyle = styles.BlurredStyle
-
 			// Навигация по полям
+			focused := m.focused
 			if s == "up" || s == "shift+tab" {
-				m.focused--
+				focused--
 			} else {
-				m.focused++
+				focused++
 			}
 
-			if m.focused > fieldPasswordIndex {
-				m.focused = fieldNameIndex
-			} else if m.focused < fieldNameIndex {
-				m.focused = fieldPasswordIndex
+			last := fieldExtraIndex + m.extra.Len() - 1
+			if focused > last {
+				focused = fieldNameIndex
+			} else if focused < fieldNameIndex {
+				focused = last
 			}
-
-			// Устанавливаем фокус на активное поле
-			switch m.focused {
-			case fieldNameIndex:
-				cmds = append(cmds, m.nameInput.Focus())
-				m.nameInput.PromptStyle = styles.FocusedStyle
-				m.nameInput.TextStyle = styles.FocusedStyle
-			case fieldLoginIndex:
-				cmds = append(cmds, m.loginInput.Focus())
-				m.loginInput.PromptStyle = styles.FocusedStyle
-				m.loginInput.TextStyle = styles.FocusedStyle
-			case fieldPasswordIndex:
-				cmds = append(cmds, m.passwordInput.Focus())
-				m.passwordInput.PromptStyle = styles.FocusedStyle
-				m.passwordInput.TextStyle = styles.FocusedStyle
+			return m.focusField(focused)
+
+		case "ctrl+n": // Добавление дополнительного поля
+			var idx int
+			m.extra, idx = m.extra.AddField()
+			return m.focusField(fieldExtraIndex + idx)
+
+		case "ctrl+x": // Удаление дополнительного поля
+			var removed bool
+			m.extra, removed = m.extra.RemoveField(m.focused - fieldExtraIndex)
+			if removed {
+				return m.focusField(fieldExtraIndex)
 			}
-			return m, tea.Batch(cmds...)
+			return m, nil
+
+		case "ctrl+t": // Переключение типа дополнительного поля
+			m.extra = m.extra.CycleType(m.focused - fieldExtraIndex)
+			return m, nil
 
 		case "enter":
+			extra, notes, tags := m.extra.Extra(), m.extra.Notes(), m.extra.Tags()
 			if m.isEditMode {
 				m.isEditMode = false // сбрасываем режим
-				return m, m.attemptEditSecret(m.sid, m.nameInput.Value(), m.loginInput.Value(), m.passwordInput.Value())
+				return m, m.attemptEditSecret(m.sid, m.nameInput.Value(), m.loginInput.Value(), m.passwordInput.Value(), extra, notes, tags)
 			}
-			return m, m.attemptAddSecret(m.nameInput.Value(), m.loginInput.Value(), m.passwordInput.Value())
+			return m, m.attemptAddSecret(m.nameInput.Value(), m.loginInput.Value(), m.passwordInput.Value(), extra, notes, tags)
 
 		case "esc":
 			m.isEditMode = false
@@ -153,6 +151,8 @@ func (m LoginSecretModel) Update(msg tea.Msg) (LoginSecretModel, tea.Cmd) {
 		m.loginInput, cmd = m.loginInput.Update(msg)
 	case fieldPasswordIndex:
 		m.passwordInput, cmd = m.passwordInput.Update(msg)
+	default:
+		m.extra, cmd = m.extra.Update(m.focused-fieldExtraIndex, msg)
 	}
 
 	if cmd != nil {
@@ -169,6 +169,7 @@ func (m LoginSecretModel) View() string {
 		m.renderInputField("👤 Логин:", m.loginInput, fieldLoginIndex),
 		m.renderInputField("🔒 Пароль:", m.passwordInput, fieldPasswordIndex),
 	}
+	fields = append(fields, m.extra.Views(m.focused-fieldExtraIndex)...)
 
 	title := "🔐 Логин и пароль"
 	buttons := lipgloss.JoinHorizontal(
@@ -184,13 +185,20 @@ func (m LoginSecretModel) View() string {
 			Width(40).
 			Render(title),
 
-		lipgloss.NewStyle().Height(2).Render(""),
+		lipgloss.NewStyle().Height(1).Render(""),
 
 		lipgloss.JoinVertical(lipgloss.Left, fields...),
 
-		lipgloss.NewStyle().Height(2).Render(""),
+		lipgloss.NewStyle().Height(1).Render(""),
 
 		buttons,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render(extraFieldsHint),
 	)
 
 	return styles.ContainerStyle.
@@ -207,6 +215,35 @@ func (m LoginSecretModel) View() string {
 		)
 }
 
+// focusField - метод переносит фокус на поле ввода с индексом index
+func (m LoginSecretModel) focusField(index int) (LoginSecretModel, tea.Cmd) {
+	// Сбрасываем стили всех полей
+	for _, input := range []*textinput.Model{&m.nameInput, &m.loginInput, &m.passwordInput} {
+		input.Blur()
+		input.PromptStyle = styles.BlurredStyle
+		input.TextStyle = styles.BlurredStyle
+	}
+	m.extra = m.extra.Blur()
+
+	m.focused = index
+	var input *textinput.Model
+	switch m.focused {
+	case fieldNameIndex:
+		input = &m.nameInput
+	case fieldLoginIndex:
+		input = &m.loginInput
+	case fieldPasswordIndex:
+		input = &m.passwordInput
+	default:
+		var cmd tea.Cmd
+		m.extra, cmd = m.extra.Focus(m.focused - fieldExtraIndex)
+		return m, cmd
+	}
+	input.PromptStyle = styles.FocusedStyle
+	input.TextStyle = styles.FocusedStyle
+	return m, input.Focus()
+}
+
 // renderInputField - метод для отрисовки полей ввода
 func (m LoginSecretModel) renderInputField(label string, input textinput.Model, index int) string {
 	var inputStyle lipgloss.Style
@@ -235,7 +272,7 @@ func (m LoginSecretModel) renderInputField(label string, input textinput.Model,
 }
 
 // attemptAddSecret - метод обработки добавления секрета
-func (m LoginSecretModel) attemptAddSecret(name string, username string, password string) tea.Cmd {
+func (m LoginSecretModel) attemptAddSecret(name string, username string, password string, extra models.SecretExtra, notes string, tags []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(name) == 0 {
 			return messages.ErrorMsg("Необходимо задать имя секрета")
@@ -246,19 +283,25 @@ func (m LoginSecretModel) attemptAddSecret(name string, username string, passwor
 		if len(password) == 0 {
 			return messages.ErrorMsg("Необходимо задать пароль")
 		}
+		if err := extra.Validate(); err != nil {
+			return messages.ErrorMsg("Некорректное дополнительное поле: " + err.Error())
+		}
 		return messages.AddSecretPasswordMsg{
 			Data: messages.SecretPassword{
 				Name:     name,
 				Type:     models.SecretPasswordType,
 				Login:    username,
 				Password: password,
+				Extra:    extra,
+				Notes:    notes,
+				Tags:     tags,
 			},
 		}
 	}
 }
 
 // attemptEditSecret - метод обработки изменения секрета
-func (m LoginSecretModel) attemptEditSecret(sid string, name string, username string, password string) tea.Cmd {
+func (m LoginSecretModel) attemptEditSecret(sid string, name string, username string, password string, extra models.SecretExtra, notes string, tags []string) tea.Cmd {
 	return func() tea.Msg {
 		if len(name) == 0 {
 			return messages.ErrorMsg("Необходимо задать имя секрета")
@@ -269,6 +312,9 @@ func (m LoginSecretModel) attemptEditSecret(sid string, name string, username st
 		if len(password) == 0 {
 			return messages.ErrorMsg("Необходимо задать пароль")
 		}
+		if err := extra.Validate(); err != nil {
+			return messages.ErrorMsg("Некорректное дополнительное поле: " + err.Error())
+		}
 		return messages.EditSecretPasswordMsg{
 			ID: sid,
 			Data: messages.SecretPassword{
@@ -276,6 +322,9 @@ func (m LoginSecretModel) attemptEditSecret(sid string, name string, username st
 				Type:     models.SecretPasswordType,
 				Login:    username,
 				Password: password,
+				Extra:    extra,
+				Notes:    notes,
+				Tags:     tags,
 			},
 		}
 	}
@@ -0,0 +1,316 @@
+package models
+
+import (
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultKitPath - файл аварийного комплекта по умолчанию
+const defaultKitPath = "go-pass-keeper-emergency-kit.txt"
+
+// defaultSharesPath - файлы частей ключа восстановления по умолчанию (к имени добавляется номер части)
+const defaultSharesPath = "go-pass-keeper-recovery-share.txt"
+
+// maxRecoveryShares - максимальное количество частей ключа восстановления
+const maxRecoveryShares = 16
+
+// Индексы полей разделения ключа восстановления на части
+const (
+	sharesTotalIndex = iota
+	sharesThresholdIndex
+	sharesPathIndex
+)
+
+// RecoveryModel - модель окна ключа восстановления: создание ключа, показ кода (один раз),
+// сохранение аварийного комплекта или разделение ключа на части для доверенных хранителей
+type RecoveryModel struct {
+	pathInput   textinput.Model
+	sharesInput []textinput.Model // количество частей, порог, путь к файлам частей
+	splitting   bool              // режим разделения ключа на части
+	qr          bool              // добавлять в файлы частей QR-коды
+	focused     int
+	windowSize  tea.WindowSizeMsg
+	code        string // код созданного ключа восстановления (пусто - ключ ещё не создан)
+	status      string // результат последней операции
+}
+
+// NewRecoveryModel - метод создания окна ключа восстановления
+func NewRecoveryModel() RecoveryModel {
+	model := RecoveryModel{}
+
+	model.pathInput = textinput.New()
+	model.pathInput.Placeholder = "Путь к файлу"
+	model.pathInput.CharLimit = 256
+	model.pathInput.TextStyle = styles.FocusedStyle
+	model.pathInput.PromptStyle = styles.FocusedStyle
+
+	model.sharesInput = make([]textinput.Model, 3)
+	model.sharesInput[sharesTotalIndex] = textinput.New()
+	model.sharesInput[sharesTotalIndex].Placeholder = "5"
+	model.sharesInput[sharesTotalIndex].CharLimit = 2
+	model.sharesInput[sharesThresholdIndex] = textinput.New()
+	model.sharesInput[sharesThresholdIndex].Placeholder = "3"
+	model.sharesInput[sharesThresholdIndex].CharLimit = 2
+	model.sharesInput[sharesPathIndex] = textinput.New()
+	model.sharesInput[sharesPathIndex].Placeholder = "Путь к файлам"
+	model.sharesInput[sharesPathIndex].CharLimit = 256
+
+	return model
+}
+
+// Init - метод инициализации текущего окна
+func (m RecoveryModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// SetCode - метод устанавливает код ключа восстановления (пустой код - окно создания ключа)
+func (m RecoveryModel) SetCode(code string) RecoveryModel {
+	m.code = code
+	m.splitting = false
+	m.qr = false
+	m.pathInput.SetValue(defaultKitPath)
+	m.sharesInput[sharesTotalIndex].SetValue("5")
+	m.sharesInput[sharesThresholdIndex].SetValue("3")
+	m.sharesInput[sharesPathIndex].SetValue(defaultSharesPath)
+	if code == "" {
+		m.pathInput.Blur()
+	} else {
+		m.pathInput.Focus()
+	}
+	return m
+}
+
+// WithStatus - метод устанавливает строку с результатом последней операции
+func (m RecoveryModel) WithStatus(status string) RecoveryModel {
+	m.status = status
+	return m
+}
+
+// Update - метод обновления текущего окна
+func (m RecoveryModel) Update(msg tea.Msg) (RecoveryModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			if m.code != "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return messages.CreateRecoveryKeyMsg{}
+			}
+		case "ctrl+d":
+			if m.code == "" {
+				return m, nil
+			}
+			m.splitting = !m.splitting
+			if m.splitting {
+				m.pathInput.Blur()
+				return m.focus(sharesTotalIndex), textinput.Blink
+			}
+			return m, m.pathInput.Focus()
+		case "ctrl+q":
+			if m.splitting {
+				m.qr = !m.qr
+			}
+			return m, nil
+		case "tab", "shift+tab", "up", "down":
+			if m.splitting {
+				next := m.focused + 1
+				if msg.String() == "shift+tab" || msg.String() == "up" {
+					next = m.focused + len(m.sharesInput) - 1
+				}
+				return m.focus(next % len(m.sharesInput)), textinput.Blink
+			}
+		case "ctrl+s":
+			if m.code == "" {
+				return m, nil
+			}
+			if m.splitting {
+				return m, m.attemptSaveShares()
+			}
+			code, path := m.code, m.pathInput.Value()
+			return m, func() tea.Msg {
+				return messages.SaveEmergencyKitMsg{Code: code, Path: path}
+			}
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.RecoveryCancelMsg{}
+			}
+		}
+	}
+
+	if m.code == "" {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	if m.splitting {
+		m.sharesInput[m.focused], cmd = m.sharesInput[m.focused].Update(msg)
+	} else {
+		m.pathInput, cmd = m.pathInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m RecoveryModel) View() string {
+	var body, buttons string
+	switch {
+	case m.code == "":
+		body = lipgloss.JoinVertical(
+			lipgloss.Center,
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Ключ восстановления открывает хранилище, если секрет забыт."),
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Код показывается один раз. Прежний ключ восстановления перестанет действовать."),
+		)
+		buttons = lipgloss.JoinHorizontal(
+			lipgloss.Center,
+			styles.ButtonStyle.Render("Enter - Создать"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("ESC - Отмена"),
+		)
+	case m.splitting:
+		qr := "нет"
+		if m.qr {
+			qr = "да"
+		}
+		body = lipgloss.JoinVertical(
+			lipgloss.Center,
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Ключ будет разделён на части: хранилище открывают любые части в количестве порога,"),
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("меньшее число частей ничего не раскрывает. Каждая часть сохраняется в отдельный файл."),
+			lipgloss.NewStyle().Height(1).Render(""),
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				m.renderInputField("🧩 Количество частей:", sharesTotalIndex),
+				m.renderInputField("🔢 Порог (частей для восстановления):", sharesThresholdIndex),
+				m.renderInputField("📁 Файлы частей:", sharesPathIndex),
+				styles.InputLabelStyle.Render("▦ QR-коды: "+qr),
+			),
+		)
+		buttons = lipgloss.JoinHorizontal(
+			lipgloss.Center,
+			styles.ButtonStyle.Render("Ctrl+S - Сохранить части"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("Ctrl+Q - QR-коды"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("Ctrl+D - Комплект"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("ESC - Закрыть"),
+		)
+	default:
+		body = lipgloss.JoinVertical(
+			lipgloss.Center,
+			lipgloss.NewStyle().
+				Foreground(styles.TextSecondary).
+				Render("Запишите код или сохраните аварийный комплект и распечатайте его:"),
+			lipgloss.NewStyle().Height(1).Render(""),
+			styles.TitleStyle.Render(m.code),
+			lipgloss.NewStyle().Height(1).Render(""),
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				styles.InputLabelStyle.Render("📁 Аварийный комплект:"),
+				styles.FocusedInputFieldStyle.Width(60).Render(m.pathInput.View()),
+			),
+		)
+		buttons = lipgloss.JoinHorizontal(
+			lipgloss.Center,
+			styles.ButtonStyle.Render("Ctrl+S - Сохранить комплект"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("Ctrl+D - Разделить на части"),
+			styles.DividerStyle.Render(),
+			styles.ButtonStyle.Render("ESC - Закрыть"),
+		)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(60).
+			Render("🛟 Ключ восстановления"),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		body,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+		m.status,
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		buttons,
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// focus - метод устанавливает фокус на поле ввода разделения ключа
+func (m RecoveryModel) focus(index int) RecoveryModel {
+	m.focused = index
+	for i := range m.sharesInput {
+		if i == index {
+			m.sharesInput[i].Focus()
+			m.sharesInput[i].PromptStyle = styles.FocusedStyle
+			m.sharesInput[i].TextStyle = styles.FocusedStyle
+		} else {
+			m.sharesInput[i].Blur()
+			m.sharesInput[i].PromptStyle = styles.BlurredStyle
+			m.sharesInput[i].TextStyle = styles.BlurredStyle
+		}
+	}
+	return m
+}
+
+// renderInputField - метод для отрисовки полей ввода разделения ключа
+func (m RecoveryModel) renderInputField(label string, index int) string {
+	inputStyle := styles.InputFieldStyle
+	if m.focused == index {
+		inputStyle = styles.FocusedInputFieldStyle
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.InputLabelStyle.Render(label),
+		inputStyle.Width(60).Render(m.sharesInput[index].View()),
+	)
+}
+
+// attemptSaveShares - метод проверки параметров разделения ключа восстановления на части
+func (m RecoveryModel) attemptSaveShares() tea.Cmd {
+	code, qr := m.code, m.qr
+	total, totalErr := strconv.Atoi(m.sharesInput[sharesTotalIndex].Value())
+	threshold, thresholdErr := strconv.Atoi(m.sharesInput[sharesThresholdIndex].Value())
+	path := m.sharesInput[sharesPathIndex].Value()
+	return func() tea.Msg {
+		if totalErr != nil || thresholdErr != nil || threshold < 2 || threshold > total || total > maxRecoveryShares {
+			return messages.ErrorMsg("Порог должен быть от 2 до количества частей, частей - не больше " + strconv.Itoa(maxRecoveryShares))
+		}
+		if path == "" {
+			return messages.ErrorMsg("Укажите путь к файлам")
+		}
+		return messages.SaveRecoverySharesMsg{Code: code, Total: total, Threshold: threshold, Path: path, QR: qr}
+	}
+}
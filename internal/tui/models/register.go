@@ -7,6 +7,8 @@ import (
 	"go-pass-keeper/internal/grpcclient/settings"
 	"go-pass-keeper/internal/tui/messages"
 	"go-pass-keeper/internal/tui/styles"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -21,6 +23,7 @@ type RegisterModel struct {
 	err        messages.ErrorMsg
 	windowSize tea.WindowSizeMsg
 	connection *settings.Settings
+	secret     *securemem.Buffer // секрет открытого хранилища (в настройках после открытия не хранится)
 }
 
 // NewLoginModel - метод для создания окна регистрации пользователя
@@ -205,15 +208,31 @@ func (m RegisterModel) View() string {
 		)
 }
 
+// SetSecret - метод задаёт секрет открытого хранилища, которым шифруется ключ нового пользователя
+// (если секрета нет в настройках)
+func (m RegisterModel) SetSecret(secret *securemem.Buffer) RegisterModel {
+	m.secret = secret
+	return m
+}
+
 // attemptRegister - метод обработки прохождения регистрации пользователя
 func (m RegisterModel) attemptRegister(username string, password string, confirm string) tea.Cmd {
+	// команда работает с копией секрета: закрытие хранилища освобождает память исходного буфера
+	secret := securemem.FromString(m.connection.Secret)
+	if secret == nil {
+		secret = m.secret.Clone()
+	}
 	return func() tea.Msg {
+		defer secret.Destroy()
 		if username == "" || password == "" || confirm == "" {
 			return messages.ErrorMsg("заполните все поля")
 		}
 		if password != confirm {
 			return messages.ErrorMsg("пароли не совпадают")
 		}
+		if secret.Len() == 0 {
+			return messages.ErrorMsg("Секрет не задан: укажите секрет в настройках")
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.connection.Timeout)*time.Second)
 		client := grpcclient.NewUserClient(m.connection.ServerAddress())
 		defer func() {
@@ -223,10 +242,43 @@ func (m RegisterModel) attemptRegister(username string, password string, confirm
 		if err := client.Connect(ctx); err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.connection.ServerAddress(), err.Error()))
 		}
-		token, salt, err := client.Register(username, password)
+		info, err := client.Register(username, password)
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка регистрации пользователя %s: %s", username, err.Error()))
 		}
-		return messages.AuthSuccessMsg{Token: token, Username: username, Salt: salt}
+		// ключ хранилища нового пользователя случайный, на сервере он хранится зашифрованным ключом из секрета
+		vaultKey, err := crypto.GenerateDataKey()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+		}
+		defer securemem.Wipe(vaultKey)
+		wrapped, err := info.WrapKey(secret.Bytes(), info.KDF, vaultKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+		}
+		check, err := info.NewKeyCheck(vaultKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+		}
+		keys := grpcclient.NewKeysClient(m.connection.ServerAddress(), info.Token)
+		defer keys.Close()
+		if err := keys.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.connection.ServerAddress(), err.Error()))
+		}
+		if err := keys.SetKdf(info.KDF, wrapped); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения ключа хранилища: %s", err.Error()))
+		}
+		if err := keys.SetKeyCheck(check); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения ключа хранилища: %s", err.Error()))
+		}
+		return messages.AuthSuccessMsg{
+			Token:      info.Token,
+			Username:   username,
+			Salt:       info.Salt,
+			UserID:     info.UserID,
+			KDF:        info.KDF,
+			WrappedKey: wrapped,
+			KeyCheck:   check,
+		}
 	}
 }
@@ -18,6 +18,7 @@ type SettingsModel struct {
 	focused    int
 	windowSize tea.WindowSizeMsg
 	connection *settings.Settings
+	err        messages.ErrorMsg
 }
 
 // Константы для именованных индексов полей
@@ -69,6 +70,14 @@ func NewSettingsModel(connection *settings.Settings) SettingsModel {
 	return model
 }
 
+// ClearSecret - метод очищает поле секрета после открытия хранилища: секрет хранится
+// в защищённой памяти окна секретов, пустое поле при сохранении настроек секрет не меняет
+func (m SettingsModel) ClearSecret() SettingsModel {
+	m.inputs[fieldSecretPassword].SetValue("")
+	m.inputs[fieldSecretPassword].Placeholder = "Без изменений"
+	return m
+}
+
 // Init - метод инициализации окна
 func (m SettingsModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -89,6 +98,7 @@ func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
 			s := msg.String()
 
 			if s == "enter" {
+				m.err = ""
 				// Сохраняем настройки
 				newConnection := settings.Settings{
 					ServerURL:  m.inputs[fieldServerURL].Value(),
@@ -196,6 +206,16 @@ func (m SettingsModel) View() string {
 				m.connection.ServerPort)),
 	)
 
+	// Сообщение об ошибке
+	if m.err != "" {
+		content = lipgloss.JoinVertical(
+			lipgloss.Center,
+			content,
+			lipgloss.NewStyle().Height(1).Render(""),
+			styles.ErrorStyle.Render("❌ "+string(m.err)),
+		)
+	}
+
 	return styles.ContainerStyle.
 		Width(m.windowSize.Width).
 		Height(m.windowSize.Height).
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ShareSecretModel - модель окна передачи секрета другому пользователю
+type ShareSecretModel struct {
+	recipientInput textinput.Model
+	windowSize     tea.WindowSizeMsg
+	sid            string // id передаваемого секрета
+	name           string // название передаваемого секрета
+}
+
+// NewShareSecretModel - метод создания модели окна передачи секрета
+func NewShareSecretModel() ShareSecretModel {
+	model := ShareSecretModel{}
+
+	model.recipientInput = textinput.New()
+	model.recipientInput.Placeholder = "Логин получателя"
+	model.recipientInput.CharLimit = 32
+	model.recipientInput.TextStyle = styles.FocusedStyle
+	model.recipientInput.PromptStyle = styles.FocusedStyle
+
+	model.recipientInput.Focus()
+
+	return model
+}
+
+// Init - метод инициализации текущего окна
+func (m ShareSecretModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// SetSecret - метод устанавливает передаваемый секрет
+func (m ShareSecretModel) SetSecret(sid string, name string) ShareSecretModel {
+	m.sid = sid
+	m.name = name
+	m.recipientInput.SetValue("")
+	return m
+}
+
+// Update - метод обновления текущего окна
+func (m ShareSecretModel) Update(msg tea.Msg) (ShareSecretModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			return m, m.attemptShare(m.recipientInput.Value())
+		case "ctrl+d":
+			return m, m.attemptRevoke(m.recipientInput.Value())
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.SecretShareCancelMsg{}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.recipientInput, cmd = m.recipientInput.Update(msg)
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m ShareSecretModel) View() string {
+	buttons := lipgloss.JoinHorizontal(
+		lipgloss.Center,
+		styles.ButtonStyle.Render("Enter - Поделиться"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render("Ctrl+D - Отозвать"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render("ESC - Отмена"),
+	)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(40).
+			Render("🤝 Поделиться секретом"),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Render("Секрет: "+m.name),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.InputLabelStyle.Render("👤 Получатель:"),
+			styles.FocusedInputFieldStyle.Width(40).Render(m.recipientInput.View()),
+		),
+
+		lipgloss.NewStyle().Height(2).Render(""),
+
+		buttons,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render("Ключ секрета шифруется открытым ключом получателя, сервер не видит содержимое"),
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// attemptShare - метод обработки передачи секрета
+func (m ShareSecretModel) attemptShare(recipient string) tea.Cmd {
+	return func() tea.Msg {
+		if len(recipient) == 0 {
+			return messages.ErrorMsg("Необходимо задать получателя")
+		}
+		return messages.ShareSecretMsg{ID: m.sid, Recipient: recipient}
+	}
+}
+
+// attemptRevoke - метод обработки отзыва доступа к секрету
+func (m ShareSecretModel) attemptRevoke(recipient string) tea.Cmd {
+	return func() tea.Msg {
+		if len(recipient) == 0 {
+			return messages.ErrorMsg("Необходимо задать получателя")
+		}
+		return messages.RevokeShareMsg{ID: m.sid, Recipient: recipient}
+	}
+}
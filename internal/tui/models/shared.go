@@ -0,0 +1,242 @@
+package models
+
+import (
+	"fmt"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SharedViewerModel - модель окна секретов, переданных пользователю
+type SharedViewerModel struct {
+	table      table.Model
+	shares     []*models.SharedSecretInfo
+	privateKey *securemem.Buffer
+	details    string
+	windowSize tea.WindowSizeMsg
+}
+
+// NewSharedViewerModel - метод создания окна переданных секретов
+func NewSharedViewerModel() SharedViewerModel {
+	return SharedViewerModel{
+		table: createSharedTable(),
+	}
+}
+
+// SetShares - метод устанавливает список переданных секретов и ключ для их расшифровки
+func (m SharedViewerModel) SetShares(shares []*models.SharedSecretInfo, privateKey *securemem.Buffer) SharedViewerModel {
+	m.shares = shares
+	m.privateKey = privateKey
+	m.details = ""
+	for _, share := range shares {
+		openShareMeta(privateKey.Bytes(), share)
+	}
+	m.table.SetRows(createSharedTableRows(shares))
+	return m
+}
+
+// Update - метод обновления текущего окна
+func (m SharedViewerModel) Update(msg tea.Msg) (SharedViewerModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			m.details = m.decryptSelected()
+			return m, nil
+		case "esc":
+			if m.details != "" {
+				m.details = ""
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return messages.SecretShareCancelMsg{}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m SharedViewerModel) View() string {
+	body := styles.TableStyle.
+		Width(m.table.Width()).
+		Render(m.table.View())
+	if m.details != "" {
+		body = styles.TableStyle.
+			Width(m.table.Width()).
+			Render(m.details)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(m.windowSize.Width-10).
+			Render("📥 Доступные мне секреты"),
+
+		lipgloss.NewStyle().Height(2).Render(""),
+
+		body,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render("↑/↓: выбор секрета • Enter: просмотр • ESC: назад"),
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// decryptSelected - метод расшифровывает выбранный секрет для просмотра
+func (m SharedViewerModel) decryptSelected() string {
+	idx := m.table.Cursor()
+	if idx < 0 || idx >= len(m.shares) {
+		return ""
+	}
+	share := m.shares[idx]
+	if m.privateKey.Len() == 0 {
+		return "❌ Закрытый ключ не загружен"
+	}
+	key, err := crypto.OpenKey(m.privateKey.Bytes(), share.WrappedKey)
+	if err != nil {
+		return fmt.Sprintf("❌ Ошибка расшифровки ключа: %s", err.Error())
+	}
+	defer securemem.Wipe(key)
+	return renderSecretDetails(share.Owner, messages.ToMessage(key, share.EncryptionOwner(), share.Secret, share.Content))
+}
+
+// openShareMeta - метод расшифровывает метаданные переданного секрета ключом передачи
+func openShareMeta(privateKey []byte, share *models.SharedSecretInfo) {
+	if privateKey == nil || len(share.Secret.EncryptedMeta) == 0 {
+		return
+	}
+	key, err := crypto.OpenKey(privateKey, share.WrappedKey)
+	if err == nil {
+		err = share.Secret.OpenMeta(key, share.EncryptionOwner())
+		securemem.Wipe(key)
+	}
+	if err != nil {
+		share.Secret.Name = undecryptedName
+	}
+}
+
+// renderSecretDetails - метод формирует текстовое представление расшифрованного секрета
+func renderSecretDetails(owner string, msg tea.Msg) string {
+	lines := []string{"Владелец: " + owner}
+	switch msg := msg.(type) {
+	case messages.GetSecretPasswordMsg:
+		lines = append(lines,
+			"Название: "+msg.Data.Name,
+			"Логин: "+msg.Data.Login,
+			"Пароль: "+msg.Data.Password)
+		lines = append(lines, renderExtraDetails(msg.Data.Extra, msg.Data.Notes, msg.Data.Tags)...)
+	case messages.GetSecretCardMsg:
+		lines = append(lines,
+			"Название: "+msg.Data.Name,
+			"Номер: "+msg.Data.Number,
+			"Срок: "+msg.Data.Date,
+			"CVV: "+msg.Data.CVV,
+			"Владелец карты: "+msg.Data.Owner)
+		lines = append(lines, renderExtraDetails(msg.Data.Extra, msg.Data.Notes, msg.Data.Tags)...)
+	case messages.GetSecretTextMsg:
+		lines = append(lines,
+			"Название: "+msg.Data.Name,
+			msg.Data.Text)
+		lines = append(lines, renderExtraDetails(msg.Data.Extra, msg.Data.Notes, msg.Data.Tags)...)
+	case messages.GetSecretBinaryMsg:
+		lines = append(lines,
+			"Файл: "+msg.Data.Name,
+			fmt.Sprintf("Размер: %d байт", len(msg.Data.Blob)))
+		lines = append(lines, renderExtraDetails(msg.Data.Extra, msg.Data.Notes, msg.Data.Tags)...)
+	case messages.ErrorMsg:
+		lines = append(lines, "❌ "+string(msg))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderExtraDetails - метод формирует строки с заметками, дополнительными полями и тегами секрета
+func renderExtraDetails(extra models.SecretExtra, notes string, tags []string) []string {
+	var lines []string
+	for _, field := range extra.Fields {
+		lines = append(lines, field.Name+": "+string(field.Value))
+	}
+	if notes != "" {
+		lines = append(lines, "Заметки: "+notes)
+	}
+	if len(tags) > 0 {
+		lines = append(lines, "Теги: "+strings.Join(tags, ", "))
+	}
+	return lines
+}
+
+// createSharedTable - метод формирования модели таблицы переданных секретов
+func createSharedTable() table.Model {
+	columns := []table.Column{
+		{Title: "Название", Width: 40},
+		{Title: "Тип", Width: 10},
+		{Title: "Владелец", Width: 20},
+		{Title: "Передан", Width: 20},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(10),
+		table.WithWidth(100),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = styles.TableHeaderStyle.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true)
+
+	s.Selected = styles.TableSelectedStyle.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	t.SetStyles(s)
+
+	return t
+}
+
+// createSharedTableRows - метод формирования строк в таблице переданных секретов
+func createSharedTableRows(shares []*models.SharedSecretInfo) []table.Row {
+	rows := make([]table.Row, len(shares))
+	for i, share := range shares {
+		rows[i] = table.Row{
+			share.Secret.Name,
+			share.Secret.Type,
+			share.Owner,
+			share.Created.Local().Format(time.DateTime),
+		}
+	}
+	return rows
+}
@@ -17,8 +17,11 @@ type TextSecretModel struct {
 	textArea   textarea.Model
 	focused    bool
 	windowSize tea.WindowSizeMsg
-	isEditMode bool   // Флаг режима редактирования
-	sid        string // id для редактирования
+	isEditMode bool               // Флаг режима редактирования
+	sid        string             // id для редактирования
+	extra      models.SecretExtra // дополнительные поля (сохраняются при изменении)
+	notes      string             // заметки (сохраняются при изменении)
+	tags       []string           // теги (сохраняются при изменении)
 }
 
 // NewTextSecretModel - метод создания модель окна создания/просмотра текстового секрета
@@ -66,6 +69,9 @@ func (m TextSecretModel) Update(msg tea.Msg) (TextSecretModel, tea.Cmd) {
 		// Заполняем поля данными для просмотра
 		m.nameInput.SetValue(msg.Data.Name)
 		m.textArea.SetValue(msg.Data.Text)
+		m.extra = msg.Data.Extra
+		m.notes = msg.Data.Notes
+		m.tags = msg.Data.Tags
 		return m, nil
 
 	case tea.KeyMsg:
@@ -203,7 +209,7 @@ func (m TextSecretModel) attemptAddSecret(name string, text string) tea.Cmd {
 	}
 }
 
-// attemptAddSecret - метод обработки добавления секрета
+// attemptEditSecret - метод обработки изменения секрета
 func (m TextSecretModel) attemptEditSecret(sid string, name string, text string) tea.Cmd {
 	return func() tea.Msg {
 		if len(name) == 0 {
@@ -215,9 +221,12 @@ func (m TextSecretModel) attemptEditSecret(sid string, name string, text string)
 		return messages.EditSecretTextMsg{
 			ID: sid,
 			Data: messages.SecretText{
-				Name: name,
-				Type: models.SecretTextType,
-				Text: text,
+				Name:  name,
+				Type:  models.SecretTextType,
+				Text:  text,
+				Extra: m.extra,
+				Notes: m.notes,
+				Tags:  m.tags,
 			},
 		}
 	}
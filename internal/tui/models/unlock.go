@@ -0,0 +1,339 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+	"go-pass-keeper/pkg/crypto"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Индексы полей окна восстановления доступа
+const (
+	recoveryCodeIndex = iota
+	recoverySecretIndex
+	recoveryConfirmIndex
+)
+
+// UnlockModel - модель окна повторного ввода секрета, если хранилище не удалось открыть
+// секретом из настроек. Если секрет забыт, доступ восстанавливается ключом восстановления
+// (или собранными частями ключа) с установкой нового секрета.
+type UnlockModel struct {
+	secretInput textinput.Model
+	recovery    []textinput.Model       // код восстановления (или часть ключа), новый секрет, подтверждение
+	recovering  bool                    // режим восстановления доступа
+	combining   bool                    // восстановление по частям ключа восстановления
+	shares      []*crypto.RecoveryShare // введённые части ключа восстановления
+	focused     int
+	windowSize  tea.WindowSizeMsg
+	err         string
+}
+
+// NewUnlockModel - метод создания модели окна ввода секрета
+func NewUnlockModel() UnlockModel {
+	model := UnlockModel{}
+
+	model.secretInput = newSecretInput("Секрет")
+	model.secretInput.PromptStyle = styles.FocusedStyle
+	model.secretInput.TextStyle = styles.FocusedStyle
+
+	model.recovery = make([]textinput.Model, 3)
+	model.recovery[recoveryCodeIndex] = textinput.New()
+	model.recovery[recoveryCodeIndex].Placeholder = "XXXX-XXXX-XXXX-XXXX-XXXX-XXXX-XXXX-XXXX-XXXX"
+	model.recovery[recoveryCodeIndex].CharLimit = 64
+	model.recovery[recoverySecretIndex] = newSecretInput("Новый секрет")
+	model.recovery[recoveryConfirmIndex] = newSecretInput("Повторите секрет")
+
+	return model
+}
+
+// newSecretInput - метод создания поля ввода секрета
+func newSecretInput(placeholder string) textinput.Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.CharLimit = 50
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	return input
+}
+
+// Init - метод инициализации текущего окна
+func (m UnlockModel) Init() tea.Cmd {
+	return m.secretInput.Focus()
+}
+
+// Reset - метод очищает поля ввода и возвращает окно к вводу секрета
+func (m UnlockModel) Reset() UnlockModel {
+	m.secretInput.SetValue("")
+	m.secretInput.Focus()
+	for i := range m.recovery {
+		m.recovery[i].SetValue("")
+	}
+	m.recovering = false
+	m.combining = false
+	m.shares = nil
+	return m
+}
+
+// WithError - метод устанавливает текст ошибки для отображения
+func (m UnlockModel) WithError(err string) UnlockModel {
+	m.err = err
+	return m
+}
+
+// Update - метод обновления текущего окна
+func (m UnlockModel) Update(msg tea.Msg) (UnlockModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+r":
+			m.recovering = !m.recovering
+			if m.recovering {
+				return m.focus(recoveryCodeIndex), textinput.Blink
+			}
+			return m, m.secretInput.Focus()
+		case "ctrl+t":
+			if !m.recovering {
+				return m, nil
+			}
+			m.combining = !m.combining
+			m.shares = nil
+			m.recovery[recoveryCodeIndex].SetValue("")
+			return m.focus(recoveryCodeIndex), textinput.Blink
+		case "ctrl+a":
+			if !m.combining {
+				return m, nil
+			}
+			return m.addShare()
+		case "tab", "shift+tab", "up", "down":
+			if m.recovering {
+				next := m.focused + 1
+				if msg.String() == "shift+tab" || msg.String() == "up" {
+					next = m.focused + len(m.recovery) - 1
+				}
+				return m.focus(next % len(m.recovery)), textinput.Blink
+			}
+		case "enter":
+			if m.recovering {
+				return m, m.attemptRecover()
+			}
+			secret := m.secretInput.Value()
+			if secret == "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return messages.UnlockSecretMsg{Secret: secret}
+			}
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.GotoMainPageMsg{}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.recovering {
+		m.recovery[m.focused], cmd = m.recovery[m.focused].Update(msg)
+	} else {
+		m.secretInput, cmd = m.secretInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m UnlockModel) View() string {
+	var title, hint, toggle string
+	var fields string
+	var extra []string
+	if m.recovering {
+		title = "🛟 Восстановление доступа"
+		hint = "Введите ключ восстановления из аварийного комплекта и новый секрет"
+		toggle = "Ctrl+R - Ввести секрет"
+		codeLabel := "🛟 Ключ восстановления:"
+		extra = append(extra, styles.ButtonStyle.Render("Ctrl+T - По частям"))
+		if m.combining {
+			hint = "Введите части ключа восстановления по одной (Ctrl+A - добавить) и новый секрет"
+			codeLabel = m.sharesLabel()
+			extra = []string{
+				styles.ButtonStyle.Render("Ctrl+A - Добавить часть"),
+				styles.DividerStyle.Render(),
+				styles.ButtonStyle.Render("Ctrl+T - Ключ целиком"),
+			}
+		}
+		fields = lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderInputField(codeLabel, m.recovery[recoveryCodeIndex], m.focused == recoveryCodeIndex),
+			m.renderInputField("🔑 Новый секрет:", m.recovery[recoverySecretIndex], m.focused == recoverySecretIndex),
+			m.renderInputField("🔑 Повторите секрет:", m.recovery[recoveryConfirmIndex], m.focused == recoveryConfirmIndex),
+		)
+	} else {
+		title = "🔐 Хранилище заблокировано"
+		hint = "Введите секрет, которым зашифровано хранилище"
+		toggle = "Ctrl+R - Секрет забыт"
+		fields = m.renderInputField("🔑 Секрет:", m.secretInput, true)
+	}
+
+	buttons := []string{
+		styles.ButtonStyle.Render("Enter - Открыть"),
+		styles.DividerStyle.Render(),
+		styles.ButtonStyle.Render(toggle),
+		styles.DividerStyle.Render(),
+	}
+	if len(extra) > 0 {
+		buttons = append(buttons, extra...)
+		buttons = append(buttons, styles.DividerStyle.Render())
+	}
+	buttons = append(buttons, styles.ButtonStyle.Render("ESC - Отмена"))
+
+	errorView := ""
+	if m.err != "" {
+		errorView = styles.ErrorStyle.Render("❌ " + m.err)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(50).
+			Render(title),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Render(hint),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		fields,
+
+		lipgloss.NewStyle().Height(1).Render(""),
+		errorView,
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.JoinHorizontal(lipgloss.Center, buttons...),
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// focus - метод устанавливает фокус на поле ввода окна восстановления
+func (m UnlockModel) focus(index int) UnlockModel {
+	m.focused = index
+	for i := range m.recovery {
+		if i == index {
+			m.recovery[i].Focus()
+			m.recovery[i].PromptStyle = styles.FocusedStyle
+			m.recovery[i].TextStyle = styles.FocusedStyle
+		} else {
+			m.recovery[i].Blur()
+			m.recovery[i].PromptStyle = styles.BlurredStyle
+			m.recovery[i].TextStyle = styles.BlurredStyle
+		}
+	}
+	return m
+}
+
+// renderInputField - метод для отрисовки полей ввода
+func (m UnlockModel) renderInputField(label string, input textinput.Model, focused bool) string {
+	inputStyle := styles.InputFieldStyle
+	if focused {
+		inputStyle = styles.FocusedInputFieldStyle
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.InputLabelStyle.Render(label),
+		inputStyle.Width(50).Render(input.View()),
+	)
+}
+
+// sharesLabel - метод формирует подпись поля ввода части ключа с количеством введённых частей
+func (m UnlockModel) sharesLabel() string {
+	if len(m.shares) == 0 {
+		return "🧩 Часть ключа восстановления:"
+	}
+	return fmt.Sprintf("🧩 Часть ключа восстановления (введено %d из %d):", len(m.shares), m.shares[0].Threshold)
+}
+
+// addShare - метод добавляет введённую часть ключа восстановления
+func (m UnlockModel) addShare() (UnlockModel, tea.Cmd) {
+	share, err := crypto.ParseRecoveryShare(m.recovery[recoveryCodeIndex].Value())
+	if err != nil {
+		return m, func() tea.Msg {
+			return messages.ErrorMsg("Часть ключа введена с ошибкой: проверьте код по листу хранителя")
+		}
+	}
+	for _, s := range m.shares {
+		if s.Index == share.Index {
+			return m, func() tea.Msg {
+				return messages.ErrorMsg(fmt.Sprintf("Часть %d уже введена", share.Index))
+			}
+		}
+	}
+	m.shares = append(m.shares, share)
+	m.recovery[recoveryCodeIndex].SetValue("")
+	status := fmt.Sprintf("Часть %d принята", share.Index)
+	return m, func() tea.Msg {
+		return messages.RecoveryStatusMsg(status)
+	}
+}
+
+// attemptRecover - метод проверки введённых ключа восстановления (или его частей) и нового секрета
+func (m UnlockModel) attemptRecover() tea.Cmd {
+	code := m.recovery[recoveryCodeIndex].Value()
+	secret := m.recovery[recoverySecretIndex].Value()
+	confirm := m.recovery[recoveryConfirmIndex].Value()
+	combining := m.combining
+	shares := append([]*crypto.RecoveryShare(nil), m.shares...)
+	return func() tea.Msg {
+		if (code == "" && len(shares) == 0) || secret == "" || confirm == "" {
+			return messages.ErrorMsg("заполните все поля")
+		}
+		if secret != confirm {
+			return messages.ErrorMsg("секреты не совпадают")
+		}
+		if !combining {
+			key, err := crypto.ParseRecoveryCode(code)
+			if err != nil {
+				return messages.ErrorMsg("Ключ восстановления введён с ошибкой: проверьте код по аварийному комплекту")
+			}
+			return messages.RecoverVaultMsg{RecoveryKey: key, Secret: secret}
+		}
+		// последняя часть может быть не добавлена явно
+		if code != "" {
+			share, err := crypto.ParseRecoveryShare(code)
+			if err != nil {
+				return messages.ErrorMsg("Часть ключа введена с ошибкой: проверьте код по листу хранителя")
+			}
+			shares = append(shares, share)
+		}
+		key, err := crypto.CombineRecoveryShares(shares)
+		switch {
+		case errors.Is(err, crypto.ErrNotEnoughShares):
+			return messages.ErrorMsg(fmt.Sprintf("Недостаточно частей ключа: нужно %d", shares[0].Threshold))
+		case errors.Is(err, crypto.ErrSharesMismatch):
+			return messages.ErrorMsg("Части ключа относятся к разным наборам")
+		case errors.Is(err, crypto.ErrDuplicateShares):
+			return messages.ErrorMsg("Одна и та же часть ключа введена дважды")
+		case err != nil:
+			return messages.ErrorMsg("Ошибка сборки ключа восстановления: " + err.Error())
+		}
+		return messages.RecoverVaultMsg{RecoveryKey: key, Secret: secret}
+	}
+}
@@ -0,0 +1,309 @@
+package models
+
+import (
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// VaultModel - модель окна выбора хранилища (личное или командное) и управления участниками
+type VaultModel struct {
+	table      table.Model
+	orgs       []*models.OrganizationInfo
+	nameInput  textinput.Model
+	loginInput textinput.Model
+	roleInput  textinput.Model
+	focused    int
+	windowSize tea.WindowSizeMsg
+}
+
+// Индексы элементов окна хранилищ
+const (
+	vaultTableIndex = iota
+	vaultNameIndex
+	vaultLoginIndex
+	vaultRoleIndex
+)
+
+// NewVaultModel - метод создания окна выбора хранилища
+func NewVaultModel() VaultModel {
+	model := VaultModel{
+		table:   createVaultTable(),
+		focused: vaultTableIndex,
+	}
+
+	model.nameInput = textinput.New()
+	model.nameInput.Placeholder = "Название новой команды"
+	model.nameInput.CharLimit = 50
+	model.nameInput.TextStyle = styles.BlurredStyle
+	model.nameInput.PromptStyle = styles.BlurredStyle
+
+	model.loginInput = textinput.New()
+	model.loginInput.Placeholder = "Логин участника"
+	model.loginInput.CharLimit = 32
+	model.loginInput.TextStyle = styles.BlurredStyle
+	model.loginInput.PromptStyle = styles.BlurredStyle
+
+	model.roleInput = textinput.New()
+	model.roleInput.Placeholder = "admin, member или readonly"
+	model.roleInput.CharLimit = 16
+	model.roleInput.TextStyle = styles.BlurredStyle
+	model.roleInput.PromptStyle = styles.BlurredStyle
+
+	return model
+}
+
+// SetVaults - метод устанавливает список организаций пользователя
+func (m VaultModel) SetVaults(orgs []*models.OrganizationInfo) VaultModel {
+	m.orgs = orgs
+	m.table.SetRows(createVaultTableRows(orgs))
+	m.nameInput.SetValue("")
+	m.loginInput.SetValue("")
+	m.roleInput.SetValue("")
+	return m.focus(vaultTableIndex)
+}
+
+// Update - метод обновления текущего окна
+func (m VaultModel) Update(msg tea.Msg) (VaultModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowSize = msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab", "shift+tab":
+			next := m.focused + 1
+			if msg.String() == "shift+tab" {
+				next = m.focused - 1
+			}
+			if next > vaultRoleIndex {
+				next = vaultTableIndex
+			} else if next < vaultTableIndex {
+				next = vaultRoleIndex
+			}
+			m = m.focus(next)
+			return m, textinput.Blink
+		case "enter":
+			switch m.focused {
+			case vaultTableIndex:
+				return m, m.attemptSelect()
+			case vaultNameIndex:
+				return m, m.attemptCreate(m.nameInput.Value())
+			default:
+				return m, m.attemptAddMember(m.loginInput.Value(), m.roleInput.Value())
+			}
+		case "ctrl+d":
+			return m, m.attemptRemoveMember(m.loginInput.Value())
+		case "esc":
+			return m, func() tea.Msg {
+				return messages.VaultCancelMsg{}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.focused {
+	case vaultTableIndex:
+		m.table, cmd = m.table.Update(msg)
+	case vaultNameIndex:
+		m.nameInput, cmd = m.nameInput.Update(msg)
+	case vaultLoginIndex:
+		m.loginInput, cmd = m.loginInput.Update(msg)
+	case vaultRoleIndex:
+		m.roleInput, cmd = m.roleInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// View - метод отрисовки текущего состояния
+func (m VaultModel) View() string {
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		styles.TitleStyle.
+			Width(m.windowSize.Width-10).
+			Render("🏢 Хранилища"),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		styles.TableStyle.
+			Width(m.table.Width()).
+			Render(m.table.View()),
+
+		lipgloss.NewStyle().Height(1).Render(""),
+
+		lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderInputField("➕ Новая команда:", m.nameInput, vaultNameIndex),
+			m.renderInputField("👤 Участник выбранной команды:", m.loginInput, vaultLoginIndex),
+			m.renderInputField("🎭 Роль:", m.roleInput, vaultRoleIndex),
+		),
+
+		lipgloss.NewStyle().
+			Foreground(styles.TextSecondary).
+			Italic(true).
+			Render("Tab: переход • Enter: выбрать / создать / добавить участника • Ctrl+D: удалить участника • ESC: назад"),
+	)
+
+	return styles.ContainerStyle.
+		Width(m.windowSize.Width).
+		Height(m.windowSize.Height).
+		Render(
+			lipgloss.Place(
+				m.windowSize.Width, m.windowSize.Height,
+				lipgloss.Center, lipgloss.Center,
+				content,
+				lipgloss.WithWhitespaceChars(" "),
+				lipgloss.WithWhitespaceForeground(styles.BackgroundColor),
+			),
+		)
+}
+
+// focus - метод устанавливает фокус на элемент окна
+func (m VaultModel) focus(index int) VaultModel {
+	m.focused = index
+	inputs := map[int]*textinput.Model{
+		vaultNameIndex:  &m.nameInput,
+		vaultLoginIndex: &m.loginInput,
+		vaultRoleIndex:  &m.roleInput,
+	}
+	for i, input := range inputs {
+		if i == index {
+			input.Focus()
+			input.PromptStyle = styles.FocusedStyle
+			input.TextStyle = styles.FocusedStyle
+		} else {
+			input.Blur()
+			input.PromptStyle = styles.BlurredStyle
+			input.TextStyle = styles.BlurredStyle
+		}
+	}
+	if index == vaultTableIndex {
+		m.table.Focus()
+	} else {
+		m.table.Blur()
+	}
+	return m
+}
+
+// renderInputField - метод для отрисовки полей ввода
+func (m VaultModel) renderInputField(label string, input textinput.Model, index int) string {
+	inputStyle := styles.InputFieldStyle
+	if index == m.focused {
+		inputStyle = styles.FocusedInputFieldStyle
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.InputLabelStyle.Render(label),
+		inputStyle.Width(40).Render(input.View()),
+	)
+}
+
+// selected - метод возвращает выбранную организацию (nil - личное хранилище)
+func (m VaultModel) selected() *models.OrganizationInfo {
+	idx := m.table.Cursor() - 1
+	if idx < 0 || idx >= len(m.orgs) {
+		return nil
+	}
+	return m.orgs[idx]
+}
+
+// attemptSelect - метод обработки выбора хранилища
+func (m VaultModel) attemptSelect() tea.Cmd {
+	org := m.selected()
+	return func() tea.Msg {
+		return messages.VaultSelectMsg{Organization: org}
+	}
+}
+
+// attemptCreate - метод обработки создания организации
+func (m VaultModel) attemptCreate(name string) tea.Cmd {
+	return func() tea.Msg {
+		if len(name) == 0 {
+			return messages.ErrorMsg("Необходимо задать название команды")
+		}
+		return messages.CreateOrganizationMsg{Name: name}
+	}
+}
+
+// attemptAddMember - метод обработки добавления участника в выбранную организацию
+func (m VaultModel) attemptAddMember(login string, role string) tea.Cmd {
+	org := m.selected()
+	return func() tea.Msg {
+		if org == nil {
+			return messages.ErrorMsg("Необходимо выбрать команду")
+		}
+		if len(login) == 0 {
+			return messages.ErrorMsg("Необходимо задать логин участника")
+		}
+		if role == "" {
+			role = models.RoleMember
+		}
+		if !models.ValidRole(role) {
+			return messages.ErrorMsg("Неизвестная роль: " + role)
+		}
+		return messages.AddMemberMsg{Organization: org, Login: login, Role: role}
+	}
+}
+
+// attemptRemoveMember - метод обработки удаления участника из выбранной организации
+func (m VaultModel) attemptRemoveMember(login string) tea.Cmd {
+	org := m.selected()
+	return func() tea.Msg {
+		if org == nil {
+			return messages.ErrorMsg("Необходимо выбрать команду")
+		}
+		if len(login) == 0 {
+			return messages.ErrorMsg("Необходимо задать логин участника")
+		}
+		return messages.RemoveMemberMsg{Organization: org, Login: login}
+	}
+}
+
+// createVaultTable - метод формирования модели таблицы хранилищ
+func createVaultTable() table.Model {
+	columns := []table.Column{
+		{Title: "Хранилище", Width: 40},
+		{Title: "Роль", Width: 12},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(6),
+		table.WithWidth(60),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = styles.TableHeaderStyle.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true)
+
+	s.Selected = styles.TableSelectedStyle.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57"))
+
+	t.SetStyles(s)
+
+	return t
+}
+
+// createVaultTableRows - метод формирования строк таблицы хранилищ (первая строка - личное хранилище)
+func createVaultTableRows(orgs []*models.OrganizationInfo) []table.Row {
+	rows := make([]table.Row, 0, len(orgs)+1)
+	rows = append(rows, table.Row{"👤 Личное хранилище", models.RoleOwner})
+	for _, org := range orgs {
+		name := "🏢 " + org.Name
+		if org.RotateKey {
+			name += " (требуется замена ключа)"
+		}
+		rows = append(rows, table.Row{name, org.Role})
+	}
+	return rows
+}
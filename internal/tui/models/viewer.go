@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"go-pass-keeper/internal/grpcclient"
+	"go-pass-keeper/internal/grpcclient/config"
 	"go-pass-keeper/internal/grpcclient/settings"
 	"go-pass-keeper/internal/models"
 	"go-pass-keeper/internal/tui/messages"
 	"go-pass-keeper/internal/tui/styles"
 	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+	"sort"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -23,6 +27,14 @@ const (
 	ViewerListState ViewerState = iota
 	SecretViewState
 	SecretAddState
+	SecretShareState
+	SharedListState
+	VaultListState
+	SecretExpireState
+	SecretAttachmentsState
+	UnlockState
+	RecoveryState
+	EscrowState
 )
 
 // Кнопки на главном окне
@@ -31,8 +43,25 @@ const (
 	ViewButton
 	DeleteButton
 	UpdateButton
+	ShareButton
+	ExpireButton
+	AttachButton
+	SharedButton
+	VaultButton
 )
 
+// expireWarning - за какое время до истечения срока секрет отмечается в списке
+const expireWarning = 7 * 24 * time.Hour
+
+// migrateBatch - количество секретов в одном пакете переноса открытых названий
+const migrateBatch = 100
+
+// wrongSecretError - сообщение о неверном секрете (хранилище не открыто)
+const wrongSecretError = "Неверный секрет: хранилище не открыто. Проверьте секрет и введите его ещё раз"
+
+// undecryptedName - название секрета, метаданные которого не удалось расшифровать
+const undecryptedName = "🔒 не удалось расшифровать"
+
 // ViewerModel - модель окна секретов
 type ViewerModel struct {
 	state      ViewerState
@@ -41,27 +70,82 @@ type ViewerModel struct {
 	windowSize tea.WindowSizeMsg
 	focusedBtn int
 	addModel   SecretAddModel
+	shareModel ShareSecretModel
+	expire     ExpireSecretModel
+	attach     AttachmentsModel
+	shared     SharedViewerModel
+	vaults     VaultModel
+	unlock     UnlockModel
+	recovery   RecoveryModel
+	escrow     EscrowModel
 	settings   *settings.Settings
 	token      string
-	cryptoKey  []byte
+	userID     string          // идентификатор пользователя (владелец личного хранилища)
+	auth       models.AuthInfo // соль и параметры получения ключа из пароля
+	username   string
+	cryptoKey  *securemem.Buffer        // ключ хранилища пользователя
+	privateKey *securemem.Buffer        // закрытый ключ для обмена секретами
+	secret     *securemem.Buffer        // секрет, которым открыто хранилище (в настройках не хранится)
+	vault      *models.OrganizationInfo // выбранное командное хранилище (nil - личное)
+	vaultKey   *securemem.Buffer        // расшифрованный ключ командного хранилища
+	archived   bool                     // просмотр архива секретов с истёкшим сроком
+	search     textinput.Model          // строка поиска
+	searching  bool                     // ввод поискового запроса
+	query      string                   // запрос, по которому показаны результаты поиска
+	migrated   map[string]bool          // хранилища, для которых запускался перенос открытых названий
+	integrity  *models.VaultState       // подписанное состояние личного хранилища, проверенное в этом сеансе
+	vaultSeq   int64                    // наибольший номер подписанного состояния, полученный в этом сеансе
+	configs    *config.Config           // файлы настроек клиента (состояния хранилищ, проверенные в прежних сеансах)
+	warning    string                   // расхождения содержимого хранилища с подписанным состоянием
+	err        messages.ErrorMsg
+	status     string
 }
 
 // NewViewerModel - метод создания окна секретов
-func NewViewerModel(connection *settings.Settings) ViewerModel {
+func NewViewerModel(connection *settings.Settings, configs *config.Config) ViewerModel {
 	return ViewerModel{
 		state:      ViewerListState,
 		table:      createTable(),
 		focusedBtn: 0,
 		addModel:   NewSecretAddModel(),
+		shareModel: NewShareSecretModel(),
+		expire:     NewExpireSecretModel(),
+		attach:     NewAttachmentsModel(),
+		shared:     NewSharedViewerModel(),
+		vaults:     NewVaultModel(),
+		unlock:     NewUnlockModel(),
+		recovery:   NewRecoveryModel(),
+		escrow:     NewEscrowModel(),
+		search:     newSearchInput(),
+		migrated:   make(map[string]bool),
 		settings:   connection,
+		configs:    configs,
 	}
 }
 
-// Init - метод инициализации текущего окна
+// newSearchInput - метод создания строки поиска
+func newSearchInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "Слова из названия или теги"
+	input.CharLimit = 100
+	input.TextStyle = styles.FocusedStyle
+	input.PromptStyle = styles.FocusedStyle
+	return input
+}
+
+// Init - метод инициализации текущего окна (пока хранилище не открыто, запрашивается секрет)
 func (m ViewerModel) Init() tea.Cmd {
+	if m.Locked() {
+		return m.unlock.Init()
+	}
 	return m.attemptGetSecrets()
 }
 
+// Locked - метод проверяет, ожидает ли хранилище ввода верного секрета
+func (m ViewerModel) Locked() bool {
+	return m.state == UnlockState
+}
+
 // Update - метод обновления текущего окна
 func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -75,6 +159,66 @@ func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// обаботка аутентификации (формирование токена и ключа)
 	case messages.AuthSuccessMsg:
 		return m.handleAuthAction(msg)
+	// повторный ввод секрета после неверного
+	case messages.UnlockSecretMsg:
+		return m.handleUnlockAction(msg.Secret)
+	// секрет не расшифровал закрытый ключ (контрольное значение ещё не сохранено)
+	case messages.WrongSecretMsg:
+		return m.lock(wrongSecretError)
+	// контрольное значение ключа хранилища сохранено
+	case messages.KeyCheckSavedMsg:
+		m.auth.KeyCheck = msg.KeyCheck
+		return m, nil
+	// восстановление доступа к хранилищу ключом восстановления
+	case messages.RecoverVaultMsg:
+		return m, m.attemptRecoverVault(msg)
+	case messages.VaultRecoveredMsg:
+		return m.handleVaultRecovered(msg)
+
+	// запрос на создание ключа восстановления
+	case messages.CreateRecoveryKeyMsg:
+		return m, m.attemptCreateRecoveryKey()
+	// ключ восстановления создан (код показывается один раз)
+	case messages.RecoveryKeyCreatedMsg:
+		m.err = ""
+		m.status = "Ключ восстановления создан, прежний больше не действует"
+		m.recovery = m.recovery.SetCode(msg.Code)
+		return m, m.recovery.Init()
+	// запрос на сохранение аварийного комплекта
+	case messages.SaveEmergencyKitMsg:
+		return m, m.attemptSaveEmergencyKit(msg)
+	// запрос на разделение ключа восстановления на части
+	case messages.SaveRecoverySharesMsg:
+		return m, m.attemptSaveRecoveryShares(msg)
+	// результат операции с ключом восстановления
+	case messages.RecoveryStatusMsg:
+		m.err = ""
+		m.status = string(msg)
+		return m, nil
+	// выход из окна ключа восстановления (код больше не показывается)
+	case messages.RecoveryCancelMsg:
+		m.state = ViewerListState
+		m.status = ""
+		m.recovery = m.recovery.SetCode("")
+		return m, nil
+
+	// открытый ключ депонирования получен (показывается отпечаток для сверки)
+	case messages.EscrowKeyMsg:
+		m.escrow = m.escrow.SetPublicKey(msg.PublicKey)
+		return m, nil
+	// запрос на депонирование ключа хранилища
+	case messages.EnrollEscrowMsg:
+		return m, m.attemptEnrollEscrow(msg)
+	case messages.EscrowEnrolledMsg:
+		m.err = ""
+		m.status = "Ключ хранилища депонирован"
+		m.escrow = m.escrow.SetEnrolled()
+		return m, nil
+	// выход из окна депонирования ключа
+	case messages.EscrowCancelMsg:
+		m.state = ViewerListState
+		m.status = ""
+		return m, nil
 
 	// запрос на добавление секрета (логин/пароль)
 	case messages.AddSecretPasswordMsg:
@@ -83,6 +227,7 @@ func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// запрос на изменение  секрета (логин/пароль)
 	case messages.EditSecretPasswordMsg:
 		m.state = ViewerListState
+		msg.DataKey = m.dataKey(msg.ID)
 		return m, m.attemptEditSecret(&msg)
 
 	// запрос на добавление секрета (банковская карта)
@@ -92,6 +237,7 @@ func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// запрос на изменение  секрета (банковская карта)
 	case messages.EditSecretCardMsg:
 		m.state = ViewerListState
+		msg.DataKey = m.dataKey(msg.ID)
 		return m, m.attemptEditSecret(&msg)
 
 	// запрос на добавление секрета (текстовые даные)
@@ -101,6 +247,7 @@ func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// запрос на изменение  секрета (текстовые даные)
 	case messages.EditSecretTextMsg:
 		m.state = ViewerListState
+		msg.DataKey = m.dataKey(msg.ID)
 		return m, m.attemptEditSecret(&msg)
 
 	// запрос на добавление секрета (бинарные данные)
@@ -110,15 +257,152 @@ func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// запрос на изменение  секрета (бинарные данные)
 	case messages.EditSecretBinaryMsg:
 		m.state = ViewerListState
+		msg.DataKey = m.dataKey(msg.ID)
 		return m, m.attemptEditSecret(&msg)
 
+	// загрузка пары ключей для обмена секретами
+	case messages.KeyPairLoadedMsg:
+		m.privateKey.Destroy()
+		m.privateKey = securemem.FromBytes(msg.PrivateKey)
+		return m, m.attemptProtectKey()
+	// запрос на передачу секрета другому пользователю
+	case messages.ShareSecretMsg:
+		m.state = ViewerListState
+		return m, m.attemptShareSecret(msg)
+	// запрос на отзыв доступа к секрету
+	case messages.RevokeShareMsg:
+		m.state = ViewerListState
+		return m, m.attemptRevokeShare(msg)
+	// отмена передачи секрета (или выход из списка переданных)
+	case messages.SecretShareCancelMsg:
+		m.state = ViewerListState
+		return m, nil
+	// результат передачи секрета
+	case messages.ShareStatusMsg:
+		m.err = ""
+		m.status = string(msg)
+		return m, nil
+	// обновление списка переданных секретов
+	case messages.SharedRefreshMsg:
+		m.state = SharedListState
+		m.shared = m.shared.SetShares(msg.Shares, m.privateKey)
+		return m, nil
+
+	// запрос на изменение срока действия секрета
+	case messages.SetExpirationMsg:
+		m.state = ViewerListState
+		return m, m.attemptSetExpiration(msg)
+	// отмена изменения срока действия
+	case messages.ExpireCancelMsg:
+		m.state = ViewerListState
+		return m, nil
+
+	// обновление списка вложений секрета
+	case messages.AttachmentsRefreshMsg:
+		m.state = SecretAttachmentsState
+		m.attach = m.attach.SetAttachments(msg.SecretID, msg.Name, msg.Attachments)
+		return m, nil
+	// запрос на добавление вложения
+	case messages.AddAttachmentMsg:
+		return m, m.attemptAddAttachment(msg)
+	// запрос на сохранение вложения на диск
+	case messages.SaveAttachmentMsg:
+		return m, m.attemptSaveAttachment(msg)
+	// запрос на удаление вложения
+	case messages.DeleteAttachmentMsg:
+		return m, m.attemptDeleteAttachment(msg)
+	// результат операции над вложением
+	case messages.AttachmentStatusMsg:
+		m.err = ""
+		m.status = string(msg)
+		return m, m.attemptGetAttachments(m.attach.sid, m.attach.name)
+	// выход из окна вложений
+	case messages.AttachmentCancelMsg:
+		m.state = ViewerListState
+		return m, nil
+
+	// обновление списка хранилищ
+	case messages.VaultsRefreshMsg:
+		m.state = VaultListState
+		m.vaults = m.vaults.SetVaults(msg.Organizations)
+		return m, nil
+	// выбор хранилища
+	case messages.VaultSelectMsg:
+		return m.handleVaultSelect(msg)
+	// отмена выбора хранилища
+	case messages.VaultCancelMsg:
+		m.state = ViewerListState
+		return m, nil
+	// запрос на создание организации
+	case messages.CreateOrganizationMsg:
+		return m, m.attemptCreateOrganization(msg)
+	// запрос на добавление участника в организацию
+	case messages.AddMemberMsg:
+		m.state = ViewerListState
+		return m, m.attemptAddMember(msg)
+	// запрос на удаление участника из организации
+	case messages.RemoveMemberMsg:
+		m.state = ViewerListState
+		return m, m.attemptRemoveMember(msg)
+	// запрос на замену ключа хранилища организации
+	case messages.RotateVaultKeyMsg:
+		return m, m.attemptRotateVaultKey(msg.Organization)
+	// ключ хранилища организации заменён (открытое хранилище переключается на новый ключ)
+	case messages.VaultKeyRotatedMsg:
+		m.err = ""
+		m.status = fmt.Sprintf("Ключ хранилища команды %s заменён", msg.Organization.Name)
+		if m.vault != nil && m.vault.ID == msg.Organization.ID {
+			return m.handleVaultSelect(messages.VaultSelectMsg{Organization: msg.Organization})
+		}
+		return m, nil
+
 	// запрос на обновление секретов
 	case messages.SecretUpdateMsg:
 		return m, m.attemptGetSecrets()
 	// обновление таблицы секретов
 	case messages.SecretRefreshMsg:
 		m.secrets = msg.Secrets
-		return m.refreshViewer(), nil
+		m.query = msg.Query
+		var cmd tea.Cmd
+		m, cmd = m.refreshViewer().migrateNames()
+		// после каждой синхронизации личное хранилище сверяется с подписанным состоянием
+		if m.vault == nil && msg.Query == "" {
+			cmd = tea.Batch(cmd, m.attemptVerifyVault())
+		}
+		return m, cmd
+	// секрет удалён
+	case messages.SecretDeleteMsg:
+		return m, m.attemptGetSecrets()
+	// результат проверки личного хранилища по подписанному состоянию
+	case messages.VaultVerifiedMsg:
+		m.vaultSeq = max(m.vaultSeq, msg.Sequence)
+		m.warning = msg.Warning
+		if msg.State != nil {
+			m.integrity = msg.State
+			if err := m.saveCheckpoint(msg.State); err != nil {
+				m.err = messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения проверенного состояния хранилища: %s", err.Error()))
+			}
+		}
+		if msg.Status != "" {
+			m.status = msg.Status
+		}
+		return m, nil
+	// ключ хранилища сохранён зашифрованным ключом из пароля
+	case messages.KeyWrappedMsg:
+		m.auth.KDF = msg.KDF
+		m.auth.WrappedKey = msg.WrappedKey
+		if msg.Connection != nil {
+			m.secret.Destroy()
+			m.secret = msg.Secret
+			*m.settings = *msg.Connection
+		}
+		m.status = fmt.Sprintf("Ключ хранилища сохранён (%s)", msg.KDF.Algorithm)
+		return m, nil
+	// результат переноса открытых названий в зашифрованные метаданные
+	case messages.NamesMigratedMsg:
+		m.err = ""
+		m.status = fmt.Sprintf("Названия секретов зашифрованы: %d", int(msg))
+		return m, m.attemptGetSecrets()
 	}
 
 	switch m.state {
@@ -126,6 +410,22 @@ func (m ViewerModel) Update(msg tea.Msg) (ViewerModel, tea.Cmd) {
 		return m.handleAddState(msg)
 	case SecretViewState:
 		return m.handleViewState(msg)
+	case SecretShareState:
+		return m.handleShareState(msg)
+	case SecretExpireState:
+		return m.handleExpireState(msg)
+	case SecretAttachmentsState:
+		return m.handleAttachmentsState(msg)
+	case SharedListState:
+		return m.handleSharedState(msg)
+	case VaultListState:
+		return m.handleVaultState(msg)
+	case UnlockState:
+		return m.handleUnlockState(msg)
+	case RecoveryState:
+		return m.handleRecoveryState(msg)
+	case EscrowState:
+		return m.handleEscrowState(msg)
 	default:
 		return m.handleListState(msg)
 	}
@@ -139,19 +439,79 @@ func (m ViewerModel) updateWindowsSize(msg tea.WindowSizeMsg) (ViewerModel, tea.
 	updatedAddModel, addModelCmd := m.addModel.Update(msg)
 	m.addModel = updatedAddModel
 
-	return m, tea.Batch(addModelCmd)
+	updatedShareModel, shareModelCmd := m.shareModel.Update(msg)
+	m.shareModel = updatedShareModel
+
+	updatedExpire, expireCmd := m.expire.Update(msg)
+	m.expire = updatedExpire
+
+	updatedAttach, attachCmd := m.attach.Update(msg)
+	m.attach = updatedAttach
+
+	updatedShared, sharedCmd := m.shared.Update(msg)
+	m.shared = updatedShared
+
+	updatedVaults, vaultsCmd := m.vaults.Update(msg)
+	m.vaults = updatedVaults
+
+	updatedUnlock, unlockCmd := m.unlock.Update(msg)
+	m.unlock = updatedUnlock
+
+	updatedRecovery, recoveryCmd := m.recovery.Update(msg)
+	m.recovery = updatedRecovery
+
+	updatedEscrow, escrowCmd := m.escrow.Update(msg)
+	m.escrow = updatedEscrow
+
+	return m, tea.Batch(addModelCmd, shareModelCmd, expireCmd, attachCmd, sharedCmd, vaultsCmd, unlockCmd, recoveryCmd, escrowCmd)
 }
 
 // handleListState - метод обработки основного окна (таблица + кнопки)
 func (m ViewerModel) handleListState(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if m.searching {
+		return m.handleSearchInput(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "/": // Поиск по слепому индексу
+			m.searching = true
+			m.search.SetValue("")
+			return m, m.search.Focus()
+
 		case "r", "R": // Обновление
 			return m.refreshViewer(), nil
 
+		case "a", "A": // Переключение между действующими и архивными секретами
+			m.archived = !m.archived
+			return m, m.attemptGetSecrets()
+
+		case "ctrl+k": // Ключ восстановления и аварийный комплект
+			m.state = RecoveryState
+			m.err = ""
+			m.status = ""
+			m.recovery = m.recovery.SetCode("")
+			return m, m.recovery.Init()
+
+		case "ctrl+l": // Закрытие хранилища: ключи и секрет удаляются из памяти
+			return m.lock("")
+
+		case "ctrl+t": // Подпись текущего содержимого хранилища: пользователь принимает расхождения
+			if m.warning == "" || m.vault != nil {
+				return m, nil
+			}
+			return m, m.attemptTrustVault()
+
+		case "ctrl+e": // Депонирование ключа хранилища организации
+			m.state = EscrowState
+			m.err = ""
+			m.status = ""
+			m.escrow = m.escrow.SetPublicKey(nil)
+			return m, m.attemptGetEscrowKey()
+
 		case "left", "h": // Навигация кнопок
 			if m.focusedBtn > 0 {
 				m.focusedBtn--
@@ -159,14 +519,17 @@ func (m ViewerModel) handleListState(msg tea.Msg) (ViewerModel, tea.Cmd) {
 			return m, nil
 
 		case "right", "l": // Навигация кнопок
-			if m.focusedBtn < 3 {
+			if m.focusedBtn < VaultButton {
 				m.focusedBtn++
 			}
 			return m, nil
 
 		case "enter": // Обработка действий
 			return m.handleEnterAction()
-		case "esc": // Выход из секретов
+		case "esc": // Сброс результатов поиска или выход из секретов
+			if m.query != "" {
+				return m, m.attemptGetSecrets()
+			}
 			return m, func() tea.Msg {
 				return messages.GotoMainPageMsg{}
 			}
@@ -181,6 +544,26 @@ func (m ViewerModel) handleListState(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	return m, cmd
 }
 
+// handleSearchInput - метод обработки ввода поискового запроса
+func (m ViewerModel) handleSearchInput(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "enter":
+			m.searching = false
+			m.search.Blur()
+			m.archived = false
+			return m, m.attemptSearchSecrets(m.search.Value())
+		case "esc":
+			m.searching = false
+			m.search.Blur()
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
 // handleAddState - метод обработки окна добавления секретов
 func (m ViewerModel) handleAddState(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// Передаем сообщение в модель добавления
@@ -189,6 +572,87 @@ func (m ViewerModel) handleAddState(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	return m, cmd
 }
 
+// handleShareState - метод обработки окна передачи секрета
+func (m ViewerModel) handleShareState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.shareModel.Update(msg)
+	m.shareModel = updatedModel
+	return m, cmd
+}
+
+// handleExpireState - метод обработки окна срока действия секрета
+func (m ViewerModel) handleExpireState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.expire.Update(msg)
+	m.expire = updatedModel
+	return m, cmd
+}
+
+// handleAttachmentsState - метод обработки окна вложений секрета
+func (m ViewerModel) handleAttachmentsState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.attach.Update(msg)
+	m.attach = updatedModel
+	return m, cmd
+}
+
+// handleSharedState - метод обработки окна переданных пользователю секретов
+func (m ViewerModel) handleSharedState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.shared.Update(msg)
+	m.shared = updatedModel
+	return m, cmd
+}
+
+// handleVaultState - метод обработки окна выбора хранилища
+func (m ViewerModel) handleVaultState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.vaults.Update(msg)
+	m.vaults = updatedModel
+	return m, cmd
+}
+
+// handleUnlockState - метод обработки окна ввода секрета
+func (m ViewerModel) handleUnlockState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.unlock.Update(msg)
+	m.unlock = updatedModel
+	return m, cmd
+}
+
+// handleRecoveryState - метод обработки окна ключа восстановления
+func (m ViewerModel) handleRecoveryState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.recovery.Update(msg)
+	m.recovery = updatedModel
+	return m, cmd
+}
+
+// handleEscrowState - метод обработки окна депонирования ключа
+func (m ViewerModel) handleEscrowState(msg tea.Msg) (ViewerModel, tea.Cmd) {
+	updatedModel, cmd := m.escrow.Update(msg)
+	m.escrow = updatedModel
+	return m, cmd
+}
+
+// secretKey - метод возвращает ключ шифрования секретов текущего хранилища
+func (m ViewerModel) secretKey() []byte {
+	if m.vault != nil {
+		return m.vaultKey.Bytes()
+	}
+	return m.cryptoKey.Bytes()
+}
+
+// vaultOwner - метод возвращает владельца текущего хранилища (организацию или пользователя),
+// к которому привязывается шифрование секретов
+func (m ViewerModel) vaultOwner() string {
+	if m.vault != nil {
+		return m.vault.ID
+	}
+	return m.userID
+}
+
+// vaultID - метод возвращает идентификатор текущего хранилища (пустой для личного)
+func (m ViewerModel) vaultID() string {
+	if m.vault != nil {
+		return m.vault.ID
+	}
+	return ""
+}
+
 // handleViewState - метод обработки окна просмотра секретов
 func (m ViewerModel) handleViewState(msg tea.Msg) (ViewerModel, tea.Cmd) {
 	// ESC в окне просмотра - возврат к списку секретов
@@ -209,6 +673,14 @@ func (m ViewerModel) handleEnterAction() (ViewerModel, tea.Cmd) {
 	if m.focusedBtn == UpdateButton {
 		return m, m.attemptGetSecrets()
 	}
+	// Если выбрана кнопка "Доступные мне"
+	if m.focusedBtn == SharedButton {
+		return m, m.attemptGetShared()
+	}
+	// Если выбрана кнопка "Хранилища"
+	if m.focusedBtn == VaultButton {
+		return m, m.attemptGetOrganizations()
+	}
 
 	if len(m.table.Rows()) == 0 {
 		return m, nil
@@ -226,20 +698,70 @@ func (m ViewerModel) handleEnterAction() (ViewerModel, tea.Cmd) {
 		return m, m.attemptDeleteSecret(selectedID)
 	}
 
+	// Если выбрана кнопка "Поделиться" и есть выбранная строка
+	if m.focusedBtn == ShareButton && m.table.SelectedRow() != nil {
+		m.state = SecretShareState
+		m.shareModel = m.shareModel.SetSecret(selectedID, m.table.SelectedRow()[1])
+		return m, m.shareModel.Init()
+	}
+
+	// Если выбрана кнопка "Срок" и есть выбранная строка
+	if m.focusedBtn == ExpireButton && m.table.SelectedRow() != nil {
+		info := m.selectedSecret(selectedID)
+		if info == nil {
+			return m, nil
+		}
+		m.state = SecretExpireState
+		m.expire = m.expire.SetSecret(info)
+		return m, m.expire.Init()
+	}
+
+	// Если выбрана кнопка "Вложения" и есть выбранная строка
+	if m.focusedBtn == AttachButton && m.table.SelectedRow() != nil {
+		m.status = ""
+		m.err = ""
+		return m, tea.Batch(m.attemptGetAttachments(selectedID, m.table.SelectedRow()[1]), m.attach.Init())
+	}
+
 	return m, nil
 }
 
-// handleAuthAction - обработчик авторизации (формирование токена и ключа шифрования)
-func (m ViewerModel) handleAuthAction(msg messages.AuthSuccessMsg) (ViewerModel, tea.Cmd) {
-	m.token = msg.Token
-	key, err := crypto.MakeCryptoKey(m.settings.Secret, msg.Salt)
-	if err != nil {
-		return m, func() tea.Msg {
-			return messages.ErrorMsg(fmt.Sprintf("Ошибка формирования ключа: %s", err.Error()))
+// selectedSecret - метод возвращает информацию о секрете из списка по идентификатору
+func (m ViewerModel) selectedSecret(sid string) *models.SecretInfo {
+	for _, secret := range m.secrets {
+		if secret.ID == sid {
+			return secret
 		}
 	}
-	m.cryptoKey = key
-	return m, nil
+	return nil
+}
+
+// dataKey - метод возвращает ключ данных секрета из списка, зашифрованный ключом хранилища
+// (изменённый секрет шифруется прежним ключом данных)
+func (m ViewerModel) dataKey(sid string) []byte {
+	if secret := m.selectedSecret(sid); secret != nil {
+		return secret.DataKey
+	}
+	return nil
+}
+
+// migrateNames - метод запускает перенос открытых названий секретов текущего хранилища
+// в зашифрованные метаданные (один раз за сеанс для каждого хранилища, если есть право изменения)
+func (m ViewerModel) migrateNames() (ViewerModel, tea.Cmd) {
+	if m.migrated[m.vaultID()] || (m.vault != nil && m.vault.Role == models.RoleReadOnly) {
+		return m, nil
+	}
+	var ids []string
+	for _, secret := range m.secrets {
+		if secret.PlainName() {
+			ids = append(ids, secret.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return m, nil
+	}
+	m.migrated[m.vaultID()] = true
+	return m, m.attemptMigrateNames(ids)
 }
 
 // refreshViewer - обновление таблицы секретов
@@ -255,6 +777,22 @@ func (m ViewerModel) View() string {
 		return m.renderViewerListView()
 	case SecretAddState:
 		return m.addModel.View()
+	case SecretShareState:
+		return m.shareModel.View()
+	case SecretExpireState:
+		return m.expire.View()
+	case SecretAttachmentsState:
+		return m.attach.WithStatus(m.renderStatus()).View()
+	case SharedListState:
+		return m.shared.View()
+	case VaultListState:
+		return m.vaults.View()
+	case UnlockState:
+		return m.unlock.WithError(string(m.err)).View()
+	case RecoveryState:
+		return m.recovery.WithStatus(m.renderStatus()).View()
+	case EscrowState:
+		return m.escrow.WithStatus(m.renderStatus()).View()
 	default:
 		return "Неизвестное состояние"
 	}
@@ -268,13 +806,14 @@ func createTable() table.Model {
 		{Title: "Тип", Width: 10},
 		{Title: "Создан", Width: 15},
 		{Title: "Обновлен", Width: 15},
+		{Title: "Истекает", Width: 14},
 	}
 
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithFocused(true),
 		table.WithHeight(10),
-		table.WithWidth(100),
+		table.WithWidth(115),
 	)
 
 	s := table.DefaultStyles()
@@ -294,6 +833,7 @@ func createTable() table.Model {
 
 // createTableRows - метод формирования строк в таблице секретов
 func createTableRows(secrets []*models.SecretInfo) []table.Row {
+	now := time.Now()
 	rows := make([]table.Row, len(secrets))
 	for i, secret := range secrets {
 		rows[i] = table.Row{
@@ -302,18 +842,54 @@ func createTableRows(secrets []*models.SecretInfo) []table.Row {
 			secret.Type,
 			secret.Created.Local().Format(time.DateTime),
 			secret.Updated.Local().Format(time.DateTime),
+			expiresColumn(secret, now),
 		}
 	}
 	return rows
 }
 
+// expiresColumn - метод формирования значения колонки срока действия:
+// ⛔ - срок истёк, ⚠️ - истекает в ближайшие expireWarning
+func expiresColumn(secret *models.SecretInfo, now time.Time) string {
+	switch {
+	case secret.Expires.IsZero():
+		return ""
+	case secret.Expired(now):
+		return "⛔ " + secret.Expires.Local().Format(time.DateOnly)
+	case secret.ExpiresWithin(now, expireWarning):
+		return "⚠️ " + secret.Expires.Local().Format(time.DateOnly)
+	default:
+		return secret.Expires.Local().Format(time.DateOnly)
+	}
+}
+
 // renderViewerListView - метод отрисовки списка секретов
 func (m ViewerModel) renderViewerListView() string {
+	title := "🔒 Управление секретами"
+	if m.vault != nil {
+		title += " • 🏢 " + m.vault.Name + " (" + m.vault.Role + ")"
+	}
+	if m.archived {
+		title += " • 🗄️ Архив"
+	}
+	if m.query != "" {
+		title += " • 🔍 " + m.query
+	}
+	search := ""
+	if m.searching {
+		search = lipgloss.JoinHorizontal(
+			lipgloss.Center,
+			styles.InputLabelStyle.Render("🔍 Поиск: "),
+			styles.FocusedInputFieldStyle.Width(50).Render(m.search.View()),
+		)
+	}
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
 		styles.TitleStyle.
 			Width(m.windowSize.Width-10).
-			Render("🔒 Управление секретами"),
+			Render(title),
+
+		m.renderWarning(),
 
 		lipgloss.NewStyle().Height(2).Render(""),
 
@@ -321,13 +897,17 @@ func (m ViewerModel) renderViewerListView() string {
 			Width(m.table.Width()).
 			Render(m.table.View()),
 
-		lipgloss.NewStyle().Height(2).Render(""),
+		search,
+
+		lipgloss.NewStyle().Height(1).Render(""),
 
 		m.renderButtons(),
 
 		lipgloss.NewStyle().Height(1).Render(""),
 
 		m.renderHelpText(),
+
+		m.renderStatus(),
 	)
 
 	return styles.ContainerStyle.
@@ -344,6 +924,20 @@ func (m ViewerModel) renderViewerListView() string {
 		)
 }
 
+// renderWarning - метод отрисовки предупреждения о расхождениях с подписанным состоянием хранилища
+// (показывается в личном хранилище, пока расхождения не устранены или не приняты)
+func (m ViewerModel) renderWarning() string {
+	if m.warning == "" || m.vault != nil {
+		return ""
+	}
+	return styles.ErrorStyle.
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(styles.ErrorColor).
+		Padding(0, 2).
+		Width(m.windowSize.Width - 14).
+		Render("⚠️ ВНИМАНИЕ: " + m.warning)
+}
+
 // renderButtons - метод отрисовки кнопок
 func (m ViewerModel) renderButtons() string {
 	buttons := []string{
@@ -351,6 +945,11 @@ func (m ViewerModel) renderButtons() string {
 		m.renderButton("👁️ Просмотр", ViewButton),
 		m.renderButton("🗑️ Удалить", DeleteButton),
 		m.renderButton("🔄 Обновить", UpdateButton),
+		m.renderButton("🤝 Поделиться", ShareButton),
+		m.renderButton("⏳ Срок", ExpireButton),
+		m.renderButton("📎 Вложения", AttachButton),
+		m.renderButton("📥 Доступные", SharedButton),
+		m.renderButton("🏢 Хранилища", VaultButton),
 	}
 
 	return lipgloss.JoinHorizontal(
@@ -375,8 +974,11 @@ func (m ViewerModel) renderButton(text string, index int) string {
 
 // renderButtons - метод отрисовки вспомогательного текста
 func (m ViewerModel) renderHelpText() string {
-	helpText := "↑/↓: выбор секрета • ←/→: выбор кнопки • Enter: действие • R: обновить • ESC: выход"
+	helpText := "↑/↓: выбор секрета • ←/→: выбор кнопки • Enter: действие • R: обновить • A: архив • /: поиск • Ctrl+K: ключ восстановления • Ctrl+E: депонирование • Ctrl+L: закрыть хранилище • ESC: выход"
 
+	if m.warning != "" && m.vault == nil {
+		helpText += " • Ctrl+T: принять содержимое хранилища"
+	}
 	if m.table.SelectedRow() != nil {
 		helpText += " • Выбрано: " + m.table.SelectedRow()[1]
 	}
@@ -387,9 +989,24 @@ func (m ViewerModel) renderHelpText() string {
 		Render(helpText)
 }
 
+// renderStatus - метод отрисовки последней ошибки или результата операции
+func (m ViewerModel) renderStatus() string {
+	if m.err != "" {
+		return styles.ErrorStyle.Render("❌ " + string(m.err))
+	}
+	if m.status != "" {
+		return lipgloss.NewStyle().
+			Foreground(styles.SuccessColor).
+			Render("✅ " + m.status)
+	}
+	return ""
+}
+
 // attemptGetSecrets - обработчик получения секретов
 func (m ViewerModel) attemptGetSecrets() tea.Cmd {
+	m, release := m.commandKeys()
 	return func() tea.Msg {
+		defer release()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
 		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
 		defer func() {
@@ -399,17 +1016,55 @@ func (m ViewerModel) attemptGetSecrets() tea.Cmd {
 		if err := client.Connect(ctx); err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
 		}
-		secrets, err := client.GetSecrets()
+		list := client.GetVaultSecrets
+		if m.archived {
+			list = client.GetArchivedSecrets
+		}
+		secrets, err := list(m.vaultID())
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения данных: %s", err.Error()))
 		}
+		openSecretsMeta(m.secretKey(), m.vaultOwner(), secrets)
 		return messages.SecretRefreshMsg{Secrets: secrets}
 	}
 }
 
+// attemptSearchSecrets - обработчик поиска секретов текущего хранилища: слова запроса
+// передаются на сервер только в виде значений слепого индекса
+func (m ViewerModel) attemptSearchSecrets(query string) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		tokens, err := crypto.BlindTokens(m.secretKey(), query)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка формирования запроса: %s", err.Error()))
+		}
+		if len(tokens) == 0 {
+			return messages.ErrorMsg("Пустой поисковый запрос")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		secrets, err := client.SearchSecrets(m.vaultID(), tokens)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка поиска: %s", err.Error()))
+		}
+		openSecretsMeta(m.secretKey(), m.vaultOwner(), secrets)
+		return messages.SecretRefreshMsg{Secrets: secrets, Query: query}
+	}
+}
+
 // attemptDeleteSecret - обработчик удаления секрета
 func (m ViewerModel) attemptDeleteSecret(sid string) tea.Cmd {
+	m, release := m.commandKeys()
 	return func() tea.Msg {
+		defer release()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
 		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
 		defer func() {
@@ -423,17 +1078,26 @@ func (m ViewerModel) attemptDeleteSecret(sid string) tea.Cmd {
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка удаления секрета: %s", err.Error()))
 		}
+		if err := m.updateVaultState(ctx, func(state *models.VaultState) { state.Remove(sid) }); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Секрет удалён, но состояние хранилища не подписано: %s", err.Error()))
+		}
 		return messages.SecretDeleteMsg{Id: id}
 	}
 }
 
 // attemptAddSecret - обработчик добавления секрета
 func (m ViewerModel) attemptAddSecret(converter messages.EncryptConverter) tea.Cmd {
+	m, release := m.commandKeys()
 	return func() tea.Msg {
-		info, content, err := converter.ToModel(m.cryptoKey)
+		defer release()
+		info, content, err := converter.ToModel(m.secretKey(), m.vaultOwner())
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления секрета: %s", err.Error()))
 		}
+		info.OrgID = m.vaultID()
+		if err := sealInfo(m.secretKey(), m.vaultOwner(), info); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления секрета: %s", err.Error()))
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
 		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
 		defer func() {
@@ -443,21 +1107,30 @@ func (m ViewerModel) attemptAddSecret(converter messages.EncryptConverter) tea.C
 		if err := client.Connect(ctx); err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
 		}
-		_, err = client.AddSecret(info, content)
+		added, err := client.AddSecret(info, content)
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления секрета: %s", err.Error()))
 		}
+		entry := models.VaultEntryFromInfo(added, crypto.ContentHash(content))
+		if err := m.updateVaultState(ctx, func(state *models.VaultState) { state.Set(entry) }); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Секрет добавлен, но состояние хранилища не подписано: %s", err.Error()))
+		}
 		return messages.SecretUpdateMsg{}
 	}
 }
 
 // attemptEditSecret - обработчик изменения секрета
 func (m ViewerModel) attemptEditSecret(converter messages.EncryptConverter) tea.Cmd {
+	m, release := m.commandKeys()
 	return func() tea.Msg {
-		info, content, err := converter.ToModel(m.cryptoKey)
+		defer release()
+		info, content, err := converter.ToModel(m.secretKey(), m.vaultOwner())
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка изменения секрета: %s", err.Error()))
 		}
+		if err := sealInfo(m.secretKey(), m.vaultOwner(), info); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка изменения секрета: %s", err.Error()))
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
 		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
 		defer func() {
@@ -467,17 +1140,123 @@ func (m ViewerModel) attemptEditSecret(converter messages.EncryptConverter) tea.
 		if err := client.Connect(ctx); err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
 		}
-		_, err = client.EditSecret(info, content)
+		edited, err := client.EditSecret(info, content)
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка изменения секрета: %s", err.Error()))
 		}
+		entry := models.VaultEntryFromInfo(edited, crypto.ContentHash(content))
+		if err := m.updateVaultState(ctx, func(state *models.VaultState) { state.Set(entry) }); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Секрет изменён, но состояние хранилища не подписано: %s", err.Error()))
+		}
+		return messages.SecretUpdateMsg{}
+	}
+}
+
+// attemptSetExpiration - обработчик изменения срока действия секрета
+func (m ViewerModel) attemptSetExpiration(msg messages.SetExpirationMsg) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		info := &models.SecretInfo{ID: msg.ID, Expires: msg.Expires, ExpirePolicy: msg.Policy}
+		updated, err := client.SetExpiration(info)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка изменения срока действия: %s", err.Error()))
+		}
+		// содержимое не изменяется, поэтому хеш остаётся подписанным ранее
+		err = m.updateVaultState(ctx, func(state *models.VaultState) {
+			hash := updated.ContentHash
+			if entry, ok := state.Entry(updated.ID); ok {
+				hash = entry.Hash
+			}
+			state.Set(models.VaultEntryFromInfo(updated, hash))
+		})
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Срок действия изменён, но состояние хранилища не подписано: %s", err.Error()))
+		}
 		return messages.SecretUpdateMsg{}
 	}
 }
 
+// attemptMigrateNames - обработчик переноса открытых названий секретов в зашифрованные метаданные.
+// Содержимое секретов не расшифровывается и сохраняется как есть, каждый пакет изменений
+// выполняется на сервере в одной транзакции.
+func (m ViewerModel) attemptMigrateNames(ids []string) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		timeout := time.Duration(m.settings.Timeout) * time.Second * time.Duration(1+len(ids)/migrateBatch)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		migrated := 0
+		for start := 0; start < len(ids); start += migrateBatch {
+			ops := make([]*models.SecretOperation, 0, migrateBatch)
+			for _, id := range ids[start:min(start+migrateBatch, len(ids))] {
+				info, content, err := client.GetSecret(id)
+				if err != nil {
+					return messages.ErrorMsg(fmt.Sprintf("Ошибка шифрования названий: %s", err.Error()))
+				}
+				if !info.PlainName() {
+					continue
+				}
+				// содержимое прежнего формата перешифровывается собственным ключом секрета заодно с названием,
+				// заметки из содержимого переносятся в метаданные
+				content, err = upgradeContent(m.secretKey(), m.vaultOwner(), info, content)
+				if err != nil {
+					return messages.ErrorMsg(fmt.Sprintf("Ошибка шифрования названий: %s", err.Error()))
+				}
+				if err := sealInfo(m.secretKey(), m.vaultOwner(), info); err != nil {
+					return messages.ErrorMsg(fmt.Sprintf("Ошибка шифрования названий: %s", err.Error()))
+				}
+				ops = append(ops, &models.SecretOperation{Kind: models.OperationEdit, Info: info, Content: content})
+			}
+			if len(ops) == 0 {
+				continue
+			}
+			results, err := client.BatchSecrets(ops)
+			if err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка шифрования названий: %s", err.Error()))
+			}
+			entries := make([]models.VaultEntry, 0, len(results))
+			for i, result := range results {
+				if result.Error == "" && result.Info != nil && i < len(ops) {
+					entries = append(entries, models.VaultEntryFromInfo(result.Info, crypto.ContentHash(ops[i].Content)))
+				}
+			}
+			err = m.updateVaultState(ctx, func(state *models.VaultState) {
+				for _, entry := range entries {
+					state.Set(entry)
+				}
+			})
+			if err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Названия зашифрованы, но состояние хранилища не подписано: %s", err.Error()))
+			}
+			migrated += len(ops)
+		}
+		return messages.NamesMigratedMsg(migrated)
+	}
+}
+
 // attemptGetSecret - обработчик получения секрета
 func (m ViewerModel) attemptGetSecret(sid string) tea.Cmd {
+	m, release := m.commandKeys()
 	return func() tea.Msg {
+		defer release()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
 		client := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
 		defer func() {
@@ -491,6 +1270,166 @@ func (m ViewerModel) attemptGetSecret(sid string) tea.Cmd {
 		if err != nil {
 			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления секрета: %s", err.Error()))
 		}
-		return messages.ToMessage(m.cryptoKey, info, content)
+		if err := m.checkVaultEntry(info, content); err != nil {
+			return messages.ErrorMsg("⚠️ ВНИМАНИЕ: " + err.Error())
+		}
+		return messages.ToMessage(m.secretKey(), m.vaultOwner(), info, content)
+	}
+}
+
+// attemptShareSecret - обработчик передачи секрета другому пользователю по ссылке: ключ данных секрета
+// шифруется открытым ключом получателя, и получатель видит текущее содержимое секрета. Секрет без
+// собственного ключа данных (зашифрованный ключом хранилища) предварительно перешифровывается.
+func (m ViewerModel) attemptShareSecret(msg messages.ShareSecretMsg) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		keeper := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		client := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			keeper.Close()
+			client.Close()
+		}()
+		if err := keeper.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		info, content, err := keeper.GetSecret(msg.ID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения секрета: %s", err.Error()))
+		}
+		if len(info.DataKey) == 0 {
+			if err := m.upgradeDataKey(ctx, keeper, info, content); err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка перешифрования секрета: %s", err.Error()))
+			}
+		}
+		dataKey, err := info.OpenDataKey(m.secretKey(), m.vaultOwner())
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки секрета: %s", err.Error()))
+		}
+		defer securemem.Wipe(dataKey)
+		public, err := client.GetPublicKey(msg.Recipient)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа получателя: %s", err.Error()))
+		}
+		wrapped, err := crypto.SealKey(public, dataKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка передачи секрета: %s", err.Error()))
+		}
+		if _, err := client.ShareSecret(msg.ID, msg.Recipient, wrapped); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка передачи секрета: %s", err.Error()))
+		}
+		return messages.ShareStatusMsg(fmt.Sprintf("Секрет передан пользователю %s", msg.Recipient))
+	}
+}
+
+// upgradeDataKey - метод перешифровывает секрет, зашифрованный ключом хранилища, собственным ключом
+// данных и сохраняет его (ключ хранилища нельзя передать другому пользователю)
+func (m ViewerModel) upgradeDataKey(ctx context.Context, keeper *grpcclient.KeeperClient, info *models.SecretInfo, content []byte) error {
+	if err := info.OpenMeta(m.secretKey(), m.vaultOwner()); err != nil {
+		return err
+	}
+	content, err := upgradeContent(m.secretKey(), m.vaultOwner(), info, content)
+	if err != nil {
+		return err
+	}
+	if err := sealInfo(m.secretKey(), m.vaultOwner(), info); err != nil {
+		return err
+	}
+	edited, err := keeper.EditSecret(info, content)
+	if err != nil {
+		return err
+	}
+	entry := models.VaultEntryFromInfo(edited, crypto.ContentHash(content))
+	return m.updateVaultState(ctx, func(state *models.VaultState) { state.Set(entry) })
+}
+
+// attemptRevokeShare - обработчик отзыва доступа к секрету
+func (m ViewerModel) attemptRevokeShare(msg messages.RevokeShareMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.RevokeShare(msg.ID, msg.Recipient); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка отзыва доступа: %s", err.Error()))
+		}
+		return messages.ShareStatusMsg(fmt.Sprintf("Доступ пользователя %s отозван", msg.Recipient))
+	}
+}
+
+// attemptGetShared - обработчик получения секретов, переданных пользователю
+func (m ViewerModel) attemptGetShared() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		shares, err := client.ListSharedWithMe()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения данных: %s", err.Error()))
+		}
+		return messages.SharedRefreshMsg{Shares: shares}
+	}
+}
+
+// sealInfo - метод формирует слепой индекс по названию и тегам секрета ключом key хранилища owner
+// и шифрует метаданные ключом данных секрета
+func sealInfo(key []byte, owner string, info *models.SecretInfo) error {
+	var err error
+	info.SearchIndex, err = crypto.BlindTokens(key, append([]string{info.Name}, info.Tags...)...)
+	if err != nil {
+		return fmt.Errorf("failed to build search index: %w", err)
+	}
+	dataKey, err := info.OpenDataKey(key, owner)
+	if err != nil {
+		return err
+	}
+	return info.SealMeta(dataKey, owner)
+}
+
+// upgradeContent - метод перешифровывает содержимое секрета в текущем формате ключом данных секрета
+// (у секрета без собственного ключа он создаётся); заметки, сохранённые в содержимом до появления
+// метаданных, переносятся в метаданные info
+func upgradeContent(key []byte, owner string, info *models.SecretInfo, content []byte) ([]byte, error) {
+	oldKey, err := info.OpenDataKey(key, owner)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := info.DataKeyFor(key, owner)
+	if err != nil {
+		return nil, err
+	}
+	return info.UpgradeContent(oldKey, dataKey, owner, content)
+}
+
+// openSecretsMeta - метод расшифровывает метаданные секретов списка и упорядочивает его по названию
+// (сервер не может упорядочить список, так как не видит названий)
+func openSecretsMeta(key []byte, owner string, secrets []*models.SecretInfo) {
+	for _, secret := range secrets {
+		dataKey, err := secret.OpenDataKey(key, owner)
+		if err == nil {
+			err = secret.OpenMeta(dataKey, owner)
+		}
+		if err != nil {
+			secret.Name = undecryptedName
+		}
 	}
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
 }
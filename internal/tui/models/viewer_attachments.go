@@ -0,0 +1,166 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/pkg/securemem"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// attemptGetAttachments - обработчик получения списка вложений секрета (названия расшифровываются)
+func (m ViewerModel) attemptGetAttachments(sid string, name string) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewAttachmentClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		attachments, err := client.GetAttachments(sid)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения вложений: %s", err.Error()))
+		}
+		dataKey, err := m.attachmentKey(ctx, sid, false)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа секрета: %s", err.Error()))
+		}
+		defer securemem.Wipe(dataKey)
+		for _, attachment := range attachments {
+			if err := attachment.OpenName(dataKey, m.vaultOwner()); err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки вложения: %s", err.Error()))
+			}
+		}
+		return messages.AttachmentsRefreshMsg{SecretID: sid, Name: name, Attachments: attachments}
+	}
+}
+
+// attemptAddAttachment - обработчик добавления вложения (название и содержимое файла
+// шифруются ключом данных секрета)
+func (m ViewerModel) attemptAddAttachment(msg messages.AddAttachmentMsg) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		data, err := readAttachment(msg.Path)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка чтения файла: %s", err.Error()))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewAttachmentClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		dataKey, err := m.attachmentKey(ctx, msg.SecretID, true)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа секрета: %s", err.Error()))
+		}
+		defer securemem.Wipe(dataKey)
+		name := filepath.Base(msg.Path)
+		info := models.NewAttachmentInfo(msg.SecretID, int64(len(data)))
+		content, err := info.Seal(dataKey, m.vaultOwner(), name, data)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка шифрования: %s", err.Error()))
+		}
+		if _, err := client.AddAttachment(info, content); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления вложения: %s", err.Error()))
+		}
+		return messages.AttachmentStatusMsg(fmt.Sprintf("Файл %s прикреплён", name))
+	}
+}
+
+// attemptSaveAttachment - обработчик сохранения расшифрованного вложения на диск
+func (m ViewerModel) attemptSaveAttachment(msg messages.SaveAttachmentMsg) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		if _, err := os.Stat(msg.Path); err == nil {
+			return messages.ErrorMsg("Файл уже существует: " + msg.Path)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewAttachmentClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		info, content, err := client.GetAttachment(msg.ID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения вложения: %s", err.Error()))
+		}
+		dataKey, err := m.attachmentKey(ctx, info.SecretID, false)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа секрета: %s", err.Error()))
+		}
+		defer securemem.Wipe(dataKey)
+		data, err := info.Open(dataKey, m.secretKey(), m.vaultOwner(), content)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки вложения: %s", err.Error()))
+		}
+		if err := os.WriteFile(msg.Path, data, 0600); err != nil {
+			return messages.ErrorMsg("Ошибка сохранения файла: " + err.Error())
+		}
+		return messages.AttachmentStatusMsg("Вложение сохранено в " + msg.Path)
+	}
+}
+
+// attachmentKey - метод возвращает ключ данных секрета sid, которым шифруются его вложения.
+// Если у секрета нет собственного ключа, при create он создаётся (содержимое секрета
+// перешифровывается), иначе возвращается ключ хранилища.
+func (m ViewerModel) attachmentKey(ctx context.Context, sid string, create bool) ([]byte, error) {
+	keeper := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+	defer keeper.Close()
+	if err := keeper.Connect(ctx); err != nil {
+		return nil, err
+	}
+	info, content, err := keeper.GetSecret(sid)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.DataKey) == 0 {
+		if !create {
+			// возвращается копия, так как вызывающий затирает ключ после использования
+			return bytes.Clone(m.secretKey()), nil
+		}
+		if err := m.upgradeDataKey(ctx, keeper, info, content); err != nil {
+			return nil, err
+		}
+	}
+	return info.OpenDataKey(m.secretKey(), m.vaultOwner())
+}
+
+// attemptDeleteAttachment - обработчик удаления вложения
+func (m ViewerModel) attemptDeleteAttachment(msg messages.DeleteAttachmentMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewAttachmentClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.DeleteAttachment(msg.ID); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка удаления вложения: %s", err.Error()))
+		}
+		return messages.AttachmentStatusMsg("Вложение удалено")
+	}
+}
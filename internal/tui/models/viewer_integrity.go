@@ -0,0 +1,278 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient"
+	"go-pass-keeper/internal/grpcclient/config"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/pkg/crypto"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// vaultStateAttempts - количество попыток изменить подписанное состояние хранилища,
+// одновременно изменяемое другими клиентами
+const vaultStateAttempts = 3
+
+// attemptVerifyVault - обработчик проверки личного хранилища: список секретов на сервере
+// (действующих и архивных) сверяется с состоянием, подписанным на клиенте. Если состояния
+// на сервере ещё нет и на этом клиенте оно не проверялось, текущее содержимое подписывается
+// (доверие при первом использовании). Отсутствие, откат или подмена состояния, проверенного
+// ранее (в том числе в прежних сеансах), считаются изменением хранилища в обход клиента.
+func (m ViewerModel) attemptVerifyVault() tea.Cmd {
+	last, lastErr := m.lastVerified()
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		key := m.cryptoKey.Bytes()
+		if len(key) == 0 {
+			return nil
+		}
+		if lastErr != nil {
+			return messages.VaultVerifiedMsg{Warning: fmt.Sprintf("не удалось прочитать ранее проверенное состояние хранилища: %s", lastErr.Error())}
+		}
+		lastSeq := max(m.vaultSeq, last.Sequence)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		keeper := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		keys := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			keeper.Close()
+			keys.Close()
+		}()
+		if err := keeper.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := keys.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		secrets, err := vaultSecrets(keeper)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка проверки хранилища: %s", err.Error()))
+		}
+		root, seq, err := keys.GetVaultRoot()
+		if errors.Is(err, grpcclient.ErrVaultRootNotFound) {
+			if lastSeq > 0 {
+				return messages.VaultVerifiedMsg{Warning: fmt.Sprintf(
+					"подписанное состояние хранилища удалено с сервера: ранее проверено состояние №%d", lastSeq)}
+			}
+			state := models.NewVaultState(secrets)
+			state.Sequence = 1
+			blob, err := state.Seal(key, m.userID)
+			if err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", err.Error()))
+			}
+			if err := keys.SetVaultRoot(blob, state.Sequence, 0); err != nil {
+				if errors.Is(err, grpcclient.ErrVaultRootConflict) {
+					// состояние подписано другим клиентом, оно будет проверено при следующей синхронизации
+					return nil
+				}
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", err.Error()))
+			}
+			if len(secrets) > 0 {
+				// содержимое, подписанное без проверки, могло быть изменено на сервере до подписи
+				return messages.VaultVerifiedMsg{State: state, Sequence: state.Sequence, Warning: fmt.Sprintf(
+					"на сервере нет подписанного состояния хранилища: текущее содержимое (секретов: %d) подписано без проверки", len(secrets))}
+			}
+			return messages.VaultVerifiedMsg{State: state, Sequence: state.Sequence,
+				Status: "Состояние хранилища подписано: изменения в обход клиента будут обнаружены"}
+		}
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка проверки хранилища: %s", err.Error()))
+		}
+		state, err := models.OpenVaultState(key, m.userID, root)
+		if err != nil {
+			return messages.VaultVerifiedMsg{Sequence: seq, Warning: "подпись состояния хранилища не сходится: состояние изменено на сервере"}
+		}
+		if state.Sequence != seq || seq < lastSeq {
+			return messages.VaultVerifiedMsg{Warning: fmt.Sprintf(
+				"состояние хранилища откачено на сервере: получено состояние №%d, ранее проверено №%d", state.Sequence, max(lastSeq, seq))}
+		}
+		if seq == last.Sequence && !bytes.Equal(state.Root, last.Root) {
+			return messages.VaultVerifiedMsg{Warning: fmt.Sprintf(
+				"состояние хранилища №%d на сервере отличается от проверенного ранее: состояние подменено", seq)}
+		}
+		return messages.VaultVerifiedMsg{State: state, Sequence: seq, Warning: vaultWarning(state.Verify(secrets, time.Now()))}
+	}
+}
+
+// attemptTrustVault - обработчик подписи текущего содержимого личного хранилища
+// (пользователь подтверждает, что найденные расхождения - его собственные изменения)
+func (m ViewerModel) attemptTrustVault() tea.Cmd {
+	last, lastErr := m.lastVerified()
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		key := m.cryptoKey.Bytes()
+		if len(key) == 0 {
+			return nil
+		}
+		if lastErr != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", lastErr.Error()))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		keeper := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		keys := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			keeper.Close()
+			keys.Close()
+		}()
+		if err := keeper.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := keys.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		secrets, err := vaultSecrets(keeper)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", err.Error()))
+		}
+		_, prev, err := keys.GetVaultRoot()
+		if err != nil && !errors.Is(err, grpcclient.ErrVaultRootNotFound) {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", err.Error()))
+		}
+		state := models.NewVaultState(secrets)
+		state.Sequence = max(prev, m.vaultSeq, last.Sequence) + 1
+		blob, err := state.Seal(key, m.userID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", err.Error()))
+		}
+		if err := keys.SetVaultRoot(blob, state.Sequence, prev); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подписи хранилища: %s", err.Error()))
+		}
+		return messages.VaultVerifiedMsg{State: state, Sequence: state.Sequence, Status: "Текущее содержимое хранилища подписано"}
+	}
+}
+
+// updateVaultState - метод вносит изменение личного хранилища, сделанное клиентом, в подписанное
+// состояние на сервере. Если состояние изменено другим клиентом, оно получается и изменяется заново.
+// Для командного хранилища и пока состояние не подписано впервые ничего не делается.
+func (m ViewerModel) updateVaultState(ctx context.Context, apply func(*models.VaultState)) error {
+	key := m.cryptoKey.Bytes()
+	if m.vault != nil || len(key) == 0 {
+		return nil
+	}
+	last, err := m.lastVerified()
+	if err != nil {
+		return err
+	}
+	keys := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+	defer keys.Close()
+	if err := keys.Connect(ctx); err != nil {
+		return err
+	}
+	for range vaultStateAttempts {
+		root, seq, err := keys.GetVaultRoot()
+		if errors.Is(err, grpcclient.ErrVaultRootNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		state, err := models.OpenVaultState(key, m.userID, root)
+		if err != nil {
+			return err
+		}
+		// откаченное состояние не подписывается заново, иначе откат станет незаметным
+		if state.Sequence != seq || seq < max(m.vaultSeq, last.Sequence) {
+			return fmt.Errorf("vault state rolled back to %d", state.Sequence)
+		}
+		apply(state)
+		state.Sequence = seq + 1
+		blob, err := state.Seal(key, m.userID)
+		if err != nil {
+			return err
+		}
+		err = keys.SetVaultRoot(blob, state.Sequence, seq)
+		if !errors.Is(err, grpcclient.ErrVaultRootConflict) {
+			return err
+		}
+	}
+	return grpcclient.ErrVaultRootConflict
+}
+
+// lastVerified - метод возвращает последнее состояние личного хранилища, проверенное на этом
+// клиенте в прежних сеансах (нулевой номер - состояние ещё не проверялось)
+func (m ViewerModel) lastVerified() (config.VaultCheckpoint, error) {
+	cp, err := m.configs.LoadCheckpoint(m.settings.ServerAddress(), m.userID)
+	if err != nil || cp == nil {
+		return config.VaultCheckpoint{}, err
+	}
+	return *cp, nil
+}
+
+// saveCheckpoint - метод сохраняет проверенное состояние личного хранилища для следующих сеансов
+func (m ViewerModel) saveCheckpoint(state *models.VaultState) error {
+	return m.configs.SaveCheckpoint(m.settings.ServerAddress(), m.userID, config.VaultCheckpoint{Sequence: state.Sequence, Root: state.Root})
+}
+
+// checkVaultEntry - метод сверяет полученный секрет личного хранилища с подписанным состоянием:
+// ревизия не должна быть старше подписанной, а содержимое той же ревизии - отличаться от подписанного
+func (m ViewerModel) checkVaultEntry(info *models.SecretInfo, content []byte) error {
+	if m.vault != nil || m.integrity == nil {
+		return nil
+	}
+	entry, ok := m.integrity.Entry(info.ID)
+	if !ok {
+		return nil
+	}
+	if info.Revision < entry.Revision {
+		return fmt.Errorf("секрет откачен на сервере к ревизии %d (подписана ревизия %d)", info.Revision, entry.Revision)
+	}
+	if info.Revision == entry.Revision && len(entry.Hash) != 0 && !bytes.Equal(crypto.ContentHash(content), entry.Hash) {
+		return errors.New("содержимое секрета подменено на сервере")
+	}
+	return nil
+}
+
+// vaultSecrets - метод получает действующие и архивные секреты личного хранилища
+func vaultSecrets(client *grpcclient.KeeperClient) ([]*models.SecretInfo, error) {
+	secrets, err := client.GetVaultSecrets("")
+	if err != nil {
+		return nil, err
+	}
+	archived, err := client.GetArchivedSecrets("")
+	if err != nil {
+		return nil, err
+	}
+	return append(secrets, archived...), nil
+}
+
+// vaultWarning - метод формирует предупреждение о расхождениях с подписанным состоянием хранилища
+func vaultWarning(report models.VaultReport) string {
+	if report.OK() {
+		return ""
+	}
+	var parts []string
+	if len(report.Missing) > 0 {
+		parts = append(parts, "пропали с сервера: "+shortIDs(report.Missing))
+	}
+	if len(report.RolledBack) > 0 {
+		parts = append(parts, "откачены к прежним версиям: "+shortIDs(report.RolledBack))
+	}
+	if len(report.Injected) > 0 {
+		parts = append(parts, "добавлены или изменены в обход клиента: "+shortIDs(report.Injected))
+	}
+	return "содержимое хранилища на сервере не совпадает с подписанным состоянием - " + strings.Join(parts, "; ")
+}
+
+// shortIDs - метод формирует список сокращённых идентификаторов секретов для предупреждения
+func shortIDs(ids []string) string {
+	const limit = 5
+	short := make([]string, 0, min(len(ids), limit))
+	for _, id := range ids[:min(len(ids), limit)] {
+		short = append(short, id[:min(len(id), 8)])
+	}
+	res := fmt.Sprintf("%d (%s", len(ids), strings.Join(short, ", "))
+	if len(ids) > limit {
+		res += ", …"
+	}
+	return res + ")"
+}
@@ -0,0 +1,290 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient"
+	"go-pass-keeper/internal/grpcclient/settings"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleAuthAction - обработчик авторизации (формирование токена и ключа шифрования)
+func (m ViewerModel) handleAuthAction(msg messages.AuthSuccessMsg) (ViewerModel, tea.Cmd) {
+	m.token = msg.Token
+	m.userID = msg.UserID
+	m.username = msg.Username
+	m.auth = models.AuthInfo{
+		Token:      msg.Token,
+		Salt:       msg.Salt,
+		UserID:     msg.UserID,
+		KDF:        msg.KDF,
+		WrappedKey: msg.WrappedKey,
+		KeyCheck:   msg.KeyCheck,
+	}
+	m.state = ViewerListState
+	m.secrets = nil
+	m.table.SetRows(nil)
+	m.wipeKeys()
+	m.vault = nil
+	m.archived = false
+	m.query = ""
+	m.migrated = make(map[string]bool)
+	m.integrity = nil
+	m.vaultSeq = 0
+	m.warning = ""
+	m.err = ""
+	m.status = ""
+	if m.settings.Secret == "" {
+		// секрет уже перенесён из настроек в защищённую память и удалён при закрытии хранилища
+		return m.lock("")
+	}
+	key, err := m.auth.UnlockKey(m.settings.Secret)
+	if err == nil {
+		err = m.auth.CheckKey(key)
+	}
+	if err != nil {
+		securemem.Wipe(key)
+		return m.lock(wrongSecretError)
+	}
+	m.openVault(key, m.settings.Secret)
+	return m, m.attemptLoadKeyPair()
+}
+
+// handleUnlockAction - обработчик повторного ввода секрета: если ключ хранилища открывается
+// и совпадает с контрольным значением, секрет переносится в защищённую память
+func (m ViewerModel) handleUnlockAction(secret string) (ViewerModel, tea.Cmd) {
+	key, err := m.auth.UnlockKey(secret)
+	if err == nil {
+		err = m.auth.CheckKey(key)
+	}
+	if err != nil {
+		securemem.Wipe(key)
+		return m.lock(wrongSecretError)
+	}
+	m.openVault(key, secret)
+	m.state = ViewerListState
+	m.err = ""
+	m.status = ""
+	return m, tea.Batch(
+		m.attemptGetSecrets(),
+		m.attemptLoadKeyPair(),
+		func() tea.Msg {
+			return messages.SecretConfirmedMsg{}
+		},
+	)
+}
+
+// lock - метод закрывает хранилище, удаляет ключи и секрет из памяти и запрашивает секрет повторно
+// (ключ, полученный из неверного секрета, не используется и не сохраняется на сервере).
+// errMsg - причина закрытия (пусто - хранилище закрыто пользователем).
+func (m ViewerModel) lock(errMsg messages.ErrorMsg) (ViewerModel, tea.Cmd) {
+	m.wipeKeys()
+	m.vault = nil
+	m.secrets = nil
+	m.integrity = nil
+	m.table.SetRows(nil)
+	m.state = UnlockState
+	m.status = ""
+	m.err = errMsg
+	m.unlock = m.unlock.Reset()
+	return m, m.unlock.Init()
+}
+
+// openVault - метод переносит ключ хранилища и секрет, которым оно открыто, в защищённую память
+// (исходный срез ключа обнуляется, секрет удаляется из общих настроек)
+func (m *ViewerModel) openVault(key []byte, secret string) {
+	m.cryptoKey.Destroy()
+	m.cryptoKey = securemem.FromBytes(key)
+	m.secret.Destroy()
+	m.secret = securemem.FromString(secret)
+	m.settings.Secret = ""
+}
+
+// commandKeys - метод возвращает копию модели с собственными копиями ключей для команды tea.Cmd
+// и функцию их удаления по завершении команды. Команда выполняется в отдельной горутине и может
+// пережить закрытие хранилища, поэтому не обращается к буферам модели, которые освобождает wipeKeys.
+func (m ViewerModel) commandKeys() (ViewerModel, func()) {
+	m.cryptoKey = m.cryptoKey.Clone()
+	m.privateKey = m.privateKey.Clone()
+	m.vaultKey = m.vaultKey.Clone()
+	m.secret = nil
+	return m, func() {
+		m.cryptoKey.Destroy()
+		m.privateKey.Destroy()
+		m.vaultKey.Destroy()
+	}
+}
+
+// wipeKeys - метод обнуляет ключи и секрет хранилища и возвращает их память системе
+// (незавершённые команды работают с собственными копиями ключей, см. commandKeys)
+func (m *ViewerModel) wipeKeys() {
+	m.cryptoKey.Destroy()
+	m.privateKey.Destroy()
+	m.vaultKey.Destroy()
+	m.secret.Destroy()
+	m.cryptoKey = nil
+	m.privateKey = nil
+	m.vaultKey = nil
+	m.secret = nil
+	m.shared = m.shared.SetShares(nil, nil)
+}
+
+// Close - метод удаляет ключи и секрет хранилища из памяти при выходе из приложения
+func (m ViewerModel) Close() {
+	m.wipeKeys()
+}
+
+// Secret - метод возвращает секрет, которым открыто хранилище (nil - хранилище не открыто)
+func (m ViewerModel) Secret() *securemem.Buffer {
+	return m.secret
+}
+
+// attemptProtectKey - обработчик сохранения на сервере контрольного значения ключа хранилища
+// и ключа хранилища, зашифрованного ключом из секрета, если этого ещё не сделано, параметры
+// получения ключа из пароля устарели или значения сохранены в устаревшем формате шифротекста.
+// Выполняется после расшифровки закрытого ключа, чтобы ключ из неверного секрета не был сохранён.
+func (m ViewerModel) attemptProtectKey() tea.Cmd {
+	var cmds []tea.Cmd
+	if len(m.auth.KeyCheck) == 0 || crypto.NeedsUpgrade(m.auth.KeyCheck) {
+		cmds = append(cmds, m.attemptSetKeyCheck())
+	}
+	if len(m.auth.WrappedKey) == 0 || crypto.NeedsUpgrade(m.auth.WrappedKey) || m.auth.KDF.Weaker(crypto.DefaultKDFParams) {
+		cmds = append(cmds, m.attemptWrapKey(m.secret.Clone(), nil))
+	}
+	return tea.Batch(cmds...)
+}
+
+// attemptLoadKeyPair - обработчик загрузки пары ключей для обмена секретами.
+// Если у пользователя ещё нет пары ключей, она создаётся и сохраняется на сервере,
+// закрытый ключ при этом шифруется ключом пользователя. Закрытый ключ, сохранённый
+// в устаревшем формате шифротекста, перешифровывается.
+func (m ViewerModel) attemptLoadKeyPair() tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		public, encrypted, err := client.GetKeyPair()
+		if err == nil {
+			private, err := crypto.Decrypt(m.cryptoKey.Bytes(), encrypted)
+			if err != nil && len(m.auth.KeyCheck) == 0 {
+				// без контрольного значения неверный секрет обнаруживается только здесь
+				return messages.WrongSecretMsg{}
+			}
+			if err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки закрытого ключа: %s", err.Error()))
+			}
+			if crypto.NeedsUpgrade(encrypted) {
+				if encrypted, err = crypto.Encrypt(m.cryptoKey.Bytes(), private); err == nil {
+					err = client.SetKeyPair(public, encrypted)
+				}
+				if err != nil {
+					return messages.ErrorMsg(fmt.Sprintf("Ошибка перешифрования закрытого ключа: %s", err.Error()))
+				}
+			}
+			return messages.KeyPairLoadedMsg{PrivateKey: private}
+		}
+		if !errors.Is(err, grpcclient.ErrKeyPairNotFound) {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключей: %s", err.Error()))
+		}
+		public, private, err := crypto.GenerateKeyPair()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключей: %s", err.Error()))
+		}
+		encrypted, err = crypto.Encrypt(m.cryptoKey.Bytes(), private)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка шифрования закрытого ключа: %s", err.Error()))
+		}
+		if err := client.SetKeyPair(public, encrypted); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения ключей: %s", err.Error()))
+		}
+		return messages.KeyPairLoadedMsg{PrivateKey: private}
+	}
+}
+
+// attemptWrapKey - обработчик сохранения ключа хранилища на сервере зашифрованным ключом из секрета secret
+// (буфер секрета освобождается по завершении команды).
+// Ключ хранилища не меняется, поэтому при усилении параметров получения ключа или смене секрета
+// перешифровывается только он, а не секреты. Если параметры слабее crypto.DefaultKDFParams,
+// используются параметры по умолчанию. connection - новые настройки при смене секрета (или nil).
+func (m ViewerModel) attemptWrapKey(secret *securemem.Buffer, connection *settings.Settings) tea.Cmd {
+	kdf := m.auth.KDF
+	if kdf.Weaker(crypto.DefaultKDFParams) {
+		kdf = crypto.DefaultKDFParams
+	}
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		defer secret.Destroy()
+		wrapped, err := m.auth.WrapKey(secret.Bytes(), kdf, m.cryptoKey.Bytes())
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения ключа хранилища: %s", err.Error()))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.SetKdf(kdf, wrapped); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения ключа хранилища: %s", err.Error()))
+		}
+		if connection == nil {
+			return messages.KeyWrappedMsg{KDF: kdf, WrappedKey: wrapped}
+		}
+		return messages.KeyWrappedMsg{KDF: kdf, WrappedKey: wrapped, Connection: connection, Secret: secret.Clone()}
+	}
+}
+
+// attemptSetKeyCheck - обработчик сохранения контрольного значения ключа хранилища
+func (m ViewerModel) attemptSetKeyCheck() tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		check, err := m.auth.NewKeyCheck(m.cryptoKey.Bytes())
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения контрольного значения ключа: %s", err.Error()))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.SetKeyCheck(check); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения контрольного значения ключа: %s", err.Error()))
+		}
+		return messages.KeyCheckSavedMsg{KeyCheck: check}
+	}
+}
+
+// ChangeSecret - метод перешифровывает ключ хранилища новым секретом из настроек connection
+// (без авторизации ключ ещё не получен и перешифровывать нечего)
+func (m ViewerModel) ChangeSecret(connection settings.Settings) tea.Cmd {
+	if m.cryptoKey == nil || connection.Secret == "" || m.secret.Equal(connection.Secret) {
+		return nil
+	}
+	secret := securemem.FromString(connection.Secret)
+	connection.Secret = ""
+	return m.attemptWrapKey(secret, &connection)
+}
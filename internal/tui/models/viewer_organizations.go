@@ -0,0 +1,336 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/pkg/crypto"
+	"go-pass-keeper/pkg/securemem"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleVaultSelect - метод переключения между личным и командным хранилищем
+func (m ViewerModel) handleVaultSelect(msg messages.VaultSelectMsg) (ViewerModel, tea.Cmd) {
+	m.state = ViewerListState
+	if msg.Organization == nil {
+		m.vault = nil
+		m.vaultKey.Destroy()
+		m.vaultKey = nil
+		return m, m.attemptGetSecrets()
+	}
+	key, err := crypto.OpenKey(m.privateKey.Bytes(), msg.Organization.VaultKey)
+	if err != nil {
+		return m, func() tea.Msg {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки ключа хранилища: %s", err.Error()))
+		}
+	}
+	m.vault = msg.Organization
+	m.vaultKey.Destroy()
+	m.vaultKey = securemem.FromBytes(key)
+	// ключ хранилища, известный удалённому участнику, заменяет владелец или администратор
+	if msg.Organization.RotateKey && msg.Organization.CanManage() {
+		return m, tea.Batch(m.attemptGetSecrets(), m.attemptRotateVaultKey(msg.Organization))
+	}
+	return m, m.attemptGetSecrets()
+}
+
+// attemptGetOrganizations - обработчик получения списка организаций пользователя
+func (m ViewerModel) attemptGetOrganizations() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewOrganizationClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		orgs, err := client.GetOrganizations()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения хранилищ: %s", err.Error()))
+		}
+		return messages.VaultsRefreshMsg{Organizations: orgs}
+	}
+}
+
+// attemptCreateOrganization - обработчик создания организации.
+// Ключ хранилища создаётся на клиенте и шифруется открытым ключом создателя.
+func (m ViewerModel) attemptCreateOrganization(msg messages.CreateOrganizationMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		keys := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		client := grpcclient.NewOrganizationClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			keys.Close()
+			client.Close()
+		}()
+		if err := keys.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		public, _, err := keys.GetKeyPair()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключей: %s", err.Error()))
+		}
+		vaultKey, err := crypto.GenerateDataKey()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+		}
+		defer securemem.Wipe(vaultKey)
+		wrapped, err := crypto.SealKey(public, vaultKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+		}
+		if _, err := client.CreateOrganization(msg.Name, wrapped); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания команды: %s", err.Error()))
+		}
+		orgs, err := client.GetOrganizations()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения хранилищ: %s", err.Error()))
+		}
+		return messages.VaultsRefreshMsg{Organizations: orgs}
+	}
+}
+
+// attemptAddMember - обработчик добавления участника в организацию.
+// Ключ хранилища расшифровывается закрытым ключом и шифруется открытым ключом участника.
+func (m ViewerModel) attemptAddMember(msg messages.AddMemberMsg) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		vaultKey, err := crypto.OpenKey(m.privateKey.Bytes(), msg.Organization.VaultKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки ключа хранилища: %s", err.Error()))
+		}
+		defer securemem.Wipe(vaultKey)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		keys := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		client := grpcclient.NewOrganizationClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			keys.Close()
+			client.Close()
+		}()
+		if err := keys.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		public, err := keys.GetPublicKey(msg.Login)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа участника: %s", err.Error()))
+		}
+		wrapped, err := crypto.SealKey(public, vaultKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления участника: %s", err.Error()))
+		}
+		if _, err := client.AddMember(msg.Organization.ID, msg.Login, msg.Role, wrapped); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка добавления участника: %s", err.Error()))
+		}
+		return messages.ShareStatusMsg(fmt.Sprintf("Пользователь %s добавлен в команду %s", msg.Login, msg.Organization.Name))
+	}
+}
+
+// attemptRemoveMember - обработчик удаления участника из организации
+func (m ViewerModel) attemptRemoveMember(msg messages.RemoveMemberMsg) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewOrganizationClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.RemoveMember(msg.Organization.ID, msg.Login); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка удаления участника: %s", err.Error()))
+		}
+		// удалённому участнику известен ключ хранилища, поэтому он сразу заменяется
+		// (покинувший команду участник заменить ключ не может, это сделает администратор)
+		if msg.Login != m.username && msg.Organization.CanManage() {
+			return messages.RotateVaultKeyMsg{Organization: msg.Organization}
+		}
+		return messages.ShareStatusMsg(fmt.Sprintf("Пользователь %s удалён из команды %s", msg.Login, msg.Organization.Name))
+	}
+}
+
+// attemptRotateVaultKey - обработчик замены ключа хранилища организации после удаления участника.
+// Новый ключ создаётся на клиенте и шифруется открытым ключом каждого оставшегося участника,
+// ключи данных секретов перешифровываются новым ключом, слепой индекс строится заново.
+// Секреты и вложения, зашифрованные прежним ключом хранилища напрямую, предварительно
+// переводятся на собственные ключи данных (прерванную замену можно повторить).
+// Ключи данных и содержимое секретов не меняются: то, что удалённый участник уже видел,
+// следует считать раскрытым, но новый ключ хранилища и новые секреты ему недоступны.
+func (m ViewerModel) attemptRotateVaultKey(org *models.OrganizationInfo) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		oldKey, err := crypto.OpenKey(m.privateKey.Bytes(), org.VaultKey)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка расшифровки ключа хранилища: %s", err.Error()))
+		}
+		defer securemem.Wipe(oldKey)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewOrganizationClient(m.settings.ServerAddress(), m.token)
+		keeper := grpcclient.NewKeeperClient(m.settings.ServerAddress(), m.token)
+		keys := grpcclient.NewShareClient(m.settings.ServerAddress(), m.token)
+		attachments := grpcclient.NewAttachmentClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+			keeper.Close()
+			keys.Close()
+			attachments.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := keeper.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := keys.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := attachments.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		secrets, err := keeper.GetVaultSecrets(org.ID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения секретов: %s", err.Error()))
+		}
+		archived, err := keeper.GetArchivedSecrets(org.ID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения секретов: %s", err.Error()))
+		}
+		secrets = append(secrets, archived...)
+		for _, secret := range secrets {
+			if err := upgradeVaultSecret(keeper, attachments, oldKey, org.ID, secret); err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка перешифрования секрета: %s", err.Error()))
+			}
+		}
+		newKey, err := crypto.GenerateDataKey()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+		}
+		defer securemem.Wipe(newKey)
+		for _, secret := range secrets {
+			if err := rewrapSecret(oldKey, newKey, org.ID, secret); err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка перешифрования секрета: %s", err.Error()))
+			}
+		}
+		members, err := client.GetMembers(org.ID)
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения участников: %s", err.Error()))
+		}
+		wrapped := make(map[string][]byte, len(members))
+		for _, member := range members {
+			public, err := keys.GetPublicKey(member.Login)
+			if err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа участника: %s", err.Error()))
+			}
+			if wrapped[member.Login], err = crypto.SealKey(public, newKey); err != nil {
+				return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа хранилища: %s", err.Error()))
+			}
+		}
+		if err := client.RotateVaultKey(org.ID, wrapped, secrets); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка замены ключа хранилища: %s", err.Error()))
+		}
+		orgs, err := client.GetOrganizations()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения хранилищ: %s", err.Error()))
+		}
+		for _, rotated := range orgs {
+			if rotated.ID == org.ID {
+				return messages.VaultKeyRotatedMsg{Organization: rotated}
+			}
+		}
+		return messages.ErrorMsg(fmt.Sprintf("Команда %s не найдена", org.Name))
+	}
+}
+
+// upgradeVaultSecret - метод переводит секрет хранилища owner и его вложения, зашифрованные
+// ключом хранилища vaultKey напрямую, на собственный ключ данных секрета
+func upgradeVaultSecret(keeper *grpcclient.KeeperClient, attachments *grpcclient.AttachmentClient, vaultKey []byte, owner string, info *models.SecretInfo) error {
+	if len(info.DataKey) == 0 {
+		full, content, err := keeper.GetSecret(info.ID)
+		if err != nil {
+			return err
+		}
+		if err := full.OpenMeta(vaultKey, owner); err != nil {
+			return err
+		}
+		if content, err = upgradeContent(vaultKey, owner, full, content); err != nil {
+			return err
+		}
+		if err := sealInfo(vaultKey, owner, full); err != nil {
+			return err
+		}
+		if _, err := keeper.EditSecret(full, content); err != nil {
+			return err
+		}
+		info.DataKey, info.EncryptedMeta = full.DataKey, full.EncryptedMeta
+	}
+	list, err := attachments.GetAttachments(info.ID)
+	if err != nil {
+		return err
+	}
+	var dataKey []byte
+	for _, attachment := range list {
+		if len(attachment.Meta) != 0 {
+			continue
+		}
+		if dataKey == nil {
+			if dataKey, err = info.OpenDataKey(vaultKey, owner); err != nil {
+				return err
+			}
+			defer securemem.Wipe(dataKey)
+		}
+		_, content, err := attachments.GetAttachment(attachment.ID)
+		if err != nil {
+			return err
+		}
+		data, err := attachment.Open(nil, vaultKey, owner, content)
+		if err != nil {
+			return err
+		}
+		upgraded := models.NewAttachmentInfo(info.ID, attachment.Size)
+		sealed, err := upgraded.Seal(dataKey, owner, attachment.Name, data)
+		if err != nil {
+			return err
+		}
+		if _, err := attachments.AddAttachment(upgraded, sealed); err != nil {
+			return err
+		}
+		if err := attachments.DeleteAttachment(attachment.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewrapSecret - метод перешифровывает ключ данных секрета хранилища owner с ключа хранилища
+// oldKey на newKey и строит слепой индекс по названию и тегам на новом ключе
+func rewrapSecret(oldKey []byte, newKey []byte, owner string, info *models.SecretInfo) error {
+	dataKey, err := info.OpenDataKey(oldKey, owner)
+	if err != nil {
+		return err
+	}
+	defer securemem.Wipe(dataKey)
+	if err := info.OpenMeta(dataKey, owner); err != nil {
+		return err
+	}
+	if info.SearchIndex, err = crypto.BlindTokens(newKey, append([]string{info.Name}, info.Tags...)...); err != nil {
+		return fmt.Errorf("failed to build search index: %w", err)
+	}
+	return info.RewrapDataKey(oldKey, newKey, owner)
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-pass-keeper/internal/grpcclient"
+	"go-pass-keeper/internal/models"
+	"go-pass-keeper/internal/tui/messages"
+	"go-pass-keeper/pkg/crypto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleVaultRecovered - обработчик восстановления доступа к хранилищу: ключ хранилища
+// зашифрован новым секретом, которым открывается хранилище
+func (m ViewerModel) handleVaultRecovered(msg messages.VaultRecoveredMsg) (ViewerModel, tea.Cmd) {
+	m.auth.KDF = msg.KDF
+	m.auth.WrappedKey = msg.WrappedKey
+	m.wipeKeys()
+	m.openVault(msg.Key, msg.Secret)
+	m.state = ViewerListState
+	m.err = ""
+	m.status = "Доступ к хранилищу восстановлен, секрет изменён"
+	m.unlock = m.unlock.Reset()
+	return m, tea.Batch(m.attemptGetSecrets(), m.attemptLoadKeyPair())
+}
+
+// attemptCreateRecoveryKey - обработчик создания ключа восстановления: ключ хранилища
+// шифруется случайным ключом восстановления и сохраняется на сервере (прежний заменяется)
+func (m ViewerModel) attemptCreateRecoveryKey() tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		recoveryKey, err := crypto.GenerateRecoveryKey()
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа восстановления: %s", err.Error()))
+		}
+		wrapped, err := m.auth.WrapRecoveryKey(recoveryKey, m.cryptoKey.Bytes())
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка создания ключа восстановления: %s", err.Error()))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.SetRecoveryKey(wrapped); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка сохранения ключа восстановления: %s", err.Error()))
+		}
+		return messages.RecoveryKeyCreatedMsg{Code: crypto.FormatRecoveryCode(recoveryKey)}
+	}
+}
+
+// attemptGetEscrowKey - обработчик получения открытого ключа депонирования организации
+func (m ViewerModel) attemptGetEscrowKey() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewEscrowClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		publicKey, err := client.GetEscrowKey()
+		if errors.Is(err, grpcclient.ErrEscrowNotConfigured) {
+			return messages.ErrorMsg("Депонирование ключей на сервере не настроено")
+		}
+		if err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка получения ключа депонирования: %s", err.Error()))
+		}
+		return messages.EscrowKeyMsg{PublicKey: publicKey}
+	}
+}
+
+// attemptEnrollEscrow - обработчик депонирования: ключ хранилища шифруется открытым ключом
+// депонирования, подтверждённым пользователем по отпечатку, и сохраняется на сервере
+func (m ViewerModel) attemptEnrollEscrow(msg messages.EnrollEscrowMsg) tea.Cmd {
+	m, release := m.commandKeys()
+	return func() tea.Msg {
+		defer release()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewEscrowClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		if err := client.Enroll(&m.auth, msg.PublicKey, m.cryptoKey.Bytes()); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка депонирования ключа: %s", err.Error()))
+		}
+		return messages.EscrowEnrolledMsg{}
+	}
+}
+
+// attemptSaveEmergencyKit - обработчик сохранения аварийного комплекта в текстовый файл
+// (существующий файл не перезаписывается)
+func (m ViewerModel) attemptSaveEmergencyKit(msg messages.SaveEmergencyKitMsg) tea.Cmd {
+	kit := models.EmergencyKit{
+		Server:       m.settings.ServerAddress(),
+		Login:        m.username,
+		UserID:       m.userID,
+		RecoveryCode: msg.Code,
+		Created:      time.Now(),
+	}
+	return func() tea.Msg {
+		if msg.Path == "" {
+			return messages.ErrorMsg("Укажите путь к файлу")
+		}
+		f, err := os.OpenFile(msg.Path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return messages.ErrorMsg("Ошибка сохранения файла: " + err.Error())
+		}
+		if _, err := f.WriteString(kit.Text()); err != nil {
+			f.Close()
+			return messages.ErrorMsg("Ошибка сохранения файла: " + err.Error())
+		}
+		if err := f.Close(); err != nil {
+			return messages.ErrorMsg("Ошибка сохранения файла: " + err.Error())
+		}
+		return messages.RecoveryStatusMsg("Аварийный комплект сохранён в " + msg.Path + ": распечатайте его и удалите файл")
+	}
+}
+
+// attemptSaveRecoveryShares - обработчик разделения ключа восстановления на части:
+// каждая часть сохраняется в отдельный файл (существующие файлы не перезаписываются)
+func (m ViewerModel) attemptSaveRecoveryShares(msg messages.SaveRecoverySharesMsg) tea.Cmd {
+	kit := models.RecoveryShareKit{
+		Server:    m.settings.ServerAddress(),
+		Login:     m.username,
+		UserID:    m.userID,
+		Total:     msg.Total,
+		Threshold: msg.Threshold,
+		Created:   time.Now(),
+	}
+	return func() tea.Msg {
+		recoveryKey, err := crypto.ParseRecoveryCode(msg.Code)
+		if err != nil {
+			return messages.ErrorMsg("Ошибка разделения ключа восстановления: " + err.Error())
+		}
+		codes, err := crypto.SplitRecoveryKey(recoveryKey, msg.Total, msg.Threshold)
+		if err != nil {
+			return messages.ErrorMsg("Ошибка разделения ключа восстановления: " + err.Error())
+		}
+		paths := make([]string, 0, len(codes))
+		for i, code := range codes {
+			kit.Index, kit.Share = i+1, code
+			path := sharePath(msg.Path, i+1)
+			if err := writeShareKit(path, kit, msg.QR); err != nil {
+				// частично сохранённый набор бесполезен
+				for _, p := range paths {
+					os.Remove(p)
+				}
+				return messages.ErrorMsg("Ошибка сохранения файла: " + err.Error())
+			}
+			paths = append(paths, path)
+		}
+		return messages.RecoveryStatusMsg(fmt.Sprintf("Части ключа сохранены в %s ... %s: раздайте их хранителям и удалите файлы",
+			paths[0], paths[len(paths)-1]))
+	}
+}
+
+// sharePath - метод формирует путь к файлу части ключа восстановления с номером index
+func sharePath(path string, index int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), index, ext)
+}
+
+// writeShareKit - метод сохраняет часть ключа восстановления в новый файл
+func writeShareKit(path string, kit models.RecoveryShareKit, qr bool) error {
+	text, err := kit.Text(qr)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// attemptRecoverVault - обработчик восстановления доступа к хранилищу ключом восстановления:
+// ключ хранилища сохраняется на сервере зашифрованным ключом из нового секрета
+func (m ViewerModel) attemptRecoverVault(msg messages.RecoverVaultMsg) tea.Cmd {
+	auth := m.auth
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.settings.Timeout)*time.Second)
+		client := grpcclient.NewKeysClient(m.settings.ServerAddress(), m.token)
+		defer func() {
+			cancel()
+			client.Close()
+		}()
+		if err := client.Connect(ctx); err != nil {
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка подключения к %s: %s", m.settings.ServerAddress(), err.Error()))
+		}
+		key, err := client.Recover(&auth, msg.RecoveryKey, msg.Secret)
+		switch {
+		case errors.Is(err, grpcclient.ErrRecoveryKeyNotFound):
+			return messages.ErrorMsg("Ключ восстановления для учётной записи не создан")
+		case errors.Is(err, models.ErrWrongKey):
+			return messages.ErrorMsg("Неверный ключ восстановления: он заменён новым или относится к другой учётной записи")
+		case err != nil:
+			return messages.ErrorMsg(fmt.Sprintf("Ошибка восстановления доступа: %s", err.Error()))
+		}
+		return messages.VaultRecoveredMsg{Key: key, KDF: auth.KDF, WrappedKey: auth.WrappedKey, Secret: msg.Secret}
+	}
+}
@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"go-pass-keeper/pkg/logger"
+	"time"
+)
+
+// Expirer - интерфейс хранилища, применяющего действия к секретам с истёкшим сроком действия
+type Expirer interface {
+	// Expire - удаление или архивирование секретов, срок действия которых истёк к моменту now
+	// (возвращает количество удалённых и архивированных секретов)
+	Expire(ctx context.Context, now time.Time) (int64, int64, error)
+}
+
+// Expire - фоновая задача контроля срока действия секретов: при запуске и далее с периодом interval
+// удаляет или перемещает в архив секреты с истёкшим сроком. Завершается при отмене контекста.
+func Expire(ctx context.Context, e Expirer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		expireOnce(ctx, e, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expireOnce - метод выполняет один проход контроля срока действия секретов
+func expireOnce(ctx context.Context, e Expirer, now time.Time) {
+	deleted, archived, err := e.Expire(ctx, now)
+	if err != nil {
+		logger.Error("Error expire secrets", err.Error())
+	}
+	if deleted > 0 || archived > 0 {
+		logger.Info("Expired secrets deleted:", deleted, "archived:", archived)
+	}
+}
@@ -0,0 +1,73 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExpirer - хранилище, запоминающее момент последней проверки
+type fakeExpirer struct {
+	calls int
+	now   time.Time
+	err   error
+}
+
+func (f *fakeExpirer) Expire(ctx context.Context, now time.Time) (int64, int64, error) {
+	f.calls++
+	f.now = now
+	if f.err != nil {
+		return 0, 0, f.err
+	}
+	return 1, 2, nil
+}
+
+func TestExpireOnce(t *testing.T) {
+	if err := logger.Initialize(config.DefaultConfig().LogLevel); err != nil {
+		logger.Panic(err)
+	}
+	now := time.Date(2025, time.October, 9, 8, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		expirer *fakeExpirer
+	}{
+		{
+			name:    "Expire secrets #1",
+			expirer: &fakeExpirer{},
+		},
+		{
+			name:    "Storage error #2",
+			expirer: &fakeExpirer{err: errors.New("connection refused")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expireOnce(context.Background(), tc.expirer, now)
+			assert.Equal(t, 1, tc.expirer.calls)
+			assert.Equal(t, now, tc.expirer.now)
+		})
+	}
+}
+
+func TestExpireStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &fakeExpirer{}
+	done := make(chan struct{})
+	go func() {
+		Expire(ctx, e, time.Hour)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker didn't stop")
+	}
+}
@@ -0,0 +1,52 @@
+// Package workers предоставляет фоновые задачи сервера
+package workers
+
+import (
+	"context"
+	"go-pass-keeper/pkg/logger"
+	"time"
+)
+
+// rewrapBatch - количество записей, перешифровываемых в одной транзакции
+const rewrapBatch = 100
+
+// Rewrapper - интерфейс хранилища, перешифровывающего ключи данных текущим мастер-ключом
+type Rewrapper interface {
+	// Rewrap - перешифрование не более batch записей (возвращает количество обработанных записей)
+	Rewrap(ctx context.Context, batch int) (int, error)
+}
+
+// Rewrap - фоновая задача ротации мастер-ключа: при запуске и далее с периодом interval
+// перешифровывает ключи данных, зашифрованные предыдущими мастер-ключами.
+// Завершается при отмене контекста.
+func Rewrap(ctx context.Context, r Rewrapper, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		rewrapAll(ctx, r)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rewrapAll - метод перешифровывает записи пакетами, пока они не закончатся
+func rewrapAll(ctx context.Context, r Rewrapper) {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := r.Rewrap(ctx, rewrapBatch)
+		if err != nil {
+			logger.Error("Error rewrap data keys", err.Error())
+			return
+		}
+		total += n
+		if n < rewrapBatch {
+			break
+		}
+	}
+	if total > 0 {
+		logger.Info("Rewrapped data keys:", total)
+	}
+}
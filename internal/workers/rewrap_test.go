@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"go-pass-keeper/internal/grpcserver/config"
+	"go-pass-keeper/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRewrapper - хранилище с заданным количеством записей для перешифрования
+type fakeRewrapper struct {
+	pending int
+	calls   int
+	err     error
+}
+
+func (f *fakeRewrapper) Rewrap(ctx context.Context, batch int) (int, error) {
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := min(batch, f.pending)
+	f.pending -= n
+	return n, nil
+}
+
+func TestRewrapAll(t *testing.T) {
+	if err := logger.Initialize(config.DefaultConfig().LogLevel); err != nil {
+		logger.Panic(err)
+	}
+
+	testCases := []struct {
+		name          string
+		rewrapper     *fakeRewrapper
+		expectedCalls int
+		expectedLeft  int
+	}{
+		{
+			name:          "Nothing to rewrap #1",
+			rewrapper:     &fakeRewrapper{},
+			expectedCalls: 1,
+		},
+		{
+			name:          "Several batches #2",
+			rewrapper:     &fakeRewrapper{pending: rewrapBatch*2 + 1},
+			expectedCalls: 3,
+		},
+		{
+			name:          "Exact batch #3",
+			rewrapper:     &fakeRewrapper{pending: rewrapBatch},
+			expectedCalls: 2,
+		},
+		{
+			name:          "Storage error #4",
+			rewrapper:     &fakeRewrapper{pending: 5, err: errors.New("unknown master key")},
+			expectedCalls: 1,
+			expectedLeft:  5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rewrapAll(context.Background(), tc.rewrapper)
+			assert.Equal(t, tc.expectedCalls, tc.rewrapper.calls)
+			assert.Equal(t, tc.expectedLeft, tc.rewrapper.pending)
+		})
+	}
+}
+
+func TestRewrapStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &fakeRewrapper{}
+	done := make(chan struct{})
+	go func() {
+		Rewrap(ctx, r, time.Hour)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker didn't stop")
+	}
+}
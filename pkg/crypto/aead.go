@@ -0,0 +1,49 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// EncryptWithAD - метод шифрует данные с привязкой к дополнительным аутентифицируемым данным ad:
+// ad не шифруется и не сохраняется, но расшифровать результат можно только с теми же ad
+func EncryptWithAD(key []byte, data []byte, ad []byte) ([]byte, error) {
+	return EncryptWithCipher(DefaultCipher, key, data, ad)
+}
+
+// DecryptWithAD - метод расшифровывает данные, зашифрованные EncryptWithAD с теми же ad.
+// Шифротексты исходного формата без заголовка (без проверки ad) также расшифровываются,
+// их следует перешифровать при следующем сохранении (см. NeedsUpgrade).
+func DecryptWithAD(key []byte, data []byte, ad []byte) ([]byte, error) {
+	h, ok := ParseHeader(data)
+	if !ok {
+		if len(data) < headerSize {
+			return nil, fmt.Errorf("encrypted data too short")
+		}
+		return open(key, data, nil)
+	}
+	envelopeErr := ErrPasswordRequired
+	if h.KDF == KDFNone {
+		plaintext, err := openEnvelope(key, data, ad)
+		if err == nil {
+			return plaintext, nil
+		}
+		envelopeErr = err
+	}
+	// nonce шифротекста исходного формата может случайно начинаться с признака заголовка
+	if plaintext, err := open(key, data, nil); err == nil {
+		return plaintext, nil
+	}
+	return nil, envelopeErr
+}
+
+// AssociatedData - метод формирует однозначное представление дополнительных данных
+// из списка значений (каждое значение предваряется его длиной)
+func AssociatedData(parts ...string) []byte {
+	var res []byte
+	for _, part := range parts {
+		res = binary.BigEndian.AppendUint32(res, uint32(len(part)))
+		res = append(res, part...)
+	}
+	return res
+}
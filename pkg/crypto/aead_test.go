@@ -0,0 +1,122 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptWithAD(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err, "GenerateDataKey failed")
+
+	ad := AssociatedData("user", "secret-1", "password")
+	data, err := EncryptWithAD(key, []byte("Тестовое сообщение"), ad)
+	require.NoError(t, err, "EncryptWithAD failed")
+
+	testCases := []struct {
+		Name          string
+		Data          []byte
+		AD            []byte
+		Expected      []byte
+		ExpectedError bool
+	}{
+		{
+			Name:     "Success. Same associated data #1",
+			Data:     data,
+			AD:       ad,
+			Expected: []byte("Тестовое сообщение"),
+		},
+		{
+			Name:          "Error. Other secret id #2",
+			Data:          data,
+			AD:            AssociatedData("user", "secret-2", "password"),
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. Other secret type #3",
+			Data:          data,
+			AD:            AssociatedData("user", "secret-1", "text"),
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. No associated data #4",
+			Data:          data,
+			AD:            nil,
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. Too short #5",
+			Data:          data[:headerSize],
+			AD:            ad,
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			decrypted, err := DecryptWithAD(key, tc.Data, tc.AD)
+			if tc.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err, "DecryptWithAD failed")
+			assert.Equal(t, tc.Expected, decrypted)
+		})
+	}
+}
+
+func TestDecryptWithADLegacy(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err, "GenerateDataKey failed")
+	ad := AssociatedData("user", "secret-1", "text")
+
+	legacy, err := seal(key, []byte("legacy"), nil)
+	require.NoError(t, err, "seal failed")
+	assert.True(t, NeedsUpgrade(legacy), "legacy ciphertext should be upgraded")
+
+	// шифротексты исходного формата расшифровываются при любых дополнительных данных,
+	// в том числе когда nonce начинается с признака заголовка
+	for i := 0; i < 512; i++ {
+		legacy, err := seal(key, []byte("legacy"), nil)
+		require.NoError(t, err, "seal failed")
+		if i%2 == 0 {
+			copy(legacy, envelopeMagic)
+			legacy, err = sealWithNonce(key, legacy[:12], []byte("legacy"))
+			require.NoError(t, err, "seal failed")
+		}
+
+		decrypted, err := DecryptWithAD(key, legacy, ad)
+		require.NoError(t, err, "DecryptWithAD failed")
+		assert.Equal(t, []byte("legacy"), decrypted)
+	}
+
+	// шифротексты с заголовком по-прежнему проверяют дополнительные данные
+	data, err := EncryptWithAD(key, []byte("current"), ad)
+	require.NoError(t, err, "EncryptWithAD failed")
+	_, err = DecryptWithAD(key, data, AssociatedData("user", "secret-2", "text"))
+	require.Error(t, err, "associated data should be verified")
+}
+
+// sealWithNonce - метод шифрует данные в исходном формате с заданным nonce
+func sealWithNonce(key []byte, nonce []byte, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Seal(append([]byte{}, nonce...), nonce, data, nil), nil
+}
+
+func TestAssociatedData(t *testing.T) {
+	// значения разделяются однозначно
+	assert.NotEqual(t, AssociatedData("ab", "c"), AssociatedData("a", "bc"))
+	assert.NotEqual(t, AssociatedData("a", ""), AssociatedData("a"))
+	assert.Equal(t, AssociatedData("a", "b"), AssociatedData("a", "b"))
+}
@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	searchKeyInfo = "go-pass-keeper/search/v1" // Контекст для HKDF ключа слепого индекса
+	blindIndexLen = 16                         // Длина значения слепого индекса (усечённый HMAC-SHA256)
+)
+
+// SearchKey - метод формирует ключ слепого индекса из ключа хранилища
+// (отдельный ключ, чтобы значения индекса не раскрывали ключ шифрования)
+func SearchKey(key []byte) ([]byte, error) {
+	searchKey, err := hkdf.Key(sha256.New, key, nil, searchKeyInfo, dataKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive search key: %w", err)
+	}
+	return searchKey, nil
+}
+
+// BlindIndex - метод вычисляет значение слепого индекса для нормализованного слова
+func BlindIndex(searchKey []byte, term string) []byte {
+	mac := hmac.New(sha256.New, searchKey)
+	mac.Write([]byte(term))
+	return mac.Sum(nil)[:blindIndexLen]
+}
+
+// SearchTerms - метод разбивает текст на слова для поиска: нижний регистр,
+// разделители - все символы, кроме букв и цифр, повторы отбрасываются
+func SearchTerms(texts ...string) []string {
+	seen := make(map[string]struct{})
+	var terms []string
+	for _, text := range texts {
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			if _, ok := seen[word]; ok {
+				continue
+			}
+			seen[word] = struct{}{}
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// BlindTokens - метод формирует значения слепого индекса для слов текстов (названия, тегов)
+func BlindTokens(key []byte, texts ...string) ([][]byte, error) {
+	searchKey, err := SearchKey(key)
+	if err != nil {
+		return nil, err
+	}
+	terms := SearchTerms(texts...)
+	tokens := make([][]byte, 0, len(terms))
+	for _, term := range terms {
+		tokens = append(tokens, BlindIndex(searchKey, term))
+	}
+	return tokens, nil
+}
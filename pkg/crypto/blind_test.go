@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Input    []string
+		Expected []string
+	}{
+		{
+			TestName: "Success. Split and lower case",
+			Input:    []string{"GitHub Work-Account"},
+			Expected: []string{"github", "work", "account"},
+		},
+		{
+			TestName: "Success. Unicode and duplicates",
+			Input:    []string{"Банк: Карта", "карта", "2FA"},
+			Expected: []string{"банк", "карта", "2fa"},
+		},
+		{
+			TestName: "Success. Only separators",
+			Input:    []string{" --- "},
+			Expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, SearchTerms(tc.Input...))
+		})
+	}
+}
+
+func TestBlindTokens(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err)
+	otherKey, err := GenerateDataKey()
+	require.NoError(t, err)
+
+	tokens, err := BlindTokens(key, "GitHub work")
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Len(t, tokens[0], blindIndexLen)
+
+	// запрос в другом регистре совпадает со значением индекса
+	query, err := BlindTokens(key, "github")
+	require.NoError(t, err)
+	assert.Equal(t, tokens[0], query[0])
+
+	// значение индекса не совпадает с открытым текстом и зависит от ключа
+	assert.NotEqual(t, []byte("github"), tokens[0])
+	other, err := BlindTokens(otherKey, "github")
+	require.NoError(t, err)
+	assert.NotEqual(t, tokens[0], other[0])
+}
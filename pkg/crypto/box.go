@@ -0,0 +1,161 @@
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	boxKeyLen      = 32                      // Длина открытого ключа X25519
+	boxKeyInfo     = "go-pass-keeper/box/v1" // Контекст для HKDF
+	dataKeyLen     = 32                      // Длина ключа содержимого (AES-256)
+	fingerprintLen = 16                      // Длина отпечатка открытого ключа (байт хеша SHA-256)
+)
+
+// GenerateKeyPair - метод генерирует пару ключей X25519 (открытый, закрытый)
+func GenerateKeyPair() ([]byte, []byte, error) {
+	private, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	return private.PublicKey().Bytes(), private.Bytes(), nil
+}
+
+// EncodeBoxKey - метод кодирует ключ X25519 в base64 для хранения в файле
+func EncodeBoxKey(key []byte) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// DecodePublicKey - метод декодирует открытый ключ X25519 из base64 с проверкой
+func DecodePublicKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if _, err := ecdh.X25519().NewPublicKey(key); err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return key, nil
+}
+
+// DecodePrivateKey - метод декодирует закрытый ключ X25519 из base64 с проверкой
+func DecodePrivateKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
+	}
+	if _, err := ecdh.X25519().NewPrivateKey(key); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return key, nil
+}
+
+// PublicKeyOf - метод вычисляет открытый ключ X25519 по закрытому
+func PublicKeyOf(privateKey []byte) ([]byte, error) {
+	private, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return private.PublicKey().Bytes(), nil
+}
+
+// KeyFingerprint - метод формирует отпечаток открытого ключа для сверки человеком
+// (начало хеша SHA-256 в hex, группами по 4 символа)
+func KeyFingerprint(publicKey []byte) string {
+	sum := sha256.Sum256(publicKey)
+	encoded := hex.EncodeToString(sum[:fingerprintLen])
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, ":")
+}
+
+// GenerateDataKey - метод генерирует случайный ключ для шифрования содержимого
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, dataKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	return key, nil
+}
+
+// SealKey - метод шифрует данные для владельца открытого ключа (эфемерный ECDH + HKDF + AES-GCM)
+func SealKey(publicKey []byte, data []byte) ([]byte, error) {
+	return SealKeyWithAD(publicKey, data, nil)
+}
+
+// SealKeyWithAD - метод шифрует данные для владельца открытого ключа с привязкой
+// к дополнительным аутентифицируемым данным ad (см. EncryptWithAD)
+func SealKeyWithAD(publicKey []byte, data []byte, ad []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	key, err := boxKey(ephemeral, recipient, ephemeral.PublicKey().Bytes(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := EncryptWithAD(key, data, ad)
+	if err != nil {
+		return nil, err
+	}
+
+	// Формируем итоговый результат: эфемерный открытый ключ + шифротекст
+	result := make([]byte, 0, boxKeyLen+len(sealed))
+	result = append(result, ephemeral.PublicKey().Bytes()...)
+	result = append(result, sealed...)
+	return result, nil
+}
+
+// OpenKey - метод расшифровывает данные, зашифрованные методом SealKey, закрытым ключом получателя
+func OpenKey(privateKey []byte, data []byte) ([]byte, error) {
+	return OpenKeyWithAD(privateKey, data, nil)
+}
+
+// OpenKeyWithAD - метод расшифровывает данные, зашифрованные методом SealKeyWithAD с теми же ad
+func OpenKeyWithAD(privateKey []byte, data []byte, ad []byte) ([]byte, error) {
+	if len(data) < boxKeyLen {
+		return nil, fmt.Errorf("sealed data too short")
+	}
+	private, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(data[:boxKeyLen])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+	key, err := boxKey(private, ephemeral, data[:boxKeyLen], private.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return DecryptWithAD(key, data[boxKeyLen:], ad)
+}
+
+// boxKey - метод формирует симметричный ключ из общего секрета ECDH
+func boxKey(private *ecdh.PrivateKey, public *ecdh.PublicKey, ephemeral []byte, recipient []byte) ([]byte, error) {
+	shared, err := private.ECDH(public)
+	if err != nil {
+		return nil, fmt.Errorf("ecdh failed: %w", err)
+	}
+	// в соль включаем оба открытых ключа, чтобы привязать ключ к конкретной паре
+	salt := make([]byte, 0, len(ephemeral)+len(recipient))
+	salt = append(salt, ephemeral...)
+	salt = append(salt, recipient...)
+	key, err := hkdf.Key(sha256.New, shared, salt, boxKeyInfo, dataKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive box key: %w", err)
+	}
+	return key, nil
+}
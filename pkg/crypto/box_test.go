@@ -0,0 +1,149 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	public1, private1, err := GenerateKeyPair()
+	require.NoError(t, err, "GenerateKeyPair failed")
+	require.Len(t, public1, 32, "Public key should be 32 bytes")
+	require.Len(t, private1, 32, "Private key should be 32 bytes")
+
+	public2, private2, err := GenerateKeyPair()
+	require.NoError(t, err, "Second GenerateKeyPair failed")
+	assert.NotEqual(t, public1, public2, "Public keys should be unique")
+	assert.NotEqual(t, private1, private2, "Private keys should be unique")
+}
+
+func TestSealOpenKey(t *testing.T) {
+	public, private, err := GenerateKeyPair()
+	require.NoError(t, err)
+	otherPublic, otherPrivate, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		TestName      string
+		SetupData     func() []byte
+		PrivateKey    []byte
+		ExpectedData  []byte
+		ExpectedError string
+	}{
+		{
+			TestName: "Success. Open sealed key",
+			SetupData: func() []byte {
+				sealed, err := SealKey(public, dataKey)
+				require.NoError(t, err)
+				return sealed
+			},
+			PrivateKey:    private,
+			ExpectedData:  dataKey,
+			ExpectedError: "",
+		},
+		{
+			TestName: "Error. Wrong private key",
+			SetupData: func() []byte {
+				sealed, err := SealKey(otherPublic, dataKey)
+				require.NoError(t, err)
+				return sealed
+			},
+			PrivateKey:    private,
+			ExpectedData:  nil,
+			ExpectedError: "decryption failed",
+		},
+		{
+			TestName: "Error. Tampered sealed data",
+			SetupData: func() []byte {
+				sealed, err := SealKey(otherPublic, dataKey)
+				require.NoError(t, err)
+				sealed[len(sealed)-1] ^= 0xFF
+				return sealed
+			},
+			PrivateKey:    otherPrivate,
+			ExpectedData:  nil,
+			ExpectedError: "decryption failed",
+		},
+		{
+			TestName: "Error. Data too short",
+			SetupData: func() []byte {
+				return []byte("short")
+			},
+			PrivateKey:    private,
+			ExpectedData:  nil,
+			ExpectedError: "sealed data too short",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			result, err := OpenKey(tc.PrivateKey, tc.SetupData())
+
+			if tc.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.ExpectedData, result)
+			}
+		})
+	}
+}
+
+func TestSealKeyInvalidPublicKey(t *testing.T) {
+	_, err := SealKey([]byte("invalid"), []byte("data"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid public key")
+}
+
+func TestSealOpenKeyWithAD(t *testing.T) {
+	public, private, err := GenerateKeyPair()
+	require.NoError(t, err)
+	dataKey, err := GenerateDataKey()
+	require.NoError(t, err)
+
+	sealed, err := SealKeyWithAD(public, dataKey, []byte("user-1"))
+	require.NoError(t, err)
+
+	opened, err := OpenKeyWithAD(private, sealed, []byte("user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, opened)
+
+	_, err = OpenKeyWithAD(private, sealed, []byte("user-2"))
+	assert.Error(t, err, "Sealed key must be bound to associated data")
+}
+
+func TestBoxKeyEncoding(t *testing.T) {
+	public, private, err := GenerateKeyPair()
+	require.NoError(t, err)
+
+	decodedPublic, err := DecodePublicKey(EncodeBoxKey(public) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, public, decodedPublic)
+
+	decodedPrivate, err := DecodePrivateKey(EncodeBoxKey(private))
+	require.NoError(t, err)
+	assert.Equal(t, private, decodedPrivate)
+
+	derived, err := PublicKeyOf(private)
+	require.NoError(t, err)
+	assert.Equal(t, public, derived)
+
+	_, err = DecodePublicKey("not base64!")
+	assert.Error(t, err)
+	_, err = DecodePublicKey(EncodeBoxKey([]byte("short")))
+	assert.Error(t, err)
+
+	fingerprint := KeyFingerprint(public)
+	assert.Len(t, fingerprint, 39, "Fingerprint is 8 groups of 4 hex digits")
+	assert.Equal(t, fingerprint, KeyFingerprint(public))
+	otherPublic, _, err := GenerateKeyPair()
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, KeyFingerprint(otherPublic))
+}
@@ -7,15 +7,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
-
-	"golang.org/x/crypto/scrypt"
 )
 
 const (
-	scryptN      = 32768 // Параметр стоимости CPU (итерации)
-	scryptR      = 8     // Параметр размера блока
-	scryptP      = 1     // Параметр параллелизма
-	scryptKeyLen = 32    // Длина ключа
+	scryptLogN   = 15              // log2 параметра стоимости CPU
+	scryptN      = 1 << scryptLogN // Параметр стоимости CPU (итерации)
+	scryptR      = 8               // Параметр размера блока
+	scryptP      = 1               // Параметр параллелизма
+	scryptKeyLen = 32              // Длина ключа
 )
 
 // GenerateSalt - метод генерирует криптографически безопасную случайную соль длинной 16 байт .
@@ -27,23 +26,26 @@ func GenerateSalt() (string, error) {
 	return base64.StdEncoding.EncodeToString(salt), nil
 }
 
-// MakeCryptoKey - метод формирует ключ из пароля и соли
+// MakeCryptoKey - метод формирует ключ из пароля и соли с параметрами LegacyKDFParams
 func MakeCryptoKey(password string, salt string) ([]byte, error) {
-	saltBytes, err := base64.StdEncoding.DecodeString(salt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode salt: %w", err)
-	}
-
-	key, err := scrypt.Key([]byte(password), saltBytes, scryptN, scryptR, scryptP, scryptKeyLen)
-	if err != nil {
-		return nil, fmt.Errorf("failed to derive key: %w", err)
-	}
-
-	return key, nil
+	return DeriveKey(password, salt, LegacyKDFParams)
 }
 
 // Encrypt - метод шифрует данные используя ключ на основе пароля и соли
+// (результат содержит заголовок с алгоритмом шифрования DefaultCipher)
 func Encrypt(key []byte, data []byte) ([]byte, error) {
+	return EncryptWithAD(key, data, nil)
+}
+
+// Decrypt - метод расшифровывает данные используя ключ на основе пароля и соли
+// (в том числе сохранённые в исходном формате без заголовка)
+func Decrypt(key []byte, data []byte) ([]byte, error) {
+	return DecryptWithAD(key, data, nil)
+}
+
+// seal - метод шифрует данные AES-256-GCM с дополнительными аутентифицируемыми данными ad
+// в исходном формате nonce || ciphertext (без заголовка)
+func seal(key []byte, data []byte, ad []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, fmt.Errorf("aes.NewCipher failed: %w", err)
@@ -59,7 +61,7 @@ func Encrypt(key []byte, data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("nonce generation failed: %w", err)
 	}
 
-	ciphertext := gcm.Seal(nil, nonce, data, nil)
+	ciphertext := gcm.Seal(nil, nonce, data, ad)
 
 	// Формируем итоговый результат: nonce + ciphertext (включая tag)
 	result := make([]byte, len(nonce)+len(ciphertext))
@@ -69,8 +71,8 @@ func Encrypt(key []byte, data []byte) ([]byte, error) {
 	return result, nil
 }
 
-// Decrypt - метод расшифровывает данные используя ключ на основе пароля и соли
-func Decrypt(key []byte, data []byte) ([]byte, error) {
+// open - метод расшифровывает данные исходного формата AES-256-GCM с дополнительными данными ad
+func open(key []byte, data []byte, ad []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, fmt.Errorf("aes.NewCipher failed: %w", err)
@@ -90,7 +92,7 @@ func Decrypt(key []byte, data []byte) ([]byte, error) {
 	nonce := data[:nonceSize]
 	ciphertext := data[nonceSize:]
 
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, ad)
 	if err != nil {
 		return nil, fmt.Errorf("decryption failed: %w", err)
 	}
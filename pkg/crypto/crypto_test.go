@@ -197,10 +197,8 @@ func TestDecrypt(t *testing.T) {
 			SetupData: func() []byte {
 				encrypted, err := Encrypt(key, testData)
 				require.NoError(t, err)
-				// Изменяем nonce (первые 12 байт для GCM)
-				if len(encrypted) >= 12 {
-					encrypted[0] ^= 0xFF
-				}
+				// Изменяем nonce (следует за заголовком)
+				encrypted[headerSize] ^= 0xFF
 				return encrypted
 			},
 			Key:           key,
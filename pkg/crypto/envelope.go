@@ -0,0 +1,239 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Формат шифротекста (конверта):
+//
+//	magic(3) || version(1) || cipher(1) || kdf(1) || параметры kdf || nonce || ciphertext
+//
+// Заголовок вместе с параметрами kdf аутентифицируется вместе с дополнительными данными,
+// поэтому подменить алгоритм или параметры незаметно нельзя. Шифротексты без заголовка
+// сохранены в исходном формате AES-256-GCM и по-прежнему расшифровываются.
+
+// Cipher - идентификатор алгоритма шифрования в заголовке шифротекста
+type Cipher byte
+
+const (
+	CipherAES256GCM         Cipher = 1 // AES-256-GCM (nonce 12 байт)
+	CipherXChaCha20Poly1305 Cipher = 2 // XChaCha20-Poly1305 (nonce 24 байта)
+)
+
+// DefaultCipher - алгоритм шифрования новых данных
+const DefaultCipher = CipherXChaCha20Poly1305
+
+// KDF - идентификатор функции получения ключа в заголовке шифротекста
+type KDF byte
+
+const (
+	KDFNone     KDF = 0 // ключ передан вызывающим и используется как есть
+	KDFScrypt   KDF = 1 // ключ получен из пароля scrypt (соль и параметры в заголовке)
+	KDFArgon2id KDF = 2 // ключ получен из пароля Argon2id (соль и параметры в заголовке)
+)
+
+const (
+	envelopeVersion byte = 1
+	headerSize           = 6
+	saltSize             = 16
+)
+
+// envelopeMagic - признак шифротекста с заголовком
+var envelopeMagic = []byte("GPK")
+
+var (
+	// ErrUnsupportedEnvelope - версия, алгоритм или функция получения ключа не поддерживаются
+	ErrUnsupportedEnvelope = errors.New("unsupported envelope")
+	// ErrPasswordRequired - шифротекст зашифрован ключом, полученным из пароля
+	ErrPasswordRequired = errors.New("envelope is encrypted with password")
+)
+
+// Header - заголовок шифротекста
+type Header struct {
+	Version byte
+	Cipher  Cipher
+	KDF     KDF
+}
+
+// ParseHeader - метод читает заголовок шифротекста.
+// Для шифротекстов исходного формата (без заголовка) возвращает false.
+func ParseHeader(data []byte) (Header, bool) {
+	if len(data) < headerSize || !bytes.Equal(data[:len(envelopeMagic)], envelopeMagic) {
+		return Header{}, false
+	}
+	return Header{Version: data[3], Cipher: Cipher(data[4]), KDF: KDF(data[5])}, true
+}
+
+// NeedsUpgrade - метод проверяет, сохранён ли шифротекст в исходном формате или устаревшим
+// алгоритмом, то есть его следует перешифровать при следующем изменении
+func NeedsUpgrade(data []byte) bool {
+	h, ok := ParseHeader(data)
+	return !ok || h.Version != envelopeVersion || h.Cipher != DefaultCipher
+}
+
+// EncryptWithCipher - метод шифрует данные алгоритмом c с дополнительными данными ad
+func EncryptWithCipher(c Cipher, key []byte, data []byte, ad []byte) ([]byte, error) {
+	return sealEnvelope(c, key, header(c, KDFNone), data, ad)
+}
+
+// EncryptWithPassword - метод шифрует данные ключом, полученным из пароля функцией DefaultKDFParams.
+// Соль и параметры функции сохраняются в заголовке, поэтому для расшифровки достаточно пароля.
+func EncryptWithPassword(password string, data []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	params := binary.BigEndian.AppendUint32(salt, DefaultKDFParams.Memory)
+	params = binary.BigEndian.AppendUint32(params, DefaultKDFParams.Iterations)
+	params = append(params, DefaultKDFParams.Parallelism)
+	key, err := passwordKey(KDFArgon2id, password, params)
+	if err != nil {
+		return nil, err
+	}
+	prefix := append(header(DefaultCipher, KDFArgon2id), params...)
+	return sealEnvelope(DefaultCipher, key, prefix, data, nil)
+}
+
+// DecryptWithPassword - метод расшифровывает данные, зашифрованные EncryptWithPassword
+// (в том числе прежними параметрами scrypt)
+func DecryptWithPassword(password string, data []byte) ([]byte, error) {
+	h, ok := ParseHeader(data)
+	if !ok || h.KDF == KDFNone {
+		return nil, fmt.Errorf("%w: password envelope expected", ErrUnsupportedEnvelope)
+	}
+	params, err := kdfParams(h.KDF, data[headerSize:])
+	if err != nil {
+		return nil, err
+	}
+	key, err := passwordKey(h.KDF, password, params)
+	if err != nil {
+		return nil, err
+	}
+	return openEnvelope(key, data, nil)
+}
+
+// header - метод формирует заголовок шифротекста
+func header(c Cipher, kdf KDF) []byte {
+	return append(append([]byte{}, envelopeMagic...), envelopeVersion, byte(c), byte(kdf))
+}
+
+// sealEnvelope - метод шифрует данные и формирует шифротекст с заголовком prefix
+func sealEnvelope(c Cipher, key []byte, prefix []byte, data []byte, ad []byte) ([]byte, error) {
+	aead, err := newAEAD(c, key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("nonce generation failed: %w", err)
+	}
+	result := make([]byte, 0, len(prefix)+len(nonce)+len(data)+aead.Overhead())
+	result = append(result, prefix...)
+	result = append(result, nonce...)
+	return aead.Seal(result, nonce, data, append(prefix[:len(prefix):len(prefix)], ad...)), nil
+}
+
+// openEnvelope - метод расшифровывает шифротекст с заголовком ключом key
+func openEnvelope(key []byte, data []byte, ad []byte) ([]byte, error) {
+	h, ok := ParseHeader(data)
+	if !ok {
+		return nil, fmt.Errorf("encrypted data too short")
+	}
+	if h.Version != envelopeVersion {
+		return nil, fmt.Errorf("%w: version %d", ErrUnsupportedEnvelope, h.Version)
+	}
+	params, err := kdfParams(h.KDF, data[headerSize:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(h.Cipher, key)
+	if err != nil {
+		return nil, err
+	}
+	prefixSize := headerSize + len(params)
+	if len(data) < prefixSize+aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted data too short")
+	}
+	prefix := data[:prefixSize:prefixSize]
+	nonce := data[prefixSize : prefixSize+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, data[prefixSize+aead.NonceSize():], append(prefix, ad...))
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+	return plaintext, nil
+}
+
+// newAEAD - метод создаёт шифр по его идентификатору
+func newAEAD(c Cipher, key []byte) (cipher.AEAD, error) {
+	if len(key) != scryptKeyLen {
+		return nil, fmt.Errorf("invalid key size %d", len(key))
+	}
+	switch c {
+	case CipherAES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("aes.NewCipher failed: %w", err)
+		}
+		return cipher.NewGCM(block)
+	case CipherXChaCha20Poly1305:
+		return chacha20poly1305.NewX(key)
+	default:
+		return nil, fmt.Errorf("%w: cipher %d", ErrUnsupportedEnvelope, c)
+	}
+}
+
+// kdfParams - метод возвращает параметры функции получения ключа, следующие за заголовком
+func kdfParams(kdf KDF, data []byte) ([]byte, error) {
+	var size int
+	switch kdf {
+	case KDFNone:
+		return nil, nil
+	case KDFScrypt:
+		size = saltSize + 3 // соль, log2(N), r, p
+	case KDFArgon2id:
+		size = saltSize + 9 // соль, память (КиБ), количество проходов, количество потоков
+	default:
+		return nil, fmt.Errorf("%w: kdf %d", ErrUnsupportedEnvelope, kdf)
+	}
+	if len(data) < size {
+		return nil, fmt.Errorf("encrypted data too short")
+	}
+	return data[:size:size], nil
+}
+
+// passwordKey - метод получает ключ из пароля по параметрам функции kdf из заголовка
+func passwordKey(kdf KDF, password string, params []byte) ([]byte, error) {
+	salt := params[:saltSize]
+	var p KDFParams
+	switch kdf {
+	case KDFScrypt:
+		logN, r := params[saltSize], params[saltSize+1]
+		if logN == 0 || logN > maxScryptLogN || r == 0 {
+			return nil, fmt.Errorf("%w: scrypt cost %d r=%d", ErrInvalidKDF, logN, r)
+		}
+		// объём памяти (128*N*r байт) вычисляется без переполнения и ограничивается до вызова scrypt
+		n := uint64(1) << logN
+		memory := 128 * n * uint64(r)
+		if memory%1024 != 0 || memory/1024 > maxKDFMemory {
+			return nil, fmt.Errorf("%w: scrypt N=%d r=%d", ErrInvalidKDF, n, r)
+		}
+		p = KDFParams{Algorithm: KDFAlgorithmScrypt, Iterations: uint32(n), Memory: uint32(memory / 1024), Parallelism: params[saltSize+2]}
+	case KDFArgon2id:
+		p = KDFParams{
+			Algorithm:   KDFAlgorithmArgon2id,
+			Memory:      binary.BigEndian.Uint32(params[saltSize:]),
+			Iterations:  binary.BigEndian.Uint32(params[saltSize+4:]),
+			Parallelism: params[saltSize+8],
+		}
+	}
+	return deriveKey([]byte(password), salt, p)
+}
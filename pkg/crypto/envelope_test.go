@@ -0,0 +1,138 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptWithCipher(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err, "GenerateDataKey failed")
+
+	testCases := []struct {
+		Name          string
+		Cipher        Cipher
+		ExpectedError bool
+	}{
+		{
+			Name:   "Success. AES-256-GCM #1",
+			Cipher: CipherAES256GCM,
+		},
+		{
+			Name:   "Success. XChaCha20-Poly1305 #2",
+			Cipher: CipherXChaCha20Poly1305,
+		},
+		{
+			Name:          "Error. Unknown cipher #3",
+			Cipher:        Cipher(42),
+			ExpectedError: true,
+		},
+	}
+
+	ad := AssociatedData("user", "secret-1", "text")
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			data, err := EncryptWithCipher(tc.Cipher, key, []byte("Тестовое сообщение"), ad)
+			if tc.ExpectedError {
+				require.ErrorIs(t, err, ErrUnsupportedEnvelope)
+				return
+			}
+			require.NoError(t, err, "EncryptWithCipher failed")
+
+			h, ok := ParseHeader(data)
+			require.True(t, ok, "header expected")
+			assert.Equal(t, Header{Version: envelopeVersion, Cipher: tc.Cipher, KDF: KDFNone}, h)
+			assert.Equal(t, tc.Cipher != DefaultCipher, NeedsUpgrade(data))
+
+			decrypted, err := DecryptWithAD(key, data, ad)
+			require.NoError(t, err, "DecryptWithAD failed")
+			assert.Equal(t, []byte("Тестовое сообщение"), decrypted)
+		})
+	}
+}
+
+func TestEnvelopeHeaderTampering(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err, "GenerateDataKey failed")
+
+	data, err := EncryptWithCipher(CipherAES256GCM, key, []byte("secret"), nil)
+	require.NoError(t, err, "EncryptWithCipher failed")
+
+	testCases := []struct {
+		Name   string
+		Tamper func(data []byte)
+		Error  error
+	}{
+		{
+			Name:   "Error. Other version #1",
+			Tamper: func(data []byte) { data[3] = 2 },
+			Error:  ErrUnsupportedEnvelope,
+		},
+		{
+			Name:   "Error. Unknown kdf #2",
+			Tamper: func(data []byte) { data[5] = 42 },
+			Error:  ErrPasswordRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tampered := append([]byte{}, data...)
+			tc.Tamper(tampered)
+			_, err := Decrypt(key, tampered)
+			require.ErrorIs(t, err, tc.Error)
+		})
+	}
+}
+
+func TestEncryptWithPassword(t *testing.T) {
+	data, err := EncryptWithPassword("passphrase", []byte("archive"))
+	require.NoError(t, err, "EncryptWithPassword failed")
+
+	h, ok := ParseHeader(data)
+	require.True(t, ok, "header expected")
+	assert.Equal(t, KDFArgon2id, h.KDF)
+
+	decrypted, err := DecryptWithPassword("passphrase", data)
+	require.NoError(t, err, "DecryptWithPassword failed")
+	assert.Equal(t, []byte("archive"), decrypted)
+
+	_, err = DecryptWithPassword("wrong", data)
+	require.Error(t, err, "wrong password should fail")
+
+	// изменение параметров scrypt в заголовке обнаруживается
+	tampered := append([]byte{}, data...)
+	tampered[headerSize] ^= 0xFF
+	_, err = DecryptWithPassword("passphrase", tampered)
+	require.Error(t, err, "tampered salt should fail")
+
+	key, err := GenerateDataKey()
+	require.NoError(t, err, "GenerateDataKey failed")
+	plain, err := Encrypt(key, []byte("archive"))
+	require.NoError(t, err, "Encrypt failed")
+	_, err = DecryptWithPassword("passphrase", plain)
+	require.ErrorIs(t, err, ErrUnsupportedEnvelope)
+}
+
+func TestDecryptWithPasswordScrypt(t *testing.T) {
+	// шифротекст с параметрами scrypt в заголовке (соль, log2(N), r, p)
+	params := append(make([]byte, saltSize), scryptLogN, scryptR, scryptP)
+	key, err := passwordKey(KDFScrypt, "passphrase", params)
+	require.NoError(t, err, "passwordKey failed")
+	data, err := sealEnvelope(DefaultCipher, key, append(header(DefaultCipher, KDFScrypt), params...), []byte("archive"), nil)
+	require.NoError(t, err, "sealEnvelope failed")
+
+	decrypted, err := DecryptWithPassword("passphrase", data)
+	require.NoError(t, err, "DecryptWithPassword failed")
+	assert.Equal(t, []byte("archive"), decrypted)
+
+	// чрезмерная стоимость отклоняется без вычисления ключа
+	for _, cost := range [][2]byte{{30, scryptR}, {31, 255}, {maxScryptLogN, 255}, {scryptLogN, 0}, {1, 1}} {
+		tampered := append([]byte{}, data...)
+		tampered[headerSize+saltSize], tampered[headerSize+saltSize+1] = cost[0], cost[1]
+		_, err = DecryptWithPassword("passphrase", tampered)
+		require.ErrorIs(t, err, ErrInvalidKDF, "N=2^%d r=%d", cost[0], cost[1])
+	}
+}
@@ -0,0 +1,117 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	KDFAlgorithmScrypt   = "scrypt"
+	KDFAlgorithmArgon2id = "argon2id"
+)
+
+// Ограничения параметров, полученных от сервера или из заголовка шифротекста
+const (
+	maxKDFMemory      = 1 << 20 // КиБ (1 ГиБ)
+	maxKDFIterations  = 64
+	maxKDFParallelism = 16
+	maxScryptLogN     = 20
+	maxScryptN        = 1 << maxScryptLogN
+)
+
+// ErrInvalidKDF - недопустимые параметры функции получения ключа
+var ErrInvalidKDF = errors.New("invalid kdf parameters")
+
+// KDFParams - описание функции получения ключа из пароля
+type KDFParams struct {
+	Algorithm   string `json:"algorithm"`
+	Memory      uint32 `json:"memory"`      // объём памяти, КиБ
+	Iterations  uint32 `json:"iterations"`  // количество проходов (для scrypt - параметр N)
+	Parallelism uint8  `json:"parallelism"` // количество потоков (для scrypt - параметр p)
+}
+
+var (
+	// LegacyKDFParams - параметры scrypt, которыми получены ключи до появления описания функции
+	LegacyKDFParams = KDFParams{
+		Algorithm:   KDFAlgorithmScrypt,
+		Memory:      128 * scryptN * scryptR / 1024,
+		Iterations:  scryptN,
+		Parallelism: scryptP,
+	}
+	// DefaultKDFParams - параметры Argon2id для новых ключей (RFC 9106, второй рекомендуемый вариант)
+	DefaultKDFParams = KDFParams{
+		Algorithm:   KDFAlgorithmArgon2id,
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 4,
+	}
+)
+
+// Validate - метод проверяет параметры (ограничивает стоимость, чтобы параметры,
+// полученные извне, не могли исчерпать память или время клиента)
+func (p KDFParams) Validate() error {
+	if p.Parallelism == 0 || p.Parallelism > maxKDFParallelism || p.Memory == 0 || p.Memory > maxKDFMemory {
+		return fmt.Errorf("%w: %s m=%d t=%d p=%d", ErrInvalidKDF, p.Algorithm, p.Memory, p.Iterations, p.Parallelism)
+	}
+	switch p.Algorithm {
+	case KDFAlgorithmArgon2id:
+		if p.Iterations == 0 || p.Iterations > maxKDFIterations || p.Memory < 8*uint32(p.Parallelism) {
+			return fmt.Errorf("%w: argon2id t=%d m=%d", ErrInvalidKDF, p.Iterations, p.Memory)
+		}
+	case KDFAlgorithmScrypt:
+		n := p.Iterations
+		if n < 2 || n > maxScryptN || n&(n-1) != 0 || (uint64(p.Memory)*1024)%(128*uint64(n)) != 0 {
+			return fmt.Errorf("%w: scrypt N=%d m=%d", ErrInvalidKDF, n, p.Memory)
+		}
+	default:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidKDF, p.Algorithm)
+	}
+	return nil
+}
+
+// Weaker - метод проверяет, слабее ли параметры target: Argon2id сильнее scrypt,
+// для одного алгоритма сравниваются объём памяти и количество проходов
+func (p KDFParams) Weaker(target KDFParams) bool {
+	if p.Algorithm != target.Algorithm {
+		return p.Algorithm != KDFAlgorithmArgon2id && target.Algorithm == KDFAlgorithmArgon2id
+	}
+	return p.Memory < target.Memory || p.Iterations < target.Iterations
+}
+
+// DeriveKey - метод получает ключ из пароля и соли (base64) функцией с параметрами params
+func DeriveKey(password string, salt string, params KDFParams) ([]byte, error) {
+	secret := []byte(password)
+	defer clear(secret)
+	return DeriveKeyBytes(secret, salt, params)
+}
+
+// DeriveKeyBytes - метод получает ключ из пароля, переданного срезом байтов (пароль из защищённой
+// памяти не копируется в неизменяемую строку), и соли (base64) функцией с параметрами params
+func DeriveKeyBytes(password []byte, salt string, params KDFParams) ([]byte, error) {
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode salt: %w", err)
+	}
+	return deriveKey(password, saltBytes, params)
+}
+
+// deriveKey - метод получает ключ из пароля и соли функцией с параметрами params
+func deriveKey(password []byte, salt []byte, params KDFParams) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if params.Algorithm == KDFAlgorithmArgon2id {
+		return argon2.IDKey(password, salt, params.Iterations, params.Memory, params.Parallelism, scryptKeyLen), nil
+	}
+	n := int(params.Iterations)
+	r := int(uint64(params.Memory) * 1024 / (128 * uint64(n)))
+	key, err := scrypt.Key(password, salt, n, r, int(params.Parallelism), scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
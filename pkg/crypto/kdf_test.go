@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestKDFParamsValidate(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Params        KDFParams
+		ExpectedError bool
+	}{
+		{
+			Name:   "Success. Default #1",
+			Params: DefaultKDFParams,
+		},
+		{
+			Name:   "Success. Legacy #2",
+			Params: LegacyKDFParams,
+		},
+		{
+			Name:          "Error. Unknown algorithm #3",
+			Params:        KDFParams{Algorithm: "md5", Memory: 1024, Iterations: 1, Parallelism: 1},
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. Too much memory #4",
+			Params:        KDFParams{Algorithm: KDFAlgorithmArgon2id, Memory: maxKDFMemory + 1, Iterations: 3, Parallelism: 4},
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. No iterations #5",
+			Params:        KDFParams{Algorithm: KDFAlgorithmArgon2id, Memory: 1024, Iterations: 0, Parallelism: 4},
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. Scrypt N is not power of two #6",
+			Params:        KDFParams{Algorithm: KDFAlgorithmScrypt, Memory: 1024, Iterations: 1000, Parallelism: 1},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Params.Validate()
+			if tc.ExpectedError {
+				require.ErrorIs(t, err, ErrInvalidKDF)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestKDFParamsWeaker(t *testing.T) {
+	stronger := DefaultKDFParams
+	stronger.Memory *= 2
+
+	assert.True(t, LegacyKDFParams.Weaker(DefaultKDFParams), "scrypt is weaker than argon2id")
+	assert.False(t, DefaultKDFParams.Weaker(LegacyKDFParams), "argon2id is not weaker than scrypt")
+	assert.True(t, DefaultKDFParams.Weaker(stronger), "less memory is weaker")
+	assert.False(t, stronger.Weaker(DefaultKDFParams), "more memory is not weaker")
+	assert.False(t, DefaultKDFParams.Weaker(DefaultKDFParams), "same parameters are not weaker")
+}
+
+func TestDeriveKey(t *testing.T) {
+	const salt = "MDEyMzQ1Njc4OTAxMjM0NQ=="
+	params := KDFParams{Algorithm: KDFAlgorithmArgon2id, Memory: 1024, Iterations: 1, Parallelism: 1}
+
+	key1, err := DeriveKey("password", salt, params)
+	require.NoError(t, err, "DeriveKey failed")
+	require.Len(t, key1, 32)
+
+	key2, err := DeriveKey("password", salt, params)
+	require.NoError(t, err, "DeriveKey failed")
+	assert.Equal(t, key1, key2, "derived keys should be identical for same inputs")
+
+	params.Iterations = 2
+	key3, err := DeriveKey("password", salt, params)
+	require.NoError(t, err, "DeriveKey failed")
+	assert.NotEqual(t, key1, key3, "derived keys should depend on parameters")
+
+	password := []byte("password")
+	params.Iterations = 1
+	key4, err := DeriveKeyBytes(password, salt, params)
+	require.NoError(t, err, "DeriveKeyBytes failed")
+	assert.Equal(t, key1, key4, "password bytes must derive the same key")
+	assert.Equal(t, []byte("password"), password, "password bytes must stay intact")
+
+	// ключ по прежним параметрам совпадает с ключом scrypt(N=32768, r=8, p=1)
+	legacy, err := DeriveKey("password", salt, LegacyKDFParams)
+	require.NoError(t, err, "DeriveKey failed")
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	require.NoError(t, err)
+	expected, err := scrypt.Key([]byte("password"), saltBytes, 32768, 8, 1, 32)
+	require.NoError(t, err, "scrypt.Key failed")
+	assert.Equal(t, expected, legacy)
+}
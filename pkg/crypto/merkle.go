@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Дерево Меркла в форме RFC 6962: хеши листьев и узлов различаются префиксом,
+// поэтому лист нельзя выдать за узел (и наоборот), а порядок листьев значим.
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+// integrityKeyInfo - контекст для HKDF ключа подписи состояния хранилища
+const integrityKeyInfo = "go-pass-keeper/integrity/v1"
+
+// IntegritySize - размер подписи состояния хранилища
+const IntegritySize = sha256.Size
+
+// ContentHash - метод вычисляет хеш содержимого секрета (SHA-256 шифротекста клиента)
+func ContentHash(content []byte) []byte {
+	sum := sha256.Sum256(content)
+	return sum[:]
+}
+
+// MerkleLeaf - метод вычисляет хеш листа дерева Меркла
+func MerkleLeaf(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleLeafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// MerkleRoot - метод вычисляет корень дерева Меркла по хешам листьев
+// (дерево пустого списка - хеш пустой строки)
+func MerkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	}
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	// левое поддерево - наибольшая степень двойки, меньшая количества листьев
+	split := 1
+	for split*2 < len(leaves) {
+		split *= 2
+	}
+	h := sha256.New()
+	h.Write([]byte{merkleNodePrefix})
+	h.Write(MerkleRoot(leaves[:split]))
+	h.Write(MerkleRoot(leaves[split:]))
+	return h.Sum(nil)
+}
+
+// IntegrityKey - метод формирует ключ подписи состояния хранилища из ключа хранилища
+// (отдельный ключ, чтобы подпись не раскрывала ключ шифрования)
+func IntegrityKey(key []byte) ([]byte, error) {
+	integrityKey, err := hkdf.Key(sha256.New, key, nil, integrityKeyInfo, dataKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive integrity key: %w", err)
+	}
+	return integrityKey, nil
+}
+
+// SignIntegrity - метод вычисляет подпись (HMAC-SHA256) данных ключом подписи состояния хранилища
+func SignIntegrity(integrityKey []byte, data []byte) []byte {
+	mac := hmac.New(sha256.New, integrityKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// VerifyIntegrity - метод проверяет подпись данных за постоянное время
+func VerifyIntegrity(integrityKey []byte, data []byte, signature []byte) bool {
+	return hmac.Equal(SignIntegrity(integrityKey, data), signature)
+}
@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := make([][]byte, 5)
+	for i := range leaves {
+		leaves[i] = MerkleLeaf([]byte{byte(i)})
+	}
+	node := func(l, r []byte) []byte {
+		h := sha256.New()
+		h.Write([]byte{merkleNodePrefix})
+		h.Write(l)
+		h.Write(r)
+		return h.Sum(nil)
+	}
+	empty := sha256.Sum256(nil)
+
+	testCases := []struct {
+		TestName string
+		Leaves   [][]byte
+		Expected []byte
+	}{
+		{
+			TestName: "Empty tree",
+			Leaves:   nil,
+			Expected: empty[:],
+		},
+		{
+			TestName: "Single leaf",
+			Leaves:   leaves[:1],
+			Expected: leaves[0],
+		},
+		{
+			TestName: "Three leaves",
+			Leaves:   leaves[:3],
+			Expected: node(node(leaves[0], leaves[1]), leaves[2]),
+		},
+		{
+			TestName: "Five leaves",
+			Leaves:   leaves,
+			Expected: node(node(node(leaves[0], leaves[1]), node(leaves[2], leaves[3])), leaves[4]),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, MerkleRoot(tc.Leaves))
+		})
+	}
+
+	// порядок листьев значим, лист не совпадает с узлом из тех же данных
+	assert.NotEqual(t, MerkleRoot(leaves[:2]), MerkleRoot([][]byte{leaves[1], leaves[0]}))
+	assert.NotEqual(t, MerkleLeaf(append(append([]byte{}, leaves[0]...), leaves[1]...)), MerkleRoot(leaves[:2]))
+}
+
+func TestSignIntegrity(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err)
+	integrityKey, err := IntegrityKey(key)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, integrityKey)
+
+	data := []byte("vault state")
+	signature := SignIntegrity(integrityKey, data)
+	assert.True(t, VerifyIntegrity(integrityKey, data, signature))
+	assert.False(t, VerifyIntegrity(integrityKey, []byte("vault statE"), signature))
+
+	otherKey, err := IntegrityKey(append([]byte{}, key[1:]...))
+	require.NoError(t, err)
+	assert.False(t, VerifyIntegrity(otherKey, data, signature))
+
+	assert.Len(t, ContentHash([]byte("content")), sha256.Size)
+}
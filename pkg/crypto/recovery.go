@@ -0,0 +1,184 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ключ восстановления - случайное значение высокой энтропии, которым независимо от секрета
+// шифруется ключ хранилища. Пользователю он показывается кодом: значение и контрольная сумма
+// в base32, группами по 4 символа. Ключ шифрования получается из значения HKDF
+// (медленная функция получения ключа из пароля не нужна).
+
+const (
+	recoveryKeyInfo      = "go-pass-keeper/recovery/v1" // Контекст для HKDF
+	recoveryKeySize      = 20                           // Размер ключа восстановления (160 бит)
+	recoveryChecksumSize = 2                            // Размер контрольной суммы кода (обнаружение опечаток)
+	recoveryGroupSize    = 4                            // Количество символов в группе кода
+)
+
+// ErrInvalidRecoveryCode - код восстановления введён с ошибкой
+var ErrInvalidRecoveryCode = errors.New("invalid recovery code")
+
+var (
+	recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+	// recoveryNormalizer - приведение кода к алфавиту base32 (разделители удаляются,
+	// похожие цифры заменяются буквами)
+	recoveryNormalizer = strings.NewReplacer("-", "", " ", "", "0", "O", "1", "I", "8", "B")
+)
+
+// GenerateRecoveryKey - метод генерирует случайный ключ восстановления
+func GenerateRecoveryKey() ([]byte, error) {
+	key := make([]byte, recoveryKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate recovery key: %w", err)
+	}
+	return key, nil
+}
+
+// FormatRecoveryCode - метод формирует код восстановления для показа пользователю
+func FormatRecoveryCode(key []byte) string {
+	sum := sha256.Sum256(key)
+	return formatCode(append(key[:len(key):len(key)], sum[:recoveryChecksumSize]...))
+}
+
+// formatCode - метод кодирует данные в base32 группами по 4 символа
+func formatCode(data []byte) string {
+	encoded := recoveryEncoding.EncodeToString(data)
+	groups := make([]string, 0, len(encoded)/recoveryGroupSize+1)
+	for len(encoded) > recoveryGroupSize {
+		groups = append(groups, encoded[:recoveryGroupSize])
+		encoded = encoded[recoveryGroupSize:]
+	}
+	return strings.Join(append(groups, encoded), "-")
+}
+
+// parseCode - метод декодирует код, сформированный formatCode (регистр и разделители не важны)
+func parseCode(code string) ([]byte, error) {
+	return recoveryEncoding.DecodeString(recoveryNormalizer.Replace(strings.ToUpper(code)))
+}
+
+// ParseRecoveryCode - метод получает ключ восстановления из кода (регистр и разделители не важны)
+func ParseRecoveryCode(code string) ([]byte, error) {
+	data, err := parseCode(code)
+	if err != nil || len(data) != recoveryKeySize+recoveryChecksumSize {
+		return nil, ErrInvalidRecoveryCode
+	}
+	key, checksum := data[:recoveryKeySize], data[recoveryKeySize:]
+	sum := sha256.Sum256(key)
+	if !bytes.Equal(sum[:recoveryChecksumSize], checksum) {
+		return nil, ErrInvalidRecoveryCode
+	}
+	return key, nil
+}
+
+// RecoveryWrappingKey - метод получает из ключа восстановления ключ для шифрования ключа хранилища
+func RecoveryWrappingKey(key []byte) ([]byte, error) {
+	if len(key) != recoveryKeySize {
+		return nil, ErrInvalidRecoveryCode
+	}
+	wrappingKey, err := hkdf.Key(sha256.New, key, nil, recoveryKeyInfo, dataKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive recovery wrapping key: %w", err)
+	}
+	return wrappingKey, nil
+}
+
+// Часть ключа восстановления - код того же вида, что и код восстановления, с порогом,
+// номером части и идентификатором набора: threshold || x || set id || y || контрольная сумма.
+// Идентификатор набора не позволяет смешать части разных разделений одного или разных ключей.
+
+const recoverySetIDSize = 2 // Размер идентификатора набора частей
+
+var (
+	ErrInvalidRecoveryShare = errors.New("invalid recovery share")
+	ErrNotEnoughShares      = errors.New("not enough recovery shares")
+	ErrSharesMismatch       = errors.New("recovery shares belong to different sets")
+)
+
+// RecoveryShare - часть ключа восстановления
+type RecoveryShare struct {
+	Threshold int    // количество частей, необходимое для восстановления ключа
+	Index     int    // номер части
+	SetID     []byte // идентификатор набора частей
+	value     []byte // значение части по схеме Шамира (номер и значения многочленов)
+}
+
+// SplitRecoveryKey - метод разделяет ключ восстановления на n частей, любые k из которых
+// восстанавливают ключ (возвращает коды частей для показа пользователю)
+func SplitRecoveryKey(key []byte, n int, k int) ([]string, error) {
+	if len(key) != recoveryKeySize {
+		return nil, ErrInvalidRecoveryCode
+	}
+	shares, err := SplitSecret(key, n, k)
+	if err != nil {
+		return nil, err
+	}
+	setID := make([]byte, recoverySetIDSize)
+	if _, err := rand.Read(setID); err != nil {
+		return nil, fmt.Errorf("failed to generate share set id: %w", err)
+	}
+	codes := make([]string, 0, n)
+	for _, share := range shares {
+		data := make([]byte, 0, 2+recoverySetIDSize+recoveryKeySize+recoveryChecksumSize)
+		data = append(data, byte(k), share[0])
+		data = append(data, setID...)
+		data = append(data, share[1:]...)
+		sum := sha256.Sum256(data)
+		codes = append(codes, formatCode(append(data, sum[:recoveryChecksumSize]...)))
+		clear(share)
+	}
+	return codes, nil
+}
+
+// ParseRecoveryShare - метод получает часть ключа восстановления из кода (регистр и разделители не важны)
+func ParseRecoveryShare(code string) (*RecoveryShare, error) {
+	const size = 2 + recoverySetIDSize + recoveryKeySize
+	data, err := parseCode(code)
+	if err != nil || len(data) != size+recoveryChecksumSize {
+		return nil, ErrInvalidRecoveryShare
+	}
+	sum := sha256.Sum256(data[:size])
+	if !bytes.Equal(sum[:recoveryChecksumSize], data[size:]) || data[0] < 2 || data[1] == 0 {
+		return nil, ErrInvalidRecoveryShare
+	}
+	value := make([]byte, 0, 1+recoveryKeySize)
+	value = append(value, data[1])
+	value = append(value, data[2+recoverySetIDSize:size]...)
+	return &RecoveryShare{
+		Threshold: int(data[0]),
+		Index:     int(data[1]),
+		SetID:     data[2 : 2+recoverySetIDSize],
+		value:     value,
+	}, nil
+}
+
+// CombineRecoveryShares - метод восстанавливает ключ восстановления по частям
+// (частей одного набора должно быть не меньше порога)
+func CombineRecoveryShares(shares []*RecoveryShare) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	first := shares[0]
+	values := make([][]byte, 0, len(shares))
+	for _, share := range shares {
+		if share.Threshold != first.Threshold || !bytes.Equal(share.SetID, first.SetID) {
+			return nil, ErrSharesMismatch
+		}
+		values = append(values, share.value)
+	}
+	if len(values) < first.Threshold {
+		return nil, fmt.Errorf("%w: %d of %d", ErrNotEnoughShares, len(values), first.Threshold)
+	}
+	key, err := CombineShares(values[:first.Threshold])
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,149 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryCode(t *testing.T) {
+	key := []byte("0123456789abcdefghij")
+	code := FormatRecoveryCode(key)
+	require.Len(t, strings.Split(code, "-"), 9)
+
+	// опечатка в одном символе обнаруживается контрольной суммой
+	typo := []byte(code)
+	if typo[0] == 'A' {
+		typo[0] = 'B'
+	} else {
+		typo[0] = 'A'
+	}
+
+	testCases := []struct {
+		TestName      string
+		Code          string
+		ExpectedError bool
+	}{
+		{
+			TestName: "Success. Formatted code",
+			Code:     code,
+		},
+		{
+			TestName: "Success. Lower case without separators",
+			Code:     strings.ToLower(strings.ReplaceAll(code, "-", " ")),
+		},
+		{
+			TestName:      "Error. Typo",
+			Code:          string(typo),
+			ExpectedError: true,
+		},
+		{
+			TestName:      "Error. Truncated code",
+			Code:          code[:len(code)-5],
+			ExpectedError: true,
+		},
+		{
+			TestName:      "Error. Not base32",
+			Code:          "!!!!-!!!!",
+			ExpectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			parsed, err := ParseRecoveryCode(tc.Code)
+			if tc.ExpectedError {
+				assert.ErrorIs(t, err, ErrInvalidRecoveryCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, key, parsed)
+		})
+	}
+}
+
+func TestRecoveryWrappingKey(t *testing.T) {
+	key, err := GenerateRecoveryKey()
+	require.NoError(t, err, "GenerateRecoveryKey failed")
+	wrappingKey, err := RecoveryWrappingKey(key)
+	require.NoError(t, err, "RecoveryWrappingKey failed")
+	assert.Len(t, wrappingKey, dataKeyLen)
+	assert.NotEqual(t, key, wrappingKey[:recoveryKeySize])
+
+	_, err = RecoveryWrappingKey([]byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidRecoveryCode)
+}
+
+func TestRecoveryShares(t *testing.T) {
+	key := []byte("0123456789abcdefghij")
+	codes, err := SplitRecoveryKey(key, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, codes, 5)
+	require.Len(t, strings.Split(codes[0], "-"), 11)
+
+	otherCodes, err := SplitRecoveryKey(key, 5, 3)
+	require.NoError(t, err)
+
+	parse := func(codes ...string) []*RecoveryShare {
+		shares := make([]*RecoveryShare, 0, len(codes))
+		for _, code := range codes {
+			share, err := ParseRecoveryShare(code)
+			require.NoError(t, err)
+			shares = append(shares, share)
+		}
+		return shares
+	}
+
+	share, err := ParseRecoveryShare(strings.ToLower(codes[1]))
+	require.NoError(t, err)
+	assert.Equal(t, 3, share.Threshold)
+	assert.Equal(t, 2, share.Index)
+
+	testCases := []struct {
+		TestName      string
+		Shares        []*RecoveryShare
+		ExpectedError error
+	}{
+		{
+			TestName: "Success. Threshold shares",
+			Shares:   parse(codes[0], codes[2], codes[4]),
+		},
+		{
+			TestName: "Success. More shares than threshold",
+			Shares:   parse(codes[3], codes[1], codes[0], codes[2]),
+		},
+		{
+			TestName:      "Error. Not enough shares",
+			Shares:        parse(codes[0], codes[1]),
+			ExpectedError: ErrNotEnoughShares,
+		},
+		{
+			TestName:      "Error. Shares of different sets",
+			Shares:        parse(codes[0], codes[1], otherCodes[2]),
+			ExpectedError: ErrSharesMismatch,
+		},
+		{
+			TestName:      "Error. Duplicate share",
+			Shares:        parse(codes[0], codes[1], codes[0]),
+			ExpectedError: ErrDuplicateShares,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			result, err := CombineRecoveryShares(tc.Shares)
+			if tc.ExpectedError != nil {
+				assert.ErrorIs(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, key, result)
+		})
+	}
+
+	// код восстановления не принимается как часть и наоборот
+	_, err = ParseRecoveryShare(FormatRecoveryCode(key))
+	assert.ErrorIs(t, err, ErrInvalidRecoveryShare)
+	_, err = ParseRecoveryCode(codes[0])
+	assert.ErrorIs(t, err, ErrInvalidRecoveryCode)
+}
@@ -0,0 +1,113 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// Разделение секрета по схеме Шамира над полем GF(256): каждый байт секрета - свободный член
+// случайного многочлена степени k-1, часть - значения многочленов в точке x (1..n).
+// Любые k частей восстанавливают секрет интерполяцией Лагранжа в нуле, меньшее число частей
+// не даёт о нём никакой информации. Арифметика поля выполняется без таблиц и ветвлений по данным.
+
+// maxShares - максимальное количество частей (точка x = 0 зарезервирована под секрет)
+const maxShares = 255
+
+var (
+	ErrInvalidShares    = errors.New("invalid secret shares")
+	ErrDuplicateShares  = errors.New("duplicate secret shares")
+	ErrInvalidThreshold = errors.New("invalid shares threshold")
+)
+
+// SplitSecret - метод разделяет секрет на n частей, любые k из которых восстанавливают секрет.
+// Часть - номер точки x (первый байт) и значения многочленов в ней.
+func SplitSecret(secret []byte, n int, k int) ([][]byte, error) {
+	if k < 2 || k > n || n > maxShares {
+		return nil, fmt.Errorf("%w: %d of %d", ErrInvalidThreshold, k, n)
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][0] = byte(i + 1)
+	}
+	coefficients := make([]byte, k)
+	for i, b := range secret {
+		coefficients[0] = b
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("failed to generate polynomial: %w", err)
+		}
+		for _, share := range shares {
+			share[i+1] = gfEval(coefficients, share[0])
+		}
+	}
+	clear(coefficients)
+	return shares, nil
+}
+
+// CombineShares - метод восстанавливает секрет по частям, полученным SplitSecret
+// (частей должно быть не меньше порога, иначе результат будет неверным)
+func CombineShares(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, ErrInvalidShares
+	}
+	size := len(shares[0])
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if len(share) != size || size < 2 || share[0] == 0 {
+			return nil, ErrInvalidShares
+		}
+		if seen[share[0]] {
+			return nil, ErrDuplicateShares
+		}
+		seen[share[0]] = true
+	}
+
+	secret := make([]byte, size-1)
+	for j, share := range shares {
+		// базисный многочлен Лагранжа в нуле: произведение x_m / (x_m - x_j), m != j
+		basis := byte(1)
+		for m, other := range shares {
+			if m != j {
+				basis = gfMul(basis, gfMul(other[0], gfInv(other[0]^share[0])))
+			}
+		}
+		for i := range secret {
+			secret[i] ^= gfMul(share[i+1], basis)
+		}
+	}
+	return secret, nil
+}
+
+// gfEval - метод вычисляет значение многочлена в точке x по схеме Горнера
+func gfEval(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// gfMul - метод умножения в GF(256) по модулю многочлена x^8 + x^4 + x^3 + x + 1
+func gfMul(a byte, b byte) byte {
+	var p byte
+	for range 8 {
+		p ^= -(b & 1) & a
+		a = a<<1 ^ (0x1b & -(a >> 7))
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv - метод вычисления обратного элемента в GF(256): a^254 (для нуля - ноль)
+func gfInv(a byte) byte {
+	result := byte(1)
+	for range 7 {
+		a = gfMul(a, a)
+		result = gfMul(result, a)
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGFArithmetic(t *testing.T) {
+	// пример умножения из FIPS-197: {57} * {83} = {c1}
+	assert.Equal(t, byte(0xc1), gfMul(0x57, 0x83))
+	for a := 1; a < 256; a++ {
+		require.Equal(t, byte(1), gfMul(byte(a), gfInv(byte(a))), "a * a^-1 must be 1 for %d", a)
+	}
+	assert.Equal(t, byte(0), gfInv(0))
+}
+
+func TestSplitCombineSecret(t *testing.T) {
+	secret := []byte("0123456789abcdefghij")
+	shares, err := SplitSecret(secret, 5, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	testCases := []struct {
+		TestName      string
+		Shares        [][]byte
+		ExpectedEqual bool
+		ExpectedError error
+	}{
+		{
+			TestName:      "Success. First shares",
+			Shares:        shares[:3],
+			ExpectedEqual: true,
+		},
+		{
+			TestName:      "Success. Other shares",
+			Shares:        [][]byte{shares[4], shares[1], shares[3]},
+			ExpectedEqual: true,
+		},
+		{
+			TestName:      "Success. All shares",
+			Shares:        shares,
+			ExpectedEqual: true,
+		},
+		{
+			TestName:      "Wrong result. Below threshold",
+			Shares:        shares[:2],
+			ExpectedEqual: false,
+		},
+		{
+			TestName:      "Error. Duplicate shares",
+			Shares:        [][]byte{shares[0], shares[1], shares[0]},
+			ExpectedError: ErrDuplicateShares,
+		},
+		{
+			TestName:      "Error. Different lengths",
+			Shares:        [][]byte{shares[0], shares[1][:5], shares[2]},
+			ExpectedError: ErrInvalidShares,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			result, err := CombineShares(tc.Shares)
+			if tc.ExpectedError != nil {
+				assert.ErrorIs(t, err, tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			if tc.ExpectedEqual {
+				assert.Equal(t, secret, result)
+			} else {
+				assert.NotEqual(t, secret, result)
+			}
+		})
+	}
+
+	for _, threshold := range [][2]int{{1, 3}, {4, 3}, {2, 256}} {
+		_, err := SplitSecret(secret, threshold[1], threshold[0])
+		assert.ErrorIs(t, err, ErrInvalidThreshold, "%d of %d", threshold[0], threshold[1])
+	}
+}
@@ -0,0 +1,217 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Аутентификация по паролю без передачи пароля серверу: SRP-6a (RFC 5054, группа 2048 бит, SHA-256).
+// Сервер хранит только соль и проверочное значение v = g^x, где x получается из пароля функцией Argon2id,
+// поэтому не видит пароль ни при регистрации, ни при входе. Логин в x не входит (соль уникальна),
+// чтобы учётную запись можно было восстановить из резервной копии под другим логином.
+
+// srpPrime - модуль группы 2048 бит из RFC 5054 (приложение A)
+const srpPrime = "AC6BDB41324A9A9BF166DE5E1389582FAF72B6651987EE07FC3192943DB56050A37329CBB4A099ED8193E0757767A13D" +
+	"D52312AB4B03310DCD7F48A9DA04FD50E8083969EDB767B0CF6095179A163AB3661A05FBD5FAAAE82918A9962F0B93B8" +
+	"55F97993EC975EEAA80D740ADBF4FF747359D041D5C33EA71D281E446B14773BCA97B43A23FB801676BD207A436C6481" +
+	"F1D2B9078717461A5B9D32E688F87748544523B524B0D57D5EA77A2775D2ECFA032CFBDBF52FB3786160279004E57AE6" +
+	"AF874E7303CE53299CCC041C7BC308D82A5698F3A8D0C38271AE35F8E9DBFBB694B5C803D89F7AE435DE236D525F5475" +
+	"9B65E372FCD68EF20FA7111F9E4AFF73"
+
+// srpSecretSize - размер секретных значений a и b (байт)
+const srpSecretSize = 32
+
+var (
+	srpN, _ = new(big.Int).SetString(srpPrime, 16)
+	srpG    = big.NewInt(2)
+	srpK    = new(big.Int).SetBytes(srpHash(srpPad(srpN), srpPad(srpG)))
+)
+
+// srpKDF - параметры Argon2id для получения x из пароля. Не зависят от DefaultKDFParams:
+// проверочные значения, сохранённые на сервере, должны оставаться действительными.
+var srpKDF = KDFParams{
+	Algorithm:   KDFAlgorithmArgon2id,
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+}
+
+// ErrSRPAuth - подтверждение стороны SRP не совпало или получено недопустимое значение
+var ErrSRPAuth = errors.New("srp authentication failed")
+
+// SRPVerifier - метод формирует соль и проверочное значение пароля для хранения на сервере
+func SRPVerifier(password string) ([]byte, []byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	x, err := srpX(password, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return salt, srpPad(new(big.Int).Exp(srpG, x, srpN)), nil
+}
+
+// SRPFakeVerifier - метод формирует соль и проверочное значение для логина без проверочного значения
+// на сервере (неизвестного или созданного до появления SRP). Значения получаются из секрета сервера seed
+// и логина, поэтому повторные ответы для логина совпадают, как и для существующей учётной записи,
+// и по ним нельзя проверить существование логина. Войти с таким проверочным значением нельзя.
+func SRPFakeVerifier(seed []byte, login string) ([]byte, []byte) {
+	mac := hmac.New(sha256.New, seed)
+	mac.Write([]byte("srp-fake-salt\x00" + login))
+	salt := mac.Sum(nil)[:saltSize]
+	mac = hmac.New(sha256.New, seed)
+	mac.Write([]byte("srp-fake-verifier\x00" + login))
+	x := new(big.Int).SetBytes(mac.Sum(nil))
+	return salt, srpPad(new(big.Int).Exp(srpG, x, srpN))
+}
+
+// SRPClient - сторона клиента SRP: знает пароль
+type SRPClient struct {
+	password    string
+	a           *big.Int
+	public      *big.Int
+	serverProof []byte
+}
+
+// NewSRPClient - метод создаёт сторону клиента и её открытое значение A = g^a
+func NewSRPClient(password string) (*SRPClient, error) {
+	a, err := srpSecret()
+	if err != nil {
+		return nil, err
+	}
+	return &SRPClient{password: password, a: a, public: new(big.Int).Exp(srpG, a, srpN)}, nil
+}
+
+// Public - метод возвращает открытое значение клиента A
+func (c *SRPClient) Public() []byte {
+	return srpPad(c.public)
+}
+
+// Proof - метод вычисляет общий ключ по соли и открытому значению сервера B
+// и возвращает подтверждение клиента M1
+func (c *SRPClient) Proof(salt []byte, serverPublic []byte) ([]byte, error) {
+	b := new(big.Int).SetBytes(serverPublic)
+	if !srpValid(b) {
+		return nil, fmt.Errorf("%w: invalid server public value", ErrSRPAuth)
+	}
+	u := srpU(c.public, b)
+	if u.Sign() == 0 {
+		return nil, fmt.Errorf("%w: invalid scrambling parameter", ErrSRPAuth)
+	}
+	x, err := srpX(c.password, salt)
+	if err != nil {
+		return nil, err
+	}
+	// S = (B - k*g^x) ^ (a + u*x) mod N
+	base := new(big.Int).Mul(srpK, new(big.Int).Exp(srpG, x, srpN))
+	base.Sub(b, base).Mod(base, srpN)
+	exp := new(big.Int).Mul(u, x)
+	exp.Add(exp, c.a)
+	key := srpHash(srpPad(new(big.Int).Exp(base, exp, srpN)))
+
+	proof := srpHash(srpPad(c.public), srpPad(b), key)
+	c.serverProof = srpHash(srpPad(c.public), proof, key)
+	return proof, nil
+}
+
+// VerifyServer - метод проверяет подтверждение сервера M2: сервер знает проверочное значение пароля
+func (c *SRPClient) VerifyServer(proof []byte) error {
+	if c.serverProof == nil || subtle.ConstantTimeCompare(c.serverProof, proof) != 1 {
+		return fmt.Errorf("%w: server proof mismatch", ErrSRPAuth)
+	}
+	return nil
+}
+
+// SRPServer - сторона сервера SRP: знает только проверочное значение пароля
+type SRPServer struct {
+	verifier *big.Int
+	client   *big.Int
+	b        *big.Int
+	public   *big.Int
+}
+
+// NewSRPServer - метод создаёт сторону сервера по проверочному значению и открытому значению клиента A
+func NewSRPServer(verifier []byte, clientPublic []byte) (*SRPServer, error) {
+	a := new(big.Int).SetBytes(clientPublic)
+	if !srpValid(a) {
+		return nil, fmt.Errorf("%w: invalid client public value", ErrSRPAuth)
+	}
+	b, err := srpSecret()
+	if err != nil {
+		return nil, err
+	}
+	v := new(big.Int).SetBytes(verifier)
+	// B = k*v + g^b mod N
+	public := new(big.Int).Mul(srpK, v)
+	public.Add(public, new(big.Int).Exp(srpG, b, srpN)).Mod(public, srpN)
+	return &SRPServer{verifier: v, client: a, b: b, public: public}, nil
+}
+
+// Public - метод возвращает открытое значение сервера B
+func (s *SRPServer) Public() []byte {
+	return srpPad(s.public)
+}
+
+// Verify - метод проверяет подтверждение клиента M1 и возвращает подтверждение сервера M2
+func (s *SRPServer) Verify(proof []byte) ([]byte, error) {
+	u := srpU(s.client, s.public)
+	// S = (A * v^u) ^ b mod N
+	base := new(big.Int).Exp(s.verifier, u, srpN)
+	base.Mul(base, s.client).Mod(base, srpN)
+	key := srpHash(srpPad(new(big.Int).Exp(base, s.b, srpN)))
+
+	expected := srpHash(srpPad(s.client), srpPad(s.public), key)
+	if subtle.ConstantTimeCompare(expected, proof) != 1 {
+		return nil, fmt.Errorf("%w: client proof mismatch", ErrSRPAuth)
+	}
+	return srpHash(srpPad(s.client), proof, key), nil
+}
+
+// srpX - метод получает секретное значение x = H(salt | Argon2id(password, salt))
+func srpX(password string, salt []byte) (*big.Int, error) {
+	inner, err := deriveKey([]byte(password), salt, srpKDF)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(srpHash(salt, inner)), nil
+}
+
+// srpU - метод вычисляет параметр u = H(PAD(A) | PAD(B))
+func srpU(a *big.Int, b *big.Int) *big.Int {
+	return new(big.Int).SetBytes(srpHash(srpPad(a), srpPad(b)))
+}
+
+// srpValid - метод проверяет открытое значение стороны: 0 < x < N
+// (значения, кратные N, позволили бы войти без пароля)
+func srpValid(x *big.Int) bool {
+	return x.Sign() > 0 && x.Cmp(srpN) < 0
+}
+
+// srpSecret - метод генерирует случайное секретное значение стороны
+func srpSecret() (*big.Int, error) {
+	buf := make([]byte, srpSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate srp secret: %w", err)
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
+
+// srpPad - метод дополняет значение нулями слева до размера модуля группы
+func srpPad(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, (srpN.BitLen()+7)/8))
+}
+
+// srpHash - метод вычисляет SHA-256 от объединения значений
+func srpHash(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,105 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRPGroup(t *testing.T) {
+	// модуль группы - безопасное простое число: N и (N-1)/2 простые
+	require.Equal(t, 2048, srpN.BitLen())
+	assert.True(t, srpN.ProbablyPrime(20))
+	q := new(big.Int).Rsh(srpN, 1)
+	assert.True(t, q.ProbablyPrime(20))
+}
+
+func TestSRP(t *testing.T) {
+	salt, verifier, err := SRPVerifier("password")
+	require.NoError(t, err, "SRPVerifier failed")
+
+	testCases := []struct {
+		Name          string
+		Password      string
+		ExpectedError bool
+	}{
+		{
+			Name:     "Success. Correct password #1",
+			Password: "password",
+		},
+		{
+			Name:          "Error. Wrong password #2",
+			Password:      "wrong",
+			ExpectedError: true,
+		},
+		{
+			Name:          "Error. Empty password #3",
+			Password:      "",
+			ExpectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			client, err := NewSRPClient(tc.Password)
+			require.NoError(t, err, "NewSRPClient failed")
+			server, err := NewSRPServer(verifier, client.Public())
+			require.NoError(t, err, "NewSRPServer failed")
+			proof, err := client.Proof(salt, server.Public())
+			require.NoError(t, err, "Proof failed")
+
+			serverProof, err := server.Verify(proof)
+			if tc.ExpectedError {
+				require.ErrorIs(t, err, ErrSRPAuth)
+				return
+			}
+			require.NoError(t, err, "Verify failed")
+			require.NoError(t, client.VerifyServer(serverProof))
+			assert.ErrorIs(t, client.VerifyServer(proof), ErrSRPAuth)
+		})
+	}
+}
+
+func TestSRPInvalidPublic(t *testing.T) {
+	_, verifier, err := SRPVerifier("password")
+	require.NoError(t, err, "SRPVerifier failed")
+
+	// открытые значения, кратные модулю, позволяют войти без пароля и отвергаются обеими сторонами
+	for _, public := range [][]byte{nil, srpN.Bytes(), new(big.Int).Mul(srpN, big.NewInt(2)).Bytes()} {
+		_, err := NewSRPServer(verifier, public)
+		assert.ErrorIs(t, err, ErrSRPAuth)
+
+		client, err := NewSRPClient("password")
+		require.NoError(t, err, "NewSRPClient failed")
+		_, err = client.Proof([]byte("salt"), public)
+		assert.ErrorIs(t, err, ErrSRPAuth)
+	}
+}
+
+func TestSRPFakeVerifier(t *testing.T) {
+	seed := []byte("server seed")
+	salt, verifier := SRPFakeVerifier(seed, "alice")
+	assert.Len(t, salt, saltSize)
+	assert.Len(t, verifier, len(srpPad(srpN)))
+
+	// ответы для логина повторяются и отличаются для других логинов и секретов сервера
+	sameSalt, sameVerifier := SRPFakeVerifier(seed, "alice")
+	assert.Equal(t, salt, sameSalt)
+	assert.Equal(t, verifier, sameVerifier)
+	otherSalt, otherVerifier := SRPFakeVerifier(seed, "bob")
+	assert.NotEqual(t, salt, otherSalt)
+	assert.NotEqual(t, verifier, otherVerifier)
+	otherSalt, _ = SRPFakeVerifier([]byte("other seed"), "alice")
+	assert.NotEqual(t, salt, otherSalt)
+
+	// войти с поддельным проверочным значением нельзя
+	client, err := NewSRPClient("password")
+	require.NoError(t, err, "NewSRPClient failed")
+	server, err := NewSRPServer(verifier, client.Public())
+	require.NoError(t, err, "NewSRPServer failed")
+	proof, err := client.Proof(salt, server.Public())
+	require.NoError(t, err, "Proof failed")
+	_, err = server.Verify(proof)
+	assert.ErrorIs(t, err, ErrSRPAuth)
+}
@@ -0,0 +1,592 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: api/attachment.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AttachmentMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// идентификатор вложения (новому вложению может задаваться клиентом,
+	// так как входит в дополнительные аутентифицируемые данные его шифрования)
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SecretId string `protobuf:"bytes,2,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	// открытое название (только у вложений, добавленных до шифрования названий)
+	Name    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Size    int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Created *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3,oneof" json:"created,omitempty"`
+	// название вложения, зашифрованное на клиенте ключом данных секрета
+	EncryptedMeta []byte `protobuf:"bytes,6,opt,name=encrypted_meta,json=encryptedMeta,proto3" json:"encrypted_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentMetadata) Reset() {
+	*x = AttachmentMetadata{}
+	mi := &file_api_attachment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentMetadata) ProtoMessage() {}
+
+func (x *AttachmentMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentMetadata) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AttachmentMetadata) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttachmentMetadata) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AttachmentMetadata) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *AttachmentMetadata) GetEncryptedMeta() []byte {
+	if x != nil {
+		return x.EncryptedMeta
+	}
+	return nil
+}
+
+type AddAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *AttachmentMetadata    `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAttachmentRequest) Reset() {
+	*x = AddAttachmentRequest{}
+	mi := &file_api_attachment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAttachmentRequest) ProtoMessage() {}
+
+func (x *AddAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*AddAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddAttachmentRequest) GetMeta() *AttachmentMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *AddAttachmentRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type AddAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *AttachmentMetadata    `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAttachmentResponse) Reset() {
+	*x = AddAttachmentResponse{}
+	mi := &file_api_attachment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAttachmentResponse) ProtoMessage() {}
+
+func (x *AddAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*AddAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddAttachmentResponse) GetMeta() *AttachmentMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type GetAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SecretId      string                 `protobuf:"bytes,1,opt,name=secret_id,json=secretId,proto3" json:"secret_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentsRequest) Reset() {
+	*x = GetAttachmentsRequest{}
+	mi := &file_api_attachment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentsRequest) ProtoMessage() {}
+
+func (x *GetAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAttachmentsRequest) GetSecretId() string {
+	if x != nil {
+		return x.SecretId
+	}
+	return ""
+}
+
+type GetAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*AttachmentMetadata  `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentsResponse) Reset() {
+	*x = GetAttachmentsResponse{}
+	mi := &file_api_attachment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentsResponse) ProtoMessage() {}
+
+func (x *GetAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*GetAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAttachmentsResponse) GetAttachments() []*AttachmentMetadata {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type GetAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *AttachmentMetadata    `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentRequest) Reset() {
+	*x = GetAttachmentRequest{}
+	mi := &file_api_attachment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentRequest) ProtoMessage() {}
+
+func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAttachmentRequest) GetMeta() *AttachmentMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type GetAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *AttachmentMetadata    `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentResponse) Reset() {
+	*x = GetAttachmentResponse{}
+	mi := &file_api_attachment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentResponse) ProtoMessage() {}
+
+func (x *GetAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*GetAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAttachmentResponse) GetMeta() *AttachmentMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *GetAttachmentResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *AttachmentMetadata    `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_api_attachment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteAttachmentRequest) GetMeta() *AttachmentMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type DeleteAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *AttachmentMetadata    `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentResponse) Reset() {
+	*x = DeleteAttachmentResponse{}
+	mi := &file_api_attachment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponse) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attachment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_api_attachment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteAttachmentResponse) GetMeta() *AttachmentMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+var File_api_attachment_proto protoreflect.FileDescriptor
+
+const file_api_attachment_proto_rawDesc = "" +
+	"\n" +
+	"\x14api/attachment.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x01\n" +
+	"\x12AttachmentMetadata\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsecret_id\x18\x02 \x01(\tR\bsecretId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x129\n" +
+	"\acreated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\acreated\x88\x01\x01\x12%\n" +
+	"\x0eencrypted_meta\x18\x06 \x01(\fR\rencryptedMetaB\n" +
+	"\n" +
+	"\b_created\"]\n" +
+	"\x14AddAttachmentRequest\x12+\n" +
+	"\x04meta\x18\x01 \x01(\v2\x17.api.AttachmentMetadataR\x04meta\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"D\n" +
+	"\x15AddAttachmentResponse\x12+\n" +
+	"\x04meta\x18\x01 \x01(\v2\x17.api.AttachmentMetadataR\x04meta\"4\n" +
+	"\x15GetAttachmentsRequest\x12\x1b\n" +
+	"\tsecret_id\x18\x01 \x01(\tR\bsecretId\"S\n" +
+	"\x16GetAttachmentsResponse\x129\n" +
+	"\vattachments\x18\x01 \x03(\v2\x17.api.AttachmentMetadataR\vattachments\"C\n" +
+	"\x14GetAttachmentRequest\x12+\n" +
+	"\x04meta\x18\x01 \x01(\v2\x17.api.AttachmentMetadataR\x04meta\"^\n" +
+	"\x15GetAttachmentResponse\x12+\n" +
+	"\x04meta\x18\x01 \x01(\v2\x17.api.AttachmentMetadataR\x04meta\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"F\n" +
+	"\x17DeleteAttachmentRequest\x12+\n" +
+	"\x04meta\x18\x01 \x01(\v2\x17.api.AttachmentMetadataR\x04meta\"G\n" +
+	"\x18DeleteAttachmentResponse\x12+\n" +
+	"\x04meta\x18\x01 \x01(\v2\x17.api.AttachmentMetadataR\x04meta2\xb8\x02\n" +
+	"\n" +
+	"Attachment\x12F\n" +
+	"\rAddAttachment\x12\x19.api.AddAttachmentRequest\x1a\x1a.api.AddAttachmentResponse\x12I\n" +
+	"\x0eGetAttachments\x12\x1a.api.GetAttachmentsRequest\x1a\x1b.api.GetAttachmentsResponse\x12F\n" +
+	"\rGetAttachment\x12\x19.api.GetAttachmentRequest\x1a\x1a.api.GetAttachmentResponse\x12O\n" +
+	"\x10DeleteAttachment\x12\x1c.api.DeleteAttachmentRequest\x1a\x1d.api.DeleteAttachmentResponseB\vZ\tpkg/protob\x06proto3"
+
+var (
+	file_api_attachment_proto_rawDescOnce sync.Once
+	file_api_attachment_proto_rawDescData []byte
+)
+
+func file_api_attachment_proto_rawDescGZIP() []byte {
+	file_api_attachment_proto_rawDescOnce.Do(func() {
+		file_api_attachment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_attachment_proto_rawDesc), len(file_api_attachment_proto_rawDesc)))
+	})
+	return file_api_attachment_proto_rawDescData
+}
+
+var file_api_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_attachment_proto_goTypes = []any{
+	(*AttachmentMetadata)(nil),       // 0: api.AttachmentMetadata
+	(*AddAttachmentRequest)(nil),     // 1: api.AddAttachmentRequest
+	(*AddAttachmentResponse)(nil),    // 2: api.AddAttachmentResponse
+	(*GetAttachmentsRequest)(nil),    // 3: api.GetAttachmentsRequest
+	(*GetAttachmentsResponse)(nil),   // 4: api.GetAttachmentsResponse
+	(*GetAttachmentRequest)(nil),     // 5: api.GetAttachmentRequest
+	(*GetAttachmentResponse)(nil),    // 6: api.GetAttachmentResponse
+	(*DeleteAttachmentRequest)(nil),  // 7: api.DeleteAttachmentRequest
+	(*DeleteAttachmentResponse)(nil), // 8: api.DeleteAttachmentResponse
+	(*timestamppb.Timestamp)(nil),    // 9: google.protobuf.Timestamp
+}
+var file_api_attachment_proto_depIdxs = []int32{
+	9,  // 0: api.AttachmentMetadata.created:type_name -> google.protobuf.Timestamp
+	0,  // 1: api.AddAttachmentRequest.meta:type_name -> api.AttachmentMetadata
+	0,  // 2: api.AddAttachmentResponse.meta:type_name -> api.AttachmentMetadata
+	0,  // 3: api.GetAttachmentsResponse.attachments:type_name -> api.AttachmentMetadata
+	0,  // 4: api.GetAttachmentRequest.meta:type_name -> api.AttachmentMetadata
+	0,  // 5: api.GetAttachmentResponse.meta:type_name -> api.AttachmentMetadata
+	0,  // 6: api.DeleteAttachmentRequest.meta:type_name -> api.AttachmentMetadata
+	0,  // 7: api.DeleteAttachmentResponse.meta:type_name -> api.AttachmentMetadata
+	1,  // 8: api.Attachment.AddAttachment:input_type -> api.AddAttachmentRequest
+	3,  // 9: api.Attachment.GetAttachments:input_type -> api.GetAttachmentsRequest
+	5,  // 10: api.Attachment.GetAttachment:input_type -> api.GetAttachmentRequest
+	7,  // 11: api.Attachment.DeleteAttachment:input_type -> api.DeleteAttachmentRequest
+	2,  // 12: api.Attachment.AddAttachment:output_type -> api.AddAttachmentResponse
+	4,  // 13: api.Attachment.GetAttachments:output_type -> api.GetAttachmentsResponse
+	6,  // 14: api.Attachment.GetAttachment:output_type -> api.GetAttachmentResponse
+	8,  // 15: api.Attachment.DeleteAttachment:output_type -> api.DeleteAttachmentResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_api_attachment_proto_init() }
+func file_api_attachment_proto_init() {
+	if File_api_attachment_proto != nil {
+		return
+	}
+	file_api_attachment_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_attachment_proto_rawDesc), len(file_api_attachment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_attachment_proto_goTypes,
+		DependencyIndexes: file_api_attachment_proto_depIdxs,
+		MessageInfos:      file_api_attachment_proto_msgTypes,
+	}.Build()
+	File_api_attachment_proto = out.File
+	file_api_attachment_proto_goTypes = nil
+	file_api_attachment_proto_depIdxs = nil
+}
@@ -0,0 +1,254 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: api/escrow.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetEscrowKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEscrowKeyRequest) Reset() {
+	*x = GetEscrowKeyRequest{}
+	mi := &file_api_escrow_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEscrowKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEscrowKeyRequest) ProtoMessage() {}
+
+func (x *GetEscrowKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_escrow_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEscrowKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetEscrowKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_escrow_proto_rawDescGZIP(), []int{0}
+}
+
+type GetEscrowKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEscrowKeyResponse) Reset() {
+	*x = GetEscrowKeyResponse{}
+	mi := &file_api_escrow_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEscrowKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEscrowKeyResponse) ProtoMessage() {}
+
+func (x *GetEscrowKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_escrow_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEscrowKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetEscrowKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_escrow_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEscrowKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+type SetEscrowKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EscrowKey     []byte                 `protobuf:"bytes,1,opt,name=escrow_key,json=escrowKey,proto3" json:"escrow_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEscrowKeyRequest) Reset() {
+	*x = SetEscrowKeyRequest{}
+	mi := &file_api_escrow_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEscrowKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEscrowKeyRequest) ProtoMessage() {}
+
+func (x *SetEscrowKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_escrow_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEscrowKeyRequest.ProtoReflect.Descriptor instead.
+func (*SetEscrowKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_escrow_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetEscrowKeyRequest) GetEscrowKey() []byte {
+	if x != nil {
+		return x.EscrowKey
+	}
+	return nil
+}
+
+type SetEscrowKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEscrowKeyResponse) Reset() {
+	*x = SetEscrowKeyResponse{}
+	mi := &file_api_escrow_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEscrowKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEscrowKeyResponse) ProtoMessage() {}
+
+func (x *SetEscrowKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_escrow_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEscrowKeyResponse.ProtoReflect.Descriptor instead.
+func (*SetEscrowKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_escrow_proto_rawDescGZIP(), []int{3}
+}
+
+var File_api_escrow_proto protoreflect.FileDescriptor
+
+const file_api_escrow_proto_rawDesc = "" +
+	"\n" +
+	"\x10api/escrow.proto\x12\x03api\"\x15\n" +
+	"\x13GetEscrowKeyRequest\"5\n" +
+	"\x14GetEscrowKeyResponse\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\"4\n" +
+	"\x13SetEscrowKeyRequest\x12\x1d\n" +
+	"\n" +
+	"escrow_key\x18\x01 \x01(\fR\tescrowKey\"\x16\n" +
+	"\x14SetEscrowKeyResponse2\x92\x01\n" +
+	"\x06Escrow\x12C\n" +
+	"\fGetEscrowKey\x12\x18.api.GetEscrowKeyRequest\x1a\x19.api.GetEscrowKeyResponse\x12C\n" +
+	"\fSetEscrowKey\x12\x18.api.SetEscrowKeyRequest\x1a\x19.api.SetEscrowKeyResponseB\vZ\tpkg/protob\x06proto3"
+
+var (
+	file_api_escrow_proto_rawDescOnce sync.Once
+	file_api_escrow_proto_rawDescData []byte
+)
+
+func file_api_escrow_proto_rawDescGZIP() []byte {
+	file_api_escrow_proto_rawDescOnce.Do(func() {
+		file_api_escrow_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_escrow_proto_rawDesc), len(file_api_escrow_proto_rawDesc)))
+	})
+	return file_api_escrow_proto_rawDescData
+}
+
+var file_api_escrow_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_escrow_proto_goTypes = []any{
+	(*GetEscrowKeyRequest)(nil),  // 0: api.GetEscrowKeyRequest
+	(*GetEscrowKeyResponse)(nil), // 1: api.GetEscrowKeyResponse
+	(*SetEscrowKeyRequest)(nil),  // 2: api.SetEscrowKeyRequest
+	(*SetEscrowKeyResponse)(nil), // 3: api.SetEscrowKeyResponse
+}
+var file_api_escrow_proto_depIdxs = []int32{
+	0, // 0: api.Escrow.GetEscrowKey:input_type -> api.GetEscrowKeyRequest
+	2, // 1: api.Escrow.SetEscrowKey:input_type -> api.SetEscrowKeyRequest
+	1, // 2: api.Escrow.GetEscrowKey:output_type -> api.GetEscrowKeyResponse
+	3, // 3: api.Escrow.SetEscrowKey:output_type -> api.SetEscrowKeyResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_escrow_proto_init() }
+func file_api_escrow_proto_init() {
+	if File_api_escrow_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_escrow_proto_rawDesc), len(file_api_escrow_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_escrow_proto_goTypes,
+		DependencyIndexes: file_api_escrow_proto_depIdxs,
+		MessageInfos:      file_api_escrow_proto_msgTypes,
+	}.Build()
+	File_api_escrow_proto = out.File
+	file_api_escrow_proto_goTypes = nil
+	file_api_escrow_proto_depIdxs = nil
+}
@@ -0,0 +1,159 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: api/escrow.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Escrow_GetEscrowKey_FullMethodName = "/api.Escrow/GetEscrowKey"
+	Escrow_SetEscrowKey_FullMethodName = "/api.Escrow/SetEscrowKey"
+)
+
+// EscrowClient is the client API for Escrow service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EscrowClient interface {
+	GetEscrowKey(ctx context.Context, in *GetEscrowKeyRequest, opts ...grpc.CallOption) (*GetEscrowKeyResponse, error)
+	SetEscrowKey(ctx context.Context, in *SetEscrowKeyRequest, opts ...grpc.CallOption) (*SetEscrowKeyResponse, error)
+}
+
+type escrowClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEscrowClient(cc grpc.ClientConnInterface) EscrowClient {
+	return &escrowClient{cc}
+}
+
+func (c *escrowClient) GetEscrowKey(ctx context.Context, in *GetEscrowKeyRequest, opts ...grpc.CallOption) (*GetEscrowKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEscrowKeyResponse)
+	err := c.cc.Invoke(ctx, Escrow_GetEscrowKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *escrowClient) SetEscrowKey(ctx context.Context, in *SetEscrowKeyRequest, opts ...grpc.CallOption) (*SetEscrowKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetEscrowKeyResponse)
+	err := c.cc.Invoke(ctx, Escrow_SetEscrowKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EscrowServer is the server API for Escrow service.
+// All implementations must embed UnimplementedEscrowServer
+// for forward compatibility.
+type EscrowServer interface {
+	GetEscrowKey(context.Context, *GetEscrowKeyRequest) (*GetEscrowKeyResponse, error)
+	SetEscrowKey(context.Context, *SetEscrowKeyRequest) (*SetEscrowKeyResponse, error)
+	mustEmbedUnimplementedEscrowServer()
+}
+
+// UnimplementedEscrowServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEscrowServer struct{}
+
+func (UnimplementedEscrowServer) GetEscrowKey(context.Context, *GetEscrowKeyRequest) (*GetEscrowKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEscrowKey not implemented")
+}
+func (UnimplementedEscrowServer) SetEscrowKey(context.Context, *SetEscrowKeyRequest) (*SetEscrowKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEscrowKey not implemented")
+}
+func (UnimplementedEscrowServer) mustEmbedUnimplementedEscrowServer() {}
+func (UnimplementedEscrowServer) testEmbeddedByValue()                {}
+
+// UnsafeEscrowServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EscrowServer will
+// result in compilation errors.
+type UnsafeEscrowServer interface {
+	mustEmbedUnimplementedEscrowServer()
+}
+
+func RegisterEscrowServer(s grpc.ServiceRegistrar, srv EscrowServer) {
+	// If the following call pancis, it indicates UnimplementedEscrowServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Escrow_ServiceDesc, srv)
+}
+
+func _Escrow_GetEscrowKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEscrowKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EscrowServer).GetEscrowKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Escrow_GetEscrowKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EscrowServer).GetEscrowKey(ctx, req.(*GetEscrowKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Escrow_SetEscrowKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEscrowKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EscrowServer).SetEscrowKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Escrow_SetEscrowKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EscrowServer).SetEscrowKey(ctx, req.(*SetEscrowKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Escrow_ServiceDesc is the grpc.ServiceDesc for Escrow service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Escrow_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Escrow",
+	HandlerType: (*EscrowServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEscrowKey",
+			Handler:    _Escrow_GetEscrowKey_Handler,
+		},
+		{
+			MethodName: "SetEscrowKey",
+			Handler:    _Escrow_SetEscrowKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/escrow.proto",
+}
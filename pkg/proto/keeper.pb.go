@@ -7,6 +7,7 @@
 package proto
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -29,6 +30,15 @@ type SecretMetadata struct {
 	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	Created       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3,oneof" json:"created,omitempty"`
 	Updated       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated,proto3,oneof" json:"updated,omitempty"`
+	OrgId         string                 `protobuf:"bytes,6,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires,proto3,oneof" json:"expires,omitempty"`
+	ExpirePolicy  string                 `protobuf:"bytes,8,opt,name=expire_policy,json=expirePolicy,proto3" json:"expire_policy,omitempty"`
+	Archived      bool                   `protobuf:"varint,9,opt,name=archived,proto3" json:"archived,omitempty"`
+	SearchIndex   [][]byte               `protobuf:"bytes,10,rep,name=search_index,json=searchIndex,proto3" json:"search_index,omitempty"`
+	EncryptedMeta []byte                 `protobuf:"bytes,11,opt,name=encrypted_meta,json=encryptedMeta,proto3" json:"encrypted_meta,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,12,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	Revision      int64                  `protobuf:"varint,13,opt,name=revision,proto3" json:"revision,omitempty"`                         // ревизия секрета (увеличивается сервером при каждом изменении)
+	ContentHash   []byte                 `protobuf:"bytes,14,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // SHA-256 содержимого, зашифрованного на клиенте
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,8 +108,73 @@ func (x *SecretMetadata) GetUpdated() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *SecretMetadata) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SecretMetadata) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+func (x *SecretMetadata) GetExpirePolicy() string {
+	if x != nil {
+		return x.ExpirePolicy
+	}
+	return ""
+}
+
+func (x *SecretMetadata) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *SecretMetadata) GetSearchIndex() [][]byte {
+	if x != nil {
+		return x.SearchIndex
+	}
+	return nil
+}
+
+func (x *SecretMetadata) GetEncryptedMeta() []byte {
+	if x != nil {
+		return x.EncryptedMeta
+	}
+	return nil
+}
+
+func (x *SecretMetadata) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *SecretMetadata) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *SecretMetadata) GetContentHash() []byte {
+	if x != nil {
+		return x.ContentHash
+	}
+	return nil
+}
+
 type GetSecretsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Archived      bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -134,6 +209,20 @@ func (*GetSecretsRequest) Descriptor() ([]byte, []int) {
 	return file_api_keeper_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *GetSecretsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *GetSecretsRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
 type GetSecretsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Secrets       []*SecretMetadata      `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
@@ -554,22 +643,475 @@ func (x *EditSecretResponse) GetMeta() *SecretMetadata {
 	return nil
 }
 
+type SetExpirationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *SecretMetadata        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExpirationRequest) Reset() {
+	*x = SetExpirationRequest{}
+	mi := &file_api_keeper_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExpirationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExpirationRequest) ProtoMessage() {}
+
+func (x *SetExpirationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExpirationRequest.ProtoReflect.Descriptor instead.
+func (*SetExpirationRequest) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetExpirationRequest) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type SetExpirationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *SecretMetadata        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExpirationResponse) Reset() {
+	*x = SetExpirationResponse{}
+	mi := &file_api_keeper_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExpirationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExpirationResponse) ProtoMessage() {}
+
+func (x *SetExpirationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExpirationResponse.ProtoReflect.Descriptor instead.
+func (*SetExpirationResponse) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetExpirationResponse) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type SecretOperation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Op:
+	//
+	//	*SecretOperation_Add
+	//	*SecretOperation_Edit
+	//	*SecretOperation_Delete
+	Op            isSecretOperation_Op `protobuf_oneof:"op"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretOperation) Reset() {
+	*x = SecretOperation{}
+	mi := &file_api_keeper_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretOperation) ProtoMessage() {}
+
+func (x *SecretOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretOperation.ProtoReflect.Descriptor instead.
+func (*SecretOperation) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SecretOperation) GetOp() isSecretOperation_Op {
+	if x != nil {
+		return x.Op
+	}
+	return nil
+}
+
+func (x *SecretOperation) GetAdd() *AddSecretRequest {
+	if x != nil {
+		if x, ok := x.Op.(*SecretOperation_Add); ok {
+			return x.Add
+		}
+	}
+	return nil
+}
+
+func (x *SecretOperation) GetEdit() *EditSecretRequest {
+	if x != nil {
+		if x, ok := x.Op.(*SecretOperation_Edit); ok {
+			return x.Edit
+		}
+	}
+	return nil
+}
+
+func (x *SecretOperation) GetDelete() *DeleteSecretRequest {
+	if x != nil {
+		if x, ok := x.Op.(*SecretOperation_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+type isSecretOperation_Op interface {
+	isSecretOperation_Op()
+}
+
+type SecretOperation_Add struct {
+	Add *AddSecretRequest `protobuf:"bytes,1,opt,name=add,proto3,oneof"`
+}
+
+type SecretOperation_Edit struct {
+	Edit *EditSecretRequest `protobuf:"bytes,2,opt,name=edit,proto3,oneof"`
+}
+
+type SecretOperation_Delete struct {
+	Delete *DeleteSecretRequest `protobuf:"bytes,3,opt,name=delete,proto3,oneof"`
+}
+
+func (*SecretOperation_Add) isSecretOperation_Op() {}
+
+func (*SecretOperation_Edit) isSecretOperation_Op() {}
+
+func (*SecretOperation_Delete) isSecretOperation_Op() {}
+
+type BatchSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operations    []*SecretOperation     `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSecretsRequest) Reset() {
+	*x = BatchSecretsRequest{}
+	mi := &file_api_keeper_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSecretsRequest) ProtoMessage() {}
+
+func (x *BatchSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSecretsRequest.ProtoReflect.Descriptor instead.
+func (*BatchSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchSecretsRequest) GetOperations() []*SecretOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type SecretOperationResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *SecretMetadata        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Code          int32                  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretOperationResult) Reset() {
+	*x = SecretOperationResult{}
+	mi := &file_api_keeper_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretOperationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretOperationResult) ProtoMessage() {}
+
+func (x *SecretOperationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretOperationResult.ProtoReflect.Descriptor instead.
+func (*SecretOperationResult) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SecretOperationResult) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *SecretOperationResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *SecretOperationResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchSecretsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Committed     bool                     `protobuf:"varint,1,opt,name=committed,proto3" json:"committed,omitempty"`
+	Results       []*SecretOperationResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSecretsResponse) Reset() {
+	*x = BatchSecretsResponse{}
+	mi := &file_api_keeper_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSecretsResponse) ProtoMessage() {}
+
+func (x *BatchSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSecretsResponse.ProtoReflect.Descriptor instead.
+func (*BatchSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchSecretsResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *BatchSecretsResponse) GetResults() []*SecretOperationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchSecretsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Tokens        [][]byte               `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSecretsRequest) Reset() {
+	*x = SearchSecretsRequest{}
+	mi := &file_api_keeper_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSecretsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSecretsRequest) ProtoMessage() {}
+
+func (x *SearchSecretsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSecretsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSecretsRequest) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchSecretsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SearchSecretsRequest) GetTokens() [][]byte {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type SearchSecretsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*SecretMetadata      `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSecretsResponse) Reset() {
+	*x = SearchSecretsResponse{}
+	mi := &file_api_keeper_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSecretsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSecretsResponse) ProtoMessage() {}
+
+func (x *SearchSecretsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keeper_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSecretsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSecretsResponse) Descriptor() ([]byte, []int) {
+	return file_api_keeper_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchSecretsResponse) GetSecrets() []*SecretMetadata {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
 var File_api_keeper_proto protoreflect.FileDescriptor
 
 const file_api_keeper_proto_rawDesc = "" +
 	"\n" +
-	"\x10api/keeper.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd6\x01\n" +
+	"\x10api/keeper.proto\x12\x03api\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\x04\n" +
 	"\x0eSecretMetadata\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
 	"\x04type\x18\x03 \x01(\tR\x04type\x129\n" +
 	"\acreated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\acreated\x88\x01\x01\x129\n" +
-	"\aupdated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\aupdated\x88\x01\x01B\n" +
+	"\aupdated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\aupdated\x88\x01\x01\x12\x15\n" +
+	"\x06org_id\x18\x06 \x01(\tR\x05orgId\x129\n" +
+	"\aexpires\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x02R\aexpires\x88\x01\x01\x12#\n" +
+	"\rexpire_policy\x18\b \x01(\tR\fexpirePolicy\x12\x1a\n" +
+	"\barchived\x18\t \x01(\bR\barchived\x12!\n" +
+	"\fsearch_index\x18\n" +
+	" \x03(\fR\vsearchIndex\x12%\n" +
+	"\x0eencrypted_meta\x18\v \x01(\fR\rencryptedMeta\x12\x1f\n" +
+	"\vwrapped_key\x18\f \x01(\fR\n" +
+	"wrappedKey\x12\x1a\n" +
+	"\brevision\x18\r \x01(\x03R\brevision\x12!\n" +
+	"\fcontent_hash\x18\x0e \x01(\fR\vcontentHashB\n" +
 	"\n" +
 	"\b_createdB\n" +
 	"\n" +
-	"\b_updated\"\x13\n" +
-	"\x11GetSecretsRequest\"C\n" +
+	"\b_updatedB\n" +
+	"\n" +
+	"\b_expires\"F\n" +
+	"\x11GetSecretsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\"C\n" +
 	"\x12GetSecretsResponse\x12-\n" +
 	"\asecrets\x18\x01 \x03(\v2\x13.api.SecretMetadataR\asecrets\"U\n" +
 	"\x10AddSecretRequest\x12'\n" +
@@ -590,15 +1132,43 @@ const file_api_keeper_proto_rawDesc = "" +
 	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\fR\acontent\"=\n" +
 	"\x12EditSecretResponse\x12'\n" +
-	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta2\xc3\x02\n" +
-	"\x06Keeper\x12=\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\"?\n" +
+	"\x14SetExpirationRequest\x12'\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\"@\n" +
+	"\x15SetExpirationResponse\x12'\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\"\xa4\x01\n" +
+	"\x0fSecretOperation\x12)\n" +
+	"\x03add\x18\x01 \x01(\v2\x15.api.AddSecretRequestH\x00R\x03add\x12,\n" +
+	"\x04edit\x18\x02 \x01(\v2\x16.api.EditSecretRequestH\x00R\x04edit\x122\n" +
+	"\x06delete\x18\x03 \x01(\v2\x18.api.DeleteSecretRequestH\x00R\x06deleteB\x04\n" +
+	"\x02op\"K\n" +
+	"\x13BatchSecretsRequest\x124\n" +
 	"\n" +
-	"GetSecrets\x12\x16.api.GetSecretsRequest\x1a\x17.api.GetSecretsResponse\x12:\n" +
-	"\tAddSecret\x12\x15.api.AddSecretRequest\x1a\x16.api.AddSecretResponse\x12:\n" +
-	"\tGetSecret\x12\x15.api.GetSecretRequest\x1a\x16.api.GetSecretResponse\x12C\n" +
-	"\fDeleteSecret\x12\x18.api.DeleteSecretRequest\x1a\x19.api.DeleteSecretResponse\x12=\n" +
+	"operations\x18\x01 \x03(\v2\x14.api.SecretOperationR\n" +
+	"operations\"j\n" +
+	"\x15SecretOperationResult\x12'\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"j\n" +
+	"\x14BatchSecretsResponse\x12\x1c\n" +
+	"\tcommitted\x18\x01 \x01(\bR\tcommitted\x124\n" +
+	"\aresults\x18\x02 \x03(\v2\x1a.api.SecretOperationResultR\aresults\"E\n" +
+	"\x14SearchSecretsRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06tokens\x18\x02 \x03(\fR\x06tokens\"F\n" +
+	"\x15SearchSecretsResponse\x12-\n" +
+	"\asecrets\x18\x01 \x03(\v2\x13.api.SecretMetadataR\asecrets2\x8f\x06\n" +
+	"\x06Keeper\x12R\n" +
 	"\n" +
-	"EditSecret\x12\x16.api.EditSecretRequest\x1a\x17.api.EditSecretResponseB\vZ\tpkg/protob\x06proto3"
+	"GetSecrets\x12\x16.api.GetSecretsRequest\x1a\x17.api.GetSecretsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/secrets\x12R\n" +
+	"\tAddSecret\x12\x15.api.AddSecretRequest\x1a\x16.api.AddSecretResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/secrets\x12Y\n" +
+	"\tGetSecret\x12\x15.api.GetSecretRequest\x1a\x16.api.GetSecretResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/secrets/{meta.id}\x12b\n" +
+	"\fDeleteSecret\x12\x18.api.DeleteSecretRequest\x1a\x19.api.DeleteSecretResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/secrets/{meta.id}\x12_\n" +
+	"\n" +
+	"EditSecret\x12\x16.api.EditSecretRequest\x1a\x17.api.EditSecretResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/v1/secrets/{meta.id}\x12s\n" +
+	"\rSetExpiration\x12\x19.api.SetExpirationRequest\x1a\x1a.api.SetExpirationResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\x1a /v1/secrets/{meta.id}/expiration\x12a\n" +
+	"\fBatchSecrets\x12\x18.api.BatchSecretsRequest\x1a\x19.api.BatchSecretsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/secrets:batch\x12e\n" +
+	"\rSearchSecrets\x12\x19.api.SearchSecretsRequest\x1a\x1a.api.SearchSecretsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/secrets:searchB\vZ\tpkg/protob\x06proto3"
 
 var (
 	file_api_keeper_proto_rawDescOnce sync.Once
@@ -612,7 +1182,7 @@ func file_api_keeper_proto_rawDescGZIP() []byte {
 	return file_api_keeper_proto_rawDescData
 }
 
-var file_api_keeper_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_keeper_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_api_keeper_proto_goTypes = []any{
 	(*SecretMetadata)(nil),        // 0: api.SecretMetadata
 	(*GetSecretsRequest)(nil),     // 1: api.GetSecretsRequest
@@ -625,35 +1195,59 @@ var file_api_keeper_proto_goTypes = []any{
 	(*DeleteSecretResponse)(nil),  // 8: api.DeleteSecretResponse
 	(*EditSecretRequest)(nil),     // 9: api.EditSecretRequest
 	(*EditSecretResponse)(nil),    // 10: api.EditSecretResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*SetExpirationRequest)(nil),  // 11: api.SetExpirationRequest
+	(*SetExpirationResponse)(nil), // 12: api.SetExpirationResponse
+	(*SecretOperation)(nil),       // 13: api.SecretOperation
+	(*BatchSecretsRequest)(nil),   // 14: api.BatchSecretsRequest
+	(*SecretOperationResult)(nil), // 15: api.SecretOperationResult
+	(*BatchSecretsResponse)(nil),  // 16: api.BatchSecretsResponse
+	(*SearchSecretsRequest)(nil),  // 17: api.SearchSecretsRequest
+	(*SearchSecretsResponse)(nil), // 18: api.SearchSecretsResponse
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
 }
 var file_api_keeper_proto_depIdxs = []int32{
-	11, // 0: api.SecretMetadata.created:type_name -> google.protobuf.Timestamp
-	11, // 1: api.SecretMetadata.updated:type_name -> google.protobuf.Timestamp
-	0,  // 2: api.GetSecretsResponse.secrets:type_name -> api.SecretMetadata
-	0,  // 3: api.AddSecretRequest.meta:type_name -> api.SecretMetadata
-	0,  // 4: api.AddSecretResponse.meta:type_name -> api.SecretMetadata
-	0,  // 5: api.GetSecretRequest.meta:type_name -> api.SecretMetadata
-	0,  // 6: api.GetSecretResponse.meta:type_name -> api.SecretMetadata
-	0,  // 7: api.DeleteSecretRequest.meta:type_name -> api.SecretMetadata
-	0,  // 8: api.DeleteSecretResponse.meta:type_name -> api.SecretMetadata
-	0,  // 9: api.EditSecretRequest.meta:type_name -> api.SecretMetadata
-	0,  // 10: api.EditSecretResponse.meta:type_name -> api.SecretMetadata
-	1,  // 11: api.Keeper.GetSecrets:input_type -> api.GetSecretsRequest
-	3,  // 12: api.Keeper.AddSecret:input_type -> api.AddSecretRequest
-	5,  // 13: api.Keeper.GetSecret:input_type -> api.GetSecretRequest
-	7,  // 14: api.Keeper.DeleteSecret:input_type -> api.DeleteSecretRequest
-	9,  // 15: api.Keeper.EditSecret:input_type -> api.EditSecretRequest
-	2,  // 16: api.Keeper.GetSecrets:output_type -> api.GetSecretsResponse
-	4,  // 17: api.Keeper.AddSecret:output_type -> api.AddSecretResponse
-	6,  // 18: api.Keeper.GetSecret:output_type -> api.GetSecretResponse
-	8,  // 19: api.Keeper.DeleteSecret:output_type -> api.DeleteSecretResponse
-	10, // 20: api.Keeper.EditSecret:output_type -> api.EditSecretResponse
-	16, // [16:21] is the sub-list for method output_type
-	11, // [11:16] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	19, // 0: api.SecretMetadata.created:type_name -> google.protobuf.Timestamp
+	19, // 1: api.SecretMetadata.updated:type_name -> google.protobuf.Timestamp
+	19, // 2: api.SecretMetadata.expires:type_name -> google.protobuf.Timestamp
+	0,  // 3: api.GetSecretsResponse.secrets:type_name -> api.SecretMetadata
+	0,  // 4: api.AddSecretRequest.meta:type_name -> api.SecretMetadata
+	0,  // 5: api.AddSecretResponse.meta:type_name -> api.SecretMetadata
+	0,  // 6: api.GetSecretRequest.meta:type_name -> api.SecretMetadata
+	0,  // 7: api.GetSecretResponse.meta:type_name -> api.SecretMetadata
+	0,  // 8: api.DeleteSecretRequest.meta:type_name -> api.SecretMetadata
+	0,  // 9: api.DeleteSecretResponse.meta:type_name -> api.SecretMetadata
+	0,  // 10: api.EditSecretRequest.meta:type_name -> api.SecretMetadata
+	0,  // 11: api.EditSecretResponse.meta:type_name -> api.SecretMetadata
+	0,  // 12: api.SetExpirationRequest.meta:type_name -> api.SecretMetadata
+	0,  // 13: api.SetExpirationResponse.meta:type_name -> api.SecretMetadata
+	3,  // 14: api.SecretOperation.add:type_name -> api.AddSecretRequest
+	9,  // 15: api.SecretOperation.edit:type_name -> api.EditSecretRequest
+	7,  // 16: api.SecretOperation.delete:type_name -> api.DeleteSecretRequest
+	13, // 17: api.BatchSecretsRequest.operations:type_name -> api.SecretOperation
+	0,  // 18: api.SecretOperationResult.meta:type_name -> api.SecretMetadata
+	15, // 19: api.BatchSecretsResponse.results:type_name -> api.SecretOperationResult
+	0,  // 20: api.SearchSecretsResponse.secrets:type_name -> api.SecretMetadata
+	1,  // 21: api.Keeper.GetSecrets:input_type -> api.GetSecretsRequest
+	3,  // 22: api.Keeper.AddSecret:input_type -> api.AddSecretRequest
+	5,  // 23: api.Keeper.GetSecret:input_type -> api.GetSecretRequest
+	7,  // 24: api.Keeper.DeleteSecret:input_type -> api.DeleteSecretRequest
+	9,  // 25: api.Keeper.EditSecret:input_type -> api.EditSecretRequest
+	11, // 26: api.Keeper.SetExpiration:input_type -> api.SetExpirationRequest
+	14, // 27: api.Keeper.BatchSecrets:input_type -> api.BatchSecretsRequest
+	17, // 28: api.Keeper.SearchSecrets:input_type -> api.SearchSecretsRequest
+	2,  // 29: api.Keeper.GetSecrets:output_type -> api.GetSecretsResponse
+	4,  // 30: api.Keeper.AddSecret:output_type -> api.AddSecretResponse
+	6,  // 31: api.Keeper.GetSecret:output_type -> api.GetSecretResponse
+	8,  // 32: api.Keeper.DeleteSecret:output_type -> api.DeleteSecretResponse
+	10, // 33: api.Keeper.EditSecret:output_type -> api.EditSecretResponse
+	12, // 34: api.Keeper.SetExpiration:output_type -> api.SetExpirationResponse
+	16, // 35: api.Keeper.BatchSecrets:output_type -> api.BatchSecretsResponse
+	18, // 36: api.Keeper.SearchSecrets:output_type -> api.SearchSecretsResponse
+	29, // [29:37] is the sub-list for method output_type
+	21, // [21:29] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_api_keeper_proto_init() }
@@ -662,13 +1256,18 @@ func file_api_keeper_proto_init() {
 		return
 	}
 	file_api_keeper_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_keeper_proto_msgTypes[13].OneofWrappers = []any{
+		(*SecretOperation_Add)(nil),
+		(*SecretOperation_Edit)(nil),
+		(*SecretOperation_Delete)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_keeper_proto_rawDesc), len(file_api_keeper_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
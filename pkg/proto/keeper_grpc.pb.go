@@ -19,11 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Keeper_GetSecrets_FullMethodName   = "/api.Keeper/GetSecrets"
-	Keeper_AddSecret_FullMethodName    = "/api.Keeper/AddSecret"
-	Keeper_GetSecret_FullMethodName    = "/api.Keeper/GetSecret"
-	Keeper_DeleteSecret_FullMethodName = "/api.Keeper/DeleteSecret"
-	Keeper_EditSecret_FullMethodName   = "/api.Keeper/EditSecret"
+	Keeper_GetSecrets_FullMethodName    = "/api.Keeper/GetSecrets"
+	Keeper_AddSecret_FullMethodName     = "/api.Keeper/AddSecret"
+	Keeper_GetSecret_FullMethodName     = "/api.Keeper/GetSecret"
+	Keeper_DeleteSecret_FullMethodName  = "/api.Keeper/DeleteSecret"
+	Keeper_EditSecret_FullMethodName    = "/api.Keeper/EditSecret"
+	Keeper_SetExpiration_FullMethodName = "/api.Keeper/SetExpiration"
+	Keeper_BatchSecrets_FullMethodName  = "/api.Keeper/BatchSecrets"
+	Keeper_SearchSecrets_FullMethodName = "/api.Keeper/SearchSecrets"
 )
 
 // KeeperClient is the client API for Keeper service.
@@ -35,6 +38,9 @@ type KeeperClient interface {
 	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
 	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
 	EditSecret(ctx context.Context, in *EditSecretRequest, opts ...grpc.CallOption) (*EditSecretResponse, error)
+	SetExpiration(ctx context.Context, in *SetExpirationRequest, opts ...grpc.CallOption) (*SetExpirationResponse, error)
+	BatchSecrets(ctx context.Context, in *BatchSecretsRequest, opts ...grpc.CallOption) (*BatchSecretsResponse, error)
+	SearchSecrets(ctx context.Context, in *SearchSecretsRequest, opts ...grpc.CallOption) (*SearchSecretsResponse, error)
 }
 
 type keeperClient struct {
@@ -95,6 +101,36 @@ func (c *keeperClient) EditSecret(ctx context.Context, in *EditSecretRequest, op
 	return out, nil
 }
 
+func (c *keeperClient) SetExpiration(ctx context.Context, in *SetExpirationRequest, opts ...grpc.CallOption) (*SetExpirationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetExpirationResponse)
+	err := c.cc.Invoke(ctx, Keeper_SetExpiration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) BatchSecrets(ctx context.Context, in *BatchSecretsRequest, opts ...grpc.CallOption) (*BatchSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchSecretsResponse)
+	err := c.cc.Invoke(ctx, Keeper_BatchSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperClient) SearchSecrets(ctx context.Context, in *SearchSecretsRequest, opts ...grpc.CallOption) (*SearchSecretsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSecretsResponse)
+	err := c.cc.Invoke(ctx, Keeper_SearchSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KeeperServer is the server API for Keeper service.
 // All implementations must embed UnimplementedKeeperServer
 // for forward compatibility.
@@ -104,6 +140,9 @@ type KeeperServer interface {
 	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
 	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
 	EditSecret(context.Context, *EditSecretRequest) (*EditSecretResponse, error)
+	SetExpiration(context.Context, *SetExpirationRequest) (*SetExpirationResponse, error)
+	BatchSecrets(context.Context, *BatchSecretsRequest) (*BatchSecretsResponse, error)
+	SearchSecrets(context.Context, *SearchSecretsRequest) (*SearchSecretsResponse, error)
 	mustEmbedUnimplementedKeeperServer()
 }
 
@@ -129,6 +168,15 @@ func (UnimplementedKeeperServer) DeleteSecret(context.Context, *DeleteSecretRequ
 func (UnimplementedKeeperServer) EditSecret(context.Context, *EditSecretRequest) (*EditSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EditSecret not implemented")
 }
+func (UnimplementedKeeperServer) SetExpiration(context.Context, *SetExpirationRequest) (*SetExpirationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExpiration not implemented")
+}
+func (UnimplementedKeeperServer) BatchSecrets(context.Context, *BatchSecretsRequest) (*BatchSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSecrets not implemented")
+}
+func (UnimplementedKeeperServer) SearchSecrets(context.Context, *SearchSecretsRequest) (*SearchSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSecrets not implemented")
+}
 func (UnimplementedKeeperServer) mustEmbedUnimplementedKeeperServer() {}
 func (UnimplementedKeeperServer) testEmbeddedByValue()                {}
 
@@ -240,6 +288,60 @@ func _Keeper_EditSecret_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Keeper_SetExpiration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExpirationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).SetExpiration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keeper_SetExpiration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).SetExpiration(ctx, req.(*SetExpirationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_BatchSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).BatchSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keeper_BatchSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).BatchSecrets(ctx, req.(*BatchSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keeper_SearchSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServer).SearchSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keeper_SearchSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServer).SearchSecrets(ctx, req.(*SearchSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Keeper_ServiceDesc is the grpc.ServiceDesc for Keeper service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +369,18 @@ var Keeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EditSecret",
 			Handler:    _Keeper_EditSecret_Handler,
 		},
+		{
+			MethodName: "SetExpiration",
+			Handler:    _Keeper_SetExpiration_Handler,
+		},
+		{
+			MethodName: "BatchSecrets",
+			Handler:    _Keeper_BatchSecrets_Handler,
+		},
+		{
+			MethodName: "SearchSecrets",
+			Handler:    _Keeper_SearchSecrets_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/keeper.proto",
@@ -0,0 +1,655 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: api/keys.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SetKdfRequest - смена параметров получения ключа из пароля: ключ шифрования
+// передаётся зашифрованным ключом, полученным с новыми параметрами
+type SetKdfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kdf           *KdfParams             `protobuf:"bytes,1,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKdfRequest) Reset() {
+	*x = SetKdfRequest{}
+	mi := &file_api_keys_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKdfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKdfRequest) ProtoMessage() {}
+
+func (x *SetKdfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKdfRequest.ProtoReflect.Descriptor instead.
+func (*SetKdfRequest) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetKdfRequest) GetKdf() *KdfParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
+func (x *SetKdfRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+type SetKdfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKdfResponse) Reset() {
+	*x = SetKdfResponse{}
+	mi := &file_api_keys_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKdfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKdfResponse) ProtoMessage() {}
+
+func (x *SetKdfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKdfResponse.ProtoReflect.Descriptor instead.
+func (*SetKdfResponse) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{1}
+}
+
+// SetKeyCheckRequest - контрольное значение ключа шифрования: константа,
+// зашифрованная ключом хранилища (позволяет обнаружить неверный секрет после входа)
+type SetKeyCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyCheck      []byte                 `protobuf:"bytes,1,opt,name=key_check,json=keyCheck,proto3" json:"key_check,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyCheckRequest) Reset() {
+	*x = SetKeyCheckRequest{}
+	mi := &file_api_keys_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyCheckRequest) ProtoMessage() {}
+
+func (x *SetKeyCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyCheckRequest.ProtoReflect.Descriptor instead.
+func (*SetKeyCheckRequest) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetKeyCheckRequest) GetKeyCheck() []byte {
+	if x != nil {
+		return x.KeyCheck
+	}
+	return nil
+}
+
+type SetKeyCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyCheckResponse) Reset() {
+	*x = SetKeyCheckResponse{}
+	mi := &file_api_keys_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyCheckResponse) ProtoMessage() {}
+
+func (x *SetKeyCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyCheckResponse.ProtoReflect.Descriptor instead.
+func (*SetKeyCheckResponse) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{3}
+}
+
+// SetRecoveryKeyRequest - ключ шифрования, зашифрованный ключом восстановления
+// (заменяет прежний: прежний ключ восстановления перестаёт действовать)
+type SetRecoveryKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryKey   []byte                 `protobuf:"bytes,1,opt,name=recovery_key,json=recoveryKey,proto3" json:"recovery_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRecoveryKeyRequest) Reset() {
+	*x = SetRecoveryKeyRequest{}
+	mi := &file_api_keys_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRecoveryKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRecoveryKeyRequest) ProtoMessage() {}
+
+func (x *SetRecoveryKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRecoveryKeyRequest.ProtoReflect.Descriptor instead.
+func (*SetRecoveryKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetRecoveryKeyRequest) GetRecoveryKey() []byte {
+	if x != nil {
+		return x.RecoveryKey
+	}
+	return nil
+}
+
+type SetRecoveryKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRecoveryKeyResponse) Reset() {
+	*x = SetRecoveryKeyResponse{}
+	mi := &file_api_keys_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRecoveryKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRecoveryKeyResponse) ProtoMessage() {}
+
+func (x *SetRecoveryKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRecoveryKeyResponse.ProtoReflect.Descriptor instead.
+func (*SetRecoveryKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{5}
+}
+
+type GetRecoveryKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecoveryKeyRequest) Reset() {
+	*x = GetRecoveryKeyRequest{}
+	mi := &file_api_keys_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecoveryKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecoveryKeyRequest) ProtoMessage() {}
+
+func (x *GetRecoveryKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecoveryKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetRecoveryKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{6}
+}
+
+type GetRecoveryKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryKey   []byte                 `protobuf:"bytes,1,opt,name=recovery_key,json=recoveryKey,proto3" json:"recovery_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecoveryKeyResponse) Reset() {
+	*x = GetRecoveryKeyResponse{}
+	mi := &file_api_keys_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecoveryKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecoveryKeyResponse) ProtoMessage() {}
+
+func (x *GetRecoveryKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecoveryKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetRecoveryKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRecoveryKeyResponse) GetRecoveryKey() []byte {
+	if x != nil {
+		return x.RecoveryKey
+	}
+	return nil
+}
+
+// SetVaultRootRequest - состояние личного хранилища, подписанное на клиенте
+// (корень дерева Меркла над идентификаторами, ревизиями и хешами содержимого секретов).
+// Сохраняется, только если номер сохранённого состояния равен previous_sequence.
+type SetVaultRootRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VaultRoot        []byte                 `protobuf:"bytes,1,opt,name=vault_root,json=vaultRoot,proto3" json:"vault_root,omitempty"`
+	Sequence         int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	PreviousSequence int64                  `protobuf:"varint,3,opt,name=previous_sequence,json=previousSequence,proto3" json:"previous_sequence,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetVaultRootRequest) Reset() {
+	*x = SetVaultRootRequest{}
+	mi := &file_api_keys_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVaultRootRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVaultRootRequest) ProtoMessage() {}
+
+func (x *SetVaultRootRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVaultRootRequest.ProtoReflect.Descriptor instead.
+func (*SetVaultRootRequest) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetVaultRootRequest) GetVaultRoot() []byte {
+	if x != nil {
+		return x.VaultRoot
+	}
+	return nil
+}
+
+func (x *SetVaultRootRequest) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SetVaultRootRequest) GetPreviousSequence() int64 {
+	if x != nil {
+		return x.PreviousSequence
+	}
+	return 0
+}
+
+type SetVaultRootResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVaultRootResponse) Reset() {
+	*x = SetVaultRootResponse{}
+	mi := &file_api_keys_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVaultRootResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVaultRootResponse) ProtoMessage() {}
+
+func (x *SetVaultRootResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVaultRootResponse.ProtoReflect.Descriptor instead.
+func (*SetVaultRootResponse) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{9}
+}
+
+type GetVaultRootRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVaultRootRequest) Reset() {
+	*x = GetVaultRootRequest{}
+	mi := &file_api_keys_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVaultRootRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVaultRootRequest) ProtoMessage() {}
+
+func (x *GetVaultRootRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVaultRootRequest.ProtoReflect.Descriptor instead.
+func (*GetVaultRootRequest) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{10}
+}
+
+type GetVaultRootResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VaultRoot     []byte                 `protobuf:"bytes,1,opt,name=vault_root,json=vaultRoot,proto3" json:"vault_root,omitempty"`
+	Sequence      int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVaultRootResponse) Reset() {
+	*x = GetVaultRootResponse{}
+	mi := &file_api_keys_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVaultRootResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVaultRootResponse) ProtoMessage() {}
+
+func (x *GetVaultRootResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_keys_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVaultRootResponse.ProtoReflect.Descriptor instead.
+func (*GetVaultRootResponse) Descriptor() ([]byte, []int) {
+	return file_api_keys_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetVaultRootResponse) GetVaultRoot() []byte {
+	if x != nil {
+		return x.VaultRoot
+	}
+	return nil
+}
+
+func (x *GetVaultRootResponse) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+var File_api_keys_proto protoreflect.FileDescriptor
+
+const file_api_keys_proto_rawDesc = "" +
+	"\n" +
+	"\x0eapi/keys.proto\x12\x03api\x1a\x0eapi/user.proto\"R\n" +
+	"\rSetKdfRequest\x12 \n" +
+	"\x03kdf\x18\x01 \x01(\v2\x0e.api.KdfParamsR\x03kdf\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\"\x10\n" +
+	"\x0eSetKdfResponse\"1\n" +
+	"\x12SetKeyCheckRequest\x12\x1b\n" +
+	"\tkey_check\x18\x01 \x01(\fR\bkeyCheck\"\x15\n" +
+	"\x13SetKeyCheckResponse\":\n" +
+	"\x15SetRecoveryKeyRequest\x12!\n" +
+	"\frecovery_key\x18\x01 \x01(\fR\vrecoveryKey\"\x18\n" +
+	"\x16SetRecoveryKeyResponse\"\x17\n" +
+	"\x15GetRecoveryKeyRequest\";\n" +
+	"\x16GetRecoveryKeyResponse\x12!\n" +
+	"\frecovery_key\x18\x01 \x01(\fR\vrecoveryKey\"}\n" +
+	"\x13SetVaultRootRequest\x12\x1d\n" +
+	"\n" +
+	"vault_root\x18\x01 \x01(\fR\tvaultRoot\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\x12+\n" +
+	"\x11previous_sequence\x18\x03 \x01(\x03R\x10previousSequence\"\x16\n" +
+	"\x14SetVaultRootResponse\"\x15\n" +
+	"\x13GetVaultRootRequest\"Q\n" +
+	"\x14GetVaultRootResponse\x12\x1d\n" +
+	"\n" +
+	"vault_root\x18\x01 \x01(\fR\tvaultRoot\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence2\x9b\x03\n" +
+	"\x04Keys\x121\n" +
+	"\x06SetKdf\x12\x12.api.SetKdfRequest\x1a\x13.api.SetKdfResponse\x12@\n" +
+	"\vSetKeyCheck\x12\x17.api.SetKeyCheckRequest\x1a\x18.api.SetKeyCheckResponse\x12I\n" +
+	"\x0eSetRecoveryKey\x12\x1a.api.SetRecoveryKeyRequest\x1a\x1b.api.SetRecoveryKeyResponse\x12I\n" +
+	"\x0eGetRecoveryKey\x12\x1a.api.GetRecoveryKeyRequest\x1a\x1b.api.GetRecoveryKeyResponse\x12C\n" +
+	"\fSetVaultRoot\x12\x18.api.SetVaultRootRequest\x1a\x19.api.SetVaultRootResponse\x12C\n" +
+	"\fGetVaultRoot\x12\x18.api.GetVaultRootRequest\x1a\x19.api.GetVaultRootResponseB\vZ\tpkg/protob\x06proto3"
+
+var (
+	file_api_keys_proto_rawDescOnce sync.Once
+	file_api_keys_proto_rawDescData []byte
+)
+
+func file_api_keys_proto_rawDescGZIP() []byte {
+	file_api_keys_proto_rawDescOnce.Do(func() {
+		file_api_keys_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_keys_proto_rawDesc), len(file_api_keys_proto_rawDesc)))
+	})
+	return file_api_keys_proto_rawDescData
+}
+
+var file_api_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_keys_proto_goTypes = []any{
+	(*SetKdfRequest)(nil),          // 0: api.SetKdfRequest
+	(*SetKdfResponse)(nil),         // 1: api.SetKdfResponse
+	(*SetKeyCheckRequest)(nil),     // 2: api.SetKeyCheckRequest
+	(*SetKeyCheckResponse)(nil),    // 3: api.SetKeyCheckResponse
+	(*SetRecoveryKeyRequest)(nil),  // 4: api.SetRecoveryKeyRequest
+	(*SetRecoveryKeyResponse)(nil), // 5: api.SetRecoveryKeyResponse
+	(*GetRecoveryKeyRequest)(nil),  // 6: api.GetRecoveryKeyRequest
+	(*GetRecoveryKeyResponse)(nil), // 7: api.GetRecoveryKeyResponse
+	(*SetVaultRootRequest)(nil),    // 8: api.SetVaultRootRequest
+	(*SetVaultRootResponse)(nil),   // 9: api.SetVaultRootResponse
+	(*GetVaultRootRequest)(nil),    // 10: api.GetVaultRootRequest
+	(*GetVaultRootResponse)(nil),   // 11: api.GetVaultRootResponse
+	(*KdfParams)(nil),              // 12: api.KdfParams
+}
+var file_api_keys_proto_depIdxs = []int32{
+	12, // 0: api.SetKdfRequest.kdf:type_name -> api.KdfParams
+	0,  // 1: api.Keys.SetKdf:input_type -> api.SetKdfRequest
+	2,  // 2: api.Keys.SetKeyCheck:input_type -> api.SetKeyCheckRequest
+	4,  // 3: api.Keys.SetRecoveryKey:input_type -> api.SetRecoveryKeyRequest
+	6,  // 4: api.Keys.GetRecoveryKey:input_type -> api.GetRecoveryKeyRequest
+	8,  // 5: api.Keys.SetVaultRoot:input_type -> api.SetVaultRootRequest
+	10, // 6: api.Keys.GetVaultRoot:input_type -> api.GetVaultRootRequest
+	1,  // 7: api.Keys.SetKdf:output_type -> api.SetKdfResponse
+	3,  // 8: api.Keys.SetKeyCheck:output_type -> api.SetKeyCheckResponse
+	5,  // 9: api.Keys.SetRecoveryKey:output_type -> api.SetRecoveryKeyResponse
+	7,  // 10: api.Keys.GetRecoveryKey:output_type -> api.GetRecoveryKeyResponse
+	9,  // 11: api.Keys.SetVaultRoot:output_type -> api.SetVaultRootResponse
+	11, // 12: api.Keys.GetVaultRoot:output_type -> api.GetVaultRootResponse
+	7,  // [7:13] is the sub-list for method output_type
+	1,  // [1:7] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_api_keys_proto_init() }
+func file_api_keys_proto_init() {
+	if File_api_keys_proto != nil {
+		return
+	}
+	file_api_user_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_keys_proto_rawDesc), len(file_api_keys_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_keys_proto_goTypes,
+		DependencyIndexes: file_api_keys_proto_depIdxs,
+		MessageInfos:      file_api_keys_proto_msgTypes,
+	}.Build()
+	File_api_keys_proto = out.File
+	file_api_keys_proto_goTypes = nil
+	file_api_keys_proto_depIdxs = nil
+}
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: api/keys.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Keys_SetKdf_FullMethodName         = "/api.Keys/SetKdf"
+	Keys_SetKeyCheck_FullMethodName    = "/api.Keys/SetKeyCheck"
+	Keys_SetRecoveryKey_FullMethodName = "/api.Keys/SetRecoveryKey"
+	Keys_GetRecoveryKey_FullMethodName = "/api.Keys/GetRecoveryKey"
+	Keys_SetVaultRoot_FullMethodName   = "/api.Keys/SetVaultRoot"
+	Keys_GetVaultRoot_FullMethodName   = "/api.Keys/GetVaultRoot"
+)
+
+// KeysClient is the client API for Keys service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KeysClient interface {
+	SetKdf(ctx context.Context, in *SetKdfRequest, opts ...grpc.CallOption) (*SetKdfResponse, error)
+	SetKeyCheck(ctx context.Context, in *SetKeyCheckRequest, opts ...grpc.CallOption) (*SetKeyCheckResponse, error)
+	SetRecoveryKey(ctx context.Context, in *SetRecoveryKeyRequest, opts ...grpc.CallOption) (*SetRecoveryKeyResponse, error)
+	GetRecoveryKey(ctx context.Context, in *GetRecoveryKeyRequest, opts ...grpc.CallOption) (*GetRecoveryKeyResponse, error)
+	SetVaultRoot(ctx context.Context, in *SetVaultRootRequest, opts ...grpc.CallOption) (*SetVaultRootResponse, error)
+	GetVaultRoot(ctx context.Context, in *GetVaultRootRequest, opts ...grpc.CallOption) (*GetVaultRootResponse, error)
+}
+
+type keysClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeysClient(cc grpc.ClientConnInterface) KeysClient {
+	return &keysClient{cc}
+}
+
+func (c *keysClient) SetKdf(ctx context.Context, in *SetKdfRequest, opts ...grpc.CallOption) (*SetKdfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetKdfResponse)
+	err := c.cc.Invoke(ctx, Keys_SetKdf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keysClient) SetKeyCheck(ctx context.Context, in *SetKeyCheckRequest, opts ...grpc.CallOption) (*SetKeyCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetKeyCheckResponse)
+	err := c.cc.Invoke(ctx, Keys_SetKeyCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keysClient) SetRecoveryKey(ctx context.Context, in *SetRecoveryKeyRequest, opts ...grpc.CallOption) (*SetRecoveryKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRecoveryKeyResponse)
+	err := c.cc.Invoke(ctx, Keys_SetRecoveryKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keysClient) GetRecoveryKey(ctx context.Context, in *GetRecoveryKeyRequest, opts ...grpc.CallOption) (*GetRecoveryKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecoveryKeyResponse)
+	err := c.cc.Invoke(ctx, Keys_GetRecoveryKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keysClient) SetVaultRoot(ctx context.Context, in *SetVaultRootRequest, opts ...grpc.CallOption) (*SetVaultRootResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetVaultRootResponse)
+	err := c.cc.Invoke(ctx, Keys_SetVaultRoot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keysClient) GetVaultRoot(ctx context.Context, in *GetVaultRootRequest, opts ...grpc.CallOption) (*GetVaultRootResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVaultRootResponse)
+	err := c.cc.Invoke(ctx, Keys_GetVaultRoot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeysServer is the server API for Keys service.
+// All implementations must embed UnimplementedKeysServer
+// for forward compatibility.
+type KeysServer interface {
+	SetKdf(context.Context, *SetKdfRequest) (*SetKdfResponse, error)
+	SetKeyCheck(context.Context, *SetKeyCheckRequest) (*SetKeyCheckResponse, error)
+	SetRecoveryKey(context.Context, *SetRecoveryKeyRequest) (*SetRecoveryKeyResponse, error)
+	GetRecoveryKey(context.Context, *GetRecoveryKeyRequest) (*GetRecoveryKeyResponse, error)
+	SetVaultRoot(context.Context, *SetVaultRootRequest) (*SetVaultRootResponse, error)
+	GetVaultRoot(context.Context, *GetVaultRootRequest) (*GetVaultRootResponse, error)
+	mustEmbedUnimplementedKeysServer()
+}
+
+// UnimplementedKeysServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeysServer struct{}
+
+func (UnimplementedKeysServer) SetKdf(context.Context, *SetKdfRequest) (*SetKdfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKdf not implemented")
+}
+func (UnimplementedKeysServer) SetKeyCheck(context.Context, *SetKeyCheckRequest) (*SetKeyCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyCheck not implemented")
+}
+func (UnimplementedKeysServer) SetRecoveryKey(context.Context, *SetRecoveryKeyRequest) (*SetRecoveryKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRecoveryKey not implemented")
+}
+func (UnimplementedKeysServer) GetRecoveryKey(context.Context, *GetRecoveryKeyRequest) (*GetRecoveryKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecoveryKey not implemented")
+}
+func (UnimplementedKeysServer) SetVaultRoot(context.Context, *SetVaultRootRequest) (*SetVaultRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultRoot not implemented")
+}
+func (UnimplementedKeysServer) GetVaultRoot(context.Context, *GetVaultRootRequest) (*GetVaultRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultRoot not implemented")
+}
+func (UnimplementedKeysServer) mustEmbedUnimplementedKeysServer() {}
+func (UnimplementedKeysServer) testEmbeddedByValue()              {}
+
+// UnsafeKeysServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeysServer will
+// result in compilation errors.
+type UnsafeKeysServer interface {
+	mustEmbedUnimplementedKeysServer()
+}
+
+func RegisterKeysServer(s grpc.ServiceRegistrar, srv KeysServer) {
+	// If the following call pancis, it indicates UnimplementedKeysServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Keys_ServiceDesc, srv)
+}
+
+func _Keys_SetKdf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKdfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeysServer).SetKdf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keys_SetKdf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeysServer).SetKdf(ctx, req.(*SetKdfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keys_SetKeyCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeysServer).SetKeyCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keys_SetKeyCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeysServer).SetKeyCheck(ctx, req.(*SetKeyCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keys_SetRecoveryKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRecoveryKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeysServer).SetRecoveryKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keys_SetRecoveryKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeysServer).SetRecoveryKey(ctx, req.(*SetRecoveryKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keys_GetRecoveryKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecoveryKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeysServer).GetRecoveryKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keys_GetRecoveryKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeysServer).GetRecoveryKey(ctx, req.(*GetRecoveryKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keys_SetVaultRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeysServer).SetVaultRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keys_SetVaultRoot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeysServer).SetVaultRoot(ctx, req.(*SetVaultRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Keys_GetVaultRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVaultRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeysServer).GetVaultRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Keys_GetVaultRoot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeysServer).GetVaultRoot(ctx, req.(*GetVaultRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Keys_ServiceDesc is the grpc.ServiceDesc for Keys service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Keys_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Keys",
+	HandlerType: (*KeysServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetKdf",
+			Handler:    _Keys_SetKdf_Handler,
+		},
+		{
+			MethodName: "SetKeyCheck",
+			Handler:    _Keys_SetKeyCheck_Handler,
+		},
+		{
+			MethodName: "SetRecoveryKey",
+			Handler:    _Keys_SetRecoveryKey_Handler,
+		},
+		{
+			MethodName: "GetRecoveryKey",
+			Handler:    _Keys_GetRecoveryKey_Handler,
+		},
+		{
+			MethodName: "SetVaultRoot",
+			Handler:    _Keys_SetVaultRoot_Handler,
+		},
+		{
+			MethodName: "GetVaultRoot",
+			Handler:    _Keys_GetVaultRoot_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/keys.proto",
+}
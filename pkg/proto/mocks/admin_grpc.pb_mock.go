@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\proto\admin_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg\proto\admin_grpc.pb.go -destination=pkg\proto\mocks\admin_grpc.pb_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	proto "go-pass-keeper/pkg/proto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockAdminClient is a mock of AdminClient interface.
+type MockAdminClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminClientMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminClientMockRecorder is the mock recorder for MockAdminClient.
+type MockAdminClientMockRecorder struct {
+	mock *MockAdminClient
+}
+
+// NewMockAdminClient creates a new mock instance.
+func NewMockAdminClient(ctrl *gomock.Controller) *MockAdminClient {
+	mock := &MockAdminClient{ctrl: ctrl}
+	mock.recorder = &MockAdminClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminClient) EXPECT() *MockAdminClientMockRecorder {
+	return m.recorder
+}
+
+// DisableUser mocks base method.
+func (m *MockAdminClient) DisableUser(ctx context.Context, in *proto.DisableUserRequest, opts ...grpc.CallOption) (*proto.DisableUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableUser", varargs...)
+	ret0, _ := ret[0].(*proto.DisableUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockAdminClientMockRecorder) DisableUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockAdminClient)(nil).DisableUser), varargs...)
+}
+
+// EnableUser mocks base method.
+func (m *MockAdminClient) EnableUser(ctx context.Context, in *proto.EnableUserRequest, opts ...grpc.CallOption) (*proto.EnableUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableUser", varargs...)
+	ret0, _ := ret[0].(*proto.EnableUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockAdminClientMockRecorder) EnableUser(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockAdminClient)(nil).EnableUser), varargs...)
+}
+
+// ForceLogout mocks base method.
+func (m *MockAdminClient) ForceLogout(ctx context.Context, in *proto.ForceLogoutRequest, opts ...grpc.CallOption) (*proto.ForceLogoutResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ForceLogout", varargs...)
+	ret0, _ := ret[0].(*proto.ForceLogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockAdminClientMockRecorder) ForceLogout(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockAdminClient)(nil).ForceLogout), varargs...)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminClient) ListUsers(ctx context.Context, in *proto.ListUsersRequest, opts ...grpc.CallOption) (*proto.ListUsersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUsers", varargs...)
+	ret0, _ := ret[0].(*proto.ListUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminClientMockRecorder) ListUsers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminClient)(nil).ListUsers), varargs...)
+}
+
+// MockAdminServer is a mock of AdminServer interface.
+type MockAdminServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServerMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminServerMockRecorder is the mock recorder for MockAdminServer.
+type MockAdminServerMockRecorder struct {
+	mock *MockAdminServer
+}
+
+// NewMockAdminServer creates a new mock instance.
+func NewMockAdminServer(ctrl *gomock.Controller) *MockAdminServer {
+	mock := &MockAdminServer{ctrl: ctrl}
+	mock.recorder = &MockAdminServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminServer) EXPECT() *MockAdminServerMockRecorder {
+	return m.recorder
+}
+
+// DisableUser mocks base method.
+func (m *MockAdminServer) DisableUser(arg0 context.Context, arg1 *proto.DisableUserRequest) (*proto.DisableUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", arg0, arg1)
+	ret0, _ := ret[0].(*proto.DisableUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockAdminServerMockRecorder) DisableUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockAdminServer)(nil).DisableUser), arg0, arg1)
+}
+
+// EnableUser mocks base method.
+func (m *MockAdminServer) EnableUser(arg0 context.Context, arg1 *proto.EnableUserRequest) (*proto.EnableUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", arg0, arg1)
+	ret0, _ := ret[0].(*proto.EnableUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockAdminServerMockRecorder) EnableUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockAdminServer)(nil).EnableUser), arg0, arg1)
+}
+
+// ForceLogout mocks base method.
+func (m *MockAdminServer) ForceLogout(arg0 context.Context, arg1 *proto.ForceLogoutRequest) (*proto.ForceLogoutResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ForceLogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockAdminServerMockRecorder) ForceLogout(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockAdminServer)(nil).ForceLogout), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminServer) ListUsers(arg0 context.Context, arg1 *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminServerMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminServer)(nil).ListUsers), arg0, arg1)
+}
+
+// mustEmbedUnimplementedAdminServer mocks base method.
+func (m *MockAdminServer) mustEmbedUnimplementedAdminServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAdminServer")
+}
+
+// mustEmbedUnimplementedAdminServer indicates an expected call of mustEmbedUnimplementedAdminServer.
+func (mr *MockAdminServerMockRecorder) mustEmbedUnimplementedAdminServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAdminServer", reflect.TypeOf((*MockAdminServer)(nil).mustEmbedUnimplementedAdminServer))
+}
+
+// MockUnsafeAdminServer is a mock of UnsafeAdminServer interface.
+type MockUnsafeAdminServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeAdminServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeAdminServerMockRecorder is the mock recorder for MockUnsafeAdminServer.
+type MockUnsafeAdminServerMockRecorder struct {
+	mock *MockUnsafeAdminServer
+}
+
+// NewMockUnsafeAdminServer creates a new mock instance.
+func NewMockUnsafeAdminServer(ctrl *gomock.Controller) *MockUnsafeAdminServer {
+	mock := &MockUnsafeAdminServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeAdminServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeAdminServer) EXPECT() *MockUnsafeAdminServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedAdminServer mocks base method.
+func (m *MockUnsafeAdminServer) mustEmbedUnimplementedAdminServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAdminServer")
+}
+
+// mustEmbedUnimplementedAdminServer indicates an expected call of mustEmbedUnimplementedAdminServer.
+func (mr *MockUnsafeAdminServerMockRecorder) mustEmbedUnimplementedAdminServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAdminServer", reflect.TypeOf((*MockUnsafeAdminServer)(nil).mustEmbedUnimplementedAdminServer))
+}
@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\proto\attachment_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg\proto\attachment_grpc.pb.go -destination=pkg\proto\mocks\attachment_grpc.pb_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	proto "go-pass-keeper/pkg/proto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockAttachmentClient is a mock of AttachmentClient interface.
+type MockAttachmentClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentClientMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentClientMockRecorder is the mock recorder for MockAttachmentClient.
+type MockAttachmentClientMockRecorder struct {
+	mock *MockAttachmentClient
+}
+
+// NewMockAttachmentClient creates a new mock instance.
+func NewMockAttachmentClient(ctrl *gomock.Controller) *MockAttachmentClient {
+	mock := &MockAttachmentClient{ctrl: ctrl}
+	mock.recorder = &MockAttachmentClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentClient) EXPECT() *MockAttachmentClientMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachmentClient) AddAttachment(ctx context.Context, in *proto.AddAttachmentRequest, opts ...grpc.CallOption) (*proto.AddAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddAttachment", varargs...)
+	ret0, _ := ret[0].(*proto.AddAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentClientMockRecorder) AddAttachment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachmentClient)(nil).AddAttachment), varargs...)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentClient) DeleteAttachment(ctx context.Context, in *proto.DeleteAttachmentRequest, opts ...grpc.CallOption) (*proto.DeleteAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAttachment", varargs...)
+	ret0, _ := ret[0].(*proto.DeleteAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentClientMockRecorder) DeleteAttachment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentClient)(nil).DeleteAttachment), varargs...)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentClient) GetAttachment(ctx context.Context, in *proto.GetAttachmentRequest, opts ...grpc.CallOption) (*proto.GetAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAttachment", varargs...)
+	ret0, _ := ret[0].(*proto.GetAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentClientMockRecorder) GetAttachment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentClient)(nil).GetAttachment), varargs...)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachmentClient) GetAttachments(ctx context.Context, in *proto.GetAttachmentsRequest, opts ...grpc.CallOption) (*proto.GetAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAttachments", varargs...)
+	ret0, _ := ret[0].(*proto.GetAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentClientMockRecorder) GetAttachments(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachmentClient)(nil).GetAttachments), varargs...)
+}
+
+// MockAttachmentServer is a mock of AttachmentServer interface.
+type MockAttachmentServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentServerMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentServerMockRecorder is the mock recorder for MockAttachmentServer.
+type MockAttachmentServerMockRecorder struct {
+	mock *MockAttachmentServer
+}
+
+// NewMockAttachmentServer creates a new mock instance.
+func NewMockAttachmentServer(ctrl *gomock.Controller) *MockAttachmentServer {
+	mock := &MockAttachmentServer{ctrl: ctrl}
+	mock.recorder = &MockAttachmentServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentServer) EXPECT() *MockAttachmentServerMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachmentServer) AddAttachment(arg0 context.Context, arg1 *proto.AddAttachmentRequest) (*proto.AddAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*proto.AddAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentServerMockRecorder) AddAttachment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachmentServer)(nil).AddAttachment), arg0, arg1)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentServer) DeleteAttachment(arg0 context.Context, arg1 *proto.DeleteAttachmentRequest) (*proto.DeleteAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*proto.DeleteAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentServerMockRecorder) DeleteAttachment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentServer)(nil).DeleteAttachment), arg0, arg1)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentServer) GetAttachment(arg0 context.Context, arg1 *proto.GetAttachmentRequest) (*proto.GetAttachmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetAttachmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentServerMockRecorder) GetAttachment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentServer)(nil).GetAttachment), arg0, arg1)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachmentServer) GetAttachments(arg0 context.Context, arg1 *proto.GetAttachmentsRequest) (*proto.GetAttachmentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetAttachmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentServerMockRecorder) GetAttachments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachmentServer)(nil).GetAttachments), arg0, arg1)
+}
+
+// mustEmbedUnimplementedAttachmentServer mocks base method.
+func (m *MockAttachmentServer) mustEmbedUnimplementedAttachmentServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAttachmentServer")
+}
+
+// mustEmbedUnimplementedAttachmentServer indicates an expected call of mustEmbedUnimplementedAttachmentServer.
+func (mr *MockAttachmentServerMockRecorder) mustEmbedUnimplementedAttachmentServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAttachmentServer", reflect.TypeOf((*MockAttachmentServer)(nil).mustEmbedUnimplementedAttachmentServer))
+}
+
+// MockUnsafeAttachmentServer is a mock of UnsafeAttachmentServer interface.
+type MockUnsafeAttachmentServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeAttachmentServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeAttachmentServerMockRecorder is the mock recorder for MockUnsafeAttachmentServer.
+type MockUnsafeAttachmentServerMockRecorder struct {
+	mock *MockUnsafeAttachmentServer
+}
+
+// NewMockUnsafeAttachmentServer creates a new mock instance.
+func NewMockUnsafeAttachmentServer(ctrl *gomock.Controller) *MockUnsafeAttachmentServer {
+	mock := &MockUnsafeAttachmentServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeAttachmentServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeAttachmentServer) EXPECT() *MockUnsafeAttachmentServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedAttachmentServer mocks base method.
+func (m *MockUnsafeAttachmentServer) mustEmbedUnimplementedAttachmentServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedAttachmentServer")
+}
+
+// mustEmbedUnimplementedAttachmentServer indicates an expected call of mustEmbedUnimplementedAttachmentServer.
+func (mr *MockUnsafeAttachmentServerMockRecorder) mustEmbedUnimplementedAttachmentServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedAttachmentServer", reflect.TypeOf((*MockUnsafeAttachmentServer)(nil).mustEmbedUnimplementedAttachmentServer))
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\proto\escrow_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg\proto\escrow_grpc.pb.go -destination=pkg\proto\mocks\escrow_grpc.pb_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	proto "go-pass-keeper/pkg/proto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockEscrowClient is a mock of EscrowClient interface.
+type MockEscrowClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscrowClientMockRecorder
+	isgomock struct{}
+}
+
+// MockEscrowClientMockRecorder is the mock recorder for MockEscrowClient.
+type MockEscrowClientMockRecorder struct {
+	mock *MockEscrowClient
+}
+
+// NewMockEscrowClient creates a new mock instance.
+func NewMockEscrowClient(ctrl *gomock.Controller) *MockEscrowClient {
+	mock := &MockEscrowClient{ctrl: ctrl}
+	mock.recorder = &MockEscrowClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscrowClient) EXPECT() *MockEscrowClientMockRecorder {
+	return m.recorder
+}
+
+// GetEscrowKey mocks base method.
+func (m *MockEscrowClient) GetEscrowKey(ctx context.Context, in *proto.GetEscrowKeyRequest, opts ...grpc.CallOption) (*proto.GetEscrowKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEscrowKey", varargs...)
+	ret0, _ := ret[0].(*proto.GetEscrowKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowKey indicates an expected call of GetEscrowKey.
+func (mr *MockEscrowClientMockRecorder) GetEscrowKey(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowKey", reflect.TypeOf((*MockEscrowClient)(nil).GetEscrowKey), varargs...)
+}
+
+// SetEscrowKey mocks base method.
+func (m *MockEscrowClient) SetEscrowKey(ctx context.Context, in *proto.SetEscrowKeyRequest, opts ...grpc.CallOption) (*proto.SetEscrowKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetEscrowKey", varargs...)
+	ret0, _ := ret[0].(*proto.SetEscrowKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEscrowKey indicates an expected call of SetEscrowKey.
+func (mr *MockEscrowClientMockRecorder) SetEscrowKey(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEscrowKey", reflect.TypeOf((*MockEscrowClient)(nil).SetEscrowKey), varargs...)
+}
+
+// MockEscrowServer is a mock of EscrowServer interface.
+type MockEscrowServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscrowServerMockRecorder
+	isgomock struct{}
+}
+
+// MockEscrowServerMockRecorder is the mock recorder for MockEscrowServer.
+type MockEscrowServerMockRecorder struct {
+	mock *MockEscrowServer
+}
+
+// NewMockEscrowServer creates a new mock instance.
+func NewMockEscrowServer(ctrl *gomock.Controller) *MockEscrowServer {
+	mock := &MockEscrowServer{ctrl: ctrl}
+	mock.recorder = &MockEscrowServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscrowServer) EXPECT() *MockEscrowServerMockRecorder {
+	return m.recorder
+}
+
+// GetEscrowKey mocks base method.
+func (m *MockEscrowServer) GetEscrowKey(arg0 context.Context, arg1 *proto.GetEscrowKeyRequest) (*proto.GetEscrowKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrowKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetEscrowKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowKey indicates an expected call of GetEscrowKey.
+func (mr *MockEscrowServerMockRecorder) GetEscrowKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowKey", reflect.TypeOf((*MockEscrowServer)(nil).GetEscrowKey), arg0, arg1)
+}
+
+// SetEscrowKey mocks base method.
+func (m *MockEscrowServer) SetEscrowKey(arg0 context.Context, arg1 *proto.SetEscrowKeyRequest) (*proto.SetEscrowKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEscrowKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetEscrowKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEscrowKey indicates an expected call of SetEscrowKey.
+func (mr *MockEscrowServerMockRecorder) SetEscrowKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEscrowKey", reflect.TypeOf((*MockEscrowServer)(nil).SetEscrowKey), arg0, arg1)
+}
+
+// mustEmbedUnimplementedEscrowServer mocks base method.
+func (m *MockEscrowServer) mustEmbedUnimplementedEscrowServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedEscrowServer")
+}
+
+// mustEmbedUnimplementedEscrowServer indicates an expected call of mustEmbedUnimplementedEscrowServer.
+func (mr *MockEscrowServerMockRecorder) mustEmbedUnimplementedEscrowServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedEscrowServer", reflect.TypeOf((*MockEscrowServer)(nil).mustEmbedUnimplementedEscrowServer))
+}
+
+// MockUnsafeEscrowServer is a mock of UnsafeEscrowServer interface.
+type MockUnsafeEscrowServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeEscrowServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeEscrowServerMockRecorder is the mock recorder for MockUnsafeEscrowServer.
+type MockUnsafeEscrowServerMockRecorder struct {
+	mock *MockUnsafeEscrowServer
+}
+
+// NewMockUnsafeEscrowServer creates a new mock instance.
+func NewMockUnsafeEscrowServer(ctrl *gomock.Controller) *MockUnsafeEscrowServer {
+	mock := &MockUnsafeEscrowServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeEscrowServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeEscrowServer) EXPECT() *MockUnsafeEscrowServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedEscrowServer mocks base method.
+func (m *MockUnsafeEscrowServer) mustEmbedUnimplementedEscrowServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedEscrowServer")
+}
+
+// mustEmbedUnimplementedEscrowServer indicates an expected call of mustEmbedUnimplementedEscrowServer.
+func (mr *MockUnsafeEscrowServerMockRecorder) mustEmbedUnimplementedEscrowServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedEscrowServer", reflect.TypeOf((*MockUnsafeEscrowServer)(nil).mustEmbedUnimplementedEscrowServer))
+}
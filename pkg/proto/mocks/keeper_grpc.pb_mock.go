@@ -62,6 +62,26 @@ func (mr *MockKeeperClientMockRecorder) AddSecret(ctx, in any, opts ...any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockKeeperClient)(nil).AddSecret), varargs...)
 }
 
+// BatchSecrets mocks base method.
+func (m *MockKeeperClient) BatchSecrets(ctx context.Context, in *proto.BatchSecretsRequest, opts ...grpc.CallOption) (*proto.BatchSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchSecrets", varargs...)
+	ret0, _ := ret[0].(*proto.BatchSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchSecrets indicates an expected call of BatchSecrets.
+func (mr *MockKeeperClientMockRecorder) BatchSecrets(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSecrets", reflect.TypeOf((*MockKeeperClient)(nil).BatchSecrets), varargs...)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockKeeperClient) DeleteSecret(ctx context.Context, in *proto.DeleteSecretRequest, opts ...grpc.CallOption) (*proto.DeleteSecretResponse, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +162,46 @@ func (mr *MockKeeperClientMockRecorder) GetSecrets(ctx, in any, opts ...any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockKeeperClient)(nil).GetSecrets), varargs...)
 }
 
+// SearchSecrets mocks base method.
+func (m *MockKeeperClient) SearchSecrets(ctx context.Context, in *proto.SearchSecretsRequest, opts ...grpc.CallOption) (*proto.SearchSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SearchSecrets", varargs...)
+	ret0, _ := ret[0].(*proto.SearchSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSecrets indicates an expected call of SearchSecrets.
+func (mr *MockKeeperClientMockRecorder) SearchSecrets(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSecrets", reflect.TypeOf((*MockKeeperClient)(nil).SearchSecrets), varargs...)
+}
+
+// SetExpiration mocks base method.
+func (m *MockKeeperClient) SetExpiration(ctx context.Context, in *proto.SetExpirationRequest, opts ...grpc.CallOption) (*proto.SetExpirationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetExpiration", varargs...)
+	ret0, _ := ret[0].(*proto.SetExpirationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExpiration indicates an expected call of SetExpiration.
+func (mr *MockKeeperClientMockRecorder) SetExpiration(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiration", reflect.TypeOf((*MockKeeperClient)(nil).SetExpiration), varargs...)
+}
+
 // MockKeeperServer is a mock of KeeperServer interface.
 type MockKeeperServer struct {
 	ctrl     *gomock.Controller
@@ -181,6 +241,21 @@ func (mr *MockKeeperServerMockRecorder) AddSecret(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecret", reflect.TypeOf((*MockKeeperServer)(nil).AddSecret), arg0, arg1)
 }
 
+// BatchSecrets mocks base method.
+func (m *MockKeeperServer) BatchSecrets(arg0 context.Context, arg1 *proto.BatchSecretsRequest) (*proto.BatchSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchSecrets", arg0, arg1)
+	ret0, _ := ret[0].(*proto.BatchSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchSecrets indicates an expected call of BatchSecrets.
+func (mr *MockKeeperServerMockRecorder) BatchSecrets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSecrets", reflect.TypeOf((*MockKeeperServer)(nil).BatchSecrets), arg0, arg1)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockKeeperServer) DeleteSecret(arg0 context.Context, arg1 *proto.DeleteSecretRequest) (*proto.DeleteSecretResponse, error) {
 	m.ctrl.T.Helper()
@@ -241,6 +316,36 @@ func (mr *MockKeeperServerMockRecorder) GetSecrets(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockKeeperServer)(nil).GetSecrets), arg0, arg1)
 }
 
+// SearchSecrets mocks base method.
+func (m *MockKeeperServer) SearchSecrets(arg0 context.Context, arg1 *proto.SearchSecretsRequest) (*proto.SearchSecretsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSecrets", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SearchSecretsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSecrets indicates an expected call of SearchSecrets.
+func (mr *MockKeeperServerMockRecorder) SearchSecrets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSecrets", reflect.TypeOf((*MockKeeperServer)(nil).SearchSecrets), arg0, arg1)
+}
+
+// SetExpiration mocks base method.
+func (m *MockKeeperServer) SetExpiration(arg0 context.Context, arg1 *proto.SetExpirationRequest) (*proto.SetExpirationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpiration", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetExpirationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExpiration indicates an expected call of SetExpiration.
+func (mr *MockKeeperServerMockRecorder) SetExpiration(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiration", reflect.TypeOf((*MockKeeperServer)(nil).SetExpiration), arg0, arg1)
+}
+
 // mustEmbedUnimplementedKeeperServer mocks base method.
 func (m *MockKeeperServer) mustEmbedUnimplementedKeeperServer() {
 	m.ctrl.T.Helper()
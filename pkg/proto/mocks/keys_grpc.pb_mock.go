@@ -0,0 +1,325 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\proto\keys_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg\proto\keys_grpc.pb.go -destination=pkg\proto\mocks\keys_grpc.pb_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	proto "go-pass-keeper/pkg/proto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockKeysClient is a mock of KeysClient interface.
+type MockKeysClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeysClientMockRecorder
+	isgomock struct{}
+}
+
+// MockKeysClientMockRecorder is the mock recorder for MockKeysClient.
+type MockKeysClientMockRecorder struct {
+	mock *MockKeysClient
+}
+
+// NewMockKeysClient creates a new mock instance.
+func NewMockKeysClient(ctrl *gomock.Controller) *MockKeysClient {
+	mock := &MockKeysClient{ctrl: ctrl}
+	mock.recorder = &MockKeysClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeysClient) EXPECT() *MockKeysClientMockRecorder {
+	return m.recorder
+}
+
+// GetRecoveryKey mocks base method.
+func (m *MockKeysClient) GetRecoveryKey(ctx context.Context, in *proto.GetRecoveryKeyRequest, opts ...grpc.CallOption) (*proto.GetRecoveryKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRecoveryKey", varargs...)
+	ret0, _ := ret[0].(*proto.GetRecoveryKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryKey indicates an expected call of GetRecoveryKey.
+func (mr *MockKeysClientMockRecorder) GetRecoveryKey(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryKey", reflect.TypeOf((*MockKeysClient)(nil).GetRecoveryKey), varargs...)
+}
+
+// GetVaultRoot mocks base method.
+func (m *MockKeysClient) GetVaultRoot(ctx context.Context, in *proto.GetVaultRootRequest, opts ...grpc.CallOption) (*proto.GetVaultRootResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVaultRoot", varargs...)
+	ret0, _ := ret[0].(*proto.GetVaultRootResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultRoot indicates an expected call of GetVaultRoot.
+func (mr *MockKeysClientMockRecorder) GetVaultRoot(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultRoot", reflect.TypeOf((*MockKeysClient)(nil).GetVaultRoot), varargs...)
+}
+
+// SetKdf mocks base method.
+func (m *MockKeysClient) SetKdf(ctx context.Context, in *proto.SetKdfRequest, opts ...grpc.CallOption) (*proto.SetKdfResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetKdf", varargs...)
+	ret0, _ := ret[0].(*proto.SetKdfResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetKdf indicates an expected call of SetKdf.
+func (mr *MockKeysClientMockRecorder) SetKdf(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKdf", reflect.TypeOf((*MockKeysClient)(nil).SetKdf), varargs...)
+}
+
+// SetKeyCheck mocks base method.
+func (m *MockKeysClient) SetKeyCheck(ctx context.Context, in *proto.SetKeyCheckRequest, opts ...grpc.CallOption) (*proto.SetKeyCheckResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetKeyCheck", varargs...)
+	ret0, _ := ret[0].(*proto.SetKeyCheckResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetKeyCheck indicates an expected call of SetKeyCheck.
+func (mr *MockKeysClientMockRecorder) SetKeyCheck(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyCheck", reflect.TypeOf((*MockKeysClient)(nil).SetKeyCheck), varargs...)
+}
+
+// SetRecoveryKey mocks base method.
+func (m *MockKeysClient) SetRecoveryKey(ctx context.Context, in *proto.SetRecoveryKeyRequest, opts ...grpc.CallOption) (*proto.SetRecoveryKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetRecoveryKey", varargs...)
+	ret0, _ := ret[0].(*proto.SetRecoveryKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecoveryKey indicates an expected call of SetRecoveryKey.
+func (mr *MockKeysClientMockRecorder) SetRecoveryKey(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecoveryKey", reflect.TypeOf((*MockKeysClient)(nil).SetRecoveryKey), varargs...)
+}
+
+// SetVaultRoot mocks base method.
+func (m *MockKeysClient) SetVaultRoot(ctx context.Context, in *proto.SetVaultRootRequest, opts ...grpc.CallOption) (*proto.SetVaultRootResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetVaultRoot", varargs...)
+	ret0, _ := ret[0].(*proto.SetVaultRootResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVaultRoot indicates an expected call of SetVaultRoot.
+func (mr *MockKeysClientMockRecorder) SetVaultRoot(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVaultRoot", reflect.TypeOf((*MockKeysClient)(nil).SetVaultRoot), varargs...)
+}
+
+// MockKeysServer is a mock of KeysServer interface.
+type MockKeysServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeysServerMockRecorder
+	isgomock struct{}
+}
+
+// MockKeysServerMockRecorder is the mock recorder for MockKeysServer.
+type MockKeysServerMockRecorder struct {
+	mock *MockKeysServer
+}
+
+// NewMockKeysServer creates a new mock instance.
+func NewMockKeysServer(ctrl *gomock.Controller) *MockKeysServer {
+	mock := &MockKeysServer{ctrl: ctrl}
+	mock.recorder = &MockKeysServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeysServer) EXPECT() *MockKeysServerMockRecorder {
+	return m.recorder
+}
+
+// GetRecoveryKey mocks base method.
+func (m *MockKeysServer) GetRecoveryKey(arg0 context.Context, arg1 *proto.GetRecoveryKeyRequest) (*proto.GetRecoveryKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecoveryKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetRecoveryKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecoveryKey indicates an expected call of GetRecoveryKey.
+func (mr *MockKeysServerMockRecorder) GetRecoveryKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecoveryKey", reflect.TypeOf((*MockKeysServer)(nil).GetRecoveryKey), arg0, arg1)
+}
+
+// GetVaultRoot mocks base method.
+func (m *MockKeysServer) GetVaultRoot(arg0 context.Context, arg1 *proto.GetVaultRootRequest) (*proto.GetVaultRootResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultRoot", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetVaultRootResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultRoot indicates an expected call of GetVaultRoot.
+func (mr *MockKeysServerMockRecorder) GetVaultRoot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultRoot", reflect.TypeOf((*MockKeysServer)(nil).GetVaultRoot), arg0, arg1)
+}
+
+// SetKdf mocks base method.
+func (m *MockKeysServer) SetKdf(arg0 context.Context, arg1 *proto.SetKdfRequest) (*proto.SetKdfResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKdf", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetKdfResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetKdf indicates an expected call of SetKdf.
+func (mr *MockKeysServerMockRecorder) SetKdf(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKdf", reflect.TypeOf((*MockKeysServer)(nil).SetKdf), arg0, arg1)
+}
+
+// SetKeyCheck mocks base method.
+func (m *MockKeysServer) SetKeyCheck(arg0 context.Context, arg1 *proto.SetKeyCheckRequest) (*proto.SetKeyCheckResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyCheck", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetKeyCheckResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetKeyCheck indicates an expected call of SetKeyCheck.
+func (mr *MockKeysServerMockRecorder) SetKeyCheck(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyCheck", reflect.TypeOf((*MockKeysServer)(nil).SetKeyCheck), arg0, arg1)
+}
+
+// SetRecoveryKey mocks base method.
+func (m *MockKeysServer) SetRecoveryKey(arg0 context.Context, arg1 *proto.SetRecoveryKeyRequest) (*proto.SetRecoveryKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecoveryKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetRecoveryKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecoveryKey indicates an expected call of SetRecoveryKey.
+func (mr *MockKeysServerMockRecorder) SetRecoveryKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecoveryKey", reflect.TypeOf((*MockKeysServer)(nil).SetRecoveryKey), arg0, arg1)
+}
+
+// SetVaultRoot mocks base method.
+func (m *MockKeysServer) SetVaultRoot(arg0 context.Context, arg1 *proto.SetVaultRootRequest) (*proto.SetVaultRootResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVaultRoot", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetVaultRootResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVaultRoot indicates an expected call of SetVaultRoot.
+func (mr *MockKeysServerMockRecorder) SetVaultRoot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVaultRoot", reflect.TypeOf((*MockKeysServer)(nil).SetVaultRoot), arg0, arg1)
+}
+
+// mustEmbedUnimplementedKeysServer mocks base method.
+func (m *MockKeysServer) mustEmbedUnimplementedKeysServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedKeysServer")
+}
+
+// mustEmbedUnimplementedKeysServer indicates an expected call of mustEmbedUnimplementedKeysServer.
+func (mr *MockKeysServerMockRecorder) mustEmbedUnimplementedKeysServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedKeysServer", reflect.TypeOf((*MockKeysServer)(nil).mustEmbedUnimplementedKeysServer))
+}
+
+// MockUnsafeKeysServer is a mock of UnsafeKeysServer interface.
+type MockUnsafeKeysServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeKeysServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeKeysServerMockRecorder is the mock recorder for MockUnsafeKeysServer.
+type MockUnsafeKeysServerMockRecorder struct {
+	mock *MockUnsafeKeysServer
+}
+
+// NewMockUnsafeKeysServer creates a new mock instance.
+func NewMockUnsafeKeysServer(ctrl *gomock.Controller) *MockUnsafeKeysServer {
+	mock := &MockUnsafeKeysServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeKeysServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeKeysServer) EXPECT() *MockUnsafeKeysServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedKeysServer mocks base method.
+func (m *MockUnsafeKeysServer) mustEmbedUnimplementedKeysServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedKeysServer")
+}
+
+// mustEmbedUnimplementedKeysServer indicates an expected call of mustEmbedUnimplementedKeysServer.
+func (mr *MockUnsafeKeysServerMockRecorder) mustEmbedUnimplementedKeysServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedKeysServer", reflect.TypeOf((*MockUnsafeKeysServer)(nil).mustEmbedUnimplementedKeysServer))
+}
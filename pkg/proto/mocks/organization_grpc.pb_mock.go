@@ -0,0 +1,360 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\proto\organization_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg\proto\organization_grpc.pb.go -destination=pkg\proto\mocks\organization_grpc.pb_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	proto "go-pass-keeper/pkg/proto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockOrganizationClient is a mock of OrganizationClient interface.
+type MockOrganizationClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationClientMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationClientMockRecorder is the mock recorder for MockOrganizationClient.
+type MockOrganizationClientMockRecorder struct {
+	mock *MockOrganizationClient
+}
+
+// NewMockOrganizationClient creates a new mock instance.
+func NewMockOrganizationClient(ctrl *gomock.Controller) *MockOrganizationClient {
+	mock := &MockOrganizationClient{ctrl: ctrl}
+	mock.recorder = &MockOrganizationClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationClient) EXPECT() *MockOrganizationClientMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockOrganizationClient) AddMember(ctx context.Context, in *proto.AddMemberRequest, opts ...grpc.CallOption) (*proto.AddMemberResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddMember", varargs...)
+	ret0, _ := ret[0].(*proto.AddMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationClientMockRecorder) AddMember(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganizationClient)(nil).AddMember), varargs...)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationClient) CreateOrganization(ctx context.Context, in *proto.CreateOrganizationRequest, opts ...grpc.CallOption) (*proto.CreateOrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateOrganization", varargs...)
+	ret0, _ := ret[0].(*proto.CreateOrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationClientMockRecorder) CreateOrganization(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationClient)(nil).CreateOrganization), varargs...)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganizationClient) GetMembers(ctx context.Context, in *proto.GetMembersRequest, opts ...grpc.CallOption) (*proto.GetMembersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMembers", varargs...)
+	ret0, _ := ret[0].(*proto.GetMembersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationClientMockRecorder) GetMembers(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganizationClient)(nil).GetMembers), varargs...)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganizationClient) GetOrganizations(ctx context.Context, in *proto.GetOrganizationsRequest, opts ...grpc.CallOption) (*proto.GetOrganizationsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrganizations", varargs...)
+	ret0, _ := ret[0].(*proto.GetOrganizationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationClientMockRecorder) GetOrganizations(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganizationClient)(nil).GetOrganizations), varargs...)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationClient) RemoveMember(ctx context.Context, in *proto.RemoveMemberRequest, opts ...grpc.CallOption) (*proto.RemoveMemberResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveMember", varargs...)
+	ret0, _ := ret[0].(*proto.RemoveMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationClientMockRecorder) RemoveMember(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationClient)(nil).RemoveMember), varargs...)
+}
+
+// RotateVaultKey mocks base method.
+func (m *MockOrganizationClient) RotateVaultKey(ctx context.Context, in *proto.RotateVaultKeyRequest, opts ...grpc.CallOption) (*proto.RotateVaultKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RotateVaultKey", varargs...)
+	ret0, _ := ret[0].(*proto.RotateVaultKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateVaultKey indicates an expected call of RotateVaultKey.
+func (mr *MockOrganizationClientMockRecorder) RotateVaultKey(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateVaultKey", reflect.TypeOf((*MockOrganizationClient)(nil).RotateVaultKey), varargs...)
+}
+
+// UpdateMember mocks base method.
+func (m *MockOrganizationClient) UpdateMember(ctx context.Context, in *proto.UpdateMemberRequest, opts ...grpc.CallOption) (*proto.UpdateMemberResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMember", varargs...)
+	ret0, _ := ret[0].(*proto.UpdateMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockOrganizationClientMockRecorder) UpdateMember(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockOrganizationClient)(nil).UpdateMember), varargs...)
+}
+
+// MockOrganizationServer is a mock of OrganizationServer interface.
+type MockOrganizationServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationServerMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationServerMockRecorder is the mock recorder for MockOrganizationServer.
+type MockOrganizationServerMockRecorder struct {
+	mock *MockOrganizationServer
+}
+
+// NewMockOrganizationServer creates a new mock instance.
+func NewMockOrganizationServer(ctrl *gomock.Controller) *MockOrganizationServer {
+	mock := &MockOrganizationServer{ctrl: ctrl}
+	mock.recorder = &MockOrganizationServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationServer) EXPECT() *MockOrganizationServerMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockOrganizationServer) AddMember(arg0 context.Context, arg1 *proto.AddMemberRequest) (*proto.AddMemberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", arg0, arg1)
+	ret0, _ := ret[0].(*proto.AddMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationServerMockRecorder) AddMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganizationServer)(nil).AddMember), arg0, arg1)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationServer) CreateOrganization(arg0 context.Context, arg1 *proto.CreateOrganizationRequest) (*proto.CreateOrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(*proto.CreateOrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationServerMockRecorder) CreateOrganization(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationServer)(nil).CreateOrganization), arg0, arg1)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganizationServer) GetMembers(arg0 context.Context, arg1 *proto.GetMembersRequest) (*proto.GetMembersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetMembersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationServerMockRecorder) GetMembers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganizationServer)(nil).GetMembers), arg0, arg1)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganizationServer) GetOrganizations(arg0 context.Context, arg1 *proto.GetOrganizationsRequest) (*proto.GetOrganizationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetOrganizationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationServerMockRecorder) GetOrganizations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganizationServer)(nil).GetOrganizations), arg0, arg1)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationServer) RemoveMember(arg0 context.Context, arg1 *proto.RemoveMemberRequest) (*proto.RemoveMemberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1)
+	ret0, _ := ret[0].(*proto.RemoveMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationServerMockRecorder) RemoveMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationServer)(nil).RemoveMember), arg0, arg1)
+}
+
+// RotateVaultKey mocks base method.
+func (m *MockOrganizationServer) RotateVaultKey(arg0 context.Context, arg1 *proto.RotateVaultKeyRequest) (*proto.RotateVaultKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateVaultKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.RotateVaultKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateVaultKey indicates an expected call of RotateVaultKey.
+func (mr *MockOrganizationServerMockRecorder) RotateVaultKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateVaultKey", reflect.TypeOf((*MockOrganizationServer)(nil).RotateVaultKey), arg0, arg1)
+}
+
+// UpdateMember mocks base method.
+func (m *MockOrganizationServer) UpdateMember(arg0 context.Context, arg1 *proto.UpdateMemberRequest) (*proto.UpdateMemberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMember", arg0, arg1)
+	ret0, _ := ret[0].(*proto.UpdateMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMember indicates an expected call of UpdateMember.
+func (mr *MockOrganizationServerMockRecorder) UpdateMember(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMember", reflect.TypeOf((*MockOrganizationServer)(nil).UpdateMember), arg0, arg1)
+}
+
+// mustEmbedUnimplementedOrganizationServer mocks base method.
+func (m *MockOrganizationServer) mustEmbedUnimplementedOrganizationServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedOrganizationServer")
+}
+
+// mustEmbedUnimplementedOrganizationServer indicates an expected call of mustEmbedUnimplementedOrganizationServer.
+func (mr *MockOrganizationServerMockRecorder) mustEmbedUnimplementedOrganizationServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedOrganizationServer", reflect.TypeOf((*MockOrganizationServer)(nil).mustEmbedUnimplementedOrganizationServer))
+}
+
+// MockUnsafeOrganizationServer is a mock of UnsafeOrganizationServer interface.
+type MockUnsafeOrganizationServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeOrganizationServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeOrganizationServerMockRecorder is the mock recorder for MockUnsafeOrganizationServer.
+type MockUnsafeOrganizationServerMockRecorder struct {
+	mock *MockUnsafeOrganizationServer
+}
+
+// NewMockUnsafeOrganizationServer creates a new mock instance.
+func NewMockUnsafeOrganizationServer(ctrl *gomock.Controller) *MockUnsafeOrganizationServer {
+	mock := &MockUnsafeOrganizationServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeOrganizationServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeOrganizationServer) EXPECT() *MockUnsafeOrganizationServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedOrganizationServer mocks base method.
+func (m *MockUnsafeOrganizationServer) mustEmbedUnimplementedOrganizationServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedOrganizationServer")
+}
+
+// mustEmbedUnimplementedOrganizationServer indicates an expected call of mustEmbedUnimplementedOrganizationServer.
+func (mr *MockUnsafeOrganizationServerMockRecorder) mustEmbedUnimplementedOrganizationServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedOrganizationServer", reflect.TypeOf((*MockUnsafeOrganizationServer)(nil).mustEmbedUnimplementedOrganizationServer))
+}
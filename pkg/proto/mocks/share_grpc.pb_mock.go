@@ -0,0 +1,325 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg\proto\share_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg\proto\share_grpc.pb.go -destination=pkg\proto\mocks\share_grpc.pb_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	proto "go-pass-keeper/pkg/proto"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockShareClient is a mock of ShareClient interface.
+type MockShareClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareClientMockRecorder
+	isgomock struct{}
+}
+
+// MockShareClientMockRecorder is the mock recorder for MockShareClient.
+type MockShareClientMockRecorder struct {
+	mock *MockShareClient
+}
+
+// NewMockShareClient creates a new mock instance.
+func NewMockShareClient(ctrl *gomock.Controller) *MockShareClient {
+	mock := &MockShareClient{ctrl: ctrl}
+	mock.recorder = &MockShareClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareClient) EXPECT() *MockShareClientMockRecorder {
+	return m.recorder
+}
+
+// GetKeyPair mocks base method.
+func (m *MockShareClient) GetKeyPair(ctx context.Context, in *proto.GetKeyPairRequest, opts ...grpc.CallOption) (*proto.GetKeyPairResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetKeyPair", varargs...)
+	ret0, _ := ret[0].(*proto.GetKeyPairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyPair indicates an expected call of GetKeyPair.
+func (mr *MockShareClientMockRecorder) GetKeyPair(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyPair", reflect.TypeOf((*MockShareClient)(nil).GetKeyPair), varargs...)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockShareClient) GetPublicKey(ctx context.Context, in *proto.GetPublicKeyRequest, opts ...grpc.CallOption) (*proto.GetPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPublicKey", varargs...)
+	ret0, _ := ret[0].(*proto.GetPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockShareClientMockRecorder) GetPublicKey(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockShareClient)(nil).GetPublicKey), varargs...)
+}
+
+// ListSharedWithMe mocks base method.
+func (m *MockShareClient) ListSharedWithMe(ctx context.Context, in *proto.ListSharedWithMeRequest, opts ...grpc.CallOption) (*proto.ListSharedWithMeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSharedWithMe", varargs...)
+	ret0, _ := ret[0].(*proto.ListSharedWithMeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedWithMe indicates an expected call of ListSharedWithMe.
+func (mr *MockShareClientMockRecorder) ListSharedWithMe(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedWithMe", reflect.TypeOf((*MockShareClient)(nil).ListSharedWithMe), varargs...)
+}
+
+// RevokeShare mocks base method.
+func (m *MockShareClient) RevokeShare(ctx context.Context, in *proto.RevokeShareRequest, opts ...grpc.CallOption) (*proto.RevokeShareResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeShare", varargs...)
+	ret0, _ := ret[0].(*proto.RevokeShareResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockShareClientMockRecorder) RevokeShare(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockShareClient)(nil).RevokeShare), varargs...)
+}
+
+// SetKeyPair mocks base method.
+func (m *MockShareClient) SetKeyPair(ctx context.Context, in *proto.SetKeyPairRequest, opts ...grpc.CallOption) (*proto.SetKeyPairResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetKeyPair", varargs...)
+	ret0, _ := ret[0].(*proto.SetKeyPairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockShareClientMockRecorder) SetKeyPair(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockShareClient)(nil).SetKeyPair), varargs...)
+}
+
+// ShareSecret mocks base method.
+func (m *MockShareClient) ShareSecret(ctx context.Context, in *proto.ShareSecretRequest, opts ...grpc.CallOption) (*proto.ShareSecretResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ShareSecret", varargs...)
+	ret0, _ := ret[0].(*proto.ShareSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockShareClientMockRecorder) ShareSecret(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockShareClient)(nil).ShareSecret), varargs...)
+}
+
+// MockShareServer is a mock of ShareServer interface.
+type MockShareServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareServerMockRecorder
+	isgomock struct{}
+}
+
+// MockShareServerMockRecorder is the mock recorder for MockShareServer.
+type MockShareServerMockRecorder struct {
+	mock *MockShareServer
+}
+
+// NewMockShareServer creates a new mock instance.
+func NewMockShareServer(ctrl *gomock.Controller) *MockShareServer {
+	mock := &MockShareServer{ctrl: ctrl}
+	mock.recorder = &MockShareServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareServer) EXPECT() *MockShareServerMockRecorder {
+	return m.recorder
+}
+
+// GetKeyPair mocks base method.
+func (m *MockShareServer) GetKeyPair(arg0 context.Context, arg1 *proto.GetKeyPairRequest) (*proto.GetKeyPairResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyPair", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetKeyPairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyPair indicates an expected call of GetKeyPair.
+func (mr *MockShareServerMockRecorder) GetKeyPair(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyPair", reflect.TypeOf((*MockShareServer)(nil).GetKeyPair), arg0, arg1)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockShareServer) GetPublicKey(arg0 context.Context, arg1 *proto.GetPublicKeyRequest) (*proto.GetPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockShareServerMockRecorder) GetPublicKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockShareServer)(nil).GetPublicKey), arg0, arg1)
+}
+
+// ListSharedWithMe mocks base method.
+func (m *MockShareServer) ListSharedWithMe(arg0 context.Context, arg1 *proto.ListSharedWithMeRequest) (*proto.ListSharedWithMeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSharedWithMe", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListSharedWithMeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSharedWithMe indicates an expected call of ListSharedWithMe.
+func (mr *MockShareServerMockRecorder) ListSharedWithMe(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSharedWithMe", reflect.TypeOf((*MockShareServer)(nil).ListSharedWithMe), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockShareServer) RevokeShare(arg0 context.Context, arg1 *proto.RevokeShareRequest) (*proto.RevokeShareResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1)
+	ret0, _ := ret[0].(*proto.RevokeShareResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockShareServerMockRecorder) RevokeShare(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockShareServer)(nil).RevokeShare), arg0, arg1)
+}
+
+// SetKeyPair mocks base method.
+func (m *MockShareServer) SetKeyPair(arg0 context.Context, arg1 *proto.SetKeyPairRequest) (*proto.SetKeyPairResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyPair", arg0, arg1)
+	ret0, _ := ret[0].(*proto.SetKeyPairResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockShareServerMockRecorder) SetKeyPair(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockShareServer)(nil).SetKeyPair), arg0, arg1)
+}
+
+// ShareSecret mocks base method.
+func (m *MockShareServer) ShareSecret(arg0 context.Context, arg1 *proto.ShareSecretRequest) (*proto.ShareSecretResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSecret", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ShareSecretResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockShareServerMockRecorder) ShareSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockShareServer)(nil).ShareSecret), arg0, arg1)
+}
+
+// mustEmbedUnimplementedShareServer mocks base method.
+func (m *MockShareServer) mustEmbedUnimplementedShareServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedShareServer")
+}
+
+// mustEmbedUnimplementedShareServer indicates an expected call of mustEmbedUnimplementedShareServer.
+func (mr *MockShareServerMockRecorder) mustEmbedUnimplementedShareServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedShareServer", reflect.TypeOf((*MockShareServer)(nil).mustEmbedUnimplementedShareServer))
+}
+
+// MockUnsafeShareServer is a mock of UnsafeShareServer interface.
+type MockUnsafeShareServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeShareServerMockRecorder
+	isgomock struct{}
+}
+
+// MockUnsafeShareServerMockRecorder is the mock recorder for MockUnsafeShareServer.
+type MockUnsafeShareServerMockRecorder struct {
+	mock *MockUnsafeShareServer
+}
+
+// NewMockUnsafeShareServer creates a new mock instance.
+func NewMockUnsafeShareServer(ctrl *gomock.Controller) *MockUnsafeShareServer {
+	mock := &MockUnsafeShareServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeShareServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeShareServer) EXPECT() *MockUnsafeShareServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedShareServer mocks base method.
+func (m *MockUnsafeShareServer) mustEmbedUnimplementedShareServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedShareServer")
+}
+
+// mustEmbedUnimplementedShareServer indicates an expected call of mustEmbedUnimplementedShareServer.
+func (mr *MockUnsafeShareServerMockRecorder) mustEmbedUnimplementedShareServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedShareServer", reflect.TypeOf((*MockUnsafeShareServer)(nil).mustEmbedUnimplementedShareServer))
+}
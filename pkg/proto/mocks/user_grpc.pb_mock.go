@@ -62,6 +62,46 @@ func (mr *MockUserClientMockRecorder) Login(ctx, in any, opts ...any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserClient)(nil).Login), varargs...)
 }
 
+// LoginFinish mocks base method.
+func (m *MockUserClient) LoginFinish(ctx context.Context, in *proto.LoginFinishRequest, opts ...grpc.CallOption) (*proto.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoginFinish", varargs...)
+	ret0, _ := ret[0].(*proto.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginFinish indicates an expected call of LoginFinish.
+func (mr *MockUserClientMockRecorder) LoginFinish(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginFinish", reflect.TypeOf((*MockUserClient)(nil).LoginFinish), varargs...)
+}
+
+// LoginStart mocks base method.
+func (m *MockUserClient) LoginStart(ctx context.Context, in *proto.LoginStartRequest, opts ...grpc.CallOption) (*proto.LoginStartResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoginStart", varargs...)
+	ret0, _ := ret[0].(*proto.LoginStartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginStart indicates an expected call of LoginStart.
+func (mr *MockUserClientMockRecorder) LoginStart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginStart", reflect.TypeOf((*MockUserClient)(nil).LoginStart), varargs...)
+}
+
 // Register mocks base method.
 func (m *MockUserClient) Register(ctx context.Context, in *proto.RegisterRequest, opts ...grpc.CallOption) (*proto.RegisterResponse, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +161,36 @@ func (mr *MockUserServerMockRecorder) Login(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServer)(nil).Login), arg0, arg1)
 }
 
+// LoginFinish mocks base method.
+func (m *MockUserServer) LoginFinish(arg0 context.Context, arg1 *proto.LoginFinishRequest) (*proto.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginFinish", arg0, arg1)
+	ret0, _ := ret[0].(*proto.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginFinish indicates an expected call of LoginFinish.
+func (mr *MockUserServerMockRecorder) LoginFinish(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginFinish", reflect.TypeOf((*MockUserServer)(nil).LoginFinish), arg0, arg1)
+}
+
+// LoginStart mocks base method.
+func (m *MockUserServer) LoginStart(arg0 context.Context, arg1 *proto.LoginStartRequest) (*proto.LoginStartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginStart", arg0, arg1)
+	ret0, _ := ret[0].(*proto.LoginStartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginStart indicates an expected call of LoginStart.
+func (mr *MockUserServerMockRecorder) LoginStart(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginStart", reflect.TypeOf((*MockUserServer)(nil).LoginStart), arg0, arg1)
+}
+
 // Register mocks base method.
 func (m *MockUserServer) Register(arg0 context.Context, arg1 *proto.RegisterRequest) (*proto.RegisterResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,1111 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: api/organization.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrganizationInfo struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role     string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	VaultKey []byte                 `protobuf:"bytes,4,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	Created  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3,oneof" json:"created,omitempty"`
+	// из организации удалён участник, ключ хранилища необходимо заменить
+	RotateKey     bool `protobuf:"varint,6,opt,name=rotate_key,json=rotateKey,proto3" json:"rotate_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationInfo) Reset() {
+	*x = OrganizationInfo{}
+	mi := &file_api_organization_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationInfo) ProtoMessage() {}
+
+func (x *OrganizationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationInfo.ProtoReflect.Descriptor instead.
+func (*OrganizationInfo) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrganizationInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrganizationInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrganizationInfo) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrganizationInfo) GetVaultKey() []byte {
+	if x != nil {
+		return x.VaultKey
+	}
+	return nil
+}
+
+func (x *OrganizationInfo) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *OrganizationInfo) GetRotateKey() bool {
+	if x != nil {
+		return x.RotateKey
+	}
+	return false
+}
+
+type Member struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created,proto3,oneof" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_api_organization_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Member) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *Member) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Member) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	VaultKey      []byte                 `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_api_organization_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrganizationRequest) GetVaultKey() []byte {
+	if x != nil {
+		return x.VaultKey
+	}
+	return nil
+}
+
+type CreateOrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *OrganizationInfo      `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationResponse) Reset() {
+	*x = CreateOrganizationResponse{}
+	mi := &file_api_organization_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationResponse) ProtoMessage() {}
+
+func (x *CreateOrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateOrganizationResponse) GetOrganization() *OrganizationInfo {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type GetOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationsRequest) Reset() {
+	*x = GetOrganizationsRequest{}
+	mi := &file_api_organization_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationsRequest) ProtoMessage() {}
+
+func (x *GetOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{4}
+}
+
+type GetOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*OrganizationInfo    `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganizationsResponse) Reset() {
+	*x = GetOrganizationsResponse{}
+	mi := &file_api_organization_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganizationsResponse) ProtoMessage() {}
+
+func (x *GetOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOrganizationsResponse) GetOrganizations() []*OrganizationInfo {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type GetMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMembersRequest) Reset() {
+	*x = GetMembersRequest{}
+	mi := &file_api_organization_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMembersRequest) ProtoMessage() {}
+
+func (x *GetMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMembersRequest.ProtoReflect.Descriptor instead.
+func (*GetMembersRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type GetMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMembersResponse) Reset() {
+	*x = GetMembersResponse{}
+	mi := &file_api_organization_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMembersResponse) ProtoMessage() {}
+
+func (x *GetMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMembersResponse.ProtoReflect.Descriptor instead.
+func (*GetMembersResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type AddMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	VaultKey      []byte                 `protobuf:"bytes,4,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberRequest) Reset() {
+	*x = AddMemberRequest{}
+	mi := &file_api_organization_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberRequest) ProtoMessage() {}
+
+func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AddMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetVaultKey() []byte {
+	if x != nil {
+		return x.VaultKey
+	}
+	return nil
+}
+
+type AddMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberResponse) Reset() {
+	*x = AddMemberResponse{}
+	mi := &file_api_organization_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberResponse) ProtoMessage() {}
+
+func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddMemberResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddMemberResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type UpdateMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemberRequest) Reset() {
+	*x = UpdateMemberRequest{}
+	mi := &file_api_organization_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberRequest) ProtoMessage() {}
+
+func (x *UpdateMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UpdateMemberRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *UpdateMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UpdateMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *Member                `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemberResponse) Reset() {
+	*x = UpdateMemberResponse{}
+	mi := &file_api_organization_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberResponse) ProtoMessage() {}
+
+func (x *UpdateMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMemberResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateMemberResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_api_organization_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_api_organization_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{13}
+}
+
+// новый ключ хранилища, зашифрованный открытым ключом участника
+type MemberVaultKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	VaultKey      []byte                 `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberVaultKey) Reset() {
+	*x = MemberVaultKey{}
+	mi := &file_api_organization_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberVaultKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberVaultKey) ProtoMessage() {}
+
+func (x *MemberVaultKey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberVaultKey.ProtoReflect.Descriptor instead.
+func (*MemberVaultKey) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MemberVaultKey) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *MemberVaultKey) GetVaultKey() []byte {
+	if x != nil {
+		return x.VaultKey
+	}
+	return nil
+}
+
+// ключ данных секрета, зашифрованный новым ключом хранилища, и слепой индекс на новом ключе
+type SecretDataKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	SearchIndex   [][]byte               `protobuf:"bytes,3,rep,name=search_index,json=searchIndex,proto3" json:"search_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecretDataKey) Reset() {
+	*x = SecretDataKey{}
+	mi := &file_api_organization_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretDataKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDataKey) ProtoMessage() {}
+
+func (x *SecretDataKey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDataKey.ProtoReflect.Descriptor instead.
+func (*SecretDataKey) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SecretDataKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecretDataKey) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *SecretDataKey) GetSearchIndex() [][]byte {
+	if x != nil {
+		return x.SearchIndex
+	}
+	return nil
+}
+
+// замена ключа хранилища организации: перечисляются все участники и все секреты организации
+type RotateVaultKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Members       []*MemberVaultKey      `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Secrets       []*SecretDataKey       `protobuf:"bytes,3,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateVaultKeyRequest) Reset() {
+	*x = RotateVaultKeyRequest{}
+	mi := &file_api_organization_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateVaultKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateVaultKeyRequest) ProtoMessage() {}
+
+func (x *RotateVaultKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateVaultKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateVaultKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RotateVaultKeyRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RotateVaultKeyRequest) GetMembers() []*MemberVaultKey {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *RotateVaultKeyRequest) GetSecrets() []*SecretDataKey {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+type RotateVaultKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateVaultKeyResponse) Reset() {
+	*x = RotateVaultKeyResponse{}
+	mi := &file_api_organization_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateVaultKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateVaultKeyResponse) ProtoMessage() {}
+
+func (x *RotateVaultKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_organization_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateVaultKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateVaultKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_organization_proto_rawDescGZIP(), []int{17}
+}
+
+var File_api_organization_proto protoreflect.FileDescriptor
+
+const file_api_organization_proto_rawDesc = "" +
+	"\n" +
+	"\x16api/organization.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcd\x01\n" +
+	"\x10OrganizationInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1b\n" +
+	"\tvault_key\x18\x04 \x01(\fR\bvaultKey\x129\n" +
+	"\acreated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\acreated\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"rotate_key\x18\x06 \x01(\bR\trotateKeyB\n" +
+	"\n" +
+	"\b_created\"y\n" +
+	"\x06Member\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x129\n" +
+	"\acreated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\acreated\x88\x01\x01B\n" +
+	"\n" +
+	"\b_created\"L\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tvault_key\x18\x02 \x01(\fR\bvaultKey\"W\n" +
+	"\x1aCreateOrganizationResponse\x129\n" +
+	"\forganization\x18\x01 \x01(\v2\x15.api.OrganizationInfoR\forganization\"\x19\n" +
+	"\x17GetOrganizationsRequest\"W\n" +
+	"\x18GetOrganizationsResponse\x12;\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x15.api.OrganizationInfoR\rorganizations\"*\n" +
+	"\x11GetMembersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\";\n" +
+	"\x12GetMembersResponse\x12%\n" +
+	"\amembers\x18\x01 \x03(\v2\v.api.MemberR\amembers\"p\n" +
+	"\x10AddMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1b\n" +
+	"\tvault_key\x18\x04 \x01(\fR\bvaultKey\"8\n" +
+	"\x11AddMemberResponse\x12#\n" +
+	"\x06member\x18\x01 \x01(\v2\v.api.MemberR\x06member\"V\n" +
+	"\x13UpdateMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\";\n" +
+	"\x14UpdateMemberResponse\x12#\n" +
+	"\x06member\x18\x01 \x01(\v2\v.api.MemberR\x06member\"B\n" +
+	"\x13RemoveMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\"\x16\n" +
+	"\x14RemoveMemberResponse\"C\n" +
+	"\x0eMemberVaultKey\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1b\n" +
+	"\tvault_key\x18\x02 \x01(\fR\bvaultKey\"c\n" +
+	"\rSecretDataKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\x12!\n" +
+	"\fsearch_index\x18\x03 \x03(\fR\vsearchIndex\"\x8b\x01\n" +
+	"\x15RotateVaultKeyRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12-\n" +
+	"\amembers\x18\x02 \x03(\v2\x13.api.MemberVaultKeyR\amembers\x12,\n" +
+	"\asecrets\x18\x03 \x03(\v2\x12.api.SecretDataKeyR\asecrets\"\x18\n" +
+	"\x16RotateVaultKeyResponse2\x86\x04\n" +
+	"\fOrganization\x12U\n" +
+	"\x12CreateOrganization\x12\x1e.api.CreateOrganizationRequest\x1a\x1f.api.CreateOrganizationResponse\x12O\n" +
+	"\x10GetOrganizations\x12\x1c.api.GetOrganizationsRequest\x1a\x1d.api.GetOrganizationsResponse\x12=\n" +
+	"\n" +
+	"GetMembers\x12\x16.api.GetMembersRequest\x1a\x17.api.GetMembersResponse\x12:\n" +
+	"\tAddMember\x12\x15.api.AddMemberRequest\x1a\x16.api.AddMemberResponse\x12C\n" +
+	"\fUpdateMember\x12\x18.api.UpdateMemberRequest\x1a\x19.api.UpdateMemberResponse\x12C\n" +
+	"\fRemoveMember\x12\x18.api.RemoveMemberRequest\x1a\x19.api.RemoveMemberResponse\x12I\n" +
+	"\x0eRotateVaultKey\x12\x1a.api.RotateVaultKeyRequest\x1a\x1b.api.RotateVaultKeyResponseB\vZ\tpkg/protob\x06proto3"
+
+var (
+	file_api_organization_proto_rawDescOnce sync.Once
+	file_api_organization_proto_rawDescData []byte
+)
+
+func file_api_organization_proto_rawDescGZIP() []byte {
+	file_api_organization_proto_rawDescOnce.Do(func() {
+		file_api_organization_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_organization_proto_rawDesc), len(file_api_organization_proto_rawDesc)))
+	})
+	return file_api_organization_proto_rawDescData
+}
+
+var file_api_organization_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_api_organization_proto_goTypes = []any{
+	(*OrganizationInfo)(nil),           // 0: api.OrganizationInfo
+	(*Member)(nil),                     // 1: api.Member
+	(*CreateOrganizationRequest)(nil),  // 2: api.CreateOrganizationRequest
+	(*CreateOrganizationResponse)(nil), // 3: api.CreateOrganizationResponse
+	(*GetOrganizationsRequest)(nil),    // 4: api.GetOrganizationsRequest
+	(*GetOrganizationsResponse)(nil),   // 5: api.GetOrganizationsResponse
+	(*GetMembersRequest)(nil),          // 6: api.GetMembersRequest
+	(*GetMembersResponse)(nil),         // 7: api.GetMembersResponse
+	(*AddMemberRequest)(nil),           // 8: api.AddMemberRequest
+	(*AddMemberResponse)(nil),          // 9: api.AddMemberResponse
+	(*UpdateMemberRequest)(nil),        // 10: api.UpdateMemberRequest
+	(*UpdateMemberResponse)(nil),       // 11: api.UpdateMemberResponse
+	(*RemoveMemberRequest)(nil),        // 12: api.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),       // 13: api.RemoveMemberResponse
+	(*MemberVaultKey)(nil),             // 14: api.MemberVaultKey
+	(*SecretDataKey)(nil),              // 15: api.SecretDataKey
+	(*RotateVaultKeyRequest)(nil),      // 16: api.RotateVaultKeyRequest
+	(*RotateVaultKeyResponse)(nil),     // 17: api.RotateVaultKeyResponse
+	(*timestamppb.Timestamp)(nil),      // 18: google.protobuf.Timestamp
+}
+var file_api_organization_proto_depIdxs = []int32{
+	18, // 0: api.OrganizationInfo.created:type_name -> google.protobuf.Timestamp
+	18, // 1: api.Member.created:type_name -> google.protobuf.Timestamp
+	0,  // 2: api.CreateOrganizationResponse.organization:type_name -> api.OrganizationInfo
+	0,  // 3: api.GetOrganizationsResponse.organizations:type_name -> api.OrganizationInfo
+	1,  // 4: api.GetMembersResponse.members:type_name -> api.Member
+	1,  // 5: api.AddMemberResponse.member:type_name -> api.Member
+	1,  // 6: api.UpdateMemberResponse.member:type_name -> api.Member
+	14, // 7: api.RotateVaultKeyRequest.members:type_name -> api.MemberVaultKey
+	15, // 8: api.RotateVaultKeyRequest.secrets:type_name -> api.SecretDataKey
+	2,  // 9: api.Organization.CreateOrganization:input_type -> api.CreateOrganizationRequest
+	4,  // 10: api.Organization.GetOrganizations:input_type -> api.GetOrganizationsRequest
+	6,  // 11: api.Organization.GetMembers:input_type -> api.GetMembersRequest
+	8,  // 12: api.Organization.AddMember:input_type -> api.AddMemberRequest
+	10, // 13: api.Organization.UpdateMember:input_type -> api.UpdateMemberRequest
+	12, // 14: api.Organization.RemoveMember:input_type -> api.RemoveMemberRequest
+	16, // 15: api.Organization.RotateVaultKey:input_type -> api.RotateVaultKeyRequest
+	3,  // 16: api.Organization.CreateOrganization:output_type -> api.CreateOrganizationResponse
+	5,  // 17: api.Organization.GetOrganizations:output_type -> api.GetOrganizationsResponse
+	7,  // 18: api.Organization.GetMembers:output_type -> api.GetMembersResponse
+	9,  // 19: api.Organization.AddMember:output_type -> api.AddMemberResponse
+	11, // 20: api.Organization.UpdateMember:output_type -> api.UpdateMemberResponse
+	13, // 21: api.Organization.RemoveMember:output_type -> api.RemoveMemberResponse
+	17, // 22: api.Organization.RotateVaultKey:output_type -> api.RotateVaultKeyResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_api_organization_proto_init() }
+func file_api_organization_proto_init() {
+	if File_api_organization_proto != nil {
+		return
+	}
+	file_api_organization_proto_msgTypes[0].OneofWrappers = []any{}
+	file_api_organization_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_organization_proto_rawDesc), len(file_api_organization_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_organization_proto_goTypes,
+		DependencyIndexes: file_api_organization_proto_depIdxs,
+		MessageInfos:      file_api_organization_proto_msgTypes,
+	}.Build()
+	File_api_organization_proto = out.File
+	file_api_organization_proto_goTypes = nil
+	file_api_organization_proto_depIdxs = nil
+}
@@ -0,0 +1,825 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: api/share.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetKeyPairRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PrivateKey    []byte                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyPairRequest) Reset() {
+	*x = SetKeyPairRequest{}
+	mi := &file_api_share_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyPairRequest) ProtoMessage() {}
+
+func (x *SetKeyPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyPairRequest.ProtoReflect.Descriptor instead.
+func (*SetKeyPairRequest) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetKeyPairRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SetKeyPairRequest) GetPrivateKey() []byte {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
+type SetKeyPairResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyPairResponse) Reset() {
+	*x = SetKeyPairResponse{}
+	mi := &file_api_share_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyPairResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyPairResponse) ProtoMessage() {}
+
+func (x *SetKeyPairResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyPairResponse.ProtoReflect.Descriptor instead.
+func (*SetKeyPairResponse) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{1}
+}
+
+type GetKeyPairRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyPairRequest) Reset() {
+	*x = GetKeyPairRequest{}
+	mi := &file_api_share_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyPairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyPairRequest) ProtoMessage() {}
+
+func (x *GetKeyPairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyPairRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyPairRequest) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{2}
+}
+
+type GetKeyPairResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PrivateKey    []byte                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyPairResponse) Reset() {
+	*x = GetKeyPairResponse{}
+	mi := &file_api_share_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyPairResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyPairResponse) ProtoMessage() {}
+
+func (x *GetKeyPairResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyPairResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyPairResponse) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetKeyPairResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GetKeyPairResponse) GetPrivateKey() []byte {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
+type GetPublicKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyRequest) Reset() {
+	*x = GetPublicKeyRequest{}
+	mi := &file_api_share_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyRequest) ProtoMessage() {}
+
+func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPublicKeyRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type GetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyResponse) Reset() {
+	*x = GetPublicKeyResponse{}
+	mi := &file_api_share_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyResponse) ProtoMessage() {}
+
+func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPublicKeyResponse) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *GetPublicKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// SharedSecret - секрет, переданный пользователю: ключ данных секрета, зашифрованный
+// открытым ключом получателя, и текущее содержимое и метаданные секрета владельца
+type SharedSecret struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Meta       *SecretMetadata        `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	Owner      string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Recipient  string                 `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	WrappedKey []byte                 `protobuf:"bytes,5,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	Content    []byte                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	Created    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created,proto3,oneof" json:"created,omitempty"`
+	// хранилище (организация или пользователь), к которому привязано шифрование секрета;
+	// пусто у копий секретов, переданных до передачи по ссылке (копия привязана к логину владельца)
+	VaultOwner    string `protobuf:"bytes,8,opt,name=vault_owner,json=vaultOwner,proto3" json:"vault_owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedSecret) Reset() {
+	*x = SharedSecret{}
+	mi := &file_api_share_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedSecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedSecret) ProtoMessage() {}
+
+func (x *SharedSecret) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedSecret.ProtoReflect.Descriptor instead.
+func (*SharedSecret) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SharedSecret) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SharedSecret) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *SharedSecret) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SharedSecret) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *SharedSecret) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *SharedSecret) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *SharedSecret) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *SharedSecret) GetVaultOwner() string {
+	if x != nil {
+		return x.VaultOwner
+	}
+	return ""
+}
+
+// ShareSecretRequest - передача секрета: ключ данных секрета шифруется открытым ключом
+// получателя, содержимое не копируется (получатель видит изменения владельца)
+type ShareSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *SecretMetadata        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareSecretRequest) Reset() {
+	*x = ShareSecretRequest{}
+	mi := &file_api_share_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareSecretRequest) ProtoMessage() {}
+
+func (x *ShareSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareSecretRequest.ProtoReflect.Descriptor instead.
+func (*ShareSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ShareSecretRequest) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *ShareSecretRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ShareSecretRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+type ShareSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *SharedSecret          `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareSecretResponse) Reset() {
+	*x = ShareSecretResponse{}
+	mi := &file_api_share_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareSecretResponse) ProtoMessage() {}
+
+func (x *ShareSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareSecretResponse.ProtoReflect.Descriptor instead.
+func (*ShareSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ShareSecretResponse) GetShare() *SharedSecret {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type ListSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_api_share_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{9}
+}
+
+type ListSharedWithMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*SharedSecret        `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeResponse) Reset() {
+	*x = ListSharedWithMeResponse{}
+	mi := &file_api_share_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeResponse) ProtoMessage() {}
+
+func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSharedWithMeResponse) GetShares() []*SharedSecret {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type RevokeShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *SecretMetadata        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Recipient     string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareRequest) Reset() {
+	*x = RevokeShareRequest{}
+	mi := &file_api_share_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareRequest) ProtoMessage() {}
+
+func (x *RevokeShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareRequest) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeShareRequest) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *RevokeShareRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type RevokeShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *SecretMetadata        `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareResponse) Reset() {
+	*x = RevokeShareResponse{}
+	mi := &file_api_share_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareResponse) ProtoMessage() {}
+
+func (x *RevokeShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_share_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareResponse) Descriptor() ([]byte, []int) {
+	return file_api_share_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RevokeShareResponse) GetMeta() *SecretMetadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+var File_api_share_proto protoreflect.FileDescriptor
+
+const file_api_share_proto_rawDesc = "" +
+	"\n" +
+	"\x0fapi/share.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x10api/keeper.proto\"S\n" +
+	"\x11SetKeyPairRequest\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\fR\n" +
+	"privateKey\"\x14\n" +
+	"\x12SetKeyPairResponse\"\x13\n" +
+	"\x11GetKeyPairRequest\"T\n" +
+	"\x12GetKeyPairResponse\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\fR\n" +
+	"privateKey\"+\n" +
+	"\x13GetPublicKeyRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\"K\n" +
+	"\x14GetPublicKeyResponse\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\"\x9e\x02\n" +
+	"\fSharedSecret\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x04meta\x18\x02 \x01(\v2\x13.api.SecretMetadataR\x04meta\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12\x1c\n" +
+	"\trecipient\x18\x04 \x01(\tR\trecipient\x12\x1f\n" +
+	"\vwrapped_key\x18\x05 \x01(\fR\n" +
+	"wrappedKey\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\fR\acontent\x129\n" +
+	"\acreated\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x00R\acreated\x88\x01\x01\x12\x1f\n" +
+	"\vvault_owner\x18\b \x01(\tR\n" +
+	"vaultOwnerB\n" +
+	"\n" +
+	"\b_created\"\x82\x01\n" +
+	"\x12ShareSecretRequest\x12'\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\fR\n" +
+	"wrappedKeyJ\x04\b\x04\x10\x05\">\n" +
+	"\x13ShareSecretResponse\x12'\n" +
+	"\x05share\x18\x01 \x01(\v2\x11.api.SharedSecretR\x05share\"\x19\n" +
+	"\x17ListSharedWithMeRequest\"E\n" +
+	"\x18ListSharedWithMeResponse\x12)\n" +
+	"\x06shares\x18\x01 \x03(\v2\x11.api.SharedSecretR\x06shares\"[\n" +
+	"\x12RevokeShareRequest\x12'\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\">\n" +
+	"\x13RevokeShareResponse\x12'\n" +
+	"\x04meta\x18\x01 \x01(\v2\x13.api.SecretMetadataR\x04meta2\x9f\x03\n" +
+	"\x05Share\x12=\n" +
+	"\n" +
+	"SetKeyPair\x12\x16.api.SetKeyPairRequest\x1a\x17.api.SetKeyPairResponse\x12=\n" +
+	"\n" +
+	"GetKeyPair\x12\x16.api.GetKeyPairRequest\x1a\x17.api.GetKeyPairResponse\x12C\n" +
+	"\fGetPublicKey\x12\x18.api.GetPublicKeyRequest\x1a\x19.api.GetPublicKeyResponse\x12@\n" +
+	"\vShareSecret\x12\x17.api.ShareSecretRequest\x1a\x18.api.ShareSecretResponse\x12O\n" +
+	"\x10ListSharedWithMe\x12\x1c.api.ListSharedWithMeRequest\x1a\x1d.api.ListSharedWithMeResponse\x12@\n" +
+	"\vRevokeShare\x12\x17.api.RevokeShareRequest\x1a\x18.api.RevokeShareResponseB\vZ\tpkg/protob\x06proto3"
+
+var (
+	file_api_share_proto_rawDescOnce sync.Once
+	file_api_share_proto_rawDescData []byte
+)
+
+func file_api_share_proto_rawDescGZIP() []byte {
+	file_api_share_proto_rawDescOnce.Do(func() {
+		file_api_share_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_share_proto_rawDesc), len(file_api_share_proto_rawDesc)))
+	})
+	return file_api_share_proto_rawDescData
+}
+
+var file_api_share_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_api_share_proto_goTypes = []any{
+	(*SetKeyPairRequest)(nil),        // 0: api.SetKeyPairRequest
+	(*SetKeyPairResponse)(nil),       // 1: api.SetKeyPairResponse
+	(*GetKeyPairRequest)(nil),        // 2: api.GetKeyPairRequest
+	(*GetKeyPairResponse)(nil),       // 3: api.GetKeyPairResponse
+	(*GetPublicKeyRequest)(nil),      // 4: api.GetPublicKeyRequest
+	(*GetPublicKeyResponse)(nil),     // 5: api.GetPublicKeyResponse
+	(*SharedSecret)(nil),             // 6: api.SharedSecret
+	(*ShareSecretRequest)(nil),       // 7: api.ShareSecretRequest
+	(*ShareSecretResponse)(nil),      // 8: api.ShareSecretResponse
+	(*ListSharedWithMeRequest)(nil),  // 9: api.ListSharedWithMeRequest
+	(*ListSharedWithMeResponse)(nil), // 10: api.ListSharedWithMeResponse
+	(*RevokeShareRequest)(nil),       // 11: api.RevokeShareRequest
+	(*RevokeShareResponse)(nil),      // 12: api.RevokeShareResponse
+	(*SecretMetadata)(nil),           // 13: api.SecretMetadata
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+}
+var file_api_share_proto_depIdxs = []int32{
+	13, // 0: api.SharedSecret.meta:type_name -> api.SecretMetadata
+	14, // 1: api.SharedSecret.created:type_name -> google.protobuf.Timestamp
+	13, // 2: api.ShareSecretRequest.meta:type_name -> api.SecretMetadata
+	6,  // 3: api.ShareSecretResponse.share:type_name -> api.SharedSecret
+	6,  // 4: api.ListSharedWithMeResponse.shares:type_name -> api.SharedSecret
+	13, // 5: api.RevokeShareRequest.meta:type_name -> api.SecretMetadata
+	13, // 6: api.RevokeShareResponse.meta:type_name -> api.SecretMetadata
+	0,  // 7: api.Share.SetKeyPair:input_type -> api.SetKeyPairRequest
+	2,  // 8: api.Share.GetKeyPair:input_type -> api.GetKeyPairRequest
+	4,  // 9: api.Share.GetPublicKey:input_type -> api.GetPublicKeyRequest
+	7,  // 10: api.Share.ShareSecret:input_type -> api.ShareSecretRequest
+	9,  // 11: api.Share.ListSharedWithMe:input_type -> api.ListSharedWithMeRequest
+	11, // 12: api.Share.RevokeShare:input_type -> api.RevokeShareRequest
+	1,  // 13: api.Share.SetKeyPair:output_type -> api.SetKeyPairResponse
+	3,  // 14: api.Share.GetKeyPair:output_type -> api.GetKeyPairResponse
+	5,  // 15: api.Share.GetPublicKey:output_type -> api.GetPublicKeyResponse
+	8,  // 16: api.Share.ShareSecret:output_type -> api.ShareSecretResponse
+	10, // 17: api.Share.ListSharedWithMe:output_type -> api.ListSharedWithMeResponse
+	12, // 18: api.Share.RevokeShare:output_type -> api.RevokeShareResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_api_share_proto_init() }
+func file_api_share_proto_init() {
+	if File_api_share_proto != nil {
+		return
+	}
+	file_api_keeper_proto_init()
+	file_api_share_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_share_proto_rawDesc), len(file_api_share_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_share_proto_goTypes,
+		DependencyIndexes: file_api_share_proto_depIdxs,
+		MessageInfos:      file_api_share_proto_msgTypes,
+	}.Build()
+	File_api_share_proto = out.File
+	file_api_share_proto_goTypes = nil
+	file_api_share_proto_depIdxs = nil
+}
@@ -7,6 +7,7 @@
 package proto
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -24,7 +25,8 @@ const (
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	SrpSalt       []byte                 `protobuf:"bytes,3,opt,name=srp_salt,json=srpSalt,proto3" json:"srp_salt,omitempty"`
+	SrpVerifier   []byte                 `protobuf:"bytes,4,opt,name=srp_verifier,json=srpVerifier,proto3" json:"srp_verifier,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,24 +68,102 @@ func (x *RegisterRequest) GetLogin() string {
 	return ""
 }
 
-func (x *RegisterRequest) GetPassword() string {
+func (x *RegisterRequest) GetSrpSalt() []byte {
 	if x != nil {
-		return x.Password
+		return x.SrpSalt
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetSrpVerifier() []byte {
+	if x != nil {
+		return x.SrpVerifier
+	}
+	return nil
+}
+
+// KdfParams - параметры получения ключа из пароля
+type KdfParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Memory        uint32                 `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Iterations    uint32                 `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Parallelism   uint32                 `protobuf:"varint,4,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KdfParams) Reset() {
+	*x = KdfParams{}
+	mi := &file_api_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KdfParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KdfParams) ProtoMessage() {}
+
+func (x *KdfParams) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KdfParams.ProtoReflect.Descriptor instead.
+func (*KdfParams) Descriptor() ([]byte, []int) {
+	return file_api_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KdfParams) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
 	}
 	return ""
 }
 
+func (x *KdfParams) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *KdfParams) GetIterations() uint32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *KdfParams) GetParallelism() uint32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
 type RegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	Salt          string                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kdf           *KdfParams             `protobuf:"bytes,4,opt,name=kdf,proto3" json:"kdf,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterResponse) Reset() {
 	*x = RegisterResponse{}
-	mi := &file_api_user_proto_msgTypes[1]
+	mi := &file_api_user_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -95,7 +175,7 @@ func (x *RegisterResponse) String() string {
 func (*RegisterResponse) ProtoMessage() {}
 
 func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_user_proto_msgTypes[1]
+	mi := &file_api_user_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,7 +188,7 @@ func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
 func (*RegisterResponse) Descriptor() ([]byte, []int) {
-	return file_api_user_proto_rawDescGZIP(), []int{1}
+	return file_api_user_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *RegisterResponse) GetToken() string {
@@ -125,17 +205,35 @@ func (x *RegisterResponse) GetSalt() string {
 	return ""
 }
 
+func (x *RegisterResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetKdf() *KdfParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
+// LoginRequest - однократный перенос учётной записи, созданной до появления SRP: вход с передачей пароля,
+// после которого проверочное значение сохраняется вместо хеша пароля (без него запрос отклоняется)
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	SrpSalt       []byte                 `protobuf:"bytes,3,opt,name=srp_salt,json=srpSalt,proto3" json:"srp_salt,omitempty"`
+	SrpVerifier   []byte                 `protobuf:"bytes,4,opt,name=srp_verifier,json=srpVerifier,proto3" json:"srp_verifier,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_api_user_proto_msgTypes[2]
+	mi := &file_api_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +245,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_user_proto_msgTypes[2]
+	mi := &file_api_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +258,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_api_user_proto_rawDescGZIP(), []int{2}
+	return file_api_user_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LoginRequest) GetLogin() string {
@@ -177,17 +275,200 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetSrpSalt() []byte {
+	if x != nil {
+		return x.SrpSalt
+	}
+	return nil
+}
+
+func (x *LoginRequest) GetSrpVerifier() []byte {
+	if x != nil {
+		return x.SrpVerifier
+	}
+	return nil
+}
+
+type LoginStartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Public        []byte                 `protobuf:"bytes,2,opt,name=public,proto3" json:"public,omitempty"` // открытое значение клиента A
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginStartRequest) Reset() {
+	*x = LoginStartRequest{}
+	mi := &file_api_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginStartRequest) ProtoMessage() {}
+
+func (x *LoginStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginStartRequest.ProtoReflect.Descriptor instead.
+func (*LoginStartRequest) Descriptor() ([]byte, []int) {
+	return file_api_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoginStartRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *LoginStartRequest) GetPublic() []byte {
+	if x != nil {
+		return x.Public
+	}
+	return nil
+}
+
+type LoginStartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SrpSalt       []byte                 `protobuf:"bytes,2,opt,name=srp_salt,json=srpSalt,proto3" json:"srp_salt,omitempty"`
+	Public        []byte                 `protobuf:"bytes,3,opt,name=public,proto3" json:"public,omitempty"` // открытое значение сервера B
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginStartResponse) Reset() {
+	*x = LoginStartResponse{}
+	mi := &file_api_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginStartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginStartResponse) ProtoMessage() {}
+
+func (x *LoginStartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginStartResponse.ProtoReflect.Descriptor instead.
+func (*LoginStartResponse) Descriptor() ([]byte, []int) {
+	return file_api_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoginStartResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LoginStartResponse) GetSrpSalt() []byte {
+	if x != nil {
+		return x.SrpSalt
+	}
+	return nil
+}
+
+func (x *LoginStartResponse) GetPublic() []byte {
+	if x != nil {
+		return x.Public
+	}
+	return nil
+}
+
+type LoginFinishRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Proof         []byte                 `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"` // подтверждение клиента M1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginFinishRequest) Reset() {
+	*x = LoginFinishRequest{}
+	mi := &file_api_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginFinishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginFinishRequest) ProtoMessage() {}
+
+func (x *LoginFinishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginFinishRequest.ProtoReflect.Descriptor instead.
+func (*LoginFinishRequest) Descriptor() ([]byte, []int) {
+	return file_api_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LoginFinishRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LoginFinishRequest) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
 type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	Salt          string                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Kdf           *KdfParams             `protobuf:"bytes,4,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,5,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	ServerProof   []byte                 `protobuf:"bytes,6,opt,name=server_proof,json=serverProof,proto3" json:"server_proof,omitempty"` // подтверждение сервера M2 (при входе по SRP)
+	KeyCheck      []byte                 `protobuf:"bytes,7,opt,name=key_check,json=keyCheck,proto3" json:"key_check,omitempty"`          // контрольное значение ключа шифрования (пусто - не задано)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_api_user_proto_msgTypes[3]
+	mi := &file_api_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -199,7 +480,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_user_proto_msgTypes[3]
+	mi := &file_api_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -212,7 +493,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_api_user_proto_rawDescGZIP(), []int{3}
+	return file_api_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LoginResponse) GetToken() string {
@@ -229,26 +510,94 @@ func (x *LoginResponse) GetSalt() string {
 	return ""
 }
 
+func (x *LoginResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetKdf() *KdfParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetServerProof() []byte {
+	if x != nil {
+		return x.ServerProof
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetKeyCheck() []byte {
+	if x != nil {
+		return x.KeyCheck
+	}
+	return nil
+}
+
 var File_api_user_proto protoreflect.FileDescriptor
 
 const file_api_user_proto_rawDesc = "" +
 	"\n" +
-	"\x0eapi/user.proto\x12\x03api\"C\n" +
+	"\x0eapi/user.proto\x12\x03api\x1a\x1cgoogle/api/annotations.proto\"u\n" +
 	"\x0fRegisterRequest\x12\x14\n" +
-	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"<\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x19\n" +
+	"\bsrp_salt\x18\x03 \x01(\fR\asrpSalt\x12!\n" +
+	"\fsrp_verifier\x18\x04 \x01(\fR\vsrpVerifierJ\x04\b\x02\x10\x03R\bpassword\"\x83\x01\n" +
+	"\tKdfParams\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x16\n" +
+	"\x06memory\x18\x02 \x01(\rR\x06memory\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x03 \x01(\rR\n" +
+	"iterations\x12 \n" +
+	"\vparallelism\x18\x04 \x01(\rR\vparallelism\"w\n" +
 	"\x10RegisterResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
-	"\x04salt\x18\x02 \x01(\tR\x04salt\"@\n" +
+	"\x04salt\x18\x02 \x01(\tR\x04salt\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12 \n" +
+	"\x03kdf\x18\x04 \x01(\v2\x0e.api.KdfParamsR\x03kdf\"~\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"9\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x19\n" +
+	"\bsrp_salt\x18\x03 \x01(\fR\asrpSalt\x12!\n" +
+	"\fsrp_verifier\x18\x04 \x01(\fR\vsrpVerifier\"A\n" +
+	"\x11LoginStartRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x16\n" +
+	"\x06public\x18\x02 \x01(\fR\x06public\"f\n" +
+	"\x12LoginStartResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x19\n" +
+	"\bsrp_salt\x18\x02 \x01(\fR\asrpSalt\x12\x16\n" +
+	"\x06public\x18\x03 \x01(\fR\x06public\"I\n" +
+	"\x12LoginFinishRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05proof\x18\x02 \x01(\fR\x05proof\"\xd5\x01\n" +
 	"\rLoginResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
-	"\x04salt\x18\x02 \x01(\tR\x04salt2o\n" +
-	"\x04User\x127\n" +
-	"\bRegister\x12\x14.api.RegisterRequest\x1a\x15.api.RegisterResponse\x12.\n" +
-	"\x05Login\x12\x11.api.LoginRequest\x1a\x12.api.LoginResponseB\vZ\tpkg/protob\x06proto3"
+	"\x04salt\x18\x02 \x01(\tR\x04salt\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12 \n" +
+	"\x03kdf\x18\x04 \x01(\v2\x0e.api.KdfParamsR\x03kdf\x12\x1f\n" +
+	"\vwrapped_key\x18\x05 \x01(\fR\n" +
+	"wrappedKey\x12!\n" +
+	"\fserver_proof\x18\x06 \x01(\fR\vserverProof\x12\x1b\n" +
+	"\tkey_check\x18\a \x01(\fR\bkeyCheck2\xe6\x02\n" +
+	"\x04User\x12U\n" +
+	"\bRegister\x12\x14.api.RegisterRequest\x1a\x15.api.RegisterResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/user/register\x12I\n" +
+	"\x05Login\x12\x11.api.LoginRequest\x1a\x12.api.LoginResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/user/login\x12^\n" +
+	"\n" +
+	"LoginStart\x12\x16.api.LoginStartRequest\x1a\x17.api.LoginStartResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/user/login/start\x12\\\n" +
+	"\vLoginFinish\x12\x17.api.LoginFinishRequest\x1a\x12.api.LoginResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/user/login/finishB\vZ\tpkg/protob\x06proto3"
 
 var (
 	file_api_user_proto_rawDescOnce sync.Once
@@ -262,23 +611,33 @@ func file_api_user_proto_rawDescGZIP() []byte {
 	return file_api_user_proto_rawDescData
 }
 
-var file_api_user_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_user_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_api_user_proto_goTypes = []any{
-	(*RegisterRequest)(nil),  // 0: api.RegisterRequest
-	(*RegisterResponse)(nil), // 1: api.RegisterResponse
-	(*LoginRequest)(nil),     // 2: api.LoginRequest
-	(*LoginResponse)(nil),    // 3: api.LoginResponse
+	(*RegisterRequest)(nil),    // 0: api.RegisterRequest
+	(*KdfParams)(nil),          // 1: api.KdfParams
+	(*RegisterResponse)(nil),   // 2: api.RegisterResponse
+	(*LoginRequest)(nil),       // 3: api.LoginRequest
+	(*LoginStartRequest)(nil),  // 4: api.LoginStartRequest
+	(*LoginStartResponse)(nil), // 5: api.LoginStartResponse
+	(*LoginFinishRequest)(nil), // 6: api.LoginFinishRequest
+	(*LoginResponse)(nil),      // 7: api.LoginResponse
 }
 var file_api_user_proto_depIdxs = []int32{
-	0, // 0: api.User.Register:input_type -> api.RegisterRequest
-	2, // 1: api.User.Login:input_type -> api.LoginRequest
-	1, // 2: api.User.Register:output_type -> api.RegisterResponse
-	3, // 3: api.User.Login:output_type -> api.LoginResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: api.RegisterResponse.kdf:type_name -> api.KdfParams
+	1, // 1: api.LoginResponse.kdf:type_name -> api.KdfParams
+	0, // 2: api.User.Register:input_type -> api.RegisterRequest
+	3, // 3: api.User.Login:input_type -> api.LoginRequest
+	4, // 4: api.User.LoginStart:input_type -> api.LoginStartRequest
+	6, // 5: api.User.LoginFinish:input_type -> api.LoginFinishRequest
+	2, // 6: api.User.Register:output_type -> api.RegisterResponse
+	7, // 7: api.User.Login:output_type -> api.LoginResponse
+	5, // 8: api.User.LoginStart:output_type -> api.LoginStartResponse
+	7, // 9: api.User.LoginFinish:output_type -> api.LoginResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_api_user_proto_init() }
@@ -292,7 +651,7 @@ func file_api_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_user_proto_rawDesc), len(file_api_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,45 @@
+//go:build linux
+
+package securemem
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// alloc - метод выделяет память буфера в отдельном анонимном отображении вне кучи Go:
+// страницы данных окружены защитными страницами без доступа (выход за границы буфера
+// завершает процесс), закреплены в оперативной памяти и исключены из дампа памяти.
+// Возвращает срез данных, всё отображение (nil - память выделена в куче) и признак закрепления.
+func alloc(size int) ([]byte, []byte, bool) {
+	page := os.Getpagesize()
+	inner := (size + page - 1) / page * page
+	mem, err := unix.Mmap(-1, 0, inner+2*page, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS)
+	if err != nil {
+		return make([]byte, size), nil, false
+	}
+	if unix.Mprotect(mem[:page], unix.PROT_NONE) != nil || unix.Mprotect(mem[page+inner:], unix.PROT_NONE) != nil {
+		unix.Munmap(mem)
+		return make([]byte, size), nil, false
+	}
+	pages := mem[page : page+inner]
+	// при превышении RLIMIT_MEMLOCK страницы остаются незакреплёнными, но по-прежнему обнуляются при освобождении
+	locked := unix.Mlock(pages) == nil
+	unix.Madvise(pages, unix.MADV_DONTDUMP)
+	// данные прижаты к концу страниц, чтобы запись за пределы буфера попадала в защитную страницу
+	start := page + inner - size
+	return mem[start : start+size : start+size], mem, locked
+}
+
+// free - метод снимает закрепление и возвращает отображение системе
+func free(mem []byte, locked bool) {
+	if mem == nil {
+		return
+	}
+	page := os.Getpagesize()
+	if locked {
+		unix.Munlock(mem[page : len(mem)-page])
+	}
+	unix.Munmap(mem)
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package securemem
+
+// alloc - защищённые отображения поддерживаются только в Linux, буфер выделяется в куче
+// и только обнуляется при освобождении
+func alloc(size int) ([]byte, []byte, bool) {
+	return make([]byte, size), nil, false
+}
+
+// free - метод освобождения отображения (память в куче освобождает сборщик мусора)
+func free(mem []byte, locked bool) {}
@@ -0,0 +1,135 @@
+// Package securemem - буферы для ключевого материала клиента: память буфера выделяется
+// вне кучи Go в отображении с защитными страницами, по возможности закрепляется в оперативной
+// памяти (не попадает в файл подкачки и дамп памяти) и обнуляется при освобождении.
+package securemem
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Buffer - буфер ключевого материала. Методы безопасны для nil-буфера (пустой буфер)
+// и для одновременного вызова из нескольких горутин.
+type Buffer struct {
+	mu     sync.Mutex
+	data   []byte
+	mem    []byte // отображение с защитными страницами (nil - память выделена в куче)
+	locked bool   // память закреплена (mlock)
+}
+
+// New - метод создаёт обнулённый буфер заданного размера. Буфер освобождается методом Destroy,
+// неосвобождённый буфер освобождается сборщиком мусора.
+func New(size int) *Buffer {
+	b := &Buffer{}
+	if size <= 0 {
+		return b
+	}
+	b.data, b.mem, b.locked = alloc(size)
+	if b.mem != nil {
+		runtime.SetFinalizer(b, (*Buffer).Destroy)
+	}
+	return b
+}
+
+// FromBytes - метод создаёт буфер с копией данных, исходный срез обнуляется
+// (пустые данные - nil-буфер)
+func FromBytes(data []byte) *Buffer {
+	if len(data) == 0 {
+		return nil
+	}
+	b := New(len(data))
+	copy(b.data, data)
+	Wipe(data)
+	return b
+}
+
+// FromString - метод создаёт буфер с копией строки (исходная строка в Go неизменяема и
+// освобождается сборщиком мусора, поэтому её следует хранить как можно меньше)
+func FromString(s string) *Buffer {
+	if s == "" {
+		return nil
+	}
+	b := New(len(s))
+	copy(b.data, s)
+	return b
+}
+
+// Clone - метод создаёт буфер с копией содержимого (nil для пустого буфера)
+func (b *Buffer) Clone() *Buffer {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.data) == 0 {
+		return nil
+	}
+	c := New(len(b.data))
+	copy(c.data, b.data)
+	return c
+}
+
+// Bytes - метод возвращает содержимое буфера (nil после Destroy).
+// Срез ссылается на память буфера: после Destroy память возвращается системе
+// и обращение к срезу завершает процесс. Горутина, которая может пережить владельца
+// буфера, должна работать со своей копией (см. Clone).
+func (b *Buffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.data
+}
+
+// Len - метод возвращает размер содержимого буфера
+func (b *Buffer) Len() int {
+	return len(b.Bytes())
+}
+
+// Equal - метод сравнивает содержимое буфера со строкой за постоянное время
+func (b *Buffer) Equal(s string) bool {
+	data := b.Bytes()
+	if len(data) != len(s) {
+		return false
+	}
+	var diff byte
+	for i := range data {
+		diff |= data[i] ^ s[i]
+	}
+	return diff == 0
+}
+
+// Locked - метод проверяет, закреплена ли память буфера
+func (b *Buffer) Locked() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.locked
+}
+
+// Destroy - метод обнуляет буфер, снимает закрепление и возвращает память системе
+// (срезы, полученные до вызова, использовать нельзя)
+func (b *Buffer) Destroy() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.data == nil {
+		return
+	}
+	Wipe(b.data)
+	free(b.mem, b.locked)
+	b.data = nil
+	b.mem = nil
+	b.locked = false
+	runtime.SetFinalizer(b, nil)
+}
+
+// Wipe - метод обнуляет срез с данными
+func Wipe(data []byte) {
+	clear(data)
+}
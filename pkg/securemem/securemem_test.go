@@ -0,0 +1,79 @@
+package securemem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromBytes(t *testing.T) {
+	source := []byte("0123456789abcdef")
+	b := FromBytes(source)
+	require.NotNil(t, b)
+	assert.Equal(t, []byte("0123456789abcdef"), b.Bytes())
+	assert.Equal(t, 16, b.Len())
+	assert.Equal(t, make([]byte, 16), source, "source must be wiped")
+
+	assert.Nil(t, FromBytes(nil))
+	assert.Nil(t, FromString(""))
+}
+
+func TestDestroy(t *testing.T) {
+	b := FromString("secret")
+	b.Destroy()
+
+	assert.Nil(t, b.Bytes())
+	assert.Equal(t, 0, b.Len())
+	assert.False(t, b.Locked())
+	assert.NotPanics(t, b.Destroy)
+}
+
+func TestNilBuffer(t *testing.T) {
+	var b *Buffer
+	assert.Nil(t, b.Bytes())
+	assert.Nil(t, b.Clone())
+	assert.False(t, b.Locked())
+	assert.True(t, b.Equal(""))
+	assert.NotPanics(t, b.Destroy)
+}
+
+func TestClone(t *testing.T) {
+	b := FromString("secret")
+	c := b.Clone()
+	require.NotNil(t, c)
+	b.Destroy()
+
+	assert.Equal(t, []byte("secret"), c.Bytes(), "clone must outlive the original")
+	c.Destroy()
+	assert.Nil(t, New(0).Clone())
+}
+
+func TestBounds(t *testing.T) {
+	b := New(10)
+	defer b.Destroy()
+	data := b.Bytes()
+
+	assert.Len(t, data, 10)
+	assert.Equal(t, 10, cap(data), "capacity must not reach beyond the buffer")
+	assert.Equal(t, make([]byte, 10), data)
+}
+
+func TestEqual(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Value    string
+		Expected bool
+	}{
+		{TestName: "Success. Same", Value: "secret", Expected: true},
+		{TestName: "Different value", Value: "secreT", Expected: false},
+		{TestName: "Different length", Value: "secrets", Expected: false},
+		{TestName: "Empty", Value: "", Expected: false},
+	}
+	b := FromString("secret")
+	for _, tc := range testCases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, b.Equal(tc.Value))
+		})
+	}
+}
@@ -3,4 +3,5 @@ set GOOS=linux
 set GOARCH=amd64
 go build -o dist/keeper-client-linux-amd64 cmd/client/main.go
 go build -o dist/keeper-server-linux-amd64 cmd/server/main.go
+go build -o dist/keeper-escrow-linux-amd64 cmd/escrow/main.go
 echo Build completed!
\ No newline at end of file
@@ -3,4 +3,5 @@ set GOOS=darwin
 set GOARCH=amd64
 go build -o dist/keeper-client-macos-amd64.exe cmd/client/main.go
 go build -o dist/keeper-server-macos-amd64.exe cmd/server/main.go
+go build -o dist/keeper-escrow-macos-amd64.exe cmd/escrow/main.go
 echo Build completed!
\ No newline at end of file
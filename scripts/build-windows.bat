@@ -3,4 +3,5 @@ set GOOS=windows
 set GOARCH=amd64
 go build -o dist/keeper-client-windows-amd64.exe cmd/client/main.go
 go build -o dist/keeper-server-windows-amd64.exe cmd/server/main.go
+go build -o dist/keeper-escrow-windows-amd64.exe cmd/escrow/main.go
 echo Build completed!
\ No newline at end of file